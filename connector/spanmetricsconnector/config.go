@@ -61,6 +61,11 @@ type Config struct {
 
 	// Events defines the configuration for events section of spans.
 	Events EventsConfig `mapstructure:"events"`
+
+	// AggregationCardinalityLimit is the maximum number of series of each metric for each service.
+	// Once reached, spans of new series are aggregated into a single series with the
+	// `otel.metric.overflow` attribute set to true. Zero means no limit.
+	AggregationCardinalityLimit int `mapstructure:"aggregation_cardinality_limit"`
 }
 
 type HistogramConfig struct {
@@ -72,6 +77,11 @@ type HistogramConfig struct {
 
 type ExemplarsConfig struct {
 	Enabled bool `mapstructure:"enabled"`
+	// MaxPerDataPoint is the maximum number of exemplars kept for each data point during a flush interval.
+	// Slower and error spans are more likely to be kept. Zero means no limit.
+	MaxPerDataPoint int `mapstructure:"max_per_data_point"`
+	// Attributes is the list of span attributes copied to the filtered attributes of the exemplars.
+	Attributes []string `mapstructure:"attributes"`
 }
 
 type ExponentialHistogramConfig struct {
@@ -108,6 +118,20 @@ func (c Config) Validate() error {
 		)
 	}
 
+	if c.AggregationCardinalityLimit < 0 {
+		return fmt.Errorf(
+			"invalid aggregation_cardinality_limit: %v, the limit should not be negative",
+			c.AggregationCardinalityLimit,
+		)
+	}
+
+	if c.Exemplars.MaxPerDataPoint < 0 {
+		return fmt.Errorf(
+			"invalid exemplars max_per_data_point: %v, the maximum number of exemplars should not be negative",
+			c.Exemplars.MaxPerDataPoint,
+		)
+	}
+
 	if c.Histogram.Explicit != nil && c.Histogram.Exponential != nil {
 		return errors.New("use either `explicit` or `exponential` buckets histogram")
 	}
@@ -47,10 +47,13 @@ func TestLoadConfig(t *testing.T) {
 					{Name: "http.method", Default: &defaultMethod},
 					{Name: "http.status_code", Default: (*string)(nil)},
 				},
-				DimensionsCacheSize:  1500,
-				MetricsFlushInterval: 30 * time.Second,
+				DimensionsCacheSize:         1500,
+				AggregationCardinalityLimit: 2000,
+				MetricsFlushInterval:        30 * time.Second,
 				Exemplars: ExemplarsConfig{
-					Enabled: true,
+					Enabled:         true,
+					MaxPerDataPoint: 10,
+					Attributes:      []string{"http.route"},
 				},
 				Histogram: HistogramConfig{
 					Unit: metrics.Seconds,
@@ -75,6 +78,7 @@ func TestLoadConfig(t *testing.T) {
 						MaxSize: 10,
 					},
 				},
+				Exemplars: ExemplarsConfig{MaxPerDataPoint: defaultMaxExemplarsPerDataPoint},
 			},
 		},
 		{
@@ -92,9 +96,13 @@ func TestLoadConfig(t *testing.T) {
 				DimensionsCacheSize:    defaultDimensionsCacheSize,
 				MetricsFlushInterval:   15 * time.Second,
 				Histogram:              HistogramConfig{Disable: false, Unit: defaultUnit},
-				Exemplars:              ExemplarsConfig{Enabled: true},
+				Exemplars:              ExemplarsConfig{Enabled: true, MaxPerDataPoint: defaultMaxExemplarsPerDataPoint},
 			},
 		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_max_exemplars"),
+			errorMessage: "invalid exemplars max_per_data_point: -1",
+		},
 	}
 
 	for _, tt := range tests {
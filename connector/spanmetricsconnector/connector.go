@@ -17,9 +17,11 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/cache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
@@ -34,10 +36,16 @@ const (
 
 	defaultDimensionsCacheSize = 1000
 
+	defaultMaxExemplarsPerDataPoint = 5
+
 	metricNameDuration = "duration"
 	metricNameCalls    = "calls"
 	metricNameEvents   = "events"
 
+	overflowKey       = metrics.Key(metricKeySeparator + "overflow")
+	overflowAttribute = "otel.metric.overflow"
+	scopeName         = "github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector"
+
 	defaultUnit = metrics.Milliseconds
 )
 
@@ -72,6 +80,14 @@ type connectorImp struct {
 	eDimensions []dimension
 
 	events EventsConfig
+
+	// Reused to hold the span attributes copied to exemplars.
+	exemplarAttributes pcommon.Map
+
+	// The attributes of the series that spans are aggregated into once the cardinality limit is reached.
+	overflowAttributes pcommon.Map
+
+	overflowSpansCounter metric.Int64Counter
 }
 
 type resourceMetrics struct {
@@ -101,8 +117,8 @@ func newDimensions(cfgDims []Dimension) []dimension {
 	return dims
 }
 
-func newConnector(logger *zap.Logger, config component.Config, ticker *clock.Ticker) (*connectorImp, error) {
-	logger.Info("Building spanmetrics connector")
+func newConnector(set component.TelemetrySettings, config component.Config, ticker *clock.Ticker) (*connectorImp, error) {
+	set.Logger.Info("Building spanmetrics connector")
 	cfg := config.(*Config)
 
 	metricKeyToDimensionsCache, err := cache.NewCache[metrics.Key, pcommon.Map](cfg.DimensionsCacheSize)
@@ -110,8 +126,19 @@ func newConnector(logger *zap.Logger, config component.Config, ticker *clock.Tic
 		return nil, err
 	}
 
+	overflowSpansCounter, err := set.MeterProvider.Meter(scopeName).Int64Counter(
+		metadata.Type+"_connector_overflow_spans",
+		metric.WithDescription("Number of spans aggregated into the overflow series because the cardinality limit was reached."),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	overflowAttributes := pcommon.NewMap()
+	overflowAttributes.PutBool(overflowAttribute, true)
+
 	return &connectorImp{
-		logger:                logger,
+		logger:                set.Logger,
 		config:                *cfg,
 		startTimestamp:        pcommon.NewTimestampFromTime(time.Now()),
 		resourceMetrics:       make(map[resourceKey]*resourceMetrics),
@@ -122,6 +149,9 @@ func newConnector(logger *zap.Logger, config component.Config, ticker *clock.Tic
 		done:                  make(chan struct{}),
 		eDimensions:           newDimensions(cfg.Events.Dimensions),
 		events:                cfg.Events,
+		exemplarAttributes:    pcommon.NewMap(),
+		overflowAttributes:    overflowAttributes,
+		overflowSpansCounter:  overflowSpansCounter,
 	}, nil
 }
 
@@ -134,7 +164,7 @@ func initHistogramMetrics(cfg Config) metrics.HistogramMetrics {
 		if cfg.Histogram.Exponential.MaxSize != 0 {
 			maxSize = cfg.Histogram.Exponential.MaxSize
 		}
-		return metrics.NewExponentialHistogramMetrics(maxSize)
+		return metrics.NewExponentialHistogramMetrics(maxSize, cfg.Exemplars.MaxPerDataPoint)
 	}
 
 	var bounds []float64
@@ -152,7 +182,7 @@ func initHistogramMetrics(cfg Config) metrics.HistogramMetrics {
 		}
 	}
 
-	return metrics.NewExplicitHistogramMetrics(bounds)
+	return metrics.NewExplicitHistogramMetrics(bounds, cfg.Exemplars.MaxPerDataPoint)
 }
 
 // unitDivider returns a unit divider to convert nanoseconds to milliseconds or seconds.
@@ -211,10 +241,13 @@ func (p *connectorImp) Capabilities() consumer.Capabilities {
 
 // ConsumeTraces implements the consumer.Traces interface.
 // It aggregates the trace data to generate metrics.
-func (p *connectorImp) ConsumeTraces(_ context.Context, traces ptrace.Traces) error {
+func (p *connectorImp) ConsumeTraces(ctx context.Context, traces ptrace.Traces) error {
 	p.lock.Lock()
-	p.aggregateMetrics(traces)
+	overflowSpans := p.aggregateMetrics(traces)
 	p.lock.Unlock()
+	if overflowSpans > 0 {
+		p.overflowSpansCounter.Add(ctx, overflowSpans)
+	}
 	return nil
 }
 
@@ -276,11 +309,11 @@ func (p *connectorImp) resetState() {
 		p.metricKeyToDimensions.RemoveEvictedItems()
 
 		// Exemplars are only relevant to this batch of traces, so must be cleared within the lock
-		if p.config.Histogram.Disable {
-			return
-		}
 		for _, m := range p.resourceMetrics {
-			m.histograms.Reset(true)
+			m.sums.Reset(true)
+			if !p.config.Histogram.Disable {
+				m.histograms.Reset(true)
+			}
 		}
 
 	}
@@ -292,7 +325,9 @@ func (p *connectorImp) resetState() {
 // Each metric is identified by a key that is built from the service name
 // and span metadata such as name, kind, status_code and any additional
 // dimensions the user has configured.
-func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
+//
+// It returns the number of spans aggregated into overflow series because the cardinality limit was reached.
+func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) (overflowSpans int64) {
 	for i := 0; i < traces.ResourceSpans().Len(); i++ {
 		rspans := traces.ResourceSpans().At(i)
 		resourceAttr := rspans.Resource().Attributes()
@@ -323,22 +358,31 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 				}
 				key := p.buildKey(serviceName, span, p.dimensions, resourceAttr)
 
-				attributes, ok := p.metricKeyToDimensions.Get(key)
-				if !ok {
-					attributes = p.buildAttributes(serviceName, span, resourceAttr, p.dimensions)
-					p.metricKeyToDimensions.Add(key, attributes)
+				var attributes pcommon.Map
+				if sums.IsCardinalityLimitReached(key, p.config.AggregationCardinalityLimit) {
+					key, attributes = overflowKey, p.overflowAttributes
+					overflowSpans++
+				} else {
+					attributes, ok = p.metricKeyToDimensions.Get(key)
+					if !ok {
+						attributes = p.buildAttributes(serviceName, span, resourceAttr, p.dimensions)
+						p.metricKeyToDimensions.Add(key, attributes)
+					}
 				}
+				exemplar, hasExemplar := p.buildExemplar(span, duration)
 				if !p.config.Histogram.Disable {
 					// aggregate histogram metrics
 					h := histograms.GetOrCreate(key, attributes)
-					p.addExemplar(span, duration, h)
+					if hasExemplar {
+						h.AddExemplar(exemplar)
+					}
 					h.Observe(duration)
 
 				}
 				// aggregate sums metrics
 				s := sums.GetOrCreate(key, attributes)
-				if p.config.Exemplars.Enabled && !span.TraceID().IsEmpty() {
-					s.AddExemplar(span.TraceID(), span.SpanID(), duration)
+				if hasExemplar {
+					s.AddExemplar(exemplar)
 				}
 				s.Add(1)
 
@@ -355,6 +399,11 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 						event.Attributes().CopyTo(rscAndEventAttrs)
 
 						eKey := p.buildKey(serviceName, span, eDimensions, rscAndEventAttrs)
+						if events.IsCardinalityLimitReached(eKey, p.config.AggregationCardinalityLimit) {
+							e := events.GetOrCreate(overflowKey, p.overflowAttributes)
+							e.Add(1)
+							continue
+						}
 						eAttributes, ok := p.metricKeyToDimensions.Get(eKey)
 						if !ok {
 							eAttributes = p.buildAttributes(serviceName, span, rscAndEventAttrs, eDimensions)
@@ -367,17 +416,32 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 			}
 		}
 	}
+	return overflowSpans
 }
 
-func (p *connectorImp) addExemplar(span ptrace.Span, duration float64, h metrics.Histogram) {
+// buildExemplar builds the exemplar of the span, if exemplars are enabled and the span has a trace ID.
+// The filtered attributes of the exemplar are only valid until the next call.
+func (p *connectorImp) buildExemplar(span ptrace.Span, duration float64) (metrics.Exemplar, bool) {
 	if !p.config.Exemplars.Enabled {
-		return
+		return metrics.Exemplar{}, false
 	}
 	if span.TraceID().IsEmpty() {
-		return
+		return metrics.Exemplar{}, false
 	}
 
-	h.AddExemplar(span.TraceID(), span.SpanID(), duration)
+	p.exemplarAttributes.Clear()
+	for _, name := range p.config.Exemplars.Attributes {
+		if v, ok := span.Attributes().Get(name); ok {
+			v.CopyTo(p.exemplarAttributes.PutEmpty(name))
+		}
+	}
+	return metrics.Exemplar{
+		TraceID:            span.TraceID(),
+		SpanID:             span.SpanID(),
+		Value:              duration,
+		IsError:            span.Status().Code() == ptrace.StatusCodeError,
+		FilteredAttributes: p.exemplarAttributes,
+	}, true
 }
 
 type resourceKey [16]byte
@@ -388,8 +452,8 @@ func (p *connectorImp) getOrCreateResourceMetrics(attr pcommon.Map) *resourceMet
 	if !ok {
 		v = &resourceMetrics{
 			histograms: initHistogramMetrics(p.config),
-			sums:       metrics.NewSumMetrics(),
-			events:     metrics.NewSumMetrics(),
+			sums:       metrics.NewSumMetrics(p.config.Exemplars.MaxPerDataPoint),
+			events:     metrics.NewSumMetrics(p.config.Exemplars.MaxPerDataPoint),
 			attributes: attr,
 		}
 		p.resourceMetrics[key] = v
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tilinna/clock"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
@@ -441,7 +442,7 @@ func disabledHistogramsConfig() HistogramConfig {
 func TestBuildKeySameServiceNameCharSequence(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
-	c, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	c, err := newConnector(newTestTelemetrySettings(zaptest.NewLogger(t)), cfg, nil)
 	require.NoError(t, err)
 
 	span0 := ptrace.NewSpan()
@@ -461,7 +462,7 @@ func TestBuildKeyExcludeDimensionsAll(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
 	cfg.ExcludeDimensions = []string{"span.kind", "service.name", "span.name", "status.code"}
-	c, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	c, err := newConnector(newTestTelemetrySettings(zaptest.NewLogger(t)), cfg, nil)
 	require.NoError(t, err)
 
 	span0 := ptrace.NewSpan()
@@ -474,7 +475,7 @@ func TestBuildKeyExcludeWrongDimensions(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
 	cfg.ExcludeDimensions = []string{"span.kind", "service.name.wrong.name", "span.name", "status.code"}
-	c, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	c, err := newConnector(newTestTelemetrySettings(zaptest.NewLogger(t)), cfg, nil)
 	require.NoError(t, err)
 
 	span0 := ptrace.NewSpan()
@@ -486,7 +487,7 @@ func TestBuildKeyExcludeWrongDimensions(t *testing.T) {
 func TestBuildKeyWithDimensions(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
-	c, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	c, err := newConnector(newTestTelemetrySettings(zaptest.NewLogger(t)), cfg, nil)
 	require.NoError(t, err)
 
 	defaultFoo := pcommon.NewValueStr("bar")
@@ -628,7 +629,7 @@ func TestConnectorCapabilities(t *testing.T) {
 	cfg := factory.CreateDefaultConfig().(*Config)
 
 	// Test
-	c, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	c, err := newConnector(newTestTelemetrySettings(zaptest.NewLogger(t)), cfg, nil)
 	c.metricsConsumer = new(consumertest.MetricsSink)
 	assert.NoError(t, err)
 	caps := c.Capabilities()
@@ -973,12 +974,18 @@ func newConnectorImp(t *testing.T, mcon consumer.Metrics, defaultNullValue *stri
 			{regionResourceAttrName, nil},
 		},
 	}
-	c, err := newConnector(logger, cfg, ticker)
+	c, err := newConnector(newTestTelemetrySettings(logger), cfg, ticker)
 	require.NoError(t, err)
 	c.metricsConsumer = mcon
 	return c
 }
 
+func newTestTelemetrySettings(logger *zap.Logger) component.TelemetrySettings {
+	set := componenttest.NewNopTelemetrySettings()
+	set.Logger = logger
+	return set
+}
+
 func stringp(str string) *string {
 	return &str
 }
@@ -1175,7 +1182,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 		{
 			name:   "initialize histogram with no config provided",
 			config: Config{},
-			want:   metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs),
+			want:   metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs, 0),
 		},
 		{
 			name: "Disable histogram",
@@ -1193,7 +1200,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					Unit: metrics.Milliseconds,
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs),
+			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs, 0),
 		},
 		{
 			name: "initialize explicit histogram with default bounds (seconds)",
@@ -1202,7 +1209,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					Unit: metrics.Seconds,
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsSeconds),
+			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsSeconds, 0),
 		},
 		{
 			name: "initialize explicit histogram with bounds (seconds)",
@@ -1217,7 +1224,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					},
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics([]float64{0.1, 1}),
+			want: metrics.NewExplicitHistogramMetrics([]float64{0.1, 1}, 0),
 		},
 		{
 			name: "initialize explicit histogram with bounds (ms)",
@@ -1232,7 +1239,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					},
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics([]float64{100, 1000}),
+			want: metrics.NewExplicitHistogramMetrics([]float64{100, 1000}, 0),
 		},
 		{
 			name: "initialize exponential histogram",
@@ -1244,7 +1251,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					},
 				},
 			},
-			want: metrics.NewExponentialHistogramMetrics(10),
+			want: metrics.NewExponentialHistogramMetrics(10, 0),
 		},
 		{
 			name: "initialize exponential histogram with default max buckets count",
@@ -1254,7 +1261,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					Exponential: &ExponentialHistogramConfig{},
 				},
 			},
-			want: metrics.NewExponentialHistogramMetrics(structure.DefaultMaxSize),
+			want: metrics.NewExponentialHistogramMetrics(structure.DefaultMaxSize, 0),
 		},
 	}
 	for _, tt := range tests {
@@ -1287,7 +1294,7 @@ func TestSpanMetrics_Events(t *testing.T) {
 			factory := NewFactory()
 			cfg := factory.CreateDefaultConfig().(*Config)
 			cfg.Events = tt.eventsConfig
-			c, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+			c, err := newConnector(newTestTelemetrySettings(zaptest.NewLogger(t)), cfg, nil)
 			require.NoError(t, err)
 			err = c.ConsumeTraces(context.Background(), buildSampleTrace())
 			require.NoError(t, err)
@@ -1349,3 +1356,93 @@ func TestExemplarsForSumMetrics(t *testing.T) {
 		}
 	}
 }
+
+func TestExemplarsLimitAndAttributes(t *testing.T) {
+	p := newConnectorImp(t, consumertest.NewNop(), stringp("defaultNullValue"), explicitHistogramsConfig, func() ExemplarsConfig {
+		return ExemplarsConfig{
+			Enabled:         true,
+			MaxPerDataPoint: 2,
+			Attributes:      []string{"http.route"},
+		}
+	}, cumulative, zaptest.NewLogger(t), nil)
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(serviceNameKey, "service-a")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	for i := 0; i < 10; i++ {
+		span := spans.AppendEmpty()
+		span.SetName("GET")
+		span.SetTraceID(pcommon.TraceID([16]byte{byte(i + 1)}))
+		span.SetSpanID(pcommon.SpanID([8]byte{byte(i + 1)}))
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 0)))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, int64(i+1)*int64(time.Millisecond))))
+		span.Attributes().PutStr("http.route", "/api")
+		span.Attributes().PutStr("http.method", "GET")
+	}
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), traces))
+	md := p.buildMetrics()
+
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+	for _, exemplars := range []pmetric.ExemplarSlice{
+		ms.At(0).Sum().DataPoints().At(0).Exemplars(),
+		ms.At(1).Histogram().DataPoints().At(0).Exemplars(),
+	} {
+		require.Equal(t, 2, exemplars.Len())
+		for i := 0; i < exemplars.Len(); i++ {
+			assert.Equal(t, map[string]any{"http.route": "/api"}, exemplars.At(i).FilteredAttributes().AsRaw())
+		}
+	}
+
+	// Exemplars are cleared after each flush with cumulative temporality.
+	p.resetState()
+	md = p.buildMetrics()
+	ms = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	assert.Equal(t, 0, ms.At(0).Sum().DataPoints().At(0).Exemplars().Len())
+	assert.Equal(t, 0, ms.At(1).Histogram().DataPoints().At(0).Exemplars().Len())
+}
+
+func TestAggregationCardinalityLimit(t *testing.T) {
+	p := newConnectorImp(t, consumertest.NewNop(), nil, explicitHistogramsConfig, disabledExemplarsConfig, cumulative, zaptest.NewLogger(t), nil)
+	p.config.AggregationCardinalityLimit = 2
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(serviceNameKey, "service-a")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	for _, name := range []string{"a", "b", "a", "c", "d", "b"} {
+		spans.AppendEmpty().SetName(name)
+	}
+
+	assert.Equal(t, int64(2), p.aggregateMetrics(traces))
+	md := p.buildMetrics()
+
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for _, m := range []pmetric.Metric{ms.At(0), ms.At(1)} {
+		counts := map[string]uint64{}
+		switch m.Type() {
+		case pmetric.MetricTypeSum:
+			dps := m.Sum().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				counts[seriesName(t, dps.At(i).Attributes())] = uint64(dps.At(i).IntValue())
+			}
+		case pmetric.MetricTypeHistogram:
+			dps := m.Histogram().DataPoints()
+			for i := 0; i < dps.Len(); i++ {
+				counts[seriesName(t, dps.At(i).Attributes())] = dps.At(i).Count()
+			}
+		}
+		assert.Equal(t, map[string]uint64{"a": 2, "b": 2, "overflow": 2}, counts, m.Name())
+	}
+}
+
+func seriesName(t *testing.T, attrs pcommon.Map) string {
+	if overflow, ok := attrs.Get(overflowAttribute); ok && overflow.Bool() {
+		assert.Equal(t, 1, attrs.Len())
+		return "overflow"
+	}
+	name, _ := attrs.Get(spanNameKey)
+	return name.Str()
+}
@@ -32,11 +32,12 @@ func createDefaultConfig() component.Config {
 		DimensionsCacheSize:    defaultDimensionsCacheSize,
 		MetricsFlushInterval:   15 * time.Second,
 		Histogram:              HistogramConfig{Disable: false, Unit: defaultUnit},
+		Exemplars:              ExemplarsConfig{MaxPerDataPoint: defaultMaxExemplarsPerDataPoint},
 	}
 }
 
 func createTracesToMetricsConnector(ctx context.Context, params connector.CreateSettings, cfg component.Config, nextConsumer consumer.Metrics) (connector.Traces, error) {
-	c, err := newConnector(params.Logger, cfg, metricsTicker(ctx, cfg))
+	c, err := newConnector(params.TelemetrySettings, cfg, metricsTicker(ctx, cfg))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
+
+import (
+	"math"
+	"math/rand"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// Exemplar holds the span data an exemplar is made of.
+type Exemplar struct {
+	TraceID pcommon.TraceID
+	SpanID  pcommon.SpanID
+	Value   float64
+	// IsError is true if the span has an error status, which makes it more likely to be kept.
+	IsError bool
+	// FilteredAttributes are the span attributes copied to the exemplar.
+	FilteredAttributes pcommon.Map
+}
+
+// minExemplarWeight is the weight of exemplars with a zero value, so that they can still be sampled.
+const minExemplarWeight = 1e-9
+
+// exemplarReservoir keeps a bounded sample of the exemplars added during a flush interval.
+// It uses weighted reservoir sampling (A-Res) where the weight of an exemplar is its value,
+// so that slower spans are more likely to be kept. Error spans are always kept over other spans.
+type exemplarReservoir struct {
+	// maxSize is the maximum number of exemplars, all exemplars are kept if it is not positive.
+	maxSize   int
+	exemplars pmetric.ExemplarSlice
+	keys      []float64
+}
+
+func newExemplarReservoir(maxSize int) *exemplarReservoir {
+	return &exemplarReservoir{
+		maxSize:   maxSize,
+		exemplars: pmetric.NewExemplarSlice(),
+	}
+}
+
+func (r *exemplarReservoir) add(e Exemplar) {
+	if r.maxSize <= 0 {
+		r.set(r.exemplars.AppendEmpty(), e)
+		return
+	}
+
+	key := math.Pow(rand.Float64(), 1/math.Max(e.Value, minExemplarWeight))
+	if e.IsError {
+		// Keys are in [0, 1], so error spans are always ranked over other spans.
+		key++
+	}
+
+	if r.exemplars.Len() < r.maxSize {
+		r.keys = append(r.keys, key)
+		r.set(r.exemplars.AppendEmpty(), e)
+		return
+	}
+
+	minIndex := 0
+	for i, k := range r.keys {
+		if k < r.keys[minIndex] {
+			minIndex = i
+		}
+	}
+	if key > r.keys[minIndex] {
+		r.keys[minIndex] = key
+		r.set(r.exemplars.At(minIndex), e)
+	}
+}
+
+func (r *exemplarReservoir) set(dest pmetric.Exemplar, e Exemplar) {
+	dest.SetTraceID(e.TraceID)
+	dest.SetSpanID(e.SpanID)
+	dest.SetDoubleValue(e.Value)
+	e.FilteredAttributes.CopyTo(dest.FilteredAttributes())
+}
+
+// copyTo copies the exemplars to dest, setting their timestamp.
+func (r *exemplarReservoir) copyTo(dest pmetric.ExemplarSlice, timestamp pcommon.Timestamp) {
+	for i := 0; i < r.exemplars.Len(); i++ {
+		r.exemplars.At(i).SetTimestamp(timestamp)
+	}
+	r.exemplars.CopyTo(dest)
+}
+
+func (r *exemplarReservoir) reset() {
+	r.exemplars = pmetric.NewExemplarSlice()
+	r.keys = r.keys[:0]
+}
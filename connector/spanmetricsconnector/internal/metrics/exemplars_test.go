@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newTestExemplar(value float64, isError bool) Exemplar {
+	attrs := pcommon.NewMap()
+	attrs.PutStr("http.route", "/api")
+	return Exemplar{
+		TraceID:            pcommon.TraceID([16]byte{1}),
+		SpanID:             pcommon.SpanID([8]byte{1}),
+		Value:              value,
+		IsError:            isError,
+		FilteredAttributes: attrs,
+	}
+}
+
+func TestExemplarReservoirUnlimited(t *testing.T) {
+	r := newExemplarReservoir(0)
+	for i := 0; i < 100; i++ {
+		r.add(newTestExemplar(float64(i), false))
+	}
+	assert.Equal(t, 100, r.exemplars.Len())
+}
+
+func TestExemplarReservoirBounded(t *testing.T) {
+	r := newExemplarReservoir(3)
+	for i := 0; i < 100; i++ {
+		r.add(newTestExemplar(float64(i), false))
+	}
+	require.Equal(t, 3, r.exemplars.Len())
+	assert.Len(t, r.keys, 3)
+
+	dest := pmetric.NewExemplarSlice()
+	r.copyTo(dest, pcommon.Timestamp(42))
+	require.Equal(t, 3, dest.Len())
+	for i := 0; i < dest.Len(); i++ {
+		assert.Equal(t, pcommon.Timestamp(42), dest.At(i).Timestamp())
+		assert.Equal(t, map[string]any{"http.route": "/api"}, dest.At(i).FilteredAttributes().AsRaw())
+	}
+
+	r.reset()
+	assert.Equal(t, 0, r.exemplars.Len())
+	assert.Empty(t, r.keys)
+}
+
+func TestExemplarReservoirKeepsErrors(t *testing.T) {
+	r := newExemplarReservoir(2)
+	r.add(newTestExemplar(1, true))
+	for i := 0; i < 100; i++ {
+		r.add(newTestExemplar(1000, false))
+	}
+	r.add(newTestExemplar(2, true))
+
+	require.Equal(t, 2, r.exemplars.Len())
+	values := []float64{r.exemplars.At(0).DoubleValue(), r.exemplars.At(1).DoubleValue()}
+	assert.ElementsMatch(t, []float64{1, 2}, values)
+}
+
+func TestExemplarReservoirPrefersSlowSpans(t *testing.T) {
+	r := newExemplarReservoir(1)
+	kept := 0
+	for i := 0; i < 100; i++ {
+		r.reset()
+		r.add(newTestExemplar(1, false))
+		r.add(newTestExemplar(1000, false))
+		if r.exemplars.At(0).DoubleValue() == 1000 {
+			kept++
+		}
+	}
+	// The slow span is kept with a probability of 1000/1001.
+	assert.Greater(t, kept, 90)
+}
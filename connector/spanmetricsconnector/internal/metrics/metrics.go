@@ -22,22 +22,24 @@ type HistogramMetrics interface {
 
 type Histogram interface {
 	Observe(value float64)
-	AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64)
+	AddExemplar(e Exemplar)
 }
 
 type explicitHistogramMetrics struct {
-	metrics map[Key]*explicitHistogram
-	bounds  []float64
+	metrics      map[Key]*explicitHistogram
+	bounds       []float64
+	maxExemplars int
 }
 
 type exponentialHistogramMetrics struct {
-	metrics map[Key]*exponentialHistogram
-	maxSize int32
+	metrics      map[Key]*exponentialHistogram
+	maxSize      int32
+	maxExemplars int
 }
 
 type explicitHistogram struct {
 	attributes pcommon.Map
-	exemplars  pmetric.ExemplarSlice
+	exemplars  *exemplarReservoir
 
 	bucketCounts []uint64
 	count        uint64
@@ -48,22 +50,28 @@ type explicitHistogram struct {
 
 type exponentialHistogram struct {
 	attributes pcommon.Map
-	exemplars  pmetric.ExemplarSlice
+	exemplars  *exemplarReservoir
 
 	histogram *structure.Histogram[float64]
 }
 
-func NewExponentialHistogramMetrics(maxSize int32) HistogramMetrics {
+// NewExponentialHistogramMetrics creates exponential histograms that keep at most
+// maxExemplars exemplars per data point, or all of them if maxExemplars is not positive.
+func NewExponentialHistogramMetrics(maxSize int32, maxExemplars int) HistogramMetrics {
 	return &exponentialHistogramMetrics{
-		metrics: make(map[Key]*exponentialHistogram),
-		maxSize: maxSize,
+		metrics:      make(map[Key]*exponentialHistogram),
+		maxSize:      maxSize,
+		maxExemplars: maxExemplars,
 	}
 }
 
-func NewExplicitHistogramMetrics(bounds []float64) HistogramMetrics {
+// NewExplicitHistogramMetrics creates explicit bucket histograms that keep at most
+// maxExemplars exemplars per data point, or all of them if maxExemplars is not positive.
+func NewExplicitHistogramMetrics(bounds []float64, maxExemplars int) HistogramMetrics {
 	return &explicitHistogramMetrics{
-		metrics: make(map[Key]*explicitHistogram),
-		bounds:  bounds,
+		metrics:      make(map[Key]*explicitHistogram),
+		bounds:       bounds,
+		maxExemplars: maxExemplars,
 	}
 }
 
@@ -72,7 +80,7 @@ func (m *explicitHistogramMetrics) GetOrCreate(key Key, attributes pcommon.Map)
 	if !ok {
 		h = &explicitHistogram{
 			attributes:   attributes,
-			exemplars:    pmetric.NewExemplarSlice(),
+			exemplars:    newExemplarReservoir(m.maxExemplars),
 			bounds:       m.bounds,
 			bucketCounts: make([]uint64, len(m.bounds)+1),
 		}
@@ -99,10 +107,7 @@ func (m *explicitHistogramMetrics) BuildMetrics(
 		dp.BucketCounts().FromRaw(h.bucketCounts)
 		dp.SetCount(h.count)
 		dp.SetSum(h.sum)
-		for i := 0; i < h.exemplars.Len(); i++ {
-			h.exemplars.At(i).SetTimestamp(timestamp)
-		}
-		h.exemplars.CopyTo(dp.Exemplars())
+		h.exemplars.copyTo(dp.Exemplars(), timestamp)
 		h.attributes.CopyTo(dp.Attributes())
 	}
 }
@@ -110,7 +115,7 @@ func (m *explicitHistogramMetrics) BuildMetrics(
 func (m *explicitHistogramMetrics) Reset(onlyExemplars bool) {
 	if onlyExemplars {
 		for _, h := range m.metrics {
-			h.exemplars = pmetric.NewExemplarSlice()
+			h.exemplars.reset()
 		}
 		return
 	}
@@ -130,7 +135,7 @@ func (m *exponentialHistogramMetrics) GetOrCreate(key Key, attributes pcommon.Ma
 		h = &exponentialHistogram{
 			histogram:  histogram,
 			attributes: attributes,
-			exemplars:  pmetric.NewExemplarSlice(),
+			exemplars:  newExemplarReservoir(m.maxExemplars),
 		}
 		m.metrics[key] = h
 	}
@@ -152,10 +157,7 @@ func (m *exponentialHistogramMetrics) BuildMetrics(
 		dp.SetStartTimestamp(start)
 		dp.SetTimestamp(timestamp)
 		expoHistToExponentialDataPoint(m.histogram, dp)
-		for i := 0; i < m.exemplars.Len(); i++ {
-			m.exemplars.At(i).SetTimestamp(timestamp)
-		}
-		m.exemplars.CopyTo(dp.Exemplars())
+		m.exemplars.copyTo(dp.Exemplars(), timestamp)
 		m.attributes.CopyTo(dp.Attributes())
 	}
 }
@@ -194,7 +196,7 @@ func expoHistToExponentialDataPoint(agg *structure.Histogram[float64], dp pmetri
 func (m *exponentialHistogramMetrics) Reset(onlyExemplars bool) {
 	if onlyExemplars {
 		for _, m := range m.metrics {
-			m.exemplars = pmetric.NewExemplarSlice()
+			m.exemplars.reset()
 		}
 		return
 	}
@@ -211,40 +213,52 @@ func (h *explicitHistogram) Observe(value float64) {
 	h.bucketCounts[index]++
 }
 
-func (h *explicitHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
-	e := h.exemplars.AppendEmpty()
-	e.SetTraceID(traceID)
-	e.SetSpanID(spanID)
-	e.SetDoubleValue(value)
+func (h *explicitHistogram) AddExemplar(e Exemplar) {
+	h.exemplars.add(e)
 }
 
 func (h *exponentialHistogram) Observe(value float64) {
 	h.histogram.Update(value)
 }
 
-func (h *exponentialHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
-	e := h.exemplars.AppendEmpty()
-	e.SetTraceID(traceID)
-	e.SetSpanID(spanID)
-	e.SetDoubleValue(value)
+func (h *exponentialHistogram) AddExemplar(e Exemplar) {
+	h.exemplars.add(e)
 }
 
 type Sum struct {
 	attributes pcommon.Map
 	count      uint64
-	exemplars  pmetric.ExemplarSlice
+	exemplars  *exemplarReservoir
 }
 
 func (s *Sum) Add(value uint64) {
 	s.count += value
 }
 
-func NewSumMetrics() SumMetrics {
-	return SumMetrics{metrics: make(map[Key]*Sum)}
+// NewSumMetrics creates sums that keep at most maxExemplars exemplars
+// per data point, or all of them if maxExemplars is not positive.
+func NewSumMetrics(maxExemplars int) SumMetrics {
+	return SumMetrics{
+		metrics:      make(map[Key]*Sum),
+		maxExemplars: maxExemplars,
+	}
 }
 
 type SumMetrics struct {
-	metrics map[Key]*Sum
+	metrics      map[Key]*Sum
+	maxExemplars int
+}
+
+// IsCardinalityLimitReached returns true if the key is a new series and the number of
+// series has reached the limit. There is no limit if limit is not positive.
+func (m *SumMetrics) IsCardinalityLimitReached(key Key, limit int) bool {
+	if limit <= 0 {
+		return false
+	}
+	if _, ok := m.metrics[key]; ok {
+		return false
+	}
+	return len(m.metrics) >= limit
 }
 
 func (m *SumMetrics) GetOrCreate(key Key, attributes pcommon.Map) *Sum {
@@ -252,18 +266,15 @@ func (m *SumMetrics) GetOrCreate(key Key, attributes pcommon.Map) *Sum {
 	if !ok {
 		s = &Sum{
 			attributes: attributes,
-			exemplars:  pmetric.NewExemplarSlice(),
+			exemplars:  newExemplarReservoir(m.maxExemplars),
 		}
 		m.metrics[key] = s
 	}
 	return s
 }
 
-func (s *Sum) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
-	e := s.exemplars.AppendEmpty()
-	e.SetTraceID(traceID)
-	e.SetSpanID(spanID)
-	e.SetDoubleValue(value)
+func (s *Sum) AddExemplar(e Exemplar) {
+	s.exemplars.add(e)
 }
 
 func (m *SumMetrics) BuildMetrics(
@@ -282,14 +293,18 @@ func (m *SumMetrics) BuildMetrics(
 		dp.SetStartTimestamp(start)
 		dp.SetTimestamp(timestamp)
 		dp.SetIntValue(int64(s.count))
-		for i := 0; i < s.exemplars.Len(); i++ {
-			s.exemplars.At(i).SetTimestamp(timestamp)
-		}
-		s.exemplars.CopyTo(dp.Exemplars())
+		s.exemplars.copyTo(dp.Exemplars(), timestamp)
 		s.attributes.CopyTo(dp.Attributes())
 	}
 }
 
-func (m *SumMetrics) Reset() {
+func (m *SumMetrics) Reset(onlyExemplars bool) {
+	if onlyExemplars {
+		for _, s := range m.metrics {
+			s.exemplars.reset()
+		}
+		return
+	}
+
 	m.metrics = make(map[Key]*Sum)
 }
@@ -42,14 +42,45 @@ type Config struct {
 	TTLDays uint `mapstructure:"ttl_days"`
 	// TTL is The data time-to-live example 30m, 48h. 0 means no ttl.
 	TTL time.Duration `mapstructure:"ttl"`
+	// CreateSchema creates the database, tables and views on start, and upgrades their schema. default is true.
+	// Disable it when the schema is managed outside the collector.
+	CreateSchema bool `mapstructure:"create_schema"`
+	// SchemaMigrationsTableName is the table recording the applied schema versions. default is `otel_schema_migrations`.
+	SchemaMigrationsTableName string `mapstructure:"schema_migrations_table_name"`
+	// LogsTable is the partitioning, sorting key and codec of the logs table.
+	LogsTable TableSettings `mapstructure:"logs_table"`
+	// TracesTable is the partitioning, sorting key and codec of the traces table.
+	TracesTable TableSettings `mapstructure:"traces_table"`
+	// MetricsTables is the partitioning, sorting key and codec of the metrics tables.
+	MetricsTables TableSettings `mapstructure:"metrics_tables"`
+	// TraceIDLookup creates a table with the time range of each trace, filled by a materialized view. default is true.
+	TraceIDLookup bool `mapstructure:"trace_id_lookup"`
+	// MetricsRollup creates per-minute rollup tables of the gauge and sum metrics, filled by materialized views.
+	MetricsRollup bool `mapstructure:"metrics_rollup"`
 }
 
-const defaultDatabase = "default"
+// TableSettings defines the schema settings of a table, applied when the table is created.
+type TableSettings struct {
+	// PartitionBy is the partition key expression. Empty means no partitions.
+	PartitionBy string `mapstructure:"partition_by"`
+	// OrderBy is the list of expressions of the sorting key.
+	OrderBy []string `mapstructure:"order_by"`
+	// Codec is the compression codec of the columns, for example ZSTD(1) or LZ4.
+	Codec string `mapstructure:"codec"`
+}
+
+const (
+	defaultDatabase                  = "default"
+	defaultSchemaMigrationsTableName = "otel_schema_migrations"
+)
 
 var (
 	errConfigNoEndpoint      = errors.New("endpoint must be specified")
 	errConfigInvalidEndpoint = errors.New("endpoint must be url format")
 	errConfigTTL             = errors.New("both 'ttl_days' and 'ttl' can not be provided. 'ttl_days' is deprecated, use 'ttl' instead")
+	errConfigNoMigrations    = errors.New("schema_migrations_table_name must be specified")
+	errConfigNoOrderBy       = errors.New("order_by must be specified")
+	errConfigNoCodec         = errors.New("codec must be specified")
 )
 
 // Validate the clickhouse server configuration.
@@ -66,6 +97,17 @@ func (cfg *Config) Validate() (err error) {
 		err = errors.Join(err, errConfigTTL)
 	}
 
+	if cfg.CreateSchema {
+		if cfg.SchemaMigrationsTableName == "" {
+			err = errors.Join(err, errConfigNoMigrations)
+		}
+		err = errors.Join(err,
+			cfg.LogsTable.validate("logs_table"),
+			cfg.TracesTable.validate("traces_table"),
+			cfg.MetricsTables.validate("metrics_tables"),
+		)
+	}
+
 	// Validate DSN with clickhouse driver.
 	// Last chance to catch invalid config.
 	if _, e := clickhouse.ParseDSN(dsn); e != nil {
@@ -75,6 +117,16 @@ func (cfg *Config) Validate() (err error) {
 	return err
 }
 
+func (s TableSettings) validate(name string) (err error) {
+	if len(s.OrderBy) == 0 {
+		err = errors.Join(err, fmt.Errorf("%s: %w", name, errConfigNoOrderBy))
+	}
+	if s.Codec == "" {
+		err = errors.Join(err, fmt.Errorf("%s: %w", name, errConfigNoCodec))
+	}
+	return err
+}
+
 func (cfg *Config) buildDSN(database string) (string, error) {
 	dsnURL, err := url.Parse(cfg.Endpoint)
 	if err != nil {
@@ -71,8 +71,36 @@ func TestLoadConfig(t *testing.T) {
 					QueueSize:    100,
 					StorageID:    &storageID,
 				},
+				CreateSchema:              true,
+				SchemaMigrationsTableName: defaultSchemaMigrationsTableName,
+				LogsTable:                 defaultCfg.(*Config).LogsTable,
+				TracesTable:               defaultCfg.(*Config).TracesTable,
+				MetricsTables:             defaultCfg.(*Config).MetricsTables,
+				TraceIDLookup:             true,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "schema"),
+			expected: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.SchemaMigrationsTableName = "schema_migrations"
+				cfg.LogsTable = TableSettings{
+					PartitionBy: "toYYYYMM(Timestamp)",
+					OrderBy:     []string{"ServiceName", "Timestamp"},
+					Codec:       "LZ4",
+				}
+				cfg.TraceIDLookup = false
+				cfg.MetricsRollup = true
+			}),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "schema-disabled"),
+			expected: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoint = defaultEndpoint
+				cfg.CreateSchema = false
+				cfg.LogsTable.Codec = ""
+			}),
+		},
 	}
 
 	for _, tt := range tests {
@@ -90,6 +118,22 @@ func TestLoadConfig(t *testing.T) {
 	}
 }
 
+func TestConfig_Validate_Schema(t *testing.T) {
+	cfg := withDefaultConfig(func(cfg *Config) {
+		cfg.Endpoint = defaultEndpoint
+		cfg.SchemaMigrationsTableName = ""
+		cfg.LogsTable.OrderBy = nil
+		cfg.MetricsTables.Codec = ""
+	})
+	err := component.ValidateConfig(cfg)
+	assert.ErrorIs(t, err, errConfigNoMigrations)
+	assert.ErrorContains(t, err, "logs_table: order_by must be specified")
+	assert.ErrorContains(t, err, "metrics_tables: codec must be specified")
+
+	cfg.CreateSchema = false
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
 func withDefaultConfig(fns ...func(*Config)) *Config {
 	cfg := createDefaultConfig().(*Config)
 	for _, fn := range fns {
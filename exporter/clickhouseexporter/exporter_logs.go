@@ -16,6 +16,7 @@ import (
 	conventions "go.opentelemetry.io/collector/semconv/v1.18.0"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
 )
 
@@ -42,11 +43,14 @@ func newLogsExporter(logger *zap.Logger, cfg *Config) (*logsExporter, error) {
 }
 
 func (e *logsExporter) start(ctx context.Context, _ component.Host) error {
+	if !e.cfg.CreateSchema {
+		return nil
+	}
 	if err := createDatabase(ctx, e.cfg); err != nil {
 		return err
 	}
 
-	return createLogsTable(ctx, e.cfg, e.client)
+	return migrateSchema(ctx, e.cfg, e.client, e.logger, logsSchema(e.cfg))
 }
 
 // shutdown will shut down the exporter.
@@ -154,8 +158,8 @@ CREATE TABLE IF NOT EXISTS %s (
      INDEX idx_body Body TYPE tokenbf_v1(32768, 3, 0) GRANULARITY 1
 ) ENGINE MergeTree()
 %s
-PARTITION BY toDate(Timestamp)
-ORDER BY (ServiceName, SeverityText, toUnixTimestamp(Timestamp), TraceId)
+%s
+ORDER BY (%s)
 SETTINGS index_granularity=8192, ttl_only_drop_parts = 1;
 `
 	// language=ClickHouse SQL
@@ -226,16 +230,20 @@ func createDatabase(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
-func createLogsTable(ctx context.Context, cfg *Config, db *sql.DB) error {
-	if _, err := db.ExecContext(ctx, renderCreateLogsTableSQL(cfg)); err != nil {
-		return fmt.Errorf("exec create logs table sql: %w", err)
+// logsSchema returns the versioned schema of the logs table.
+func logsSchema(cfg *Config) schemaObject {
+	return schemaObject{
+		name: cfg.LogsTableName,
+		migrations: []schemaMigration{
+			{version: 1, description: "create logs table", statements: []string{renderCreateLogsTableSQL(cfg)}},
+		},
 	}
-	return nil
 }
 
 func renderCreateLogsTableSQL(cfg *Config) string {
 	ttlExpr := generateTTLExpr(cfg.TTLDays, cfg.TTL)
-	return fmt.Sprintf(createLogsTableSQL, cfg.LogsTableName, ttlExpr)
+	return internal.RenderCreateTableSQL(createLogsTableSQL, cfg.LogsTableName, ttlExpr,
+		cfg.LogsTable.PartitionBy, cfg.LogsTable.OrderBy, cfg.LogsTable.Codec)
 }
 
 func renderInsertLogsSQL(cfg *Config) string {
@@ -8,6 +8,8 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"errors"
+	"fmt"
+	"io"
 	"strings"
 	"testing"
 	"time"
@@ -51,7 +53,7 @@ func TestLogsExporter_New(t *testing.T) {
 	}{
 		"no dsn": {
 			config: withDefaultConfig(),
-			want:   failWithMsg("exec create logs table sql: parse dsn address failed"),
+			want:   failWithMsg("exec create schema migrations table sql: parse dsn address failed"),
 		},
 	}
 
@@ -79,7 +81,7 @@ func TestExporter_pushLogsData(t *testing.T) {
 		var items int
 		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
 			t.Logf("%d, values:%+v", items, values)
-			if strings.HasPrefix(query, "INSERT") {
+			if strings.HasPrefix(query, "INSERT INTO otel_logs") {
 				items++
 			}
 			return nil
@@ -93,7 +95,7 @@ func TestExporter_pushLogsData(t *testing.T) {
 	})
 	t.Run("test check resource metadata", func(t *testing.T) {
 		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
-			if strings.HasPrefix(query, "INSERT") {
+			if strings.HasPrefix(query, "INSERT INTO otel_logs") {
 				require.Equal(t, "https://opentelemetry.io/schemas/1.4.0", values[8])
 				require.Equal(t, map[string]string{
 					"service.name": "test-service",
@@ -106,7 +108,7 @@ func TestExporter_pushLogsData(t *testing.T) {
 	})
 	t.Run("test check scope metadata", func(t *testing.T) {
 		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
-			if strings.HasPrefix(query, "INSERT") {
+			if strings.HasPrefix(query, "INSERT INTO otel_logs") {
 				require.Equal(t, "https://opentelemetry.io/schemas/1.7.0", values[10])
 				require.Equal(t, "io.opentelemetry.contrib.clickhouse", values[11])
 				require.Equal(t, "1.0.0", values[12])
@@ -165,32 +167,44 @@ func mustPushLogsData(t *testing.T, exporter *logsExporter, ld plog.Logs) {
 }
 
 func initClickhouseTestServer(t *testing.T, recorder recorder) {
+	initClickhouseTestServerWithRows(t, recorder, nil)
+}
+
+// initClickhouseTestServerWithRows registers a test driver whose queries return the rows of rows.
+func initClickhouseTestServerWithRows(t *testing.T, recorder recorder, rows rows) {
 	driverName = t.Name()
 	sql.Register(t.Name(), &testClickhouseDriver{
 		recorder: recorder,
+		rows:     rows,
 	})
 }
 
 type recorder func(query string, values []driver.Value) error
 
+type rows func(query string, values []driver.Value) [][]driver.Value
+
 type testClickhouseDriver struct {
 	recorder recorder
+	rows     rows
 }
 
 func (t *testClickhouseDriver) Open(_ string) (driver.Conn, error) {
 	return &testClickhouseDriverConn{
 		recorder: t.recorder,
+		rows:     t.rows,
 	}, nil
 }
 
 type testClickhouseDriverConn struct {
 	recorder recorder
+	rows     rows
 }
 
 func (t *testClickhouseDriverConn) Prepare(query string) (driver.Stmt, error) {
 	return &testClickhouseDriverStmt{
 		query:    query,
 		recorder: t.recorder,
+		rows:     t.rows,
 	}, nil
 }
 
@@ -209,6 +223,7 @@ func (*testClickhouseDriverConn) CheckNamedValue(_ *driver.NamedValue) error {
 type testClickhouseDriverStmt struct {
 	query    string
 	recorder recorder
+	rows     rows
 }
 
 func (*testClickhouseDriverStmt) Close() error {
@@ -223,8 +238,43 @@ func (t *testClickhouseDriverStmt) Exec(args []driver.Value) (driver.Result, err
 	return nil, t.recorder(t.query, args)
 }
 
-func (t *testClickhouseDriverStmt) Query(_ []driver.Value) (driver.Rows, error) {
-	return nil, nil
+func (t *testClickhouseDriverStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if err := t.recorder(t.query, args); err != nil {
+		return nil, err
+	}
+	var values [][]driver.Value
+	if t.rows != nil {
+		values = t.rows(t.query, args)
+	}
+	return &testClickhouseDriverRows{values: values}, nil
+}
+
+type testClickhouseDriverRows struct {
+	values [][]driver.Value
+}
+
+func (t *testClickhouseDriverRows) Columns() []string {
+	if len(t.values) == 0 {
+		return []string{"value"}
+	}
+	columns := make([]string, len(t.values[0]))
+	for i := range columns {
+		columns[i] = fmt.Sprintf("value%d", i)
+	}
+	return columns
+}
+
+func (*testClickhouseDriverRows) Close() error {
+	return nil
+}
+
+func (t *testClickhouseDriverRows) Next(dest []driver.Value) error {
+	if len(t.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, t.values[0])
+	t.values = t.values[1:]
+	return nil
 }
 
 type testClickhouseDriverTx struct {
@@ -37,14 +37,39 @@ func newMetricsExporter(logger *zap.Logger, cfg *Config) (*metricsExporter, erro
 }
 
 func (e *metricsExporter) start(ctx context.Context, _ component.Host) error {
+	internal.SetLogger(e.logger)
+
+	if !e.cfg.CreateSchema {
+		return nil
+	}
 	if err := createDatabase(ctx, e.cfg); err != nil {
 		return err
 	}
 
-	internal.SetLogger(e.logger)
+	return migrateSchema(ctx, e.cfg, e.client, e.logger, metricsSchema(e.cfg)...)
+}
 
-	ttlExpr := generateTTLExpr(e.cfg.TTLDays, e.cfg.TTL)
-	return internal.NewMetricsTable(ctx, e.cfg.MetricsTableName, ttlExpr, e.client)
+// metricsSchema returns the versioned schema of the metrics tables and, if enabled,
+// of their per-minute rollups.
+func metricsSchema(cfg *Config) []schemaObject {
+	ttlExpr := generateTTLExpr(cfg.TTLDays, cfg.TTL)
+	objects := []schemaObject{{
+		name: cfg.MetricsTableName,
+		migrations: []schemaMigration{
+			{version: 1, description: "create metrics tables", statements: internal.MetricsTablesSQL(cfg.MetricsTableName, ttlExpr,
+				cfg.MetricsTables.PartitionBy, cfg.MetricsTables.OrderBy, cfg.MetricsTables.Codec)},
+		},
+	}}
+	if cfg.MetricsRollup {
+		objects = append(objects, schemaObject{
+			name: cfg.MetricsTableName + "_1m",
+			migrations: []schemaMigration{
+				{version: 1, description: "create per-minute rollup tables and views", statements: internal.MetricsRollupSQL(cfg.Database,
+					cfg.MetricsTableName, cfg.MetricsTables.Codec)},
+			},
+		})
+	}
+	return objects
 }
 
 // shutdown will shut down the exporter.
@@ -23,7 +23,7 @@ func TestExporter_pushMetricsData(t *testing.T) {
 	t.Run("push success", func(t *testing.T) {
 		items := &atomic.Int32{}
 		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
-			if strings.HasPrefix(query, "INSERT") {
+			if strings.HasPrefix(query, "INSERT INTO otel_metrics") {
 				items.Add(1)
 			}
 			return nil
@@ -35,7 +35,7 @@ func TestExporter_pushMetricsData(t *testing.T) {
 	})
 	t.Run("push failure", func(t *testing.T) {
 		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
-			if strings.HasPrefix(query, "INSERT") {
+			if strings.HasPrefix(query, "INSERT INTO otel_metrics") {
 				return fmt.Errorf("mock insert error")
 			}
 			return nil
@@ -57,7 +57,7 @@ func TestExporter_pushMetricsData(t *testing.T) {
 			"otel_metrics_summary":               {},
 		}
 		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
-			if strings.HasPrefix(query, "INSERT") {
+			if strings.HasPrefix(query, "INSERT INTO otel_metrics") {
 				items.Add(1)
 				if strings.HasPrefix(query, "INSERT INTO otel_metrics_exponential_histogram") {
 					idx := itemIdxs["otel_metrics_exponential_histogram"]
@@ -16,6 +16,7 @@ import (
 	conventions "go.opentelemetry.io/collector/semconv/v1.18.0"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
 )
 
@@ -42,11 +43,14 @@ func newTracesExporter(logger *zap.Logger, cfg *Config) (*tracesExporter, error)
 }
 
 func (e *tracesExporter) start(ctx context.Context, _ component.Host) error {
+	if !e.cfg.CreateSchema {
+		return nil
+	}
 	if err := createDatabase(ctx, e.cfg); err != nil {
 		return err
 	}
 
-	return createTracesTable(ctx, e.cfg, e.client)
+	return migrateSchema(ctx, e.cfg, e.client, e.logger, tracesSchema(e.cfg)...)
 }
 
 // shutdown will shut down the exporter.
@@ -193,8 +197,8 @@ CREATE TABLE IF NOT EXISTS %s (
      INDEX idx_duration Duration TYPE minmax GRANULARITY 1
 ) ENGINE MergeTree()
 %s
-PARTITION BY toDate(Timestamp)
-ORDER BY (ServiceName, SpanName, toUnixTimestamp(Timestamp), TraceId)
+%s
+ORDER BY (%s)
 SETTINGS index_granularity=8192, ttl_only_drop_parts = 1;
 `
 	// language=ClickHouse SQL
@@ -273,17 +277,27 @@ GROUP BY TraceId;
 `
 )
 
-func createTracesTable(ctx context.Context, cfg *Config, db *sql.DB) error {
-	if _, err := db.ExecContext(ctx, renderCreateTracesTableSQL(cfg)); err != nil {
-		return fmt.Errorf("exec create traces table sql: %w", err)
+// tracesSchema returns the versioned schema of the traces table and, if enabled,
+// of the trace ID lookup table.
+func tracesSchema(cfg *Config) []schemaObject {
+	objects := []schemaObject{{
+		name: cfg.TracesTableName,
+		migrations: []schemaMigration{
+			{version: 1, description: "create traces table", statements: []string{renderCreateTracesTableSQL(cfg)}},
+		},
+	}}
+	if cfg.TraceIDLookup {
+		objects = append(objects, schemaObject{
+			name: cfg.TracesTableName + "_trace_id_ts",
+			migrations: []schemaMigration{
+				{version: 1, description: "create trace ID lookup table and view", statements: []string{
+					renderCreateTraceIDTsTableSQL(cfg),
+					renderTraceIDTsMaterializedViewSQL(cfg),
+				}},
+			},
+		})
 	}
-	if _, err := db.ExecContext(ctx, renderCreateTraceIDTsTableSQL(cfg)); err != nil {
-		return fmt.Errorf("exec create traceIDTs table sql: %w", err)
-	}
-	if _, err := db.ExecContext(ctx, renderTraceIDTsMaterializedViewSQL(cfg)); err != nil {
-		return fmt.Errorf("exec create traceIDTs view sql: %w", err)
-	}
-	return nil
+	return objects
 }
 
 func renderInsertTracesSQL(cfg *Config) string {
@@ -292,7 +306,8 @@ func renderInsertTracesSQL(cfg *Config) string {
 
 func renderCreateTracesTableSQL(cfg *Config) string {
 	ttlExpr := generateTTLExpr(cfg.TTLDays, cfg.TTL)
-	return fmt.Sprintf(createTracesTableSQL, cfg.TracesTableName, ttlExpr)
+	return internal.RenderCreateTableSQL(createTracesTableSQL, cfg.TracesTableName, ttlExpr,
+		cfg.TracesTable.PartitionBy, cfg.TracesTable.OrderBy, cfg.TracesTable.Codec)
 }
 
 func renderCreateTraceIDTsTableSQL(cfg *Config) string {
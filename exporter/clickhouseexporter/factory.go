@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal/metadata"
 )
 
@@ -33,15 +34,33 @@ func createDefaultConfig() component.Config {
 	queueSettings.NumConsumers = 1
 
 	return &Config{
-		TimeoutSettings:  exporterhelper.NewDefaultTimeoutSettings(),
-		QueueSettings:    queueSettings,
-		RetrySettings:    exporterhelper.NewDefaultRetrySettings(),
-		ConnectionParams: map[string]string{},
-		Database:         defaultDatabase,
-		LogsTableName:    "otel_logs",
-		TracesTableName:  "otel_traces",
-		MetricsTableName: "otel_metrics",
-		TTL:              0,
+		TimeoutSettings:           exporterhelper.NewDefaultTimeoutSettings(),
+		QueueSettings:             queueSettings,
+		RetrySettings:             exporterhelper.NewDefaultRetrySettings(),
+		ConnectionParams:          map[string]string{},
+		Database:                  defaultDatabase,
+		LogsTableName:             "otel_logs",
+		TracesTableName:           "otel_traces",
+		MetricsTableName:          "otel_metrics",
+		TTL:                       0,
+		CreateSchema:              true,
+		SchemaMigrationsTableName: defaultSchemaMigrationsTableName,
+		LogsTable: TableSettings{
+			PartitionBy: "toDate(Timestamp)",
+			OrderBy:     []string{"ServiceName", "SeverityText", "toUnixTimestamp(Timestamp)", "TraceId"},
+			Codec:       internal.DefaultCodec,
+		},
+		TracesTable: TableSettings{
+			PartitionBy: "toDate(Timestamp)",
+			OrderBy:     []string{"ServiceName", "SpanName", "toUnixTimestamp(Timestamp)", "TraceId"},
+			Codec:       internal.DefaultCodec,
+		},
+		MetricsTables: TableSettings{
+			PartitionBy: "toDate(TimeUnix)",
+			OrderBy:     []string{"MetricName", "Attributes", "toUnixTimestamp64Nano(TimeUnix)"},
+			Codec:       internal.DefaultCodec,
+		},
+		TraceIDLookup: true,
 	}
 }
 
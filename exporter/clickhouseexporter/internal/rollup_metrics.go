@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// language=ClickHouse SQL
+	createRollupTableSQL = `
+CREATE TABLE IF NOT EXISTS %s_%s_1m (
+    ResourceAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+    ServiceName LowCardinality(String) CODEC(ZSTD(1)),
+    MetricName String CODEC(ZSTD(1)),
+    MetricUnit String CODEC(ZSTD(1)),
+    Attributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+    Minute DateTime CODEC(Delta, ZSTD(1)),
+    Min SimpleAggregateFunction(min, Float64) CODEC(ZSTD(1)),
+    Max SimpleAggregateFunction(max, Float64) CODEC(ZSTD(1)),
+    Sum SimpleAggregateFunction(sum, Float64) CODEC(ZSTD(1)),
+    Count SimpleAggregateFunction(sum, UInt64) CODEC(ZSTD(1))
+) ENGINE AggregatingMergeTree()
+PARTITION BY toDate(Minute)
+ORDER BY (ServiceName, MetricName, MetricUnit, ResourceAttributes, Attributes, Minute)
+SETTINGS index_granularity=8192;
+`
+	// language=ClickHouse SQL
+	createRollupViewSQL = `
+CREATE MATERIALIZED VIEW IF NOT EXISTS %[2]s_%[3]s_1m_mv
+TO %[1]s.%[2]s_%[3]s_1m
+AS SELECT
+    ResourceAttributes,
+    ResourceAttributes['service.name'] AS ServiceName,
+    MetricName,
+    MetricUnit,
+    Attributes,
+    toStartOfMinute(TimeUnix) AS Minute,
+    min(Value) AS Min,
+    max(Value) AS Max,
+    sum(Value) AS Sum,
+    count() AS Count
+FROM %[1]s.%[2]s_%[3]s%[4]s
+GROUP BY ResourceAttributes, MetricName, MetricUnit, Attributes, Minute;
+`
+)
+
+// rollupMetricTypes are the suffixes of the metric tables with a per-minute rollup, and the
+// filter of the data points that can be aggregated: only the delta sums are, since the sum
+// of the points of cumulative sums is meaningless.
+var rollupMetricTypes = []struct {
+	name   string
+	filter string
+}{
+	{name: "gauge"},
+	// 1 is AGGREGATION_TEMPORALITY_DELTA.
+	{name: "sum", filter: "\nWHERE AggTemp = 1"},
+}
+
+// MetricsRollupSQL renders the statements creating the per-minute rollup tables of the gauge and delta sum
+// metrics, and the materialized views filling them from the metric tables.
+func MetricsRollupSQL(database string, tableName string, codec string) []string {
+	queries := make([]string, 0, 2*len(rollupMetricTypes))
+	for _, metricType := range rollupMetricTypes {
+		query := fmt.Sprintf(createRollupTableSQL, tableName, metricType.name)
+		if codec != "" && codec != DefaultCodec {
+			query = strings.ReplaceAll(query, DefaultCodec, codec)
+		}
+		queries = append(queries, query, fmt.Sprintf(createRollupViewSQL, database, tableName, metricType.name, metricType.filter))
+	}
+	return queries
+}
@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultCodec is the compression codec of the columns in the create table templates.
+const DefaultCodec = "ZSTD(1)"
+
+// RenderCreateTableSQL renders a create table template, whose placeholders are the table name,
+// the TTL, the PARTITION BY clause and the ORDER BY expressions, with the codec of its columns.
+// An empty partitionBy creates a table without partitions.
+func RenderCreateTableSQL(template string, tableName string, ttlExpr string, partitionBy string, orderBy []string, codec string) string {
+	var partitionExpr string
+	if partitionBy != "" {
+		partitionExpr = "PARTITION BY " + partitionBy
+	}
+	query := fmt.Sprintf(template, tableName, ttlExpr, partitionExpr, strings.Join(orderBy, ", "))
+	if codec != "" && codec != DefaultCodec {
+		query = strings.ReplaceAll(query, DefaultCodec, codec)
+	}
+	return query
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouseexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// language=ClickHouse SQL
+	createSchemaMigrationsTableSQL = `
+CREATE TABLE IF NOT EXISTS %s (
+     Name String,
+     Version UInt32,
+     Description String,
+     Checksum String,
+     AppliedAt DateTime64(3) DEFAULT now64(3)
+) ENGINE ReplacingMergeTree()
+ORDER BY (Name, Version);
+`
+	// language=ClickHouse SQL
+	selectSchemaVersionSQL = `SELECT Version, Checksum FROM %s FINAL WHERE Name = ? ORDER BY Version DESC LIMIT 1`
+	// language=ClickHouse SQL
+	insertSchemaVersionSQL = `INSERT INTO %s (Name, Version, Description, Checksum) VALUES (?, ?, ?, ?)`
+)
+
+// schemaMigration upgrades the schema of a schemaObject to its version.
+// The statements must be idempotent: they are applied again if the exporter
+// stops before the version is recorded, or by several exporters starting at the same time.
+type schemaMigration struct {
+	version     uint32
+	description string
+	statements  []string
+}
+
+// checksum identifies the statements of the migration, which are rendered from the settings
+// of the exporter, e.g. the TTL or the sorting key of the tables.
+func (m schemaMigration) checksum() string {
+	sum := sha256.Sum256([]byte(strings.Join(m.statements, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// schemaObject is a set of tables and views whose schema is versioned together,
+// named after its main table.
+type schemaObject struct {
+	name       string
+	migrations []schemaMigration
+}
+
+// migrateSchema applies the migrations of the objects newer than their version
+// recorded in the schema migrations table, in order. The migrations already applied are
+// not applied again, so a warning is logged when the settings they were rendered from changed.
+func migrateSchema(ctx context.Context, cfg *Config, db *sql.DB, logger *zap.Logger, objects ...schemaObject) error {
+	query := fmt.Sprintf(createSchemaMigrationsTableSQL, cfg.SchemaMigrationsTableName)
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("exec create schema migrations table sql: %w", err)
+	}
+
+	for _, object := range objects {
+		version, checksum, err := schemaVersion(ctx, cfg, db, object.name)
+		if err != nil {
+			return err
+		}
+		for _, migration := range object.migrations {
+			if migration.version == version && checksum != migration.checksum() {
+				logger.Warn("The schema settings differ from the ones the schema was created with, the tables and views must be altered by hand to apply them",
+					zap.String("object", object.name),
+					zap.Uint32("version", migration.version))
+			}
+			if migration.version <= version {
+				continue
+			}
+			logger.Info("Applying schema migration",
+				zap.String("object", object.name),
+				zap.Uint32("version", migration.version),
+				zap.String("description", migration.description))
+			for _, statement := range migration.statements {
+				if _, err := db.ExecContext(ctx, statement); err != nil {
+					return fmt.Errorf("exec migration %d of %s: %w", migration.version, object.name, err)
+				}
+			}
+			if err := recordSchemaVersion(ctx, cfg, db, object.name, migration); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// schemaVersion returns the last version of the object applied and its checksum, 0 if none is.
+func schemaVersion(ctx context.Context, cfg *Config, db *sql.DB, name string) (uint32, string, error) {
+	var version uint32
+	var checksum string
+	err := db.QueryRowContext(ctx, fmt.Sprintf(selectSchemaVersionSQL, cfg.SchemaMigrationsTableName), name).Scan(&version, &checksum)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, "", fmt.Errorf("query schema version of %s: %w", name, err)
+	}
+	return version, checksum, nil
+}
+
+func recordSchemaVersion(ctx context.Context, cfg *Config, db *sql.DB, name string, migration schemaMigration) error {
+	err := doWithTx(ctx, db, func(tx *sql.Tx) error {
+		statement, err := tx.PrepareContext(ctx, fmt.Sprintf(insertSchemaVersionSQL, cfg.SchemaMigrationsTableName))
+		if err != nil {
+			return fmt.Errorf("PrepareContext:%w", err)
+		}
+		defer func() {
+			_ = statement.Close()
+		}()
+		_, err = statement.ExecContext(ctx, name, migration.version, migration.description, migration.checksum())
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("record migration %d of %s: %w", migration.version, name, err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package clickhouseexporter
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestMigrateSchema(t *testing.T) {
+	cfg := withTestExporterConfig()(defaultEndpoint)
+	objects := tracesSchema(cfg)
+	tests := []struct {
+		name     string
+		checksum string
+		warning  bool
+	}{
+		{
+			name:     "same settings",
+			checksum: objects[0].migrations[0].checksum(),
+		},
+		{
+			name:     "changed settings",
+			checksum: "other",
+			warning:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []string
+			var recorded [][]driver.Value
+			initClickhouseTestServerWithRows(t, func(query string, values []driver.Value) error {
+				queries = append(queries, query)
+				if strings.HasPrefix(query, "INSERT INTO otel_schema_migrations") {
+					recorded = append(recorded, values)
+				}
+				return nil
+			}, func(_ string, values []driver.Value) [][]driver.Value {
+				// The first migration of otel_traces is already applied.
+				if values[0] == "otel_traces" {
+					return [][]driver.Value{{int64(1), tt.checksum}}
+				}
+				return nil
+			})
+
+			db, err := newClickhouseClient(cfg)
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, db.Close())
+			}()
+
+			objects := tracesSchema(cfg)
+			objects[0].migrations = append(objects[0].migrations, schemaMigration{
+				version:     2,
+				description: "add index",
+				statements:  []string{"ALTER TABLE otel_traces ADD INDEX IF NOT EXISTS idx_span_name SpanName TYPE bloom_filter GRANULARITY 1"},
+			})
+			core, logs := observer.New(zap.WarnLevel)
+			require.NoError(t, migrateSchema(context.Background(), cfg, db, zap.New(core), objects...))
+
+			assert.Contains(t, queries[0], "CREATE TABLE IF NOT EXISTS otel_schema_migrations")
+			assert.Contains(t, queries, objects[0].migrations[1].statements[0])
+			assert.NotContains(t, queries, renderCreateTracesTableSQL(cfg))
+			assert.Contains(t, queries, renderCreateTraceIDTsTableSQL(cfg))
+			assert.Contains(t, queries, renderTraceIDTsMaterializedViewSQL(cfg))
+			assert.Equal(t, [][]driver.Value{
+				{"otel_traces", uint32(2), "add index", objects[0].migrations[1].checksum()},
+				{"otel_traces_trace_id_ts", uint32(1), "create trace ID lookup table and view", objects[1].migrations[0].checksum()},
+			}, recorded)
+			if tt.warning {
+				require.Equal(t, 1, logs.Len())
+				assert.Equal(t, "otel_traces", logs.All()[0].ContextMap()["object"])
+			} else {
+				assert.Zero(t, logs.Len())
+			}
+		})
+	}
+}
+
+func TestExporter_CreateSchemaDisabled(t *testing.T) {
+	initClickhouseTestServer(t, func(query string, _ []driver.Value) error {
+		t.Errorf("unexpected query: %s", query)
+		return nil
+	})
+	newTestLogsExporter(t, defaultEndpoint, func(cfg *Config) {
+		cfg.CreateSchema = false
+	})
+	newTestTracesExporter(t, defaultEndpoint, func(cfg *Config) {
+		cfg.CreateSchema = false
+	})
+}
+
+func TestRenderCreateLogsTableSQL(t *testing.T) {
+	cfg := withDefaultConfig(func(cfg *Config) {
+		cfg.LogsTable = TableSettings{
+			OrderBy: []string{"ServiceName", "Timestamp"},
+			Codec:   "LZ4",
+		}
+	})
+	query := renderCreateLogsTableSQL(cfg)
+	assert.Contains(t, query, "Timestamp DateTime64(9) CODEC(Delta, LZ4)")
+	assert.Contains(t, query, "Body String CODEC(LZ4)")
+	assert.NotContains(t, query, "ZSTD(1)")
+	assert.NotContains(t, query, "PARTITION BY")
+	assert.Contains(t, query, "ORDER BY (ServiceName, Timestamp)")
+
+	query = renderCreateLogsTableSQL(withDefaultConfig())
+	assert.Contains(t, query, "PARTITION BY toDate(Timestamp)")
+	assert.Contains(t, query, "ORDER BY (ServiceName, SeverityText, toUnixTimestamp(Timestamp), TraceId)")
+}
+
+func TestMetricsSchema(t *testing.T) {
+	objects := metricsSchema(withDefaultConfig())
+	require.Len(t, objects, 1)
+	assert.Equal(t, "otel_metrics", objects[0].name)
+	assert.Len(t, objects[0].migrations[0].statements, 5)
+
+	objects = metricsSchema(withDefaultConfig(func(cfg *Config) {
+		cfg.Database = "otel"
+		cfg.MetricsRollup = true
+	}))
+	require.Len(t, objects, 2)
+	assert.Equal(t, "otel_metrics_1m", objects[1].name)
+	statements := objects[1].migrations[0].statements
+	require.Len(t, statements, 4)
+	assert.Contains(t, statements[0], "CREATE TABLE IF NOT EXISTS otel_metrics_gauge_1m")
+	assert.Contains(t, statements[0], "ORDER BY (ServiceName, MetricName, MetricUnit, ResourceAttributes, Attributes, Minute)")
+	assert.Contains(t, statements[1], "TO otel.otel_metrics_gauge_1m")
+	assert.Contains(t, statements[1], "FROM otel.otel_metrics_gauge\nGROUP BY")
+	assert.Contains(t, statements[3], "FROM otel.otel_metrics_sum\nWHERE AggTemp = 1\nGROUP BY")
+}
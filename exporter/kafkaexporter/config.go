@@ -35,6 +35,18 @@ type Config struct {
 	// The name of the kafka topic to export to (default otlp_spans for traces, otlp_metrics for metrics)
 	Topic string `mapstructure:"topic"`
 
+	// TopicFromAttribute is the resource attribute holding the topic of the telemetry,
+	// Topic is used for the telemetry without it.
+	TopicFromAttribute string `mapstructure:"topic_from_attribute"`
+
+	// PartitionTracesByID if true the trace ID is the key of the traces messages, so that
+	// the spans of a trace are in the same partition.
+	PartitionTracesByID bool `mapstructure:"partition_traces_by_id"`
+
+	// MessageKeyFromAttribute is the resource attribute holding the key of the metrics
+	// and logs messages, so that the telemetry of a resource is in the same partition.
+	MessageKeyFromAttribute string `mapstructure:"message_key_from_attribute"`
+
 	// Encoding of messages (default "otlp_proto")
 	Encoding string `mapstructure:"encoding"`
 
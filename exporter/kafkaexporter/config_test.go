@@ -54,9 +54,12 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 2,
 					QueueSize:    10,
 				},
-				Topic:    "spans",
-				Encoding: "otlp_proto",
-				Brokers:  []string{"foo:123", "bar:456"},
+				Topic:                   "spans",
+				TopicFromAttribute:      "kafka.topic",
+				PartitionTracesByID:     true,
+				MessageKeyFromAttribute: "service.name",
+				Encoding:                "otlp_proto",
+				Brokers:                 []string{"foo:123", "bar:456"},
 				Authentication: kafka.Authentication{
 					PlainText: &kafka.PlainTextConfig{
 						Username: "jdoe",
@@ -106,9 +109,12 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 2,
 					QueueSize:    10,
 				},
-				Topic:    "spans",
-				Encoding: "otlp_proto",
-				Brokers:  []string{"foo:123", "bar:456"},
+				Topic:                   "spans",
+				TopicFromAttribute:      "kafka.topic",
+				PartitionTracesByID:     true,
+				MessageKeyFromAttribute: "service.name",
+				Encoding:                "otlp_proto",
+				Brokers:                 []string{"foo:123", "bar:456"},
 				Authentication: kafka.Authentication{
 					PlainText: &kafka.PlainTextConfig{
 						Username: "jdoe",
@@ -158,6 +164,9 @@ func TestLoadConfig(t *testing.T) {
 					QueueSize:    10,
 				},
 				Topic:                                "spans",
+				TopicFromAttribute:                   "kafka.topic",
+				PartitionTracesByID:                  true,
+				MessageKeyFromAttribute:              "service.name",
 				Encoding:                             "otlp_proto",
 				Brokers:                              []string{"foo:123", "bar:456"},
 				ResolveCanonicalBootstrapServersOnly: true,
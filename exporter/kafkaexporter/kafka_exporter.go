@@ -23,10 +23,12 @@ var errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
 
 // kafkaTracesProducer uses sarama to produce trace messages to Kafka.
 type kafkaTracesProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler TracesMarshaler
-	logger    *zap.Logger
+	producer       sarama.SyncProducer
+	topic          string
+	topicAttribute string
+	partitionByID  bool
+	marshaler      TracesMarshaler
+	logger         *zap.Logger
 }
 
 type kafkaErrors struct {
@@ -39,11 +41,16 @@ func (ke kafkaErrors) Error() string {
 }
 
 func (e *kafkaTracesProducer) tracesPusher(_ context.Context, td ptrace.Traces) error {
-	messages, err := e.marshaler.Marshal(td, e.topic)
-	if err != nil {
-		return consumererror.NewPermanent(err)
+	var messages []*sarama.ProducerMessage
+	for _, batch := range splitTraces(td, e.topicAttribute, e.topic, e.partitionByID) {
+		batchMessages, err := e.marshaler.Marshal(batch.data, batch.topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		setMessagesKey(batchMessages, batch.key)
+		messages = append(messages, batchMessages...)
 	}
-	err = e.producer.SendMessages(messages)
+	err := e.producer.SendMessages(messages)
 	if err != nil {
 		var prodErr sarama.ProducerErrors
 		if errors.As(err, &prodErr) {
@@ -62,18 +69,25 @@ func (e *kafkaTracesProducer) Close(context.Context) error {
 
 // kafkaMetricsProducer uses sarama to produce metrics messages to kafka
 type kafkaMetricsProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler MetricsMarshaler
-	logger    *zap.Logger
+	producer       sarama.SyncProducer
+	topic          string
+	topicAttribute string
+	keyAttribute   string
+	marshaler      MetricsMarshaler
+	logger         *zap.Logger
 }
 
 func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pmetric.Metrics) error {
-	messages, err := e.marshaler.Marshal(md, e.topic)
-	if err != nil {
-		return consumererror.NewPermanent(err)
+	var messages []*sarama.ProducerMessage
+	for _, batch := range splitMetrics(md, e.topicAttribute, e.topic, e.keyAttribute) {
+		batchMessages, err := e.marshaler.Marshal(batch.data, batch.topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		setMessagesKey(batchMessages, batch.key)
+		messages = append(messages, batchMessages...)
 	}
-	err = e.producer.SendMessages(messages)
+	err := e.producer.SendMessages(messages)
 	if err != nil {
 		var prodErr sarama.ProducerErrors
 		if errors.As(err, &prodErr) {
@@ -92,18 +106,25 @@ func (e *kafkaMetricsProducer) Close(context.Context) error {
 
 // kafkaLogsProducer uses sarama to produce logs messages to kafka
 type kafkaLogsProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler LogsMarshaler
-	logger    *zap.Logger
+	producer       sarama.SyncProducer
+	topic          string
+	topicAttribute string
+	keyAttribute   string
+	marshaler      LogsMarshaler
+	logger         *zap.Logger
 }
 
 func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld plog.Logs) error {
-	messages, err := e.marshaler.Marshal(ld, e.topic)
-	if err != nil {
-		return consumererror.NewPermanent(err)
+	var messages []*sarama.ProducerMessage
+	for _, batch := range splitLogs(ld, e.topicAttribute, e.topic, e.keyAttribute) {
+		batchMessages, err := e.marshaler.Marshal(batch.data, batch.topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		setMessagesKey(batchMessages, batch.key)
+		messages = append(messages, batchMessages...)
 	}
-	err = e.producer.SendMessages(messages)
+	err := e.producer.SendMessages(messages)
 	if err != nil {
 		var prodErr sarama.ProducerErrors
 		if errors.As(err, &prodErr) {
@@ -174,10 +195,12 @@ func newMetricsExporter(config Config, set exporter.CreateSettings, marshalers m
 	}
 
 	return &kafkaMetricsProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:       producer,
+		topic:          config.Topic,
+		topicAttribute: config.TopicFromAttribute,
+		keyAttribute:   config.MessageKeyFromAttribute,
+		marshaler:      marshaler,
+		logger:         set.Logger,
 	}, nil
 
 }
@@ -193,10 +216,12 @@ func newTracesExporter(config Config, set exporter.CreateSettings, marshalers ma
 		return nil, err
 	}
 	return &kafkaTracesProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:       producer,
+		topic:          config.Topic,
+		topicAttribute: config.TopicFromAttribute,
+		partitionByID:  config.PartitionTracesByID,
+		marshaler:      marshaler,
+		logger:         set.Logger,
 	}, nil
 }
 
@@ -211,10 +236,12 @@ func newLogsExporter(config Config, set exporter.CreateSettings, marshalers map[
 	}
 
 	return &kafkaLogsProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:       producer,
+		topic:          config.Topic,
+		topicAttribute: config.TopicFromAttribute,
+		keyAttribute:   config.MessageKeyFromAttribute,
+		marshaler:      marshaler,
+		logger:         set.Logger,
 	}, nil
 
 }
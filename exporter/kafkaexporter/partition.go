@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// messageBatch is the telemetry sent to a topic with a message key.
+type messageBatch[T any] struct {
+	topic string
+	key   string
+	data  T
+}
+
+type batchID struct {
+	topic string
+	key   string
+}
+
+// getTopic returns the topic of the resource, read from the attribute if set,
+// the default topic otherwise.
+func getTopic(resource pcommon.Resource, attribute string, defaultTopic string) string {
+	if attribute == "" {
+		return defaultTopic
+	}
+	if v, ok := resource.Attributes().Get(attribute); ok && v.AsString() != "" {
+		return v.AsString()
+	}
+	return defaultTopic
+}
+
+// getKey returns the message key of the resource, read from the attribute.
+func getKey(resource pcommon.Resource, attribute string) string {
+	if attribute == "" {
+		return ""
+	}
+	if v, ok := resource.Attributes().Get(attribute); ok {
+		return v.AsString()
+	}
+	return ""
+}
+
+// setMessagesKey sets the key of the messages that don't have one yet.
+func setMessagesKey(messages []*sarama.ProducerMessage, key string) {
+	if key == "" {
+		return
+	}
+	for _, m := range messages {
+		if m.Key == nil {
+			m.Key = sarama.ByteEncoder(key)
+		}
+	}
+}
+
+// splitTraces splits the traces per topic and, if partitionByID is set, per trace ID.
+// The batches are in the order of their first span so that the order of the spans
+// of a key is kept.
+func splitTraces(td ptrace.Traces, topicAttribute string, defaultTopic string, partitionByID bool) []messageBatch[ptrace.Traces] {
+	if topicAttribute == "" && !partitionByID {
+		return []messageBatch[ptrace.Traces]{{topic: defaultTopic, data: td}}
+	}
+
+	var batches []messageBatch[ptrace.Traces]
+	indexes := make(map[batchID]int)
+	getBatch := func(id batchID) ptrace.Traces {
+		i, ok := indexes[id]
+		if !ok {
+			i = len(batches)
+			indexes[id] = i
+			batches = append(batches, messageBatch[ptrace.Traces]{topic: id.topic, key: id.key, data: ptrace.NewTraces()})
+		}
+		return batches[i].data
+	}
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		topic := getTopic(rs.Resource(), topicAttribute, defaultTopic)
+		if !partitionByID {
+			rs.CopyTo(getBatch(batchID{topic: topic}).ResourceSpans().AppendEmpty())
+			continue
+		}
+
+		// The resource and scopes are copied once per trace.
+		resourceSpans := make(map[batchID]ptrace.ResourceSpans)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			scopeSpans := make(map[batchID]ptrace.ScopeSpans)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				id := batchID{topic: topic, key: span.TraceID().String()}
+				destSS, ok := scopeSpans[id]
+				if !ok {
+					destRS, ok := resourceSpans[id]
+					if !ok {
+						destRS = getBatch(id).ResourceSpans().AppendEmpty()
+						rs.Resource().CopyTo(destRS.Resource())
+						destRS.SetSchemaUrl(rs.SchemaUrl())
+						resourceSpans[id] = destRS
+					}
+					destSS = destRS.ScopeSpans().AppendEmpty()
+					ss.Scope().CopyTo(destSS.Scope())
+					destSS.SetSchemaUrl(ss.SchemaUrl())
+					scopeSpans[id] = destSS
+				}
+				span.CopyTo(destSS.Spans().AppendEmpty())
+			}
+		}
+	}
+	return batches
+}
+
+// splitMetrics splits the metrics per topic and message key, both read from
+// resource attributes.
+func splitMetrics(md pmetric.Metrics, topicAttribute string, defaultTopic string, keyAttribute string) []messageBatch[pmetric.Metrics] {
+	if topicAttribute == "" && keyAttribute == "" {
+		return []messageBatch[pmetric.Metrics]{{topic: defaultTopic, data: md}}
+	}
+
+	var batches []messageBatch[pmetric.Metrics]
+	indexes := make(map[batchID]int)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		id := batchID{
+			topic: getTopic(rm.Resource(), topicAttribute, defaultTopic),
+			key:   getKey(rm.Resource(), keyAttribute),
+		}
+		idx, ok := indexes[id]
+		if !ok {
+			idx = len(batches)
+			indexes[id] = idx
+			batches = append(batches, messageBatch[pmetric.Metrics]{topic: id.topic, key: id.key, data: pmetric.NewMetrics()})
+		}
+		rm.CopyTo(batches[idx].data.ResourceMetrics().AppendEmpty())
+	}
+	return batches
+}
+
+// splitLogs splits the logs per topic and message key, both read from resource
+// attributes.
+func splitLogs(ld plog.Logs, topicAttribute string, defaultTopic string, keyAttribute string) []messageBatch[plog.Logs] {
+	if topicAttribute == "" && keyAttribute == "" {
+		return []messageBatch[plog.Logs]{{topic: defaultTopic, data: ld}}
+	}
+
+	var batches []messageBatch[plog.Logs]
+	indexes := make(map[batchID]int)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		id := batchID{
+			topic: getTopic(rl.Resource(), topicAttribute, defaultTopic),
+			key:   getKey(rl.Resource(), keyAttribute),
+		}
+		idx, ok := indexes[id]
+		if !ok {
+			idx = len(batches)
+			indexes[id] = idx
+			batches = append(batches, messageBatch[plog.Logs]{topic: id.topic, key: id.key, data: plog.NewLogs()})
+		}
+		rl.CopyTo(batches[idx].data.ResourceLogs().AppendEmpty())
+	}
+	return batches
+}
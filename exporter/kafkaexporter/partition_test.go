@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkaexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	traceID1 = pcommon.TraceID([16]byte{1})
+	traceID2 = pcommon.TraceID([16]byte{2})
+)
+
+func generateTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, service := range []string{"a", "b"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", service)
+		rs.Resource().Attributes().PutStr("topic", "spans_"+service)
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName("scope")
+		for _, traceID := range []pcommon.TraceID{traceID1, traceID2, traceID1} {
+			ss.Spans().AppendEmpty().SetTraceID(traceID)
+		}
+	}
+	return td
+}
+
+func generateMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, service := range []string{"a", "b", "a", "c"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("service.name", service)
+		if service != "c" {
+			rm.Resource().Attributes().PutStr("topic", "metrics_"+service)
+		}
+		rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+	}
+	return md
+}
+
+func generateLogs() plog.Logs {
+	ld := plog.NewLogs()
+	for _, service := range []string{"a", "b", "a"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", service)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("log " + service)
+	}
+	return ld
+}
+
+func TestSplitTraces(t *testing.T) {
+	td := generateTraces()
+
+	batches := splitTraces(td, "", "otlp_spans", false)
+	require.Len(t, batches, 1)
+	assert.Equal(t, "otlp_spans", batches[0].topic)
+	assert.Equal(t, "", batches[0].key)
+	assert.Equal(t, td, batches[0].data)
+
+	batches = splitTraces(td, "topic", "otlp_spans", false)
+	require.Len(t, batches, 2)
+	assert.Equal(t, "spans_a", batches[0].topic)
+	assert.Equal(t, "spans_b", batches[1].topic)
+	assert.Equal(t, 3, batches[0].data.SpanCount())
+	assert.Equal(t, 3, batches[1].data.SpanCount())
+
+	batches = splitTraces(td, "", "otlp_spans", true)
+	require.Len(t, batches, 2)
+	assert.Equal(t, traceID1.String(), batches[0].key)
+	assert.Equal(t, traceID2.String(), batches[1].key)
+	// The spans of both resources are in the batch of their trace, with their resource and scope.
+	assert.Equal(t, 4, batches[0].data.SpanCount())
+	require.Equal(t, 2, batches[0].data.ResourceSpans().Len())
+	rs := batches[0].data.ResourceSpans().At(0)
+	v, _ := rs.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "a", v.Str())
+	require.Equal(t, 1, rs.ScopeSpans().Len())
+	assert.Equal(t, "scope", rs.ScopeSpans().At(0).Scope().Name())
+	assert.Equal(t, 2, rs.ScopeSpans().At(0).Spans().Len())
+	assert.Equal(t, 2, batches[1].data.SpanCount())
+
+	batches = splitTraces(td, "topic", "otlp_spans", true)
+	require.Len(t, batches, 4)
+	for _, b := range batches {
+		for i := 0; i < b.data.ResourceSpans().Len(); i++ {
+			spans := b.data.ResourceSpans().At(i).ScopeSpans().At(0).Spans()
+			for j := 0; j < spans.Len(); j++ {
+				assert.Equal(t, b.key, spans.At(j).TraceID().String())
+			}
+		}
+	}
+}
+
+func TestSplitMetrics(t *testing.T) {
+	md := generateMetrics()
+
+	batches := splitMetrics(md, "", "otlp_metrics", "")
+	require.Len(t, batches, 1)
+	assert.Equal(t, md, batches[0].data)
+
+	batches = splitMetrics(md, "topic", "otlp_metrics", "service.name")
+	require.Len(t, batches, 3)
+	assert.Equal(t, messageBatch[pmetric.Metrics]{topic: "metrics_a", key: "a", data: batches[0].data}, batches[0])
+	assert.Equal(t, 2, batches[0].data.ResourceMetrics().Len())
+	assert.Equal(t, messageBatch[pmetric.Metrics]{topic: "metrics_b", key: "b", data: batches[1].data}, batches[1])
+	assert.Equal(t, messageBatch[pmetric.Metrics]{topic: "otlp_metrics", key: "c", data: batches[2].data}, batches[2])
+}
+
+func TestSplitLogs(t *testing.T) {
+	ld := generateLogs()
+
+	batches := splitLogs(ld, "", "otlp_logs", "")
+	require.Len(t, batches, 1)
+	assert.Equal(t, ld, batches[0].data)
+
+	batches = splitLogs(ld, "", "otlp_logs", "service.name")
+	require.Len(t, batches, 2)
+	assert.Equal(t, "a", batches[0].key)
+	assert.Equal(t, 2, batches[0].data.LogRecordCount())
+	assert.Equal(t, "b", batches[1].key)
+	assert.Equal(t, 1, batches[1].data.LogRecordCount())
+}
+
+func expectMessage(producer *mocks.SyncProducer, topic string, key string) {
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		var gotKey string
+		if msg.Key != nil {
+			b, _ := msg.Key.Encode()
+			gotKey = string(b)
+		}
+		if msg.Topic != topic || gotKey != key {
+			return assert.AnError
+		}
+		return nil
+	})
+}
+
+func TestTracesPusherPartitionByID(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, sarama.NewConfig())
+	expectMessage(producer, "spans_a", traceID1.String())
+	expectMessage(producer, "spans_a", traceID2.String())
+	expectMessage(producer, "spans_b", traceID1.String())
+	expectMessage(producer, "spans_b", traceID2.String())
+
+	p := kafkaTracesProducer{
+		producer:       producer,
+		topic:          "otlp_spans",
+		topicAttribute: "topic",
+		partitionByID:  true,
+		marshaler:      newPdataTracesMarshaler(&ptrace.ProtoMarshaler{}, defaultEncoding),
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	require.NoError(t, p.tracesPusher(context.Background(), generateTraces()))
+}
+
+func TestMetricsPusherMessageKey(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, sarama.NewConfig())
+	expectMessage(producer, "metrics_a", "a")
+	expectMessage(producer, "metrics_b", "b")
+	expectMessage(producer, "otlp_metrics", "c")
+
+	p := kafkaMetricsProducer{
+		producer:       producer,
+		topic:          "otlp_metrics",
+		topicAttribute: "topic",
+		keyAttribute:   "service.name",
+		marshaler:      newPdataMetricsMarshaler(&pmetric.ProtoMarshaler{}, defaultEncoding),
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	require.NoError(t, p.metricsDataPusher(context.Background(), generateMetrics()))
+}
+
+func TestLogsPusherMessageKey(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, sarama.NewConfig())
+	// The raw marshaler produces a message per log record, all with the key of their resource.
+	expectMessage(producer, "otlp_logs", "a")
+	expectMessage(producer, "otlp_logs", "a")
+	expectMessage(producer, "otlp_logs", "b")
+
+	p := kafkaLogsProducer{
+		producer:     producer,
+		topic:        "otlp_logs",
+		keyAttribute: "service.name",
+		marshaler:    newRawMarshaler(),
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	require.NoError(t, p.logsDataPusher(context.Background(), generateLogs()))
+}
@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelarrowexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otelarrowexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	arrowpb "github.com/open-telemetry/otel-arrow/api/experimental/arrow/v1"
+	arrowConfig "github.com/open-telemetry/otel-arrow/pkg/config"
+	arrowRecord "github.com/open-telemetry/otel-arrow/pkg/otel/arrow_record"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errDowngraded is returned once the receiver is known not to support
+// OTel-Arrow, so that the data is sent with OTLP instead.
+var errDowngraded = errors.New("the receiver does not support OTel-Arrow")
+
+// defaultDowngradeDuration is the duration after which OTel-Arrow is tried again once
+// the pool is downgraded to OTLP, when the streams have no maximum lifetime.
+const defaultDowngradeDuration = time.Hour
+
+// arrowStreamClient is the client side of an Arrow stream of any signal.
+type arrowStreamClient interface {
+	Send(*arrowpb.BatchArrowRecords) error
+	Recv() (*arrowpb.BatchStatus, error)
+	CloseSend() error
+}
+
+// streamClientFunc opens an Arrow stream on the connection.
+type streamClientFunc func(ctx context.Context, conn *grpc.ClientConn, opts ...grpc.CallOption) (arrowStreamClient, error)
+
+func newTracesStreamClient(ctx context.Context, conn *grpc.ClientConn, opts ...grpc.CallOption) (arrowStreamClient, error) {
+	return arrowpb.NewArrowTracesServiceClient(conn).ArrowTraces(ctx, opts...)
+}
+
+func newMetricsStreamClient(ctx context.Context, conn *grpc.ClientConn, opts ...grpc.CallOption) (arrowStreamClient, error) {
+	return arrowpb.NewArrowMetricsServiceClient(conn).ArrowMetrics(ctx, opts...)
+}
+
+func newLogsStreamClient(ctx context.Context, conn *grpc.ClientConn, opts ...grpc.CallOption) (arrowStreamClient, error) {
+	return arrowpb.NewArrowLogsServiceClient(conn).ArrowLogs(ctx, opts...)
+}
+
+// arrowStream is a long-lived Arrow stream. Its producer keeps the schemas and
+// the dictionaries of the batches already sent, so that the next batches only
+// carry their deltas.
+type arrowStream struct {
+	client   arrowStreamClient
+	producer *arrowRecord.Producer
+	cancel   context.CancelFunc
+	expires  time.Time
+}
+
+// streamPool holds the Arrow streams of an exporter, each one carrying one batch at a time.
+type streamPool struct {
+	settings  ArrowSettings
+	conn      *grpc.ClientConn
+	newClient streamClientFunc
+	// producerOptions are the options of the producers of the streams.
+	producerOptions []arrowConfig.Option
+	callOptions     []grpc.CallOption
+	logger          *zap.Logger
+
+	// ctx is the parent of the contexts of the streams, canceled on shutdown.
+	ctx    context.Context
+	cancel context.CancelFunc
+	// streams holds the idle streams, nil until the stream is opened.
+	streams chan *arrowStream
+	// downgradedUntil is the time in Unix nanoseconds until which the data is sent with OTLP,
+	// after which a new stream is opened to find out whether the receiver was upgraded.
+	downgradedUntil atomic.Int64
+}
+
+func newStreamPool(ctx context.Context, settings ArrowSettings, conn *grpc.ClientConn, newClient streamClientFunc, producerOptions []arrowConfig.Option, callOptions []grpc.CallOption, logger *zap.Logger) *streamPool {
+	p := &streamPool{
+		settings:        settings,
+		conn:            conn,
+		newClient:       newClient,
+		producerOptions: producerOptions,
+		callOptions:     callOptions,
+		logger:          logger,
+		streams:         make(chan *arrowStream, settings.NumStreams),
+	}
+	p.ctx, p.cancel = context.WithCancel(ctx)
+	for i := 0; i < settings.NumStreams; i++ {
+		p.streams <- nil
+	}
+	return p
+}
+
+// send encodes a batch with the producer of an idle stream, sends it and waits
+// for its status. It returns errDowngraded if the receiver does not support
+// OTel-Arrow.
+func (p *streamPool) send(ctx context.Context, encode func(*arrowRecord.Producer) (*arrowpb.BatchArrowRecords, error)) error {
+	if time.Now().UnixNano() < p.downgradedUntil.Load() {
+		return errDowngraded
+	}
+
+	var stream *arrowStream
+	select {
+	case stream = <-p.streams:
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	if stream != nil && !stream.expires.IsZero() && time.Now().After(stream.expires) {
+		stream.close()
+		stream = nil
+	}
+	if stream == nil {
+		var err error
+		if stream, err = p.openStream(); err != nil {
+			p.streams <- nil
+			return p.streamError(err)
+		}
+	}
+
+	if err := stream.send(ctx, encode); err != nil {
+		// The dictionaries of the producer and of the consumer of the receiver
+		// may differ after an error, the next batch is sent on a new stream.
+		stream.close()
+		p.streams <- nil
+		return p.streamError(err)
+	}
+	p.streams <- stream
+	return nil
+}
+
+func (p *streamPool) openStream() (*arrowStream, error) {
+	ctx, cancel := context.WithCancel(p.ctx)
+	client, err := p.newClient(ctx, p.conn, p.callOptions...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	stream := &arrowStream{
+		client:   client,
+		producer: arrowRecord.NewProducerWithOptions(p.producerOptions...),
+		cancel:   cancel,
+	}
+	if p.settings.MaxStreamLifetime > 0 {
+		stream.expires = time.Now().Add(p.settings.MaxStreamLifetime)
+	}
+	return stream, nil
+}
+
+// streamError downgrades the pool to OTLP if the receiver does not implement
+// the Arrow services, until the maximum lifetime of the streams has passed.
+func (p *streamPool) streamError(err error) error {
+	if status.Code(err) != codes.Unimplemented {
+		return err
+	}
+	duration := p.settings.MaxStreamLifetime
+	if duration <= 0 {
+		duration = defaultDowngradeDuration
+	}
+	now := time.Now()
+	if until := p.downgradedUntil.Swap(now.Add(duration).UnixNano()); now.UnixNano() >= until {
+		p.logger.Info("The receiver does not support OTel-Arrow, sending the data with OTLP",
+			zap.Duration("retry_after", duration), zap.Error(err))
+	}
+	return errDowngraded
+}
+
+// shutdown closes the idle streams and cancels the others.
+func (p *streamPool) shutdown() {
+	p.cancel()
+	for {
+		select {
+		case stream := <-p.streams:
+			if stream != nil {
+				stream.close()
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (s *arrowStream) send(ctx context.Context, encode func(*arrowRecord.Producer) (*arrowpb.BatchArrowRecords, error)) error {
+	batch, err := encode(s.producer)
+	if err != nil {
+		return fmt.Errorf("encode arrow batch: %w", err)
+	}
+
+	// The stream outlives ctx, it is canceled if ctx is done before the status is received.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.cancel()
+		case <-done:
+		}
+	}()
+
+	// The error of a broken stream is returned by Recv.
+	if err = s.client.Send(batch); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	resp, err := s.client.Recv()
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		if errors.Is(err, io.EOF) {
+			// The receiver ended the stream without status, the batch can be sent again on a new stream.
+			return status.Error(codes.Unavailable, "arrow stream closed by the receiver")
+		}
+		return err
+	}
+	if resp.BatchId != batch.BatchId {
+		// The stream is out of sync, the batch can be sent again on a new stream.
+		return status.Errorf(codes.Unavailable, "unexpected status of arrow batch %d, waiting for batch %d", resp.BatchId, batch.BatchId)
+	}
+	if resp.StatusCode != arrowpb.StatusCode_OK {
+		// The status codes of the batches are gRPC codes.
+		return status.Error(codes.Code(resp.StatusCode), resp.StatusMessage)
+	}
+	return nil
+}
+
+func (s *arrowStream) close() {
+	_ = s.client.CloseSend()
+	s.cancel()
+	_ = s.producer.Close()
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelarrowexporter
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	arrowpb "github.com/open-telemetry/otel-arrow/api/experimental/arrow/v1"
+	arrowRecord "github.com/open-telemetry/otel-arrow/pkg/otel/arrow_record"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeStreamClient acknowledges the batches it is sent.
+type fakeStreamClient struct {
+	batchID int64
+}
+
+func (c *fakeStreamClient) Send(batch *arrowpb.BatchArrowRecords) error {
+	c.batchID = batch.BatchId
+	return nil
+}
+
+func (c *fakeStreamClient) Recv() (*arrowpb.BatchStatus, error) {
+	return &arrowpb.BatchStatus{BatchId: c.batchID, StatusCode: arrowpb.StatusCode_OK}, nil
+}
+
+func (c *fakeStreamClient) CloseSend() error {
+	return nil
+}
+
+func TestStreamPoolDowngrade(t *testing.T) {
+	// The receiver is upgraded after the first stream.
+	var opened atomic.Int32
+	newClient := func(context.Context, *grpc.ClientConn, ...grpc.CallOption) (arrowStreamClient, error) {
+		if opened.Add(1) == 1 {
+			return nil, status.Error(codes.Unimplemented, "unknown service")
+		}
+		return &fakeStreamClient{}, nil
+	}
+	settings := ArrowSettings{NumStreams: 1, MaxStreamLifetime: 50 * time.Millisecond}
+	p := newStreamPool(context.Background(), settings, nil, newClient, nil, nil, zap.NewNop())
+	defer p.shutdown()
+
+	encode := func(*arrowRecord.Producer) (*arrowpb.BatchArrowRecords, error) {
+		return &arrowpb.BatchArrowRecords{BatchId: 1}, nil
+	}
+	assert.ErrorIs(t, p.send(context.Background(), encode), errDowngraded)
+	// The data is sent with OTLP without trying OTel-Arrow again until the stream lifetime has passed.
+	assert.ErrorIs(t, p.send(context.Background(), encode), errDowngraded)
+	assert.Equal(t, int32(1), opened.Load())
+
+	require.Eventually(t, func() bool {
+		return p.send(context.Background(), encode) == nil
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(2), opened.Load())
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelarrowexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otelarrowexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+// Config defines configuration for OTel-Arrow exporter.
+type Config struct {
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+
+	configgrpc.GRPCClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Arrow configures the OTel-Arrow streams.
+	Arrow ArrowSettings `mapstructure:"arrow"`
+}
+
+// ArrowSettings configures the OTel-Arrow streams of the exporter.
+type ArrowSettings struct {
+	// NumStreams is the number of concurrent Arrow streams. Each stream
+	// carries one batch at a time.
+	NumStreams int `mapstructure:"num_streams"`
+
+	// MaxStreamLifetime is the duration after which a stream is closed and
+	// a new one is opened for the next batch, resetting the dictionaries of
+	// the stream. It should be lower than the maximum connection age of the
+	// receiver or of the load balancers in between. 0 means no limit.
+	MaxStreamLifetime time.Duration `mapstructure:"max_stream_lifetime"`
+
+	// Disabled sends the data with OTLP instead of OTel-Arrow.
+	Disabled bool `mapstructure:"disabled"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if err := cfg.QueueSettings.Validate(); err != nil {
+		return fmt.Errorf("queue settings has invalid configuration: %w", err)
+	}
+	return nil
+}
+
+// Validate checks if the Arrow settings are valid.
+func (cfg *ArrowSettings) Validate() error {
+	if cfg.Disabled {
+		return nil
+	}
+	if cfg.NumStreams < 1 {
+		return errors.New("num_streams must be at least 1")
+	}
+	if cfg.MaxStreamLifetime < 0 {
+		return errors.New("max_stream_lifetime must not be negative")
+	}
+	return nil
+}
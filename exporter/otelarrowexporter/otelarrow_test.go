@@ -0,0 +1,358 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelarrowexporter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	arrowpb "github.com/open-telemetry/otel-arrow/api/experimental/arrow/v1"
+	arrowRecord "github.com/open-telemetry/otel-arrow/pkg/otel/arrow_record"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+// arrowStreamServer is the server side of an Arrow stream of any signal.
+type arrowStreamServer interface {
+	Send(*arrowpb.BatchStatus) error
+	Recv() (*arrowpb.BatchArrowRecords, error)
+}
+
+// testArrowServer decodes the Arrow streams into sinks.
+type testArrowServer struct {
+	arrowpb.UnimplementedArrowTracesServiceServer
+	arrowpb.UnimplementedArrowMetricsServiceServer
+	arrowpb.UnimplementedArrowLogsServiceServer
+
+	traces  consumertest.TracesSink
+	metrics consumertest.MetricsSink
+	logs    consumertest.LogsSink
+
+	// statusCode is the status of the batches.
+	statusCode arrowpb.StatusCode
+	// closeStreams ends the streams without status once a batch is received.
+	closeStreams bool
+	// batchIDOffset is added to the batch ID of the statuses.
+	batchIDOffset int64
+
+	mu      sync.Mutex
+	streams int
+}
+
+func (s *testArrowServer) streamCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.streams
+}
+
+func (s *testArrowServer) ArrowTraces(stream arrowpb.ArrowTracesService_ArrowTracesServer) error {
+	return s.serve(stream, func(c *arrowRecord.Consumer, batch *arrowpb.BatchArrowRecords) error {
+		traces, err := c.TracesFrom(batch)
+		for _, td := range traces {
+			_ = s.traces.ConsumeTraces(context.Background(), td)
+		}
+		return err
+	})
+}
+
+func (s *testArrowServer) ArrowMetrics(stream arrowpb.ArrowMetricsService_ArrowMetricsServer) error {
+	return s.serve(stream, func(c *arrowRecord.Consumer, batch *arrowpb.BatchArrowRecords) error {
+		metrics, err := c.MetricsFrom(batch)
+		for _, md := range metrics {
+			_ = s.metrics.ConsumeMetrics(context.Background(), md)
+		}
+		return err
+	})
+}
+
+func (s *testArrowServer) ArrowLogs(stream arrowpb.ArrowLogsService_ArrowLogsServer) error {
+	return s.serve(stream, func(c *arrowRecord.Consumer, batch *arrowpb.BatchArrowRecords) error {
+		logs, err := c.LogsFrom(batch)
+		for _, ld := range logs {
+			_ = s.logs.ConsumeLogs(context.Background(), ld)
+		}
+		return err
+	})
+}
+
+func (s *testArrowServer) serve(stream arrowStreamServer, consume func(*arrowRecord.Consumer, *arrowpb.BatchArrowRecords) error) error {
+	s.mu.Lock()
+	s.streams++
+	s.mu.Unlock()
+
+	c := arrowRecord.NewConsumer()
+	defer c.Close()
+	for {
+		batch, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = consume(c, batch); err != nil {
+			return err
+		}
+		if s.closeStreams {
+			return nil
+		}
+		if err = stream.Send(&arrowpb.BatchStatus{BatchId: batch.BatchId + s.batchIDOffset, StatusCode: s.statusCode}); err != nil {
+			return err
+		}
+	}
+}
+
+type testTracesServer struct {
+	ptraceotlp.UnimplementedGRPCServer
+	sink consumertest.TracesSink
+}
+
+func (s *testTracesServer) Export(ctx context.Context, req ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	return ptraceotlp.NewExportResponse(), s.sink.ConsumeTraces(ctx, req.Traces())
+}
+
+type testMetricsServer struct {
+	pmetricotlp.UnimplementedGRPCServer
+	sink consumertest.MetricsSink
+}
+
+func (s *testMetricsServer) Export(ctx context.Context, req pmetricotlp.ExportRequest) (pmetricotlp.ExportResponse, error) {
+	return pmetricotlp.NewExportResponse(), s.sink.ConsumeMetrics(ctx, req.Metrics())
+}
+
+type testLogsServer struct {
+	plogotlp.UnimplementedGRPCServer
+	sink consumertest.LogsSink
+}
+
+func (s *testLogsServer) Export(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	return plogotlp.NewExportResponse(), s.sink.ConsumeLogs(ctx, req.Logs())
+}
+
+// testServer is a receiver serving OTLP, and OTel-Arrow unless arrow is nil.
+type testServer struct {
+	endpoint string
+	arrow    *testArrowServer
+	traces   *testTracesServer
+	metrics  *testMetricsServer
+	logs     *testLogsServer
+}
+
+func newTestServer(t *testing.T, arrow *testArrowServer) *testServer {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	srv := &testServer{
+		endpoint: ln.Addr().String(),
+		arrow:    arrow,
+		traces:   &testTracesServer{},
+		metrics:  &testMetricsServer{},
+		logs:     &testLogsServer{},
+	}
+	server := grpc.NewServer()
+	ptraceotlp.RegisterGRPCServer(server, srv.traces)
+	pmetricotlp.RegisterGRPCServer(server, srv.metrics)
+	plogotlp.RegisterGRPCServer(server, srv.logs)
+	if arrow != nil {
+		arrowpb.RegisterArrowTracesServiceServer(server, arrow)
+		arrowpb.RegisterArrowMetricsServiceServer(server, arrow)
+		arrowpb.RegisterArrowLogsServiceServer(server, arrow)
+	}
+	go func() {
+		_ = server.Serve(ln)
+	}()
+	t.Cleanup(server.Stop)
+	return srv
+}
+
+func newTestConfig(endpoint string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.TLSSetting = configtls.TLSClientSetting{
+		Insecure: true,
+	}
+	cfg.QueueSettings.Enabled = false
+	cfg.RetrySettings.Enabled = false
+	cfg.Arrow.NumStreams = 1
+	return cfg
+}
+
+func TestSendArrow(t *testing.T) {
+	srv := newTestServer(t, &testArrowServer{})
+	cfg := newTestConfig(srv.endpoint)
+	factory := NewFactory()
+	set := exportertest.NewNopCreateSettings()
+	host := componenttest.NewNopHost()
+
+	traces, err := factory.CreateTracesExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, traces.Start(context.Background(), host))
+	defer func() { assert.NoError(t, traces.Shutdown(context.Background())) }()
+	metrics, err := factory.CreateMetricsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, metrics.Start(context.Background(), host))
+	defer func() { assert.NoError(t, metrics.Shutdown(context.Background())) }()
+	logs, err := factory.CreateLogsExporter(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, logs.Start(context.Background(), host))
+	defer func() { assert.NoError(t, logs.Shutdown(context.Background())) }()
+
+	// The batches of a signal are sent on the same stream.
+	for i := 0; i < 3; i++ {
+		require.NoError(t, traces.ConsumeTraces(context.Background(), testdata.GenerateTracesTwoSpansSameResource()))
+		require.NoError(t, metrics.ConsumeMetrics(context.Background(), testdata.GenerateMetricsOneMetric()))
+		require.NoError(t, logs.ConsumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+	}
+	assert.Equal(t, 3, srv.arrow.streamCount())
+
+	assert.Equal(t, 6, srv.arrow.traces.SpanCount())
+	assert.Equal(t, 6, srv.arrow.metrics.DataPointCount())
+	assert.Equal(t, 3, srv.arrow.logs.LogRecordCount())
+	assert.Zero(t, srv.traces.sink.SpanCount())
+	assert.Zero(t, srv.metrics.sink.DataPointCount())
+	assert.Zero(t, srv.logs.sink.LogRecordCount())
+}
+
+func TestSendArrowMaxStreamLifetime(t *testing.T) {
+	srv := newTestServer(t, &testArrowServer{})
+	cfg := newTestConfig(srv.endpoint)
+	cfg.Arrow.MaxStreamLifetime = time.Nanosecond
+	exp, err := NewFactory().CreateTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, exp.Shutdown(context.Background())) }()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	}
+	assert.Equal(t, 3, srv.arrow.streamCount())
+	assert.Equal(t, 3, srv.arrow.traces.SpanCount())
+}
+
+func TestSendArrowBatchStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode arrowpb.StatusCode
+		permanent  bool
+	}{
+		{
+			name:       "invalid argument",
+			statusCode: arrowpb.StatusCode_INVALID_ARGUMENT,
+			permanent:  true,
+		},
+		{
+			name:       "unavailable",
+			statusCode: arrowpb.StatusCode_UNAVAILABLE,
+			permanent:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(t, &testArrowServer{statusCode: tt.statusCode})
+			exp, err := NewFactory().CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), newTestConfig(srv.endpoint))
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() { assert.NoError(t, exp.Shutdown(context.Background())) }()
+
+			err = exp.ConsumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord())
+			require.Error(t, err)
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+
+			// The next batch is sent on a new stream.
+			require.Error(t, exp.ConsumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+			assert.Equal(t, 2, srv.arrow.streamCount())
+		})
+	}
+}
+
+func TestSendArrowStreamErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		server *testArrowServer
+	}{
+		{
+			name:   "stream closed without status",
+			server: &testArrowServer{closeStreams: true},
+		},
+		{
+			name:   "unexpected batch id",
+			server: &testArrowServer{batchIDOffset: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(t, tt.server)
+			exp, err := NewFactory().CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), newTestConfig(srv.endpoint))
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() { assert.NoError(t, exp.Shutdown(context.Background())) }()
+
+			// The batch is retried on a new stream.
+			err = exp.ConsumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord())
+			require.Error(t, err)
+			assert.False(t, consumererror.IsPermanent(err))
+			assert.Equal(t, codes.Unavailable, status.Code(err))
+		})
+	}
+}
+
+func TestSendFallbackOTLP(t *testing.T) {
+	// The receiver only supports OTLP.
+	srv := newTestServer(t, nil)
+	exp, err := NewFactory().CreateTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), newTestConfig(srv.endpoint))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, exp.Shutdown(context.Background())) }()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+	}
+	assert.Equal(t, 3, srv.traces.sink.SpanCount())
+	assert.Equal(t, testdata.GenerateTracesOneSpan(), srv.traces.sink.AllTraces()[0])
+}
+
+func TestSendArrowDisabled(t *testing.T) {
+	srv := newTestServer(t, &testArrowServer{})
+	cfg := newTestConfig(srv.endpoint)
+	cfg.Arrow.Disabled = true
+	exp, err := NewFactory().CreateMetricsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, exp.Shutdown(context.Background())) }()
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), testdata.GenerateMetricsOneMetric()))
+	assert.Zero(t, srv.arrow.streamCount())
+	assert.Equal(t, 2, srv.metrics.sink.DataPointCount())
+}
+
+func TestSendArrow_NoBackend(t *testing.T) {
+	cfg := newTestConfig("localhost:56569")
+	cfg.TimeoutSettings.Timeout = 100 * time.Millisecond
+	exp, err := NewFactory().CreateTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, exp.Shutdown(context.Background())) }()
+
+	err = exp.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan())
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+}
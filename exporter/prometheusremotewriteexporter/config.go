@@ -5,6 +5,8 @@ package prometheusremotewriteexporter // import "github.com/open-telemetry/opent
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -51,6 +53,67 @@ type Config struct {
 
 	// SendMetadata controls whether prometheus metadata will be generated and sent
 	SendMetadata bool `mapstructure:"send_metadata"`
+
+	// RemoteWriteProtoMsg is the protobuf message sent to the remote write endpoint,
+	// it selects the version of the remote write protocol.
+	RemoteWriteProtoMsg RemoteWriteProtoMsg `mapstructure:"protobuf_message"`
+
+	// ExponentialHistograms allows customizing the export of exponential histograms
+	ExponentialHistograms *ExponentialHistograms `mapstructure:"exponential_histograms,omitempty"`
+
+	// Tenant allows sending the metrics to the tenants of a multi-tenant remote write endpoint
+	Tenant *Tenant `mapstructure:"tenant,omitempty"`
+}
+
+// Tenant allows to configure how the tenant of the metrics is read and sent.
+type Tenant struct {
+	// ResourceAttribute is the resource attribute holding the tenant of the metrics.
+	ResourceAttribute string `mapstructure:"resource_attribute"`
+
+	// Header is the HTTP header carrying the tenant, X-Scope-OrgID if empty.
+	Header string `mapstructure:"header"`
+
+	// Default is the tenant of the metrics without the resource attribute. If empty,
+	// these metrics are sent without tenant header.
+	Default string `mapstructure:"default"`
+
+	// DropResourceAttribute if true the resource attribute is removed from the metrics,
+	// so that it's neither exported as label nor in target_info.
+	DropResourceAttribute bool `mapstructure:"drop_resource_attribute"`
+
+	// MaxTenants is the maximum number of tenants exported at the same time, each with
+	// its own queue or WAL. The metrics of other tenants fail with a retryable error until
+	// the queue or WAL of an idle tenant is closed. 100 if zero.
+	MaxTenants int `mapstructure:"max_tenants"`
+
+	// IdleTimeout is the duration without metrics after which the queue or WAL of a tenant
+	// is closed. 5 minutes if zero.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+
+	// QueueSize is the number of batches queued for each tenant if the WAL is disabled.
+	// 100 if zero.
+	QueueSize int `mapstructure:"queue_size"`
+}
+
+// RemoteWriteProtoMsg is the protobuf message of a version of the remote write protocol.
+type RemoteWriteProtoMsg string
+
+const (
+	// RemoteWriteProtoMsgV1 is the message of the remote write 1.0 protocol.
+	RemoteWriteProtoMsgV1 RemoteWriteProtoMsg = "prometheus.WriteRequest"
+	// RemoteWriteProtoMsgV2 is the message of the remote write 2.0 protocol, with interned
+	// symbols, per series metadata and created timestamps.
+	RemoteWriteProtoMsgV2 RemoteWriteProtoMsg = "io.prometheus.write.v2.Request"
+)
+
+type ExponentialHistograms struct {
+	// MaxScale is the highest scale of the exported histograms, the exponential histograms
+	// with a higher scale are downscaled. It must be between -4 and 8.
+	MaxScale int32 `mapstructure:"max_scale"`
+
+	// ConvertToClassic if true the exponential histograms are exported as classic histograms
+	// with explicit buckets, for the remote write receivers without native histogram support.
+	ConvertToClassic bool `mapstructure:"convert_to_classic"`
 }
 
 type CreatedMetric struct {
@@ -114,5 +177,52 @@ func (cfg *Config) Validate() error {
 		cfg.MaxBatchSizeBytes = 3000000
 	}
 
+	switch cfg.RemoteWriteProtoMsg {
+	case "":
+		cfg.RemoteWriteProtoMsg = RemoteWriteProtoMsgV1
+	case RemoteWriteProtoMsgV1:
+	case RemoteWriteProtoMsgV2:
+		if cfg.WAL != nil {
+			return fmt.Errorf("wal is not supported with the %s protobuf message", RemoteWriteProtoMsgV2)
+		}
+	default:
+		return fmt.Errorf("protobuf_message must be %s or %s, got %s", RemoteWriteProtoMsgV1, RemoteWriteProtoMsgV2, cfg.RemoteWriteProtoMsg)
+	}
+
+	if cfg.ExponentialHistograms == nil {
+		cfg.ExponentialHistograms = &ExponentialHistograms{
+			MaxScale: defaultExponentialHistogramMaxScale,
+		}
+	}
+	if cfg.ExponentialHistograms.MaxScale < -4 || cfg.ExponentialHistograms.MaxScale > 8 {
+		return fmt.Errorf("exponential histograms max_scale must be between -4 and 8, got %d", cfg.ExponentialHistograms.MaxScale)
+	}
+
+	if cfg.Tenant != nil {
+		if cfg.Tenant.ResourceAttribute == "" {
+			return fmt.Errorf("tenant resource_attribute can't be empty")
+		}
+		if cfg.Tenant.Header == "" {
+			cfg.Tenant.Header = defaultTenantHeader
+		}
+		if cfg.Tenant.MaxTenants < 0 || cfg.Tenant.IdleTimeout < 0 || cfg.Tenant.QueueSize < 0 {
+			return fmt.Errorf("tenant max_tenants, idle_timeout and queue_size can't be negative")
+		}
+		if cfg.Tenant.MaxTenants == 0 {
+			cfg.Tenant.MaxTenants = defaultMaxTenants
+		}
+		if cfg.Tenant.IdleTimeout == 0 {
+			cfg.Tenant.IdleTimeout = defaultTenantIdleTimeout
+		}
+		if cfg.Tenant.QueueSize == 0 {
+			cfg.Tenant.QueueSize = defaultTenantQueueSize
+		}
+		for header := range cfg.HTTPClientSettings.Headers {
+			if strings.EqualFold(header, cfg.Tenant.Header) {
+				return fmt.Errorf("the %s tenant header can't be set in headers", cfg.Tenant.Header)
+			}
+		}
+	}
+
 	return nil
 }
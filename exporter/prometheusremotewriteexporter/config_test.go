@@ -77,7 +77,9 @@ func TestLoadConfig(t *testing.T) {
 				TargetInfo: &TargetInfo{
 					Enabled: true,
 				},
-				CreatedMetric: &CreatedMetric{Enabled: true},
+				CreatedMetric:         &CreatedMetric{Enabled: true},
+				RemoteWriteProtoMsg:   RemoteWriteProtoMsgV1,
+				ExponentialHistograms: &ExponentialHistograms{MaxScale: 8},
 			},
 		},
 		{
@@ -88,6 +90,30 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "negative_num_consumers"),
 			errorMessage: "remote write consumer number can't be negative",
 		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_protobuf_message"),
+			errorMessage: "protobuf_message must be prometheus.WriteRequest or io.prometheus.write.v2.Request, got prometheus.WriteRequestV3",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "remote_write_v2_with_wal"),
+			errorMessage: "wal is not supported with the io.prometheus.write.v2.Request protobuf message",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_max_scale"),
+			errorMessage: "exponential histograms max_scale must be between -4 and 8, got 10",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "tenant_without_resource_attribute"),
+			errorMessage: "tenant resource_attribute can't be empty",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "tenant_header_conflict"),
+			errorMessage: "the X-Scope-OrgID tenant header can't be set in headers",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "tenant_negative_max_tenants"),
+			errorMessage: "tenant max_tenants, idle_timeout and queue_size can't be negative",
+		},
 	}
 
 	for _, tt := range tests {
@@ -134,3 +160,40 @@ func TestDisabledTargetInfo(t *testing.T) {
 
 	assert.False(t, cfg.(*Config).TargetInfo.Enabled)
 }
+
+func TestRemoteWriteV2Config(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "remote_write_v2").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	assert.Equal(t, RemoteWriteProtoMsgV2, cfg.(*Config).RemoteWriteProtoMsg)
+	assert.Equal(t, &ExponentialHistograms{MaxScale: 4, ConvertToClassic: true}, cfg.(*Config).ExponentialHistograms)
+}
+
+func TestTenantConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "tenant").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	assert.Equal(t, &Tenant{
+		ResourceAttribute:     "tenant.id",
+		Header:                "X-Scope-OrgID",
+		Default:               "anonymous",
+		DropResourceAttribute: true,
+		MaxTenants:            10,
+		IdleTimeout:           5 * time.Minute,
+		QueueSize:             100,
+	}, cfg.(*Config).Tenant)
+}
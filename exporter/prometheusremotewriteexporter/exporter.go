@@ -29,6 +29,7 @@ import (
 
 	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
 )
 
 // prwExporter converts OTLP metrics to Prometheus remote write TimeSeries and sends them to a remote endpoint.
@@ -45,6 +46,13 @@ type prwExporter struct {
 	retrySettings     exporterhelper.RetrySettings
 	wal               *prweWAL
 	exporterSettings  prometheusremotewrite.Settings
+	protoMsg          RemoteWriteProtoMsg
+	tenant            *Tenant
+
+	tenantsMu     sync.Mutex // tenantsMu protects the fields below.
+	tenants       map[string]*tenantSender
+	tenantsCtx    context.Context
+	tenantsCancel context.CancelFunc
 }
 
 // newPRWExporter initializes a new prwExporter instance and sets fields accordingly.
@@ -79,12 +87,20 @@ func newPRWExporter(cfg *Config, set exporter.CreateSettings) (*prwExporter, err
 			AddMetricSuffixes:   cfg.AddMetricSuffixes,
 			SendMetadata:        cfg.SendMetadata,
 		},
+		protoMsg: cfg.RemoteWriteProtoMsg,
+		tenant:   cfg.Tenant,
+		tenants:  make(map[string]*tenantSender),
+	}
+	if cfg.ExponentialHistograms != nil {
+		maxScale := cfg.ExponentialHistograms.MaxScale
+		prwe.exporterSettings.ExponentialHistogramMaxScale = &maxScale
+		prwe.exporterSettings.ConvertExponentialHistogramsToClassic = cfg.ExponentialHistograms.ConvertToClassic
 	}
 	if cfg.WAL == nil {
 		return prwe, nil
 	}
 
-	prwe.wal, err = newWAL(cfg.WAL, prwe.export)
+	prwe.wal, err = newWAL(cfg.WAL, defaultWALName, prwe.export)
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +113,14 @@ func (prwe *prwExporter) Start(ctx context.Context, host component.Host) (err er
 	if err != nil {
 		return err
 	}
-	return prwe.turnOnWALIfEnabled(contextWithLogger(ctx, prwe.settings.Logger.Named("prw.wal")))
+	ctx = contextWithLogger(ctx, prwe.settings.Logger.Named("prw.wal"))
+	if err = prwe.turnOnWALIfEnabled(ctx); err != nil {
+		return err
+	}
+	if prwe.tenant == nil {
+		return nil
+	}
+	return prwe.startTenants(ctx)
 }
 
 func (prwe *prwExporter) shutdownWALIfEnabled() error {
@@ -109,13 +132,13 @@ func (prwe *prwExporter) shutdownWALIfEnabled() error {
 
 // Shutdown stops the exporter from accepting incoming calls(and return error), and wait for current export operations
 // to finish before returning
-func (prwe *prwExporter) Shutdown(context.Context) error {
+func (prwe *prwExporter) Shutdown(ctx context.Context) error {
 	select {
 	case <-prwe.closeChan:
 	default:
 		close(prwe.closeChan)
 	}
-	err := prwe.shutdownWALIfEnabled()
+	err := multierr.Combine(prwe.shutdownWALIfEnabled(), prwe.stopTenants(ctx))
 	prwe.wg.Wait()
 	return err
 }
@@ -131,19 +154,35 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 	case <-prwe.closeChan:
 		return errors.New("shutdown has been called")
 	default:
+		if prwe.tenant != nil {
+			return prwe.pushTenantMetrics(ctx, md)
+		}
+		return prwe.pushMetrics(ctx, md)
+	}
+}
 
-		tsMap, err := prometheusremotewrite.FromMetrics(md, prwe.exporterSettings)
+// pushMetrics converts and exports the metrics of the tenant of ctx.
+func (prwe *prwExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if prwe.protoMsg == RemoteWriteProtoMsgV2 {
+		tsMap, symbols, err := prometheusremotewrite.FromMetricsV2(md, prwe.exporterSettings)
 		if err != nil {
 			err = consumererror.NewPermanent(err)
 		}
-
-		var m []*prompb.MetricMetadata
-		if prwe.exporterSettings.SendMetadata {
-			m = prometheusremotewrite.OtelMetricsToMetadata(md, prwe.exporterSettings.AddMetricSuffixes)
-		}
 		// Call export even if a conversion error, since there may be points that were successfully converted.
-		return multierr.Combine(err, prwe.handleExport(ctx, tsMap, m))
+		return multierr.Combine(err, prwe.handleExportV2(ctx, tsMap, symbols))
+	}
+
+	tsMap, err := prometheusremotewrite.FromMetrics(md, prwe.exporterSettings)
+	if err != nil {
+		err = consumererror.NewPermanent(err)
+	}
+
+	var m []*prompb.MetricMetadata
+	if prwe.exporterSettings.SendMetadata {
+		m = prometheusremotewrite.OtelMetricsToMetadata(md, prwe.exporterSettings.AddMetricSuffixes)
 	}
+	// Call export even if a conversion error, since there may be points that were successfully converted.
+	return multierr.Combine(err, prwe.handleExport(ctx, tsMap, m))
 }
 
 func validateAndSanitizeExternalLabels(cfg *Config) (map[string]string, error) {
@@ -175,16 +214,42 @@ func (prwe *prwExporter) handleExport(ctx context.Context, tsMap map[string]*pro
 	}
 
 	// Otherwise the WAL is enabled, and just persist the requests to the WAL
-	// and they'll be exported in another goroutine to the RemoteWrite endpoint.
-	if err = prwe.wal.persistToWAL(requests); err != nil {
+	// of the tenant and they'll be exported in another goroutine to the RemoteWrite endpoint.
+	wal, release, err := prwe.walForTenant(tenantFromContext(ctx))
+	if errors.Is(err, errTooManyTenants) {
+		return err
+	}
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	defer release()
+	if err = wal.persistToWAL(requests); err != nil {
 		return consumererror.NewPermanent(err)
 	}
 	return nil
 }
 
+func (prwe *prwExporter) handleExportV2(ctx context.Context, tsMap map[string]*writev2.TimeSeries, symbols writev2.SymbolsTable) error {
+	// There are no metrics to export, so return.
+	if len(tsMap) == 0 {
+		return nil
+	}
+
+	requests, err := batchTimeSeriesV2(tsMap, symbols, prwe.maxBatchSizeBytes)
+	if err != nil {
+		return err
+	}
+	return exportConcurrently(ctx, prwe.concurrency, requests, prwe.executeV2)
+}
+
 // export sends a Snappy-compressed WriteRequest containing TimeSeries to a remote write endpoint in order
 func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteRequest) error {
-	input := make(chan *prompb.WriteRequest, len(requests))
+	return exportConcurrently(ctx, prwe.concurrency, requests, prwe.execute)
+}
+
+// exportConcurrently executes the requests with up to concurrency workers.
+func exportConcurrently[T any](ctx context.Context, concurrency int, requests []T, execute func(context.Context, T) error) error {
+	input := make(chan T, len(requests))
 	for _, request := range requests {
 		input <- request
 	}
@@ -192,7 +257,7 @@ func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteReq
 
 	var wg sync.WaitGroup
 
-	concurrencyLimit := int(math.Min(float64(prwe.concurrency), float64(len(requests))))
+	concurrencyLimit := int(math.Min(float64(concurrency), float64(len(requests))))
 	wg.Add(concurrencyLimit) // used to wait for workers to be finished
 
 	var mu sync.Mutex
@@ -211,7 +276,7 @@ func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteReq
 					if !ok {
 						return
 					}
-					if errExecute := prwe.execute(ctx, request); errExecute != nil {
+					if errExecute := execute(ctx, request); errExecute != nil {
 						mu.Lock()
 						errs = multierr.Append(errs, consumererror.NewPermanent(errExecute))
 						mu.Unlock()
@@ -226,13 +291,27 @@ func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteReq
 }
 
 func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequest) error {
+	// Uses proto.Marshal to convert the WriteRequest into bytes array
+	data, err := proto.Marshal(writeReq)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return prwe.send(ctx, data, "application/x-protobuf", "0.1.0")
+}
+
+func (prwe *prwExporter) executeV2(ctx context.Context, writeReq *writev2.Request) error {
+	data, err := writeReq.Marshal()
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return prwe.send(ctx, data, writev2.ContentType, writev2.Version)
+}
+
+// send sends the Snappy-compressed request body to the remote write endpoint,
+// the content type and version headers depending on the version of the protocol.
+func (prwe *prwExporter) send(ctx context.Context, data []byte, contentType string, version string) error {
 	// executeFunc can be used for backoff and non backoff scenarios.
 	executeFunc := func() error {
-		// Uses proto.Marshal to convert the WriteRequest into bytes array
-		data, err := proto.Marshal(writeReq)
-		if err != nil {
-			return backoff.Permanent(consumererror.NewPermanent(err))
-		}
 		buf := make([]byte, len(data), cap(data))
 		compressedData := snappy.Encode(buf, data)
 
@@ -245,9 +324,12 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 		// Add necessary headers specified by:
 		// https://cortexmetrics.io/docs/apis/#remote-api
 		req.Header.Add("Content-Encoding", "snappy")
-		req.Header.Set("Content-Type", "application/x-protobuf")
-		req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("X-Prometheus-Remote-Write-Version", version)
 		req.Header.Set("User-Agent", prwe.userAgentHeader)
+		if tenant := tenantFromContext(ctx); tenant != "" {
+			req.Header.Set(prwe.tenant.Header, tenant)
+		}
 
 		resp, err := prwe.client.Do(req)
 		if err != nil {
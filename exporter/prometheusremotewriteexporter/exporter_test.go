@@ -30,6 +30,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
 )
 
 // Test_NewPRWExporter checks that a new exporter instance with non-nil fields is initialized
@@ -335,6 +336,66 @@ func TestNoMetricsNoError(t *testing.T) {
 	assert.NoError(t, runExportPipeline(nil, serverURL))
 }
 
+func Test_PushMetricsV2(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []*writev2.Request
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, writev2.Version, r.Header.Get("X-Prometheus-Remote-Write-Version"))
+		assert.Equal(t, writev2.ContentType, r.Header.Get("Content-Type"))
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		dest, err := snappy.Decode(nil, body)
+		require.NoError(t, err)
+		req := &writev2.Request{}
+		require.NoError(t, req.Unmarshal(dest))
+		mu.Lock()
+		received = append(received, req)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = server.URL
+	cfg.RemoteWriteProtoMsg = RemoteWriteProtoMsgV2
+	cfg.TargetInfo.Enabled = false
+	set := exportertest.NewNopCreateSettings()
+	prwe, err := newPRWExporter(cfg, set)
+	require.NoError(t, err)
+	require.NoError(t, prwe.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, prwe.Shutdown(context.Background()))
+	}()
+
+	md := getMetricsFromMetricList(
+		validMetrics1[validSum],
+		getExpHistogramMetric("exponential_hist", lbs1, time1, &floatVal1, uint64(2), 2, []uint64{1, 1}),
+	)
+	require.NoError(t, prwe.PushMetrics(context.Background(), md))
+
+	require.Len(t, received, 1)
+	req := received[0]
+	require.Len(t, req.Timeseries, 2)
+	var samples, histograms int
+	for _, ts := range req.Timeseries {
+		labels, err := writev2.DesymbolizeLabels(ts.LabelsRefs, req.Symbols)
+		require.NoError(t, err)
+		assert.NotEmpty(t, labels)
+		samples += len(ts.Samples)
+		histograms += len(ts.Histograms)
+		if len(ts.Histograms) > 0 {
+			assert.Equal(t, writev2.MetricTypeHistogram, ts.Metadata.Type)
+		} else {
+			assert.Equal(t, writev2.MetricTypeGauge, ts.Metadata.Type)
+		}
+	}
+	assert.Equal(t, 1, samples)
+	assert.Equal(t, 1, histograms)
+}
+
 func runExportPipeline(ts *prompb.TimeSeries, endpoint *url.URL) error {
 	// First we will construct a TimeSeries array from the testutils package
 	testmap := make(map[string]*prompb.TimeSeries)
@@ -927,7 +988,7 @@ func TestWALOnExporterRoundTrip(t *testing.T) {
 
 	// 3. Let's now read back all of the WAL records and ensure
 	// that all the prompb.WriteRequest values exist as we sent them.
-	wal, _, werr := cfg.WAL.createWAL()
+	wal, _, werr := cfg.WAL.createWAL(defaultWALName)
 	assert.NoError(t, werr)
 	assert.NotNil(t, wal)
 	t.Cleanup(func() {
@@ -19,6 +19,18 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/resourcetotelemetry"
 )
 
+// defaultExponentialHistogramMaxScale is the highest scale of the native histograms supported by Prometheus.
+const defaultExponentialHistogramMaxScale = 8
+
+// defaultTenantHeader is the tenant header of Cortex and Mimir.
+const defaultTenantHeader = "X-Scope-OrgID"
+
+const (
+	defaultMaxTenants        = 100
+	defaultTenantIdleTimeout = 5 * time.Minute
+	defaultTenantQueueSize   = 100
+)
+
 // NewFactory creates a new Prometheus Remote Write exporter.
 func NewFactory() exporter.Factory {
 	return exporter.NewFactory(
@@ -102,5 +114,9 @@ func createDefaultConfig() component.Config {
 		CreatedMetric: &CreatedMetric{
 			Enabled: false,
 		},
+		RemoteWriteProtoMsg: RemoteWriteProtoMsgV1,
+		ExponentialHistograms: &ExponentialHistograms{
+			MaxScale: defaultExponentialHistogramMaxScale,
+		},
 	}
 }
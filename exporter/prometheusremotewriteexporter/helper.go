@@ -8,6 +8,8 @@ import (
 	"sort"
 
 	"github.com/prometheus/prometheus/prompb"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
 )
 
 // batchTimeSeries splits series into multiple batch write requests.
@@ -69,6 +71,54 @@ func batchTimeSeries(tsMap map[string]*prompb.TimeSeries, maxBatchByteSize int,
 	return requests, nil
 }
 
+// batchTimeSeriesV2 splits series into multiple remote write 2.0 requests. Every request
+// holds the whole symbols table since the series reference it.
+func batchTimeSeriesV2(tsMap map[string]*writev2.TimeSeries, symbols writev2.SymbolsTable, maxBatchByteSize int) ([]*writev2.Request, error) {
+	if len(tsMap) == 0 {
+		return nil, errors.New("invalid tsMap: cannot be empty map")
+	}
+
+	requests := make([]*writev2.Request, 0, 1)
+	tsArray := make([]writev2.TimeSeries, 0, len(tsMap))
+	sizeOfSymbols := symbols.Size()
+	sizeOfCurrentBatch := sizeOfSymbols
+
+	i := 0
+	for _, v := range tsMap {
+		sizeOfSeries := v.Size()
+
+		if len(tsArray) != 0 && sizeOfCurrentBatch+sizeOfSeries >= maxBatchByteSize {
+			requests = append(requests, convertTimeseriesToRequestV2(tsArray, symbols))
+
+			tsArray = make([]writev2.TimeSeries, 0, len(tsMap)-i)
+			sizeOfCurrentBatch = sizeOfSymbols
+		}
+
+		tsArray = append(tsArray, *v)
+		sizeOfCurrentBatch += sizeOfSeries
+		i++
+	}
+
+	if len(tsArray) != 0 {
+		requests = append(requests, convertTimeseriesToRequestV2(tsArray, symbols))
+	}
+
+	return requests, nil
+}
+
+func convertTimeseriesToRequestV2(tsArray []writev2.TimeSeries, symbols writev2.SymbolsTable) *writev2.Request {
+	for i := range tsArray {
+		sL := tsArray[i].Samples
+		sort.Slice(sL, func(i, j int) bool {
+			return sL[i].Timestamp < sL[j].Timestamp
+		})
+	}
+	return &writev2.Request{
+		Symbols:    symbols.Symbols(),
+		Timeseries: tsArray,
+	}
+}
+
 func convertTimeseriesToRequest(tsArray []prompb.TimeSeries) *prompb.WriteRequest {
 	// the remote_write endpoint only requires the timeseries.
 	// otlp defines it's own way to handle metric metadata
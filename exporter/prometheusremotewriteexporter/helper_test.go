@@ -8,6 +8,9 @@ import (
 
 	"github.com/prometheus/prometheus/prompb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
 )
 
 // Test_batchTimeSeries checks batchTimeSeries return the correct number of requests
@@ -68,6 +71,71 @@ func Test_batchTimeSeries(t *testing.T) {
 	}
 }
 
+// Test_batchTimeSeriesV2 checks batchTimeSeriesV2 return the correct number of requests
+// depending on byte size, each holding the symbols table and sorted samples.
+func Test_batchTimeSeriesV2(t *testing.T) {
+	symbols := writev2.NewSymbolTable()
+	labels := getPromLabels(label11, value11, label12, value12, label21, value21, label22, value22)
+	ts1 := &writev2.TimeSeries{
+		LabelsRefs: symbols.SymbolizeLabels(labels, nil),
+		Samples:    []writev2.Sample{{Value: floatVal2, Timestamp: msTime2}, {Value: floatVal1, Timestamp: msTime1}},
+	}
+	ts2 := &writev2.TimeSeries{
+		LabelsRefs: symbols.SymbolizeLabels(labels[:2], nil),
+		Samples:    []writev2.Sample{{Value: floatVal1, Timestamp: msTime1}, {Value: floatVal2, Timestamp: msTime2}, {Value: floatVal3, Timestamp: msTime3}},
+	}
+
+	tests := []struct {
+		name                string
+		tsMap               map[string]*writev2.TimeSeries
+		maxBatchByteSize    int
+		numExpectedRequests int
+		returnErr           bool
+	}{
+		{
+			"no_timeseries",
+			map[string]*writev2.TimeSeries{},
+			100,
+			-1,
+			true,
+		},
+		{
+			"normal_case",
+			map[string]*writev2.TimeSeries{"ts1": ts1},
+			300,
+			1,
+			false,
+		},
+		{
+			"two_requests",
+			map[string]*writev2.TimeSeries{"ts1": ts1, "ts2": ts2},
+			symbols.Size() + ts1.Size() + 1,
+			2,
+			false,
+		},
+	}
+	// run tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests, err := batchTimeSeriesV2(tt.tsMap, symbols, tt.maxBatchByteSize)
+			if tt.returnErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.numExpectedRequests, len(requests))
+			for _, req := range requests {
+				assert.Equal(t, symbols.Symbols(), req.Symbols)
+				for _, ts := range req.Timeseries {
+					for i := 1; i < len(ts.Samples); i++ {
+						assert.LessOrEqual(t, ts.Samples[i-1].Timestamp, ts.Samples[i].Timestamp)
+					}
+				}
+			}
+		})
+	}
+}
+
 // Ensure that before a prompb.WriteRequest is created, that the points per TimeSeries
 // are sorted by Timestamp value, to prevent Prometheus from barfing when it gets poorly
 // sorted values. See issues:
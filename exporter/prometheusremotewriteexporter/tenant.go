@@ -0,0 +1,333 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// splitByTenant splits the metrics per tenant, read from the resource attribute of
+// their resource. The metrics without tenant are returned for the empty tenant.
+func splitByTenant(md pmetric.Metrics, cfg *Tenant) map[string]pmetric.Metrics {
+	tenants := make(map[string]pmetric.Metrics)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		tenant := cfg.Default
+		if v, ok := rm.Resource().Attributes().Get(cfg.ResourceAttribute); ok && v.AsString() != "" {
+			tenant = v.AsString()
+		}
+
+		tmd, ok := tenants[tenant]
+		if !ok {
+			tmd = pmetric.NewMetrics()
+			tenants[tenant] = tmd
+		}
+		trm := tmd.ResourceMetrics().AppendEmpty()
+		rm.CopyTo(trm)
+		if cfg.DropResourceAttribute {
+			trm.Resource().Attributes().Remove(cfg.ResourceAttribute)
+		}
+	}
+	return tenants
+}
+
+// errTooManyTenants is returned for the metrics of a new tenant while max_tenants
+// tenants are being exported, until the sender of an idle tenant is closed.
+var errTooManyTenants = errors.New("too many tenants")
+
+// tenantSender exports the metrics of a tenant independently of the other tenants,
+// through its own WAL if the WAL is enabled, otherwise through its own queue.
+type tenantSender struct {
+	wal    *prweWAL
+	queue  chan pmetric.Metrics
+	cancel context.CancelFunc
+	stopCh chan struct{}
+	done   chan struct{}
+
+	// The fields below are protected by prwExporter.tenantsMu.
+	inflight int
+	lastUsed time.Time
+}
+
+// stop stops the sender. The metrics left in the queue are exported until ctx is done.
+func (s *tenantSender) stop(ctx context.Context) error {
+	defer s.cancel()
+	if s.wal != nil {
+		// The WAL is read until its context is canceled.
+		s.cancel()
+		return s.wal.stop()
+	}
+	close(s.stopCh)
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// pushTenantMetrics pushes the metrics of every tenant to the sender of the tenant, so
+// that a slow tenant doesn't delay the others. If some tenants fail with a retryable
+// error, only their metrics are returned to be retried, so that the metrics already
+// accepted for the other tenants are not sent again.
+func (prwe *prwExporter) pushTenantMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var (
+		errs         error
+		permanentErr error
+		retry        = pmetric.NewMetrics()
+	)
+	for tenant, tmd := range splitByTenant(md, prwe.tenant) {
+		err := prwe.pushToTenant(ctx, tenant, tmd)
+		switch {
+		case err == nil:
+		case consumererror.IsPermanent(err):
+			permanentErr = multierr.Append(permanentErr, fmt.Errorf("tenant %q: %w", tenant, err))
+		default:
+			errs = multierr.Append(errs, fmt.Errorf("tenant %q: %w", tenant, err))
+			tmd.ResourceMetrics().MoveAndAppendTo(retry.ResourceMetrics())
+		}
+	}
+
+	if errs == nil {
+		return permanentErr
+	}
+	if permanentErr != nil {
+		// The returned error must not be permanent for the other tenants to be retried.
+		prwe.settings.Logger.Error("dropping the metrics of the tenants that failed permanently", zap.Error(permanentErr))
+	}
+	return consumererror.NewMetrics(errs, retry)
+}
+
+// pushToTenant persists the metrics to the WAL of the tenant if the WAL is enabled,
+// otherwise it queues them to be exported by the sender of the tenant.
+func (prwe *prwExporter) pushToTenant(ctx context.Context, tenant string, md pmetric.Metrics) error {
+	if prwe.walEnabled() {
+		return prwe.pushMetrics(contextWithTenant(ctx, tenant), md)
+	}
+
+	sender, release, err := prwe.acquireTenant(tenant)
+	if errors.Is(err, errTooManyTenants) {
+		return err
+	}
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	defer release()
+	select {
+	case sender.queue <- md:
+		return nil
+	default:
+		return errors.New("the queue of the tenant is full")
+	}
+}
+
+// walForTenant returns the WAL of the tenant, creating and starting it if needed, and
+// a function to call once the requests are persisted, for the WAL not to be closed meanwhile.
+// The metrics without tenant use the default WAL.
+func (prwe *prwExporter) walForTenant(tenant string) (*prweWAL, func(), error) {
+	if tenant == "" {
+		return prwe.wal, func() {}, nil
+	}
+	sender, release, err := prwe.acquireTenant(tenant)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sender.wal, release, nil
+}
+
+// acquireTenant returns the sender of the tenant, creating and starting it if needed,
+// and a function to call once the sender is no longer used, for it not to be closed meanwhile.
+func (prwe *prwExporter) acquireTenant(tenant string) (*tenantSender, func(), error) {
+	prwe.tenantsMu.Lock()
+	defer prwe.tenantsMu.Unlock()
+	sender, ok := prwe.tenants[tenant]
+	if !ok {
+		if len(prwe.tenants) >= prwe.tenant.MaxTenants {
+			return nil, nil, fmt.Errorf("%w: %d tenants are already exported", errTooManyTenants, prwe.tenant.MaxTenants)
+		}
+		var err error
+		if sender, err = prwe.startTenant(tenant); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	sender.inflight++
+	return sender, func() {
+		prwe.tenantsMu.Lock()
+		defer prwe.tenantsMu.Unlock()
+		sender.inflight--
+		sender.lastUsed = time.Now()
+	}, nil
+}
+
+// startTenant creates and starts the sender of the tenant. tenantsMu must be held.
+func (prwe *prwExporter) startTenant(tenant string) (*tenantSender, error) {
+	if prwe.tenantsCtx == nil {
+		return nil, errors.New("the tenants haven't been started")
+	}
+
+	ctx, cancel := context.WithCancel(prwe.tenantsCtx)
+	sender := &tenantSender{
+		cancel:   cancel,
+		stopCh:   make(chan struct{}),
+		done:     make(chan struct{}),
+		lastUsed: time.Now(),
+	}
+	if prwe.walEnabled() {
+		w, err := newWAL(prwe.wal.walConfig, tenantWALName(tenant), func(ctx context.Context, reqL []*prompb.WriteRequest) error {
+			return prwe.export(contextWithTenant(ctx, tenant), reqL)
+		})
+		if err == nil {
+			err = w.run(ctx)
+		}
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		sender.wal = w
+	} else {
+		sender.queue = make(chan pmetric.Metrics, prwe.tenant.QueueSize)
+		go prwe.runTenant(contextWithTenant(ctx, tenant), sender)
+	}
+	prwe.tenants[tenant] = sender
+	return sender, nil
+}
+
+// runTenant exports the metrics queued for the tenant of ctx until the sender is stopped.
+func (prwe *prwExporter) runTenant(ctx context.Context, sender *tenantSender) {
+	defer close(sender.done)
+	push := func(md pmetric.Metrics) {
+		if err := prwe.pushMetrics(ctx, md); err != nil {
+			prwe.settings.Logger.Error("failed to export the metrics of a tenant",
+				zap.String("tenant", tenantFromContext(ctx)), zap.Error(err))
+		}
+	}
+	for {
+		select {
+		case md := <-sender.queue:
+			push(md)
+		case <-sender.stopCh:
+			for {
+				select {
+				case md := <-sender.queue:
+					push(md)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// startTenants starts the WALs of the tenants left by a previous run, so that their
+// requests are exported even if no more metrics are received for them, and then
+// periodically closes the senders of the idle tenants.
+func (prwe *prwExporter) startTenants(ctx context.Context) error {
+	prwe.tenantsMu.Lock()
+	prwe.tenantsCtx, prwe.tenantsCancel = context.WithCancel(ctx)
+	prwe.tenantsMu.Unlock()
+
+	if prwe.walEnabled() {
+		if err := prwe.startTenantWALs(); err != nil {
+			return err
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(prwe.tenant.IdleTimeout)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				prwe.closeIdleTenants(time.Now())
+			case <-prwe.tenantsCtx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// startTenantWALs starts the WALs of the tenants left by a previous run, regardless
+// of max_tenants since their requests must be exported.
+func (prwe *prwExporter) startTenantWALs() error {
+	entries, err := os.ReadDir(prwe.wal.walConfig.Directory)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	prwe.tenantsMu.Lock()
+	defer prwe.tenantsMu.Unlock()
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), tenantWALPrefix) {
+			continue
+		}
+		tenant, err := url.PathUnescape(strings.TrimPrefix(entry.Name(), tenantWALPrefix))
+		if err != nil || tenant == "" {
+			prwe.settings.Logger.Warn("skipping the WAL of an invalid tenant", zap.String("name", entry.Name()))
+			continue
+		}
+		if _, err = prwe.startTenant(tenant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// closeIdleTenants stops the senders of the tenants that haven't received metrics
+// for idle_timeout. The requests read from the WAL of a tenant but not yet exported
+// are kept in the WAL, and exported once the WAL of the tenant is started again.
+func (prwe *prwExporter) closeIdleTenants(now time.Time) {
+	var idle []*tenantSender
+	prwe.tenantsMu.Lock()
+	for tenant, sender := range prwe.tenants {
+		if sender.inflight > 0 || len(sender.queue) > 0 || now.Sub(sender.lastUsed) < prwe.tenant.IdleTimeout {
+			continue
+		}
+		idle = append(idle, sender)
+		delete(prwe.tenants, tenant)
+	}
+	ctx := prwe.tenantsCtx
+	prwe.tenantsMu.Unlock()
+
+	for _, sender := range idle {
+		if err := sender.stop(ctx); err != nil {
+			prwe.settings.Logger.Warn("failed to stop the sender of an idle tenant", zap.Error(err))
+		}
+	}
+}
+
+// stopTenants stops the senders of all the tenants, exporting the queued metrics until ctx is done.
+func (prwe *prwExporter) stopTenants(ctx context.Context) (errs error) {
+	prwe.tenantsMu.Lock()
+	senders := prwe.tenants
+	prwe.tenants = make(map[string]*tenantSender)
+	cancel := prwe.tenantsCancel
+	prwe.tenantsMu.Unlock()
+
+	for _, sender := range senders {
+		errs = multierr.Append(errs, sender.stop(ctx))
+	}
+	if cancel != nil {
+		cancel()
+	}
+	return errs
+}
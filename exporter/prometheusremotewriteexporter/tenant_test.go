@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func getTenantMetrics(tenants ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, tenant := range tenants {
+		rm := md.ResourceMetrics().AppendEmpty()
+		if tenant != "" {
+			rm.Resource().Attributes().PutStr("tenant.id", tenant)
+		}
+		rm.Resource().Attributes().PutStr("service.name", "app")
+		metric := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		metric.SetName("requests")
+		dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(1e9)
+		dp.SetDoubleValue(1)
+	}
+	return md
+}
+
+// newTenantConfig returns the tenant config with the defaults set by Config.Validate.
+func newTenantConfig(defaultTenant string) *Tenant {
+	return &Tenant{
+		ResourceAttribute: "tenant.id",
+		Header:            defaultTenantHeader,
+		Default:           defaultTenant,
+		MaxTenants:        defaultMaxTenants,
+		IdleTimeout:       defaultTenantIdleTimeout,
+		QueueSize:         defaultTenantQueueSize,
+	}
+}
+
+// newTenantServer returns a remote write server counting the received time series per tenant,
+// which blocks the requests of the blocked tenant until unblock is closed.
+func newTenantServer(t *testing.T, blocked string, unblock chan struct{}) (*httptest.Server, func() map[string]int) {
+	var (
+		mu       sync.Mutex
+		received = make(map[string]int)
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		dest, err := snappy.Decode(nil, body)
+		assert.NoError(t, err)
+		wr := &prompb.WriteRequest{}
+		assert.NoError(t, proto.Unmarshal(dest, wr))
+
+		tenant := r.Header.Get("X-Scope-OrgID")
+		if tenant == blocked {
+			<-unblock
+		}
+		mu.Lock()
+		received[tenant] += len(wr.Timeseries)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() map[string]int {
+		mu.Lock()
+		defer mu.Unlock()
+		got := make(map[string]int, len(received))
+		for tenant, n := range received {
+			got[tenant] = n
+		}
+		return got
+	}
+}
+
+func newTenantExporter(t *testing.T, endpoint string, tenant *Tenant) *prwExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = endpoint
+	cfg.TargetInfo.Enabled = false
+	cfg.Tenant = tenant
+	prwe, err := newPRWExporter(cfg, exportertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, prwe.Start(context.Background(), componenttest.NewNopHost()))
+	return prwe
+}
+
+func TestSplitByTenant(t *testing.T) {
+	md := getTenantMetrics("team-a", "team-b", "team-a", "")
+
+	tests := []struct {
+		name            string
+		cfg             *Tenant
+		expectedTenants map[string]int
+	}{
+		{
+			name:            "no_default",
+			cfg:             &Tenant{ResourceAttribute: "tenant.id"},
+			expectedTenants: map[string]int{"team-a": 2, "team-b": 1, "": 1},
+		},
+		{
+			name:            "default",
+			cfg:             &Tenant{ResourceAttribute: "tenant.id", Default: "team-b"},
+			expectedTenants: map[string]int{"team-a": 2, "team-b": 2},
+		},
+		{
+			name:            "drop_resource_attribute",
+			cfg:             &Tenant{ResourceAttribute: "tenant.id", DropResourceAttribute: true},
+			expectedTenants: map[string]int{"team-a": 2, "team-b": 1, "": 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenants := splitByTenant(md, tt.cfg)
+			got := make(map[string]int)
+			for tenant, tmd := range tenants {
+				got[tenant] = tmd.ResourceMetrics().Len()
+				if !tt.cfg.DropResourceAttribute {
+					continue
+				}
+				for i := 0; i < tmd.ResourceMetrics().Len(); i++ {
+					_, ok := tmd.ResourceMetrics().At(i).Resource().Attributes().Get("tenant.id")
+					assert.False(t, ok)
+				}
+			}
+			assert.Equal(t, tt.expectedTenants, got)
+		})
+	}
+
+	// The split metrics are copies.
+	_, ok := md.ResourceMetrics().At(0).Resource().Attributes().Get("tenant.id")
+	assert.True(t, ok)
+}
+
+func TestPushMetricsPerTenant(t *testing.T) {
+	server, received := newTenantServer(t, "", nil)
+	prwe := newTenantExporter(t, server.URL, newTenantConfig("anonymous"))
+
+	require.NoError(t, prwe.PushMetrics(context.Background(), getTenantMetrics("team-a", "team-b", "")))
+	require.NoError(t, prwe.Shutdown(context.Background()))
+	assert.Equal(t, map[string]int{"team-a": 1, "team-b": 1, "anonymous": 1}, received())
+}
+
+func TestPushMetricsSlowTenant(t *testing.T) {
+	unblock := make(chan struct{})
+	server, received := newTenantServer(t, "slow", unblock)
+	prwe := newTenantExporter(t, server.URL, newTenantConfig(""))
+
+	// The metrics of the other tenant are exported while the slow tenant is blocked.
+	require.NoError(t, prwe.PushMetrics(context.Background(), getTenantMetrics("slow", "team-a")))
+	require.NoError(t, prwe.PushMetrics(context.Background(), getTenantMetrics("slow", "team-a")))
+	require.Eventually(t, func() bool {
+		return received()["team-a"] == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.NotContains(t, received(), "slow")
+
+	close(unblock)
+	require.NoError(t, prwe.Shutdown(context.Background()))
+	assert.Equal(t, map[string]int{"team-a": 2, "slow": 2}, received())
+}
+
+func TestPushMetricsMaxTenants(t *testing.T) {
+	server, received := newTenantServer(t, "", nil)
+	tenant := newTenantConfig("")
+	tenant.MaxTenants = 1
+	prwe := newTenantExporter(t, server.URL, tenant)
+
+	require.NoError(t, prwe.PushMetrics(context.Background(), getTenantMetrics("team-a")))
+
+	// Only the metrics of the tenant over the limit are returned to be retried.
+	err := prwe.PushMetrics(context.Background(), getTenantMetrics("team-a", "team-b"))
+	require.ErrorIs(t, err, errTooManyTenants)
+	assert.False(t, consumererror.IsPermanent(err))
+	var metricsErr consumererror.Metrics
+	require.ErrorAs(t, err, &metricsErr)
+	require.Equal(t, 1, metricsErr.Data().ResourceMetrics().Len())
+	v, _ := metricsErr.Data().ResourceMetrics().At(0).Resource().Attributes().Get("tenant.id")
+	assert.Equal(t, "team-b", v.Str())
+
+	// The sender of the idle tenant is closed, leaving room for the other tenant.
+	require.Eventually(t, func() bool {
+		return received()["team-a"] == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	prwe.closeIdleTenants(time.Now().Add(tenant.IdleTimeout))
+	assert.Empty(t, prwe.tenants)
+	require.NoError(t, prwe.PushMetrics(context.Background(), metricsErr.Data()))
+
+	require.NoError(t, prwe.Shutdown(context.Background()))
+	assert.Equal(t, map[string]int{"team-a": 2, "team-b": 1}, received())
+}
+
+func TestTenantWALs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = server.URL
+	cfg.WAL = &WALConfig{Directory: t.TempDir()}
+	cfg.Tenant = newTenantConfig("")
+	cfg.Tenant.MaxTenants = 1
+	ctx := context.Background()
+
+	prwe, err := newPRWExporter(cfg, exportertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, prwe.Start(ctx, componenttest.NewNopHost()))
+
+	defaultWAL, release, err := prwe.walForTenant("")
+	require.NoError(t, err)
+	release()
+	assert.Same(t, prwe.wal, defaultWAL)
+
+	tenantWAL, release, err := prwe.walForTenant("team/a")
+	require.NoError(t, err)
+	assert.NotSame(t, prwe.wal, tenantWAL)
+	assert.Equal(t, filepath.Join(cfg.WAL.Directory, "prom_remotewrite_tenant_team%2Fa"), tenantWAL.walPath)
+	sameWAL, releaseSame, err := prwe.walForTenant("team/a")
+	require.NoError(t, err)
+	releaseSame()
+	assert.Same(t, tenantWAL, sameWAL)
+
+	// The WAL of another tenant exceeds max_tenants.
+	_, _, err = prwe.walForTenant("team-b")
+	require.ErrorIs(t, err, errTooManyTenants)
+
+	// The WAL in use is not closed even if idle.
+	prwe.closeIdleTenants(time.Now().Add(cfg.Tenant.IdleTimeout))
+	assert.Contains(t, prwe.tenants, "team/a")
+	release()
+	prwe.closeIdleTenants(time.Now().Add(cfg.Tenant.IdleTimeout))
+	assert.NotContains(t, prwe.tenants, "team/a")
+	require.NoError(t, prwe.Shutdown(ctx))
+
+	// The WALs of the tenants are started again on restart.
+	prwe, err = newPRWExporter(cfg, exportertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, prwe.Start(ctx, componenttest.NewNopHost()))
+	assert.Contains(t, prwe.tenants, "team/a")
+	require.NoError(t, prwe.Shutdown(ctx))
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"path/filepath"
 	"sync"
 	"sync/atomic"
@@ -24,6 +25,7 @@ type prweWAL struct {
 	mu        sync.Mutex // mu protects the fields below.
 	wal       *wal.Log
 	walConfig *WALConfig
+	walName   string
 	walPath   string
 
 	exportSink func(ctx context.Context, reqL []*prompb.WriteRequest) error
@@ -59,7 +61,7 @@ func (wc *WALConfig) truncateFrequency() time.Duration {
 	return defaultWALTruncateFrequency
 }
 
-func newWAL(walConfig *WALConfig, exportSink func(context.Context, []*prompb.WriteRequest) error) (*prweWAL, error) {
+func newWAL(walConfig *WALConfig, walName string, exportSink func(context.Context, []*prompb.WriteRequest) error) (*prweWAL, error) {
 	if walConfig == nil {
 		// There are cases for which the WAL can be disabled.
 		// TODO: Perhaps log that the WAL wasn't enabled.
@@ -69,14 +71,27 @@ func newWAL(walConfig *WALConfig, exportSink func(context.Context, []*prompb.Wri
 	return &prweWAL{
 		exportSink: exportSink,
 		walConfig:  walConfig,
+		walName:    walName,
 		stopChan:   make(chan struct{}),
 		rWALIndex:  &atomic.Uint64{},
 		wWALIndex:  &atomic.Uint64{},
 	}, nil
 }
 
-func (wc *WALConfig) createWAL() (*wal.Log, string, error) {
-	walPath := filepath.Join(wc.Directory, "prom_remotewrite")
+const (
+	defaultWALName = "prom_remotewrite"
+	// tenantWALPrefix prefixes the names of the WALs of the tenants.
+	tenantWALPrefix = "prom_remotewrite_tenant_"
+)
+
+// tenantWALName returns the name of the WAL of a tenant, escaped so that it
+// is a single path element.
+func tenantWALName(tenant string) string {
+	return tenantWALPrefix + url.PathEscape(tenant)
+}
+
+func (wc *WALConfig) createWAL(walName string) (*wal.Log, string, error) {
+	walPath := filepath.Join(wc.Directory, walName)
 	log, err := wal.Open(walPath, &wal.Options{
 		SegmentCacheSize: wc.bufferSize(),
 		NoCopy:           true,
@@ -103,7 +118,7 @@ func (prwe *prweWAL) retrieveWALIndices() (err error) {
 		return err
 	}
 
-	log, walPath, err := prwe.walConfig.createWAL()
+	log, walPath, err := prwe.walConfig.createWAL(prwe.walName)
 	if err != nil {
 		return err
 	}
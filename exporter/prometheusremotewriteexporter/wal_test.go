@@ -21,14 +21,14 @@ func doNothingExportSink(_ context.Context, reqL []*prompb.WriteRequest) error {
 
 func TestWALCreation_nilConfig(t *testing.T) {
 	config := (*WALConfig)(nil)
-	pwal, err := newWAL(config, doNothingExportSink)
+	pwal, err := newWAL(config, defaultWALName, doNothingExportSink)
 	require.Equal(t, err, errNilConfig)
 	require.Nil(t, pwal)
 }
 
 func TestWALCreation_nonNilConfig(t *testing.T) {
 	config := &WALConfig{Directory: t.TempDir()}
-	pwal, err := newWAL(config, doNothingExportSink)
+	pwal, err := newWAL(config, defaultWALName, doNothingExportSink)
 	require.NotNil(t, pwal)
 	assert.Nil(t, err)
 	assert.NoError(t, pwal.stop())
@@ -80,7 +80,7 @@ func TestWALStopManyTimes(t *testing.T) {
 		TruncateFrequency: 60 * time.Microsecond,
 		BufferSize:        1,
 	}
-	pwal, err := newWAL(config, doNothingExportSink)
+	pwal, err := newWAL(config, defaultWALName, doNothingExportSink)
 	require.Nil(t, err)
 	require.NotNil(t, pwal)
 
@@ -99,7 +99,7 @@ func TestWAL_persist(t *testing.T) {
 	// Unit tests that requests written to the WAL persist.
 	config := &WALConfig{Directory: t.TempDir()}
 
-	pwal, err := newWAL(config, doNothingExportSink)
+	pwal, err := newWAL(config, defaultWALName, doNothingExportSink)
 	require.Nil(t, err)
 
 	// 1. Write out all the entries.
@@ -27,6 +27,8 @@ const (
 	K8sServiceType EndpointType = "k8s.service"
 	// K8sNodeType is a Kubernetes Node endpoint.
 	K8sNodeType EndpointType = "k8s.node"
+	// K8sIngressType is a Kubernetes Ingress endpoint.
+	K8sIngressType EndpointType = "k8s.ingress"
 	// HostPortType is a hostport endpoint.
 	HostPortType EndpointType = "hostport"
 	// ContainerType is a container endpoint.
@@ -38,6 +40,7 @@ var (
 	_ EndpointDetails = (*Port)(nil)
 	_ EndpointDetails = (*K8sService)(nil)
 	_ EndpointDetails = (*K8sNode)(nil)
+	_ EndpointDetails = (*K8sIngress)(nil)
 	_ EndpointDetails = (*HostPort)(nil)
 	_ EndpointDetails = (*Container)(nil)
 )
@@ -129,6 +132,56 @@ func (s *K8sService) Type() EndpointType {
 	return K8sServiceType
 }
 
+// K8sIngress is a discovered k8s ingress rule path. Each host and path pair
+// of an Ingress object is reported as its own endpoint.
+type K8sIngress struct {
+	// Name of the ingress.
+	Name string
+	// UID is the unique ID in the cluster for the ingress.
+	UID string
+	// Labels is a map of user-specified metadata.
+	Labels map[string]string
+	// Annotations is a map of user-specified metadata.
+	Annotations map[string]string
+	// Namespace must be unique for ingresses with same name.
+	Namespace string
+	// Scheme represents whether the ingress path is accessible via https or http.
+	Scheme string
+	// Host is the fully qualified domain name of a network host.
+	Host string
+	// Path is a extended POSIX regex as defined by IEEE Std 1003.1 or a plain path prefix.
+	Path string
+	// TLS indicates whether the host is covered by a TLS section of the ingress.
+	TLS bool
+	// ServiceName is the name of the service backing the path.
+	ServiceName string
+	// ServicePort is the port number of the service backing the path, if set by number.
+	ServicePort uint16
+	// ServicePortName is the port name of the service backing the path, if set by name.
+	ServicePortName string
+}
+
+func (i *K8sIngress) Env() EndpointEnv {
+	return map[string]any{
+		"uid":               i.UID,
+		"name":              i.Name,
+		"labels":            i.Labels,
+		"annotations":       i.Annotations,
+		"namespace":         i.Namespace,
+		"scheme":            i.Scheme,
+		"host":              i.Host,
+		"path":              i.Path,
+		"tls":               i.TLS,
+		"service_name":      i.ServiceName,
+		"service_port":      i.ServicePort,
+		"service_port_name": i.ServicePortName,
+	}
+}
+
+func (i *K8sIngress) Type() EndpointType {
+	return K8sIngressType
+}
+
 // Pod is a discovered k8s pod.
 type Pod struct {
 	// Name of the pod.
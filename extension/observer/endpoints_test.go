@@ -126,6 +126,51 @@ func TestEndpointEnv(t *testing.T) {
 				"service_type": "LoadBalancer",
 			},
 		},
+		{
+			name: "Ingress",
+			endpoint: Endpoint{
+				ID:     EndpointID("ingress_id"),
+				Target: "https://host-1/",
+				Details: &K8sIngress{
+					Name: "ingress_name",
+					UID:  "ingress-uid",
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+					Annotations: map[string]string{
+						"annotation_1": "value_1",
+					},
+					Namespace:   "ingress-namespace",
+					Scheme:      "https",
+					Host:        "host-1",
+					Path:        "/",
+					TLS:         true,
+					ServiceName: "service-1",
+					ServicePort: 8080,
+				},
+			},
+			want: EndpointEnv{
+				"type":     "k8s.ingress",
+				"endpoint": "https://host-1/",
+				"id":       "ingress_id",
+				"name":     "ingress_name",
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+				"annotations": map[string]string{
+					"annotation_1": "value_1",
+				},
+				"uid":               "ingress-uid",
+				"namespace":         "ingress-namespace",
+				"scheme":            "https",
+				"host":              "host-1",
+				"path":              "/",
+				"tls":               true,
+				"service_name":      "service-1",
+				"service_port":      uint16(8080),
+				"service_port_name": "",
+			},
+		},
 		{
 			name: "Host port",
 			endpoint: Endpoint{
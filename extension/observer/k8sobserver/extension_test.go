@@ -307,3 +307,56 @@ func TestExtensionObserveNodes(t *testing.T) {
 
 	require.NoError(t, ext.Shutdown(context.Background()))
 }
+
+func TestExtensionObserveIngresses(t *testing.T) {
+	factory := NewFactory()
+	config := factory.CreateDefaultConfig().(*Config)
+	mockServiceHost(t, config)
+
+	set := extensiontest.NewNopCreateSettings()
+	set.ID = component.NewID(metadata.Type)
+	ext, err := newObserver(config, set)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+
+	obs := ext.(*k8sObserver)
+	ingressListerWatcher := framework.NewFakeControllerSource()
+	obs.ingressListerWatcher = ingressListerWatcher
+
+	ingressListerWatcher.Add(ingress)
+
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &endpointSink{}
+	obs.ListAndWatch(sink)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.added) == 2
+	})
+
+	assert.ElementsMatch(t, []observer.EndpointID{
+		"k8s_observer/ingress-1-UID/host-1.example.com/",
+		"k8s_observer/ingress-1-UID/host.internal/api",
+	}, endpointIDs(sink.added))
+
+	ingressListerWatcher.Modify(ingressV2)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.changed) == 2
+	})
+
+	for _, e := range sink.changed {
+		assert.Equal(t, map[string]string{
+			"env":             "prod",
+			"ingress-version": "2",
+		}, e.Details.(*observer.K8sIngress).Labels)
+	}
+
+	ingressListerWatcher.Delete(ingressV2)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.removed) == 2
+	})
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
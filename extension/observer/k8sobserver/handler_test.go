@@ -165,6 +165,61 @@ func TestServiceEndpointsChanged(t *testing.T) {
 	}, th.ListEndpoints())
 }
 
+func TestIngressEndpointsAdded(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingress, true)
+	assert.ElementsMatch(t, []observer.EndpointID{
+		"test-1/ingress-1-UID/host-1.example.com/",
+		"test-1/ingress-1-UID/host.internal/api",
+	}, endpointIDs(th.ListEndpoints()))
+}
+
+func TestIngressEndpointsRemoved(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingress, true)
+	th.OnDelete(ingress)
+	assert.Empty(t, th.ListEndpoints())
+}
+
+func TestIngressEndpointsChanged(t *testing.T) {
+	th := newTestHandler()
+	// Nothing changed.
+	th.OnUpdate(ingress, ingress)
+	require.Empty(t, th.ListEndpoints())
+
+	// Path removed and host changed.
+	updatedIngress := ingress.DeepCopy()
+	updatedIngress.Spec.Rules = updatedIngress.Spec.Rules[:1]
+	updatedIngress.Spec.Rules[0].HTTP.Paths[0].Path = "/health"
+	th.OnAdd(ingress, true)
+	th.OnUpdate(ingress, updatedIngress)
+	require.ElementsMatch(t, []observer.Endpoint{
+		{
+			ID:     "test-1/ingress-1-UID/host-1.example.com/health",
+			Target: "https://host-1.example.com/health",
+			Details: &observer.K8sIngress{
+				Name:        "ingress-1",
+				Namespace:   "default",
+				UID:         "ingress-1-UID",
+				Labels:      map[string]string{"env": "prod"},
+				Scheme:      "https",
+				Host:        "host-1.example.com",
+				Path:        "/health",
+				TLS:         true,
+				ServiceName: "service-1",
+				ServicePort: 8080,
+			}},
+	}, th.ListEndpoints())
+}
+
+func endpointIDs(endpoints []observer.Endpoint) []observer.EndpointID {
+	var ids []observer.EndpointID
+	for _, e := range endpoints {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
 func TestNodeEndpointsAdded(t *testing.T) {
 	th := newTestHandler()
 	th.OnAdd(node1V1, true)
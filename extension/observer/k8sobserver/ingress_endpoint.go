@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver"
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	v1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// convertIngressToEndpoints converts a ingress instance into a slice of endpoints. One endpoint
+// is created for each host and path pair of the ingress rules. The rules without host use the
+// address of the load-balancer of the ingress, and are skipped if it has none yet.
+func convertIngressToEndpoints(idNamespace string, ingress *v1.Ingress) []observer.Endpoint {
+	endpoints := []observer.Endpoint{}
+
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		host := rule.Host
+		if host == "" {
+			if host = loadBalancerHost(ingress); host == "" {
+				continue
+			}
+		}
+		tls := isTLSHost(ingress, host)
+		scheme := "http"
+		if tls {
+			scheme = "https"
+		}
+		for _, path := range rule.HTTP.Paths {
+			endpointID := observer.EndpointID(
+				fmt.Sprintf(
+					"%s/%s/%s%s",
+					idNamespace,
+					ingress.UID,
+					host,
+					path.Path,
+				),
+			)
+
+			ingressDetails := &observer.K8sIngress{
+				UID:         string(ingress.UID),
+				Annotations: ingress.Annotations,
+				Labels:      ingress.Labels,
+				Name:        ingress.Name,
+				Namespace:   ingress.Namespace,
+				Scheme:      scheme,
+				Host:        host,
+				Path:        path.Path,
+				TLS:         tls,
+			}
+			if path.Backend.Service != nil {
+				ingressDetails.ServiceName = path.Backend.Service.Name
+				ingressDetails.ServicePort = uint16(path.Backend.Service.Port.Number)
+				ingressDetails.ServicePortName = path.Backend.Service.Port.Name
+			}
+
+			endpoints = append(endpoints, observer.Endpoint{
+				ID:      endpointID,
+				Target:  (&url.URL{Scheme: scheme, Host: host, Path: path.Path}).String(),
+				Details: ingressDetails,
+			})
+		}
+	}
+
+	return endpoints
+}
+
+// loadBalancerHost returns the hostname or IP address of the first load-balancer
+// ingress point of the ingress, or an empty string if there is none.
+func loadBalancerHost(ingress *v1.Ingress) string {
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.Hostname != "" {
+			return lb.Hostname
+		}
+		if lb.IP != "" {
+			if strings.Contains(lb.IP, ":") {
+				// IPv6 addresses are enclosed in brackets in URLs.
+				return "[" + lb.IP + "]"
+			}
+			return lb.IP
+		}
+	}
+	return ""
+}
+
+// isTLSHost returns whether the provided host is listed in one of the TLS sections of the ingress.
+// Wildcard TLS hosts match a single leading DNS label as defined by the Ingress specification.
+func isTLSHost(ingress *v1.Ingress, host string) bool {
+	for _, tls := range ingress.Spec.TLS {
+		for _, tlsHost := range tls.Hosts {
+			if tlsHost == host {
+				return true
+			}
+			if strings.HasPrefix(tlsHost, "*.") {
+				if _, rest, found := strings.Cut(host, "."); found && rest == tlsHost[2:] {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestIngressObjectToEndpoint(t *testing.T) {
+	expectedEndpoints := []observer.Endpoint{
+		{
+			ID:     "namespace/ingress-1-UID/host-1.example.com/",
+			Target: "https://host-1.example.com/",
+			Details: &observer.K8sIngress{
+				Name:        "ingress-1",
+				Namespace:   "default",
+				UID:         "ingress-1-UID",
+				Labels:      map[string]string{"env": "prod"},
+				Scheme:      "https",
+				Host:        "host-1.example.com",
+				Path:        "/",
+				TLS:         true,
+				ServiceName: "service-1",
+				ServicePort: 8080,
+			}},
+		{
+			ID:     "namespace/ingress-1-UID/host.internal/api",
+			Target: "http://host.internal/api",
+			Details: &observer.K8sIngress{
+				Name:            "ingress-1",
+				Namespace:       "default",
+				UID:             "ingress-1-UID",
+				Labels:          map[string]string{"env": "prod"},
+				Scheme:          "http",
+				Host:            "host.internal",
+				Path:            "/api",
+				ServiceName:     "service-2",
+				ServicePortName: "http",
+			}},
+	}
+
+	endpoints := convertIngressToEndpoints("namespace", ingress)
+	require.Equal(t, expectedEndpoints, endpoints)
+}
+
+func TestIngressRuleWithoutHost(t *testing.T) {
+	noHost := newIngress("ingress-1")
+	noHost.Spec.Rules = noHost.Spec.Rules[1:]
+	noHost.Spec.Rules[0].Host = ""
+
+	// The rule is skipped until the load-balancer has an address.
+	require.Empty(t, convertIngressToEndpoints("namespace", noHost))
+
+	for _, tt := range []struct {
+		lb     networkingv1.IngressLoadBalancerIngress
+		host   string
+		target string
+	}{
+		{lb: networkingv1.IngressLoadBalancerIngress{Hostname: "lb.example.net"}, host: "lb.example.net", target: "http://lb.example.net/api"},
+		{lb: networkingv1.IngressLoadBalancerIngress{IP: "10.0.0.1"}, host: "10.0.0.1", target: "http://10.0.0.1/api"},
+		{lb: networkingv1.IngressLoadBalancerIngress{IP: "fd00::1"}, host: "[fd00::1]", target: "http://[fd00::1]/api"},
+	} {
+		noHost.Status.LoadBalancer.Ingress = []networkingv1.IngressLoadBalancerIngress{tt.lb}
+		endpoints := convertIngressToEndpoints("namespace", noHost)
+		require.Len(t, endpoints, 1)
+		require.Equal(t, observer.EndpointID("namespace/ingress-1-UID/"+tt.host+"/api"), endpoints[0].ID)
+		require.Equal(t, tt.target, endpoints[0].Target)
+		require.Equal(t, tt.host, endpoints[0].Details.(*observer.K8sIngress).Host)
+	}
+}
+
+func TestIsTLSHost(t *testing.T) {
+	tls := &networkingv1.Ingress{
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{
+				{Hosts: []string{"exact.example.com", "*.wildcard.com"}},
+			},
+		},
+	}
+
+	require.True(t, isTLSHost(tls, "exact.example.com"))
+	require.True(t, isTLSHost(tls, "foo.wildcard.com"))
+	require.False(t, isTLSHost(tls, "foo.bar.wildcard.com"))
+	require.False(t, isTLSHost(tls, "wildcard.com"))
+	require.False(t, isTLSHost(tls, "other.example.com"))
+}
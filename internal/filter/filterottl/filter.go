@@ -12,6 +12,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
 )
@@ -142,6 +143,27 @@ func NewBoolExprForResource(conditions []string, functions map[string]ottl.Facto
 	return &s, nil
 }
 
+// NewBoolExprForScope creates a BoolExpr[ottlscope.TransformContext] that will return true if any of the given OTTL conditions evaluate to true.
+// The passed in functions should use the ottlscope.TransformContext.
+// If a function named `match` is not present in the function map it will be added automatically so that parsing works as expected
+func NewBoolExprForScope(conditions []string, functions map[string]ottl.Factory[ottlscope.TransformContext], errorMode ottl.ErrorMode, set component.TelemetrySettings) (expr.BoolExpr[ottlscope.TransformContext], error) {
+	match := newMatchFactory[ottlscope.TransformContext]()
+	if _, ok := functions[match.Name()]; !ok {
+		functions[match.Name()] = match
+	}
+	statmentsStr := conditionsToStatements(conditions)
+	parser, err := ottlscope.NewParser(functions, set)
+	if err != nil {
+		return nil, err
+	}
+	statements, err := parser.ParseStatements(statmentsStr)
+	if err != nil {
+		return nil, err
+	}
+	s := ottlscope.NewStatements(statements, set, ottlscope.WithErrorMode(errorMode))
+	return &s, nil
+}
+
 func conditionsToStatements(conditions []string) []string {
 	statements := make([]string, len(conditions))
 	for i, condition := range conditions {
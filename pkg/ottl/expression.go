@@ -631,13 +631,17 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 			return &literal[K]{value: *i}, nil
 		}
 		if eL.Path != nil {
-			return p.pathParser(eL.Path)
+			return p.parsePath(eL.Path)
 		}
 		if eL.Converter != nil {
 			return p.newGetterFromConverter(*eL.Converter)
 		}
 	}
 
+	if val.Lambda != nil {
+		return nil, fmt.Errorf("lambdas can only be passed to functions that iterate over maps or slices")
+	}
+
 	if val.List != nil {
 		lg := listGetter[K]{slice: make([]Getter[K], len(val.List.Values))}
 		for i, v := range val.List.Values {
@@ -16,6 +16,45 @@ type PathExpressionParser[K any] func(*Path) (GetSetter[K], error)
 
 type EnumParser func(*EnumSymbol) (*Enum, error)
 
+// parsePath resolves the `key` and `value` paths of the current element when parsing
+// a lambda, otherwise the path is parsed using the PathExpressionParser of the context.
+func (p *Parser[K]) parsePath(path *Path) (GetSetter[K], error) {
+	if p.inLambda && isElementPath(path) {
+		return newElementPath[K](path)
+	}
+	return p.pathParser(path)
+}
+
+// lambdaParser returns a copy of the parser to parse the body of a lambda, so that the parser
+// shared by concurrent calls is not modified.
+func (p *Parser[K]) lambdaParser() *Parser[K] {
+	lp := *p
+	lp.inLambda = true
+	return &lp
+}
+
+func (p *Parser[K]) newElementStatement(parsed *parsedStatement) (ElementStatement[K], error) {
+	p = p.lambdaParser()
+	function, err := p.newFunctionCall(parsed.Editor)
+	if err != nil {
+		return ElementStatement[K]{}, err
+	}
+	condition, err := p.newBoolExpr(parsed.WhereClause)
+	if err != nil {
+		return ElementStatement[K]{}, err
+	}
+	return ElementStatement[K]{statement: &Statement[K]{function: function, condition: condition}}, nil
+}
+
+func (p *Parser[K]) newElementCondition(parsed *booleanExpression) (ElementCondition[K], error) {
+	p = p.lambdaParser()
+	condition, err := p.newBoolExpr(parsed)
+	if err != nil {
+		return ElementCondition[K]{}, err
+	}
+	return ElementCondition[K]{condition: condition}, nil
+}
+
 type Enum int64
 
 func (p *Parser[K]) newFunctionCall(ed editor) (Expr[K], error) {
@@ -243,7 +282,7 @@ func (p *Parser[K]) buildArg(argVal value, argType reflect.Type) (any, error) {
 		if argVal.Literal == nil || argVal.Literal.Path == nil {
 			return nil, fmt.Errorf("must be a Path")
 		}
-		arg, err := p.pathParser(argVal.Literal.Path)
+		arg, err := p.parsePath(argVal.Literal.Path)
 		if err != nil {
 			return nil, err
 		}
@@ -308,6 +347,24 @@ func (p *Parser[K]) buildArg(argVal value, argType reflect.Type) (any, error) {
 			return nil, err
 		}
 		return StandardTimeGetter[K]{Getter: arg.Get}, nil
+	case strings.HasPrefix(name, "ElementStatement"):
+		if argVal.Lambda == nil || argVal.Lambda.Statement == nil {
+			return nil, fmt.Errorf("must be a statement wrapped in braces")
+		}
+		arg, err := p.newElementStatement(argVal.Lambda.Statement)
+		if err != nil {
+			return nil, err
+		}
+		return arg, nil
+	case strings.HasPrefix(name, "ElementCondition"):
+		if argVal.Lambda == nil || argVal.Lambda.Condition == nil {
+			return nil, fmt.Errorf("must be a condition wrapped in braces")
+		}
+		arg, err := p.newElementCondition(argVal.Lambda.Condition)
+		if err != nil {
+			return nil, err
+		}
+		return arg, nil
 	case name == "Enum":
 		arg, err := p.enumParser(argVal.Enum)
 		if err != nil {
@@ -237,7 +237,8 @@ type value struct {
 	Bool           *boolean         `parser:"| @Boolean"`
 	Enum           *EnumSymbol      `parser:"| @Uppercase (?! Lowercase)"`
 	FunctionName   *string          `parser:"| @(Uppercase(Uppercase | Lowercase)*)"`
-	List           *list            `parser:"| @@)"`
+	List           *list            `parser:"| @@"`
+	Lambda         *lambda          `parser:"| @@)"`
 }
 
 func (v *value) checkForCustomError() error {
@@ -247,9 +248,26 @@ func (v *value) checkForCustomError() error {
 	if v.MathExpression != nil {
 		return v.MathExpression.checkForCustomError()
 	}
+	if v.Lambda != nil {
+		return v.Lambda.checkForCustomError()
+	}
 	return nil
 }
 
+// lambda represents either a condition or a statement wrapped in braces that
+// is passed to a function which evaluates it for every element of a map or slice.
+type lambda struct {
+	Condition *booleanExpression `parser:"'{' ( @@"`
+	Statement *parsedStatement   `parser:"| @@ ) '}'"`
+}
+
+func (l *lambda) checkForCustomError() error {
+	if l.Condition != nil {
+		return l.Condition.checkForCustomError()
+	}
+	return l.Statement.checkForCustomError()
+}
+
 // Path represents a telemetry path mathExpression.
 type Path struct {
 	Fields []Field `parser:"@@ ( '.' @@ )*"`
@@ -444,7 +462,7 @@ func buildLexer() *lexer.StatefulDefinition {
 		{Name: `Equal`, Pattern: `=`},
 		{Name: `LParen`, Pattern: `\(`},
 		{Name: `RParen`, Pattern: `\)`},
-		{Name: `Punct`, Pattern: `[,.\[\]]`},
+		{Name: `Punct`, Pattern: `[,.\[\]{}]`},
 		{Name: `Uppercase`, Pattern: `[A-Z][A-Z0-9_]*`},
 		{Name: `Lowercase`, Pattern: `[a-z][a-z0-9_]*`},
 		{Name: "whitespace", Pattern: `\s+`},
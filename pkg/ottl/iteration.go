@@ -0,0 +1,403 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+const (
+	// elementKeyPath is the path used within a lambda to access the key of the current
+	// map entry, or the index of the current slice element.
+	elementKeyPath = "key"
+	// elementValuePath is the path used within a lambda to access the value of the current element.
+	elementValuePath = "value"
+)
+
+var errNoElement = errors.New("no element is being iterated over")
+
+// ElementStatement is a function argument holding a statement wrapped in braces,
+// such as `{set(value, "redacted") where key == "password"}`. It is executed
+// for every element iterated over by functions calling Iterate, where the
+// `key` and `value` paths refer to the current element.
+type ElementStatement[K any] struct {
+	statement *Statement[K]
+}
+
+// Execute executes the statement for the current element.
+func (s ElementStatement[K]) Execute(ctx context.Context, tCtx K) error {
+	_, _, err := s.statement.Execute(ctx, tCtx)
+	return err
+}
+
+// ElementCondition is a function argument holding a condition wrapped in braces,
+// such as `{IsMatch(key, "^http\\.")}`. It is evaluated for every element
+// iterated over by functions calling Iterate, where the `key` and `value`
+// paths refer to the current element.
+type ElementCondition[K any] struct {
+	condition BoolExpr[K]
+}
+
+// Eval evaluates the condition for the current element.
+func (c ElementCondition[K]) Eval(ctx context.Context, tCtx K) (bool, error) {
+	return c.condition.Eval(ctx, tCtx)
+}
+
+type elementContextKey struct{}
+
+// element is the map entry or slice element that is currently being iterated over.
+type element struct {
+	// key is a string for map entries and an int64 index for slice elements.
+	key any
+	// value is a pcommon.Value or a ptrace.SpanEvent.
+	value any
+	// newKey is set when the key of a map entry is renamed.
+	newKey *string
+}
+
+// Iterate calls fn for every element of target, which must be a pcommon.Map,
+// pcommon.Slice or ptrace.SpanEventSlice. The context passed to fn gives
+// ElementStatement and ElementCondition arguments access to the element through
+// the `key` and `value` paths. Elements for which fn returns true are removed from target.
+// Map entries whose key was set during the call to fn are renamed afterwards.
+func Iterate(ctx context.Context, target any, fn func(ctx context.Context) (bool, error)) error {
+	switch t := target.(type) {
+	case pcommon.Map:
+		return iterateMap(ctx, t, fn)
+	case pcommon.Slice:
+		remove, err := iterateIndexed(ctx, t.Len(), func(i int) any { return t.At(i) }, fn)
+		if err != nil {
+			return err
+		}
+		i := 0
+		t.RemoveIf(func(pcommon.Value) bool {
+			i++
+			return remove[i-1]
+		})
+		return nil
+	case ptrace.SpanEventSlice:
+		remove, err := iterateIndexed(ctx, t.Len(), func(i int) any { return t.At(i) }, fn)
+		if err != nil {
+			return err
+		}
+		i := 0
+		t.RemoveIf(func(ptrace.SpanEvent) bool {
+			i++
+			return remove[i-1]
+		})
+		return nil
+	default:
+		return TypeError(fmt.Sprintf("expected pcommon.Map, pcommon.Slice or ptrace.SpanEventSlice but got %T", target))
+	}
+}
+
+func iterateMap(ctx context.Context, m pcommon.Map, fn func(ctx context.Context) (bool, error)) error {
+	// The keys are collected first since the map is modified while iterating.
+	keys := make([]string, 0, m.Len())
+	m.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	for _, k := range keys {
+		v, ok := m.Get(k)
+		if !ok {
+			continue
+		}
+		e := &element{key: k, value: v}
+		remove, err := fn(context.WithValue(ctx, elementContextKey{}, e))
+		if err != nil {
+			return err
+		}
+		switch {
+		case remove:
+			m.Remove(k)
+		case e.newKey != nil && *e.newKey != k:
+			renamed := pcommon.NewValueEmpty()
+			v.CopyTo(renamed)
+			m.Remove(k)
+			renamed.CopyTo(m.PutEmpty(*e.newKey))
+		}
+	}
+	return nil
+}
+
+func iterateIndexed(ctx context.Context, length int, at func(int) any, fn func(ctx context.Context) (bool, error)) ([]bool, error) {
+	remove := make([]bool, length)
+	for i := 0; i < length; i++ {
+		e := &element{key: int64(i), value: at(i)}
+		r, err := fn(context.WithValue(ctx, elementContextKey{}, e))
+		if err != nil {
+			return nil, err
+		}
+		remove[i] = r
+	}
+	return remove, nil
+}
+
+func elementFromContext(ctx context.Context) (*element, error) {
+	e, ok := ctx.Value(elementContextKey{}).(*element)
+	if !ok {
+		return nil, errNoElement
+	}
+	return e, nil
+}
+
+// isElementPath returns true if the path refers to the element being iterated over.
+func isElementPath(path *Path) bool {
+	if path == nil || len(path.Fields) == 0 {
+		return false
+	}
+	name := path.Fields[0].Name
+	return name == elementKeyPath || name == elementValuePath
+}
+
+// newElementPath returns a GetSetter for the `key` and `value` paths used within lambdas.
+func newElementPath[K any](path *Path) (GetSetter[K], error) {
+	first := path.Fields[0]
+	if first.Name == elementKeyPath {
+		if len(path.Fields) > 1 || len(first.Keys) > 0 {
+			return nil, fmt.Errorf("%q cannot be indexed or have fields", elementKeyPath)
+		}
+		return StandardGetSetter[K]{
+			Getter: func(ctx context.Context, _ K) (any, error) {
+				e, err := elementFromContext(ctx)
+				if err != nil {
+					return nil, err
+				}
+				if e.newKey != nil {
+					return *e.newKey, nil
+				}
+				return e.key, nil
+			},
+			Setter: func(ctx context.Context, _ K, val any) error {
+				e, err := elementFromContext(ctx)
+				if err != nil {
+					return err
+				}
+				if _, ok := e.key.(string); !ok {
+					return errors.New("only the keys of map entries can be set")
+				}
+				newKey, ok := val.(string)
+				if !ok {
+					return TypeError(fmt.Sprintf("expected string key but got %T", val))
+				}
+				e.newKey = &newKey
+				return nil
+			},
+		}, nil
+	}
+
+	switch len(path.Fields) {
+	case 1:
+		return StandardGetSetter[K]{
+			Getter: func(ctx context.Context, _ K) (any, error) {
+				e, err := elementFromContext(ctx)
+				if err != nil {
+					return nil, err
+				}
+				if event, ok := e.value.(ptrace.SpanEvent); ok {
+					if len(first.Keys) > 0 {
+						return nil, errors.New("span events cannot be indexed")
+					}
+					return event, nil
+				}
+				return getElementValue(e.value.(pcommon.Value), first.Keys)
+			},
+			Setter: func(ctx context.Context, _ K, val any) error {
+				e, err := elementFromContext(ctx)
+				if err != nil {
+					return err
+				}
+				v, ok := e.value.(pcommon.Value)
+				if !ok {
+					return errors.New("span events cannot be set, set their fields instead")
+				}
+				return setElementValue(v, first.Keys, val)
+			},
+		}, nil
+	case 2:
+		if len(first.Keys) > 0 {
+			return nil, errors.New("span events cannot be indexed")
+		}
+		return newSpanEventFieldPath[K](path.Fields[1])
+	default:
+		return nil, fmt.Errorf("invalid path expression %v", path.Fields)
+	}
+}
+
+// newSpanEventFieldPath returns a GetSetter for a field of a span event that is being iterated over,
+// such as `value.name` or `value.attributes["key"]`.
+func newSpanEventFieldPath[K any](field Field) (GetSetter[K], error) {
+	eventFromContext := func(ctx context.Context) (ptrace.SpanEvent, error) {
+		e, err := elementFromContext(ctx)
+		if err != nil {
+			return ptrace.SpanEvent{}, err
+		}
+		event, ok := e.value.(ptrace.SpanEvent)
+		if !ok {
+			return ptrace.SpanEvent{}, fmt.Errorf("only span events have the field %q", field.Name)
+		}
+		return event, nil
+	}
+
+	switch field.Name {
+	case "name":
+		return StandardGetSetter[K]{
+			Getter: func(ctx context.Context, _ K) (any, error) {
+				event, err := eventFromContext(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return event.Name(), nil
+			},
+			Setter: func(ctx context.Context, _ K, val any) error {
+				event, err := eventFromContext(ctx)
+				if err != nil {
+					return err
+				}
+				if name, ok := val.(string); ok {
+					event.SetName(name)
+				}
+				return nil
+			},
+		}, nil
+	case "attributes":
+		return StandardGetSetter[K]{
+			Getter: func(ctx context.Context, _ K) (any, error) {
+				event, err := eventFromContext(ctx)
+				if err != nil {
+					return nil, err
+				}
+				if len(field.Keys) == 0 {
+					return event.Attributes(), nil
+				}
+				if field.Keys[0].String == nil {
+					return nil, errors.New("attributes must be indexed by a string")
+				}
+				v, ok := event.Attributes().Get(*field.Keys[0].String)
+				if !ok {
+					return nil, nil
+				}
+				return getElementValue(v, field.Keys[1:])
+			},
+			Setter: func(ctx context.Context, _ K, val any) error {
+				event, err := eventFromContext(ctx)
+				if err != nil {
+					return err
+				}
+				if len(field.Keys) == 0 {
+					if m, ok := val.(pcommon.Map); ok {
+						m.CopyTo(event.Attributes())
+					}
+					return nil
+				}
+				if field.Keys[0].String == nil {
+					return errors.New("attributes must be indexed by a string")
+				}
+				v, ok := event.Attributes().Get(*field.Keys[0].String)
+				if !ok {
+					v = event.Attributes().PutEmpty(*field.Keys[0].String)
+				}
+				return setElementValue(v, field.Keys[1:], val)
+			},
+		}, nil
+	case "time_unix_nano":
+		return StandardGetSetter[K]{
+			Getter: func(ctx context.Context, _ K) (any, error) {
+				event, err := eventFromContext(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return event.Timestamp().AsTime().UnixNano(), nil
+			},
+			Setter: func(ctx context.Context, _ K, val any) error {
+				event, err := eventFromContext(ctx)
+				if err != nil {
+					return err
+				}
+				if i, ok := val.(int64); ok {
+					event.SetTimestamp(pcommon.Timestamp(i))
+				}
+				return nil
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid span event field %q", field.Name)
+	}
+}
+
+// getElementValue returns the value, or a value nested within it when keys are provided.
+// A nil value is returned if a map key does not exist.
+func getElementValue(v pcommon.Value, keys []Key) (any, error) {
+	for _, k := range keys {
+		switch v.Type() {
+		case pcommon.ValueTypeMap:
+			if k.String == nil {
+				return nil, errors.New("map must be indexed by a string")
+			}
+			var ok bool
+			if v, ok = v.Map().Get(*k.String); !ok {
+				return nil, nil
+			}
+		case pcommon.ValueTypeSlice:
+			if k.Int == nil {
+				return nil, errors.New("slice must be indexed by an int")
+			}
+			if int(*k.Int) >= v.Slice().Len() || *k.Int < 0 {
+				return nil, fmt.Errorf("index %v out of bounds", *k.Int)
+			}
+			v = v.Slice().At(int(*k.Int))
+		default:
+			return nil, fmt.Errorf("type %v does not support indexing", v.Type())
+		}
+	}
+	return ottlcommon.GetValue(v), nil
+}
+
+// setElementValue sets the value, or a value nested within it when keys are provided.
+// Map entries are created if they do not exist.
+func setElementValue(v pcommon.Value, keys []Key, val any) error {
+	for _, k := range keys {
+		switch v.Type() {
+		case pcommon.ValueTypeMap:
+			if k.String == nil {
+				return errors.New("map must be indexed by a string")
+			}
+			next, ok := v.Map().Get(*k.String)
+			if !ok {
+				next = v.Map().PutEmpty(*k.String)
+			}
+			v = next
+		case pcommon.ValueTypeSlice:
+			if k.Int == nil {
+				return errors.New("slice must be indexed by an int")
+			}
+			if int(*k.Int) >= v.Slice().Len() || *k.Int < 0 {
+				return fmt.Errorf("index %v out of bounds", *k.Int)
+			}
+			v = v.Slice().At(int(*k.Int))
+		default:
+			return fmt.Errorf("type %v does not support indexing", v.Type())
+		}
+	}
+
+	switch t := val.(type) {
+	case pcommon.Value:
+		t.CopyTo(v)
+	case pcommon.Map:
+		t.CopyTo(v.SetEmptyMap())
+	case pcommon.Slice:
+		t.CopyTo(v.SetEmptySlice())
+	default:
+		return v.FromRaw(val)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+func Test_Iterate_Map(t *testing.T) {
+	m := pcommon.NewMap()
+	m.PutStr("a", "1")
+	m.PutStr("b", "2")
+	m.PutStr("c", "3")
+
+	key, err := newElementPath[any](&Path{Fields: []Field{{Name: "key"}}})
+	require.NoError(t, err)
+	val, err := newElementPath[any](&Path{Fields: []Field{{Name: "value"}}})
+	require.NoError(t, err)
+
+	err = Iterate(context.Background(), m, func(ctx context.Context) (bool, error) {
+		k, err := key.Get(ctx, nil)
+		if err != nil {
+			return false, err
+		}
+		switch k {
+		case "a":
+			return true, nil
+		case "b":
+			return false, key.Set(ctx, nil, "renamed")
+		}
+		return false, val.Set(ctx, nil, int64(4))
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"renamed": "2", "c": int64(4)}, m.AsRaw())
+}
+
+func Test_Iterate_Slice(t *testing.T) {
+	s := pcommon.NewSlice()
+	require.NoError(t, s.FromRaw([]any{"a", map[string]any{"b": "c"}, "d"}))
+
+	key, err := newElementPath[any](&Path{Fields: []Field{{Name: "key"}}})
+	require.NoError(t, err)
+	nested, err := newElementPath[any](&Path{Fields: []Field{{Name: "value", Keys: []Key{{String: ottltest.Strp("b")}}}}})
+	require.NoError(t, err)
+
+	err = Iterate(context.Background(), s, func(ctx context.Context) (bool, error) {
+		i, err := key.Get(ctx, nil)
+		if err != nil {
+			return false, err
+		}
+		if i == int64(1) {
+			return false, nested.Set(ctx, nil, "e")
+		}
+		assert.Error(t, key.Set(ctx, nil, "key"), "slice indexes cannot be set")
+		return i == int64(2), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"a", map[string]any{"b": "e"}}, s.AsRaw())
+}
+
+func Test_Iterate_SpanEvents(t *testing.T) {
+	events := ptrace.NewSpanEventSlice()
+	events.AppendEmpty().SetName("a")
+	events.AppendEmpty().SetName("b")
+
+	name, err := newElementPath[any](&Path{Fields: []Field{{Name: "value"}, {Name: "name"}}})
+	require.NoError(t, err)
+
+	err = Iterate(context.Background(), events, func(ctx context.Context) (bool, error) {
+		n, err := name.Get(ctx, nil)
+		return n == "a", err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, events.Len())
+	assert.Equal(t, "b", events.At(0).Name())
+}
+
+func Test_Iterate_Error(t *testing.T) {
+	err := Iterate(context.Background(), "not iterable", func(context.Context) (bool, error) {
+		return false, nil
+	})
+	assert.Error(t, err)
+
+	key, err := newElementPath[any](&Path{Fields: []Field{{Name: "key"}}})
+	require.NoError(t, err)
+	_, err = key.Get(context.Background(), nil)
+	assert.ErrorIs(t, err, errNoElement)
+}
+
+func Test_newElementPath_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		path *Path
+	}{
+		{
+			name: "indexed key",
+			path: &Path{Fields: []Field{{Name: "key", Keys: []Key{{String: ottltest.Strp("a")}}}}},
+		},
+		{
+			name: "key with fields",
+			path: &Path{Fields: []Field{{Name: "key"}, {Name: "name"}}},
+		},
+		{
+			name: "unknown span event field",
+			path: &Path{Fields: []Field{{Name: "value"}, {Name: "kind"}}},
+		},
+		{
+			name: "too many fields",
+			path: &Path{Fields: []Field{{Name: "value"}, {Name: "attributes"}, {Name: "a"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newElementPath[any](tt.path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+type lambdaArguments struct {
+	Target    Getter[any]
+	Condition ElementCondition[any]
+}
+
+func Test_Parser_Lambda_Concurrent(t *testing.T) {
+	functions := CreateFactoryMap(
+		NewFactory("delete_if", &lambdaArguments{}, func(FunctionContext, Arguments) (ExprFunc[any], error) {
+			return func(context.Context, any) (any, error) {
+				return nil, nil
+			}, nil
+		}),
+	)
+	p, err := NewParser(functions, testParsePath, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	// The `key` path only refers to the current element inside the lambda,
+	// whatever the other statements being parsed with the same parser.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := p.ParseStatement(`delete_if(attributes, {key == "a"})`)
+			assert.NoError(t, err)
+			_, err = p.ParseStatement(`delete_if(key, {key == "a"})`)
+			assert.ErrorContains(t, err, "bad path")
+		}()
+	}
+	wg.Wait()
+}
@@ -78,7 +78,7 @@ func Test_lexer(t *testing.T) {
 			{"OpNot", "not"},
 			{"Boolean", "false"},
 		}},
-		{"nothing_recognizable", "{}", true, []result{
+		{"nothing_recognizable", "#", true, []result{
 			{"", ""},
 		}},
 		{"basic_ident_expr", `set(attributes["bytes"], 0x0102030405060708)`, false, []result{
@@ -92,6 +92,19 @@ func Test_lexer(t *testing.T) {
 			{"Bytes", "0x0102030405060708"},
 			{"RParen", ")"},
 		}},
+		{"lambda", `{IsMatch(key, "a")}`, false, []result{
+			{"Punct", "{"},
+			{"Uppercase", "I"},
+			{"Lowercase", "s"},
+			{"Uppercase", "M"},
+			{"Lowercase", "atch"},
+			{"LParen", "("},
+			{"Lowercase", "key"},
+			{"Punct", ","},
+			{"String", `"a"`},
+			{"RParen", ")"},
+			{"Punct", "}"},
+		}},
 		{"Mixing case numbers and underscores", `aBCd_123E_4`, false, []result{
 			{"Lowercase", "a"},
 			{"Uppercase", "BC"},
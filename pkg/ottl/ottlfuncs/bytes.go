@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// getBytes returns the raw bytes of the target, which must be
+// a string, a byte slice or a pcommon.Value of type String or Bytes.
+// nolint:exhaustive
+func getBytes[K any](ctx context.Context, tCtx K, target ottl.Getter[K]) ([]byte, error) {
+	val, err := target.Get(ctx, tCtx)
+	if err != nil {
+		return nil, err
+	}
+	switch v := val.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case pcommon.ByteSlice:
+		return v.AsRaw(), nil
+	case pcommon.Value:
+		switch v.Type() {
+		case pcommon.ValueTypeStr:
+			return []byte(v.Str()), nil
+		case pcommon.ValueTypeBytes:
+			return v.Bytes().AsRaw(), nil
+		}
+		return nil, ottl.TypeError(fmt.Sprintf("expected string or []byte but got pcommon.Value of type %s", v.Type()))
+	case nil:
+		return nil, ottl.TypeError("expected string or []byte but got nil")
+	default:
+		return nil, ottl.TypeError(fmt.Sprintf("expected string or []byte but got %T", v))
+	}
+}
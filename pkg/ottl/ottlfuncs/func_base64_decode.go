@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type Base64DecodeArguments[K any] struct {
+	Target ottl.Getter[K]
+}
+
+func NewBase64DecodeFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Base64Decode", &Base64DecodeArguments[K]{}, createBase64DecodeFunction[K])
+}
+
+func createBase64DecodeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*Base64DecodeArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("Base64DecodeFactory args must be of type *Base64DecodeArguments[K]")
+	}
+
+	return base64Decode(args.Target), nil
+}
+
+// base64Decode returns the string decoded from the standard base64 encoding of the target string or byte slice.
+// Padding is optional, so that both padded and unpadded encodings can be decoded.
+func base64Decode[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := getBytes(ctx, tCtx, target)
+		if err != nil {
+			return nil, err
+		}
+		decoded, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(string(val), "="))
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 encoding: %w", err)
+		}
+		return string(decoded), nil
+	}
+}
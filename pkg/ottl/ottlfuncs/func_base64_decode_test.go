@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Base64Decode(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         any
+		expected      any
+		expectedError string
+	}{
+		{
+			name:     "padded string",
+			value:    "aGVsbG8gd29ybGQ=",
+			expected: "hello world",
+		},
+		{
+			name:     "unpadded string",
+			value:    "aGVsbG8gd29ybGQ",
+			expected: "hello world",
+		},
+		{
+			name:     "byte slice",
+			value:    []byte("aGVsbG8gd29ybGQ="),
+			expected: "hello world",
+		},
+		{
+			name:          "invalid encoding",
+			value:         "not base64!",
+			expectedError: "invalid base64 encoding",
+		},
+		{
+			name:          "int",
+			value:         int64(1),
+			expectedError: "expected string or []byte but got int64",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := base64Decode[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(nil, nil)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
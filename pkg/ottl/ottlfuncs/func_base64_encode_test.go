@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Base64Encode(t *testing.T) {
+	bytesValue := pcommon.NewValueBytes()
+	bytesValue.Bytes().FromRaw([]byte{0x00, 0xff, 0x10})
+
+	tests := []struct {
+		name          string
+		value         any
+		expected      any
+		expectedError string
+	}{
+		{
+			name:     "string",
+			value:    "hello world",
+			expected: "aGVsbG8gd29ybGQ=",
+		},
+		{
+			name:     "empty string",
+			value:    "",
+			expected: "",
+		},
+		{
+			name:     "byte slice",
+			value:    []byte{0x00, 0xff, 0x10},
+			expected: "AP8Q",
+		},
+		{
+			name:     "pcommon string value",
+			value:    pcommon.NewValueStr("hello world"),
+			expected: "aGVsbG8gd29ybGQ=",
+		},
+		{
+			name:     "pcommon bytes value",
+			value:    bytesValue,
+			expected: "AP8Q",
+		},
+		{
+			name:          "int",
+			value:         int64(1),
+			expectedError: "expected string or []byte but got int64",
+		},
+		{
+			name:          "nil",
+			value:         nil,
+			expectedError: "expected string or []byte but got nil",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := base64Encode[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(nil, nil)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
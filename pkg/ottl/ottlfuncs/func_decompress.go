@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+const (
+	gzipFormat = "gzip"
+	zlibFormat = "zlib"
+
+	// defaultMaxDecompressedSize limits the size of the decompressed data, so that
+	// small payloads expanding to gigabytes can't exhaust the memory of the collector.
+	defaultMaxDecompressedSize = 10 * 1024 * 1024
+)
+
+type DecompressArguments[K any] struct {
+	Target  ottl.Getter[K]
+	Format  string
+	MaxSize ottl.Optional[int64]
+}
+
+func NewDecompressFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Decompress", &DecompressArguments[K]{}, createDecompressFunction[K])
+}
+
+func createDecompressFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*DecompressArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("DecompressFactory args must be of type *DecompressArguments[K]")
+	}
+
+	return decompress(args.Target, args.Format, args.MaxSize)
+}
+
+// decompress returns the string decompressed from the target string or byte slice using the format.
+// It fails if the decompressed data is larger than maxSize bytes.
+func decompress[K any](target ottl.Getter[K], format string, maxSize ottl.Optional[int64]) (ottl.ExprFunc[K], error) {
+	var newReader func(io.Reader) (io.ReadCloser, error)
+	switch format {
+	case gzipFormat:
+		newReader = func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		}
+	case zlibFormat:
+		newReader = zlib.NewReader
+	default:
+		return nil, fmt.Errorf("invalid compression format %q, must be %q or %q", format, gzipFormat, zlibFormat)
+	}
+
+	limit := int64(defaultMaxDecompressedSize)
+	if !maxSize.IsEmpty() {
+		limit = maxSize.Get()
+		if limit <= 0 {
+			return nil, fmt.Errorf("invalid max size %d, must be positive", limit)
+		}
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := getBytes(ctx, tCtx, target)
+		if err != nil {
+			return nil, err
+		}
+		r, err := newReader(bytes.NewReader(val))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress %s data: %w", format, err)
+		}
+		defer r.Close()
+		decompressed, err := io.ReadAll(io.LimitReader(r, limit+1))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress %s data: %w", format, err)
+		}
+		if int64(len(decompressed)) > limit {
+			return nil, fmt.Errorf("unable to decompress %s data: decompressed data is larger than %d bytes", format, limit)
+		}
+		return string(decompressed), nil
+	}, nil
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func compressForTest(t *testing.T, format string, content string) []byte {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch format {
+	case gzipFormat:
+		w = gzip.NewWriter(&buf)
+	case zlibFormat:
+		w = zlib.NewWriter(&buf)
+	}
+	_, err := w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func Test_Decompress(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         any
+		format        string
+		maxSize       ottl.Optional[int64]
+		expected      any
+		expectedError string
+	}{
+		{
+			name:     "gzip byte slice",
+			value:    compressForTest(t, gzipFormat, `{"message": "hello"}`),
+			format:   gzipFormat,
+			expected: `{"message": "hello"}`,
+		},
+		{
+			name:     "gzip string",
+			value:    string(compressForTest(t, gzipFormat, "hello")),
+			format:   gzipFormat,
+			expected: "hello",
+		},
+		{
+			name:     "zlib byte slice",
+			value:    compressForTest(t, zlibFormat, "hello"),
+			format:   zlibFormat,
+			expected: "hello",
+		},
+		{
+			name:          "not compressed",
+			value:         "hello",
+			format:        gzipFormat,
+			expectedError: "unable to decompress gzip data",
+		},
+		{
+			name:     "max size",
+			value:    compressForTest(t, gzipFormat, "hello"),
+			format:   gzipFormat,
+			maxSize:  ottl.NewTestingOptional[int64](5),
+			expected: "hello",
+		},
+		{
+			name:          "larger than max size",
+			value:         compressForTest(t, zlibFormat, "hello"),
+			format:        zlibFormat,
+			maxSize:       ottl.NewTestingOptional[int64](4),
+			expectedError: "decompressed data is larger than 4 bytes",
+		},
+		{
+			name:          "larger than default max size",
+			value:         compressForTest(t, gzipFormat, strings.Repeat("a", defaultMaxDecompressedSize+1)),
+			format:        gzipFormat,
+			expectedError: "decompressed data is larger than 10485760 bytes",
+		},
+		{
+			name:          "wrong format",
+			value:         compressForTest(t, gzipFormat, "hello"),
+			format:        zlibFormat,
+			expectedError: "unable to decompress zlib data",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc, err := decompress[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			}, tt.format, tt.maxSize)
+			require.NoError(t, err)
+			result, err := exprFunc(nil, nil)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Decompress_InvalidFormat(t *testing.T) {
+	_, err := decompress[any](&ottl.StandardGetSetter[any]{}, "brotli", ottl.Optional[int64]{})
+	assert.ErrorContains(t, err, `invalid compression format "brotli"`)
+}
+
+func Test_Decompress_InvalidMaxSize(t *testing.T) {
+	_, err := decompress[any](&ottl.StandardGetSetter[any]{}, gzipFormat, ottl.NewTestingOptional[int64](0))
+	assert.ErrorContains(t, err, "invalid max size 0, must be positive")
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type DeleteIfArguments[K any] struct {
+	Target    ottl.Getter[K]
+	Condition ottl.ElementCondition[K]
+}
+
+func NewDeleteIfFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("delete_if", &DeleteIfArguments[K]{}, createDeleteIfFunction[K])
+}
+
+func createDeleteIfFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*DeleteIfArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("DeleteIfFactory args must be of type *DeleteIfArguments[K]")
+	}
+
+	return deleteIf(args.Target, args.Condition), nil
+}
+
+func deleteIf[K any](target ottl.Getter[K], condition ottl.ElementCondition[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		return nil, ottl.Iterate(ctx, val, func(ctx context.Context) (bool, error) {
+			return condition.Eval(ctx, tCtx)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func Test_deleteIf(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		want      func(span ptrace.Span)
+	}{
+		{
+			name:      "map keys",
+			statement: `delete_if(attributes, {IsMatch(key, "^HTTP\\.") or value == "secret-value"})`,
+			want: func(span ptrace.Span) {
+				span.Attributes().Remove("HTTP.Method")
+				span.Attributes().Remove("token")
+			},
+		},
+		{
+			name:      "nested map values",
+			statement: `delete_if(attributes["nested"], {key == "password"})`,
+			want: func(span ptrace.Span) {
+				v, _ := span.Attributes().Get("nested")
+				v.Map().Remove("password")
+			},
+		},
+		{
+			name:      "slice elements",
+			statement: `delete_if(attributes["list"], {value == "b" or key == 2})`,
+			want: func(span ptrace.Span) {
+				v, _ := span.Attributes().Get("list")
+				v.Slice().RemoveIf(func(v pcommon.Value) bool {
+					return v.Str() != "a"
+				})
+			},
+		},
+		{
+			name:      "span events by name",
+			statement: `delete_if(events, {value.name == "exception"})`,
+			want: func(span ptrace.Span) {
+				span.Events().RemoveIf(func(event ptrace.SpanEvent) bool {
+					return event.Name() == "exception"
+				})
+			},
+		},
+		{
+			name:      "span events by attribute",
+			statement: `delete_if(events, {value.attributes["drop"] == true})`,
+			want: func(span ptrace.Span) {
+				span.Events().RemoveIf(func(event ptrace.SpanEvent) bool {
+					return event.Name() == "exception"
+				})
+			},
+		},
+		{
+			name:      "nothing matches",
+			statement: `delete_if(events, {value.name == "missing"})`,
+			want:      func(span ptrace.Span) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newIterationTestParser(t)
+			statement, err := p.ParseStatement(tt.statement)
+			require.NoError(t, err)
+
+			span := newIterationTestSpan()
+			_, _, err = statement.Execute(context.Background(), span)
+			require.NoError(t, err)
+
+			expected := newIterationTestSpan()
+			tt.want(expected)
+			assert.Equal(t, expected.Attributes().AsRaw(), span.Attributes().AsRaw())
+			assert.Equal(t, expected.Events(), span.Events())
+		})
+	}
+}
+
+func Test_deleteIf_invalid(t *testing.T) {
+	p := newIterationTestParser(t)
+	_, err := p.ParseStatement(`delete_if(attributes, {set(value, "redacted")})`)
+	assert.Error(t, err)
+}
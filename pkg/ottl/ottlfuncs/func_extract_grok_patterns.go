@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vjeantet/grok"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ExtractGrokPatternsArguments[K any] struct {
+	Target             ottl.StringGetter[K]
+	Pattern            string
+	NamedCapturesOnly  ottl.Optional[bool]
+	PatternDefinitions ottl.Optional[[]string]
+}
+
+func NewExtractGrokPatternsFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ExtractGrokPatterns", &ExtractGrokPatternsArguments[K]{}, createExtractGrokPatternsFunction[K])
+}
+
+func createExtractGrokPatternsFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ExtractGrokPatternsArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ExtractGrokPatternsFactory args must be of type *ExtractGrokPatternsArguments[K]")
+	}
+
+	return extractGrokPatterns(args.Target, args.Pattern, args.NamedCapturesOnly, args.PatternDefinitions)
+}
+
+func extractGrokPatterns[K any](target ottl.StringGetter[K], pattern string, nco ottl.Optional[bool], patternDefinitions ottl.Optional[[]string]) (ottl.ExprFunc[K], error) {
+	namedCapturesOnly := !nco.IsEmpty() && nco.Get()
+
+	definitions := map[string]string{}
+	if !patternDefinitions.IsEmpty() {
+		for _, definition := range patternDefinitions.Get() {
+			name, expression, found := strings.Cut(definition, "=")
+			if !found || name == "" {
+				return nil, fmt.Errorf("the pattern definition %q supplied to ExtractGrokPatterns must be in the format NAME=PATTERN", definition)
+			}
+			definitions[name] = expression
+		}
+	}
+
+	g, err := grok.NewWithConfig(&grok.Config{
+		NamedCapturesOnly: namedCapturesOnly,
+		Patterns:          definitions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("the pattern definitions supplied to ExtractGrokPatterns are not valid: %w", err)
+	}
+
+	// Compiling the pattern up front validates it, and
+	// caches the compiled regular expression for later use.
+	if _, err = g.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("the pattern supplied to ExtractGrokPatterns is not a valid pattern: %w", err)
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		matches, err := g.ParseTyped(pattern, val)
+		if err != nil {
+			return nil, err
+		}
+
+		result := pcommon.NewMap()
+		for name, value := range matches {
+			switch v := value.(type) {
+			case int:
+				result.PutInt(name, int64(v))
+			case float64:
+				result.PutDouble(name, v)
+			case string:
+				result.PutStr(name, v)
+			}
+		}
+		return result, nil
+	}, nil
+}
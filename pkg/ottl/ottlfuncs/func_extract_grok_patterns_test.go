@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_extractGrokPatterns(t *testing.T) {
+	tests := []struct {
+		name               string
+		target             ottl.StringGetter[any]
+		pattern            string
+		namedCapturesOnly  bool
+		patternDefinitions []string
+		want               func(pcommon.Map)
+	}{
+		{
+			name: "named captures",
+			target: &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return `127.0.0.1 GET /index.html 200`, nil
+				},
+			},
+			pattern:           "%{IP:client} %{WORD:method} %{URIPATHPARAM:request} %{NUMBER:status}",
+			namedCapturesOnly: true,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("client", "127.0.0.1")
+				expectedMap.PutStr("method", "GET")
+				expectedMap.PutStr("request", "/index.html")
+				expectedMap.PutStr("status", "200")
+			},
+		},
+		{
+			name: "typed captures",
+			target: &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return `took 12 ms for 0.5 MB`, nil
+				},
+			},
+			pattern:           "took %{NUMBER:duration:int} ms for %{NUMBER:size:float} MB",
+			namedCapturesOnly: true,
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutInt("duration", 12)
+				expectedMap.PutDouble("size", 0.5)
+			},
+		},
+		{
+			name: "unnamed captures",
+			target: &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return `user=admin`, nil
+				},
+			},
+			pattern: "user=%{USERNAME}",
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("USERNAME", "admin")
+			},
+		},
+		{
+			name: "custom pattern definitions",
+			target: &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return `request-id: ab12-cd34`, nil
+				},
+			},
+			pattern:            "request-id: %{REQUESTID:id}",
+			namedCapturesOnly:  true,
+			patternDefinitions: []string{"REQUESTID=%{HEX}-%{HEX}", "HEX=[a-f0-9]+"},
+			want: func(expectedMap pcommon.Map) {
+				expectedMap.PutStr("id", "ab12-cd34")
+			},
+		},
+		{
+			name: "no match",
+			target: &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return `not an ip`, nil
+				},
+			},
+			pattern:           "^%{IP:client}$",
+			namedCapturesOnly: true,
+			want:              func(pcommon.Map) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patternDefinitions ottl.Optional[[]string]
+			if tt.patternDefinitions != nil {
+				patternDefinitions = ottl.NewTestingOptional[[]string](tt.patternDefinitions)
+			}
+			exprFunc, err := extractGrokPatterns(tt.target, tt.pattern, ottl.NewTestingOptional[bool](tt.namedCapturesOnly), patternDefinitions)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+
+			expected := pcommon.NewMap()
+			tt.want(expected)
+			assert.Equal(t, expected.AsRaw(), resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_extractGrokPatterns_validation(t *testing.T) {
+	tests := []struct {
+		name               string
+		pattern            string
+		patternDefinitions []string
+		expectedError      string
+	}{
+		{
+			name:          "unknown pattern",
+			pattern:       "%{UNKNOWN:value}",
+			expectedError: "the pattern supplied to ExtractGrokPatterns is not a valid pattern",
+		},
+		{
+			name:          "invalid regex",
+			pattern:       "(%{WORD:value}",
+			expectedError: "the pattern supplied to ExtractGrokPatterns is not a valid pattern",
+		},
+		{
+			name:               "invalid pattern definition",
+			pattern:            "%{WORD:value}",
+			patternDefinitions: []string{"MISSING_EQUAL_SIGN"},
+			expectedError:      "must be in the format NAME=PATTERN",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patternDefinitions ottl.Optional[[]string]
+			if tt.patternDefinitions != nil {
+				patternDefinitions = ottl.NewTestingOptional[[]string](tt.patternDefinitions)
+			}
+			target := &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return "", nil
+				},
+			}
+			_, err := extractGrokPatterns[any](target, tt.pattern, ottl.Optional[bool]{}, patternDefinitions)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
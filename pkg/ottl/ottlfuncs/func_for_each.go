@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ForEachArguments[K any] struct {
+	Target    ottl.Getter[K]
+	Statement ottl.ElementStatement[K]
+}
+
+func NewForEachFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("for_each", &ForEachArguments[K]{}, createForEachFunction[K])
+}
+
+func createForEachFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ForEachArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ForEachFactory args must be of type *ForEachArguments[K]")
+	}
+
+	return forEach(args.Target, args.Statement), nil
+}
+
+func forEach[K any](target ottl.Getter[K], statement ottl.ElementStatement[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		return nil, ottl.Iterate(ctx, val, func(ctx context.Context) (bool, error) {
+			return false, statement.Execute(ctx, tCtx)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// newIterationTestParser returns a parser for spans that supports the
+// `attributes` path, optionally indexed by a single string key, and the `events` path.
+func newIterationTestParser(t *testing.T) ottl.Parser[ptrace.Span] {
+	pathParser := func(path *ottl.Path) (ottl.GetSetter[ptrace.Span], error) {
+		if len(path.Fields) != 1 {
+			return nil, fmt.Errorf("invalid path %v", path.Fields)
+		}
+		field := path.Fields[0]
+		switch field.Name {
+		case "attributes":
+			return ottl.StandardGetSetter[ptrace.Span]{
+				Getter: func(_ context.Context, span ptrace.Span) (any, error) {
+					if len(field.Keys) == 0 {
+						return span.Attributes(), nil
+					}
+					v, ok := span.Attributes().Get(*field.Keys[0].String)
+					if !ok {
+						return nil, nil
+					}
+					switch v.Type() {
+					case pcommon.ValueTypeMap:
+						return v.Map(), nil
+					case pcommon.ValueTypeSlice:
+						return v.Slice(), nil
+					}
+					return v.AsRaw(), nil
+				},
+				Setter: func(_ context.Context, span ptrace.Span, val any) error {
+					return span.Attributes().PutEmpty(*field.Keys[0].String).FromRaw(val)
+				},
+			}, nil
+		case "events":
+			return ottl.StandardGetSetter[ptrace.Span]{
+				Getter: func(_ context.Context, span ptrace.Span) (any, error) {
+					return span.Events(), nil
+				},
+			}, nil
+		}
+		return nil, fmt.Errorf("invalid path %v", path.Fields)
+	}
+	p, err := ottl.NewParser[ptrace.Span](StandardFuncs[ptrace.Span](), pathParser, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	return p
+}
+
+func newIterationTestSpan() ptrace.Span {
+	span := ptrace.NewSpan()
+	span.Attributes().PutStr("HTTP.Method", "GET")
+	span.Attributes().PutStr("token", "secret-value")
+	nested := span.Attributes().PutEmptyMap("nested")
+	nested.PutStr("user", "bob")
+	nested.PutStr("password", "hunter2")
+	list := span.Attributes().PutEmptySlice("list")
+	list.AppendEmpty().SetStr("a")
+	list.AppendEmpty().SetStr("b")
+	list.AppendEmpty().SetStr("c")
+
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().PutBool("drop", true)
+	event = span.Events().AppendEmpty()
+	event.SetName("message")
+	return span
+}
+
+func Test_forEach(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		want      func(span ptrace.Span)
+	}{
+		{
+			name:      "lowercase all keys",
+			statement: `for_each(attributes, {set(key, ConvertCase(key, "lower"))})`,
+			want: func(span ptrace.Span) {
+				v, _ := span.Attributes().Get("HTTP.Method")
+				v.CopyTo(span.Attributes().PutEmpty("http.method"))
+				span.Attributes().Remove("HTTP.Method")
+			},
+		},
+		{
+			name:      "redact matching values",
+			statement: `for_each(attributes, {set(value, "redacted") where IsMatch(value, "^secret")})`,
+			want: func(span ptrace.Span) {
+				span.Attributes().PutStr("token", "redacted")
+			},
+		},
+		{
+			name:      "nested map",
+			statement: `for_each(attributes["nested"], {set(value, "redacted") where key == "password"})`,
+			want: func(span ptrace.Span) {
+				v, _ := span.Attributes().Get("nested")
+				v.Map().PutStr("password", "redacted")
+			},
+		},
+		{
+			name:      "nested iteration",
+			statement: `for_each(attributes, {for_each(value, {set(value, "redacted") where key == "password"}) where IsMap(value)})`,
+			want: func(span ptrace.Span) {
+				v, _ := span.Attributes().Get("nested")
+				v.Map().PutStr("password", "redacted")
+			},
+		},
+		{
+			name:      "slice",
+			statement: `for_each(attributes["list"], {set(value, Concat([value, key], "-"))})`,
+			want: func(span ptrace.Span) {
+				v, _ := span.Attributes().Get("list")
+				v.Slice().At(0).SetStr("a-0")
+				v.Slice().At(1).SetStr("b-1")
+				v.Slice().At(2).SetStr("c-2")
+			},
+		},
+		{
+			name:      "span events",
+			statement: `for_each(events, {set(value.attributes["handled"], true) where value.name == "exception"})`,
+			want: func(span ptrace.Span) {
+				span.Events().At(0).Attributes().PutBool("handled", true)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newIterationTestParser(t)
+			statement, err := p.ParseStatement(tt.statement)
+			require.NoError(t, err)
+
+			span := newIterationTestSpan()
+			_, _, err = statement.Execute(context.Background(), span)
+			require.NoError(t, err)
+
+			expected := newIterationTestSpan()
+			tt.want(expected)
+			assert.Equal(t, expected.Attributes().AsRaw(), span.Attributes().AsRaw())
+			assert.Equal(t, expected.Events(), span.Events())
+		})
+	}
+}
+
+func Test_forEach_invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+	}{
+		{
+			name:      "condition instead of statement",
+			statement: `for_each(attributes, {key == "token"})`,
+		},
+		{
+			name:      "element path outside of lambda",
+			statement: `set(key, "token")`,
+		},
+		{
+			name:      "lambda passed as value",
+			statement: `set(attributes["test"], {key == "token"})`,
+		},
+		{
+			name:      "indexed key",
+			statement: `for_each(attributes, {set(key["a"], "b")})`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newIterationTestParser(t)
+			_, err := p.ParseStatement(tt.statement)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_forEach_bad_target(t *testing.T) {
+	p := newIterationTestParser(t)
+	statement, err := p.ParseStatement(`for_each(attributes["token"], {set(value, "redacted")})`)
+	require.NoError(t, err)
+
+	_, _, err = statement.Execute(context.Background(), newIterationTestSpan())
+	assert.Error(t, err)
+}
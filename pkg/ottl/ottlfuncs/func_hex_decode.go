@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type HexDecodeArguments[K any] struct {
+	Target ottl.Getter[K]
+}
+
+func NewHexDecodeFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("HexDecode", &HexDecodeArguments[K]{}, createHexDecodeFunction[K])
+}
+
+func createHexDecodeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*HexDecodeArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("HexDecodeFactory args must be of type *HexDecodeArguments[K]")
+	}
+
+	return hexDecode(args.Target), nil
+}
+
+// hexDecode returns the string decoded from the hexadecimal encoding of the target string or byte slice.
+func hexDecode[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := getBytes(ctx, tCtx, target)
+		if err != nil {
+			return nil, err
+		}
+		decoded := make([]byte, hex.DecodedLen(len(val)))
+		if _, err = hex.Decode(decoded, val); err != nil {
+			return nil, fmt.Errorf("invalid hex encoding: %w", err)
+		}
+		return string(decoded), nil
+	}
+}
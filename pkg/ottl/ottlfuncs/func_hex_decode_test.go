@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_HexDecode(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         any
+		expected      any
+		expectedError string
+	}{
+		{
+			name:     "lowercase string",
+			value:    "68656c6c6f",
+			expected: "hello",
+		},
+		{
+			name:     "uppercase string",
+			value:    "68656C6C6F",
+			expected: "hello",
+		},
+		{
+			name:     "byte slice",
+			value:    []byte("68656c6c6f"),
+			expected: "hello",
+		},
+		{
+			name:          "odd length",
+			value:         "686",
+			expectedError: "invalid hex encoding",
+		},
+		{
+			name:          "invalid character",
+			value:         "zz",
+			expectedError: "invalid hex encoding",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := hexDecode[any](&ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.value, nil
+				},
+			})
+			result, err := exprFunc(nil, nil)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
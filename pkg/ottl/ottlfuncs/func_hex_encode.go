@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type HexEncodeArguments[K any] struct {
+	Target ottl.Getter[K]
+}
+
+func NewHexEncodeFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("HexEncode", &HexEncodeArguments[K]{}, createHexEncodeFunction[K])
+}
+
+func createHexEncodeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*HexEncodeArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("HexEncodeFactory args must be of type *HexEncodeArguments[K]")
+	}
+
+	return hexEncode(args.Target), nil
+}
+
+// hexEncode returns the lowercase hexadecimal encoding of the target string or byte slice.
+func hexEncode[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := getBytes(ctx, tCtx, target)
+		if err != nil {
+			return nil, err
+		}
+		return hex.EncodeToString(val), nil
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ParseKeyValueArguments[K any] struct {
+	Target        ottl.StringGetter[K]
+	Delimiter     ottl.Optional[string]
+	PairDelimiter ottl.Optional[string]
+}
+
+func NewParseKeyValueFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseKeyValue", &ParseKeyValueArguments[K]{}, createParseKeyValueFunction[K])
+}
+
+func createParseKeyValueFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseKeyValueArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ParseKeyValueFactory args must be of type *ParseKeyValueArguments[K]")
+	}
+
+	return parseKeyValue[K](args.Target, args.Delimiter, args.PairDelimiter)
+}
+
+// parseKeyValue returns a `pcommon.Map` with the key value pairs of the target string.
+// The behavior is the same as the `key_value_parser` stanza operator: pairs are split by
+// whitespace unless a pair delimiter is provided, quoted text is preserved and quotes
+// and surrounding whitespace are trimmed from the keys and values.
+func parseKeyValue[K any](target ottl.StringGetter[K], d ottl.Optional[string], p ottl.Optional[string]) (ottl.ExprFunc[K], error) {
+	delimiter := "="
+	if !d.IsEmpty() {
+		delimiter = d.Get()
+	}
+	if delimiter == "" {
+		return nil, fmt.Errorf("delimiter cannot be set to an empty string")
+	}
+
+	pairSplitFunc := splitStringByWhitespace
+	if !p.IsEmpty() {
+		pairDelimiter := p.Get()
+		if pairDelimiter == "" {
+			return nil, fmt.Errorf("pair delimiter cannot be set to an empty string")
+		}
+		if pairDelimiter == delimiter {
+			return nil, fmt.Errorf("pair delimiter %q cannot be equal to delimiter %q", pairDelimiter, delimiter)
+		}
+		pairSplitFunc = func(input string) []string {
+			return strings.Split(input, pairDelimiter)
+		}
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		source, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if source == "" {
+			return nil, fmt.Errorf("cannot parse from empty target")
+		}
+
+		var errs error
+		result := pcommon.NewMap()
+		for _, raw := range pairSplitFunc(source) {
+			pair := strings.Split(raw, delimiter)
+			if len(pair) != 2 {
+				errs = multierr.Append(errs, fmt.Errorf("expected %q to split by %q into two items, got %d", raw, delimiter, len(pair)))
+				continue
+			}
+			key := strings.TrimSpace(strings.Trim(pair[0], "\"'"))
+			value := strings.TrimSpace(strings.Trim(pair[1], "\"'"))
+			result.PutStr(key, value)
+		}
+		if errs != nil {
+			return nil, errs
+		}
+		return result, nil
+	}, nil
+}
+
+// splitStringByWhitespace splits the input on spaces that are not within quotes.
+func splitStringByWhitespace(input string) []string {
+	quoted := false
+	return strings.FieldsFunc(input, func(r rune) bool {
+		if r == '"' || r == '\'' {
+			quoted = !quoted
+		}
+		return !quoted && r == ' '
+	})
+}
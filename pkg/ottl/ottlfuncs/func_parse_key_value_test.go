@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseKeyValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		delimiter     string
+		pairDelimiter string
+		expected      map[string]any
+	}{
+		{
+			name:   "default delimiters",
+			target: "name=test level=info",
+			expected: map[string]any{
+				"name":  "test",
+				"level": "info",
+			},
+		},
+		{
+			name:   "quoted values",
+			target: `msg="hello world" user='jane doe'`,
+			expected: map[string]any{
+				"msg":  "hello world",
+				"user": "jane doe",
+			},
+		},
+		{
+			name:          "custom delimiters",
+			target:        "name:test; level:info",
+			delimiter:     ":",
+			pairDelimiter: ";",
+			expected: map[string]any{
+				"name":  "test",
+				"level": "info",
+			},
+		},
+		{
+			name:          "multi character delimiters",
+			target:        "name=>test||level=>info",
+			delimiter:     "=>",
+			pairDelimiter: "||",
+			expected: map[string]any{
+				"name":  "test",
+				"level": "info",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			var delimiter, pairDelimiter ottl.Optional[string]
+			if tt.delimiter != "" {
+				delimiter = ottl.NewTestingOptional[string](tt.delimiter)
+			}
+			if tt.pairDelimiter != "" {
+				pairDelimiter = ottl.NewTestingOptional[string](tt.pairDelimiter)
+			}
+			exprFunc, err := parseKeyValue[any](target, delimiter, pairDelimiter)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_parseKeyValue_bad_target(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		expectedError string
+	}{
+		{
+			name:          "empty target",
+			target:        "",
+			expectedError: "cannot parse from empty target",
+		},
+		{
+			name:          "missing delimiter",
+			target:        "name=test level",
+			expectedError: `expected "level" to split by "=" into two items, got 1`,
+		},
+		{
+			name:          "too many delimiters",
+			target:        "name=test=value",
+			expectedError: `expected "name=test=value" to split by "=" into two items, got 3`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc, err := parseKeyValue[any](target, ottl.Optional[string]{}, ottl.Optional[string]{})
+			require.NoError(t, err)
+
+			_, err = exprFunc(context.Background(), nil)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
+
+func Test_parseKeyValue_bad_delimiters(t *testing.T) {
+	target := &ottl.StandardStringGetter[any]{}
+
+	_, err := parseKeyValue[any](target, ottl.NewTestingOptional[string](""), ottl.Optional[string]{})
+	assert.ErrorContains(t, err, "delimiter cannot be set to an empty string")
+
+	_, err = parseKeyValue[any](target, ottl.Optional[string]{}, ottl.NewTestingOptional[string](""))
+	assert.ErrorContains(t, err, "pair delimiter cannot be set to an empty string")
+
+	_, err = parseKeyValue[any](target, ottl.NewTestingOptional[string](":"), ottl.NewTestingOptional[string](":"))
+	assert.ErrorContains(t, err, `pair delimiter ":" cannot be equal to delimiter ":"`)
+}
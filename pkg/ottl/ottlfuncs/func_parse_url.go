@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ParseURLArguments[K any] struct {
+	Target ottl.Getter[K]
+}
+
+func NewParseURLFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseURL", &ParseURLArguments[K]{}, createParseURLFunction[K])
+}
+
+func createParseURLFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseURLArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("ParseURLFactory args must be of type *ParseURLArguments[K]")
+	}
+
+	return parseURL(args.Target), nil
+}
+
+// parseURL returns a `pcommon.Map` with the components of the target URL:
+//
+//	scheme   -> string
+//	user     -> string, only present if the URL has user information
+//	host     -> string, without the port
+//	port     -> int64, only present if the URL has a port
+//	path     -> string, with its escapes decoded
+//	query    -> map of the query parameters, a parameter set multiple times is a slice of strings
+//	fragment -> string, only present if the URL has a fragment
+func parseURL[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := getBytes(ctx, tCtx, target)
+		if err != nil {
+			return nil, err
+		}
+		u, err := url.Parse(string(val))
+		if err != nil {
+			return nil, err
+		}
+
+		result := pcommon.NewMap()
+		result.PutStr("scheme", u.Scheme)
+		if u.User != nil {
+			result.PutStr("user", u.User.Username())
+		}
+		result.PutStr("host", u.Hostname())
+		if p := u.Port(); p != "" {
+			port, err := strconv.ParseInt(p, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port %q: %w", p, err)
+			}
+			result.PutInt("port", port)
+		}
+		result.PutStr("path", u.Path)
+
+		query := result.PutEmptyMap("query")
+		for key, values := range u.Query() {
+			if len(values) == 1 {
+				query.PutStr(key, values[0])
+				continue
+			}
+			s := query.PutEmptySlice(key)
+			s.EnsureCapacity(len(values))
+			for _, v := range values {
+				s.AppendEmpty().SetStr(v)
+			}
+		}
+		if u.Fragment != "" {
+			result.PutStr("fragment", u.Fragment)
+		}
+		return result, nil
+	}
+}
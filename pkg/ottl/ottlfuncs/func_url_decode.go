@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type URLDecodeArguments[K any] struct {
+	Target ottl.Getter[K]
+}
+
+func NewURLDecodeFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("URLDecode", &URLDecodeArguments[K]{}, createURLDecodeFunction[K])
+}
+
+func createURLDecodeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*URLDecodeArguments[K])
+
+	if !ok {
+		return nil, fmt.Errorf("URLDecodeFactory args must be of type *URLDecodeArguments[K]")
+	}
+
+	return urlDecode(args.Target), nil
+}
+
+// urlDecode returns the target string or byte slice with its URL escapes decoded,
+// such as "%2F" into "/" and "+" into a space.
+func urlDecode[K any](target ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := getBytes(ctx, tCtx, target)
+		if err != nil {
+			return nil, err
+		}
+		decoded, err := url.QueryUnescape(string(val))
+		if err != nil {
+			return nil, fmt.Errorf("invalid URL encoding: %w", err)
+		}
+		return decoded, nil
+	}
+}
@@ -10,8 +10,10 @@ import (
 func StandardFuncs[K any]() map[string]ottl.Factory[K] {
 	f := []ottl.Factory[K]{
 		// Editors
+		NewDeleteIfFactory[K](),
 		NewDeleteKeyFactory[K](),
 		NewDeleteMatchingKeysFactory[K](),
+		NewForEachFactory[K](),
 		NewKeepKeysFactory[K](),
 		NewLimitFactory[K](),
 		NewMergeMapsFactory[K](),
@@ -34,12 +36,18 @@ func StandardConverters[K any]() map[string]ottl.Factory[K] {
 func converters[K any]() []ottl.Factory[K] {
 	return []ottl.Factory[K]{
 		// Converters
+		NewBase64DecodeFactory[K](),
+		NewBase64EncodeFactory[K](),
 		NewConcatFactory[K](),
 		NewConvertCaseFactory[K](),
+		NewDecompressFactory[K](),
 		NewDoubleFactory[K](),
 		NewDurationFactory[K](),
+		NewExtractGrokPatternsFactory[K](),
 		NewExtractPatternsFactory[K](),
 		NewFnvFactory[K](),
+		NewHexDecodeFactory[K](),
+		NewHexEncodeFactory[K](),
 		NewHoursFactory[K](),
 		NewIntFactory[K](),
 		NewIsBoolFactory[K](),
@@ -55,6 +63,8 @@ func converters[K any]() []ottl.Factory[K] {
 		NewNanosecondsFactory[K](),
 		NewNowFactory[K](),
 		NewParseJSONFactory[K](),
+		NewParseKeyValueFactory[K](),
+		NewParseURLFactory[K](),
 		NewSecondsFactory[K](),
 		NewSHA1Factory[K](),
 		NewSHA256Factory[K](),
@@ -68,6 +78,7 @@ func converters[K any]() []ottl.Factory[K] {
 		NewUnixMilliFactory[K](),
 		NewUnixNanoFactory[K](),
 		NewUnixSecondsFactory[K](),
+		NewURLDecodeFactory[K](),
 		NewUUIDFactory[K](),
 	}
 }
@@ -77,6 +77,9 @@ type Parser[K any] struct {
 	pathParser        PathExpressionParser[K]
 	enumParser        EnumParser
 	telemetrySettings component.TelemetrySettings
+	// inLambda is set on the copy of the parser parsing a lambda,
+	// where the `key` and `value` paths refer to the current element.
+	inLambda bool
 }
 
 func NewParser[K any](
@@ -306,3 +309,90 @@ func (s *Statements[K]) Eval(ctx context.Context, tCtx K) (bool, error) {
 	}
 	return false, nil
 }
+
+// LogicOperation defines the function for evaluating a ConditionSequence, either `and` or `or`.
+type LogicOperation string
+
+const (
+	And LogicOperation = "and"
+	Or  LogicOperation = "or"
+)
+
+// ConditionSequence represents a list of Conditions that will be evaluated sequentially for a TransformContext
+// and will handle errors returned by conditions based on an ErrorMode.
+// By default, the conditions are ORed together, but they can be ANDed together using the WithLogicOperation option.
+type ConditionSequence[K any] struct {
+	conditions        []*Condition[K]
+	errorMode         ErrorMode
+	telemetrySettings component.TelemetrySettings
+	logicOp           LogicOperation
+}
+
+type ConditionSequenceOption[K any] func(*ConditionSequence[K])
+
+// WithConditionSequenceErrorMode sets the ErrorMode of a ConditionSequence
+func WithConditionSequenceErrorMode[K any](errorMode ErrorMode) ConditionSequenceOption[K] {
+	return func(c *ConditionSequence[K]) {
+		c.errorMode = errorMode
+	}
+}
+
+// WithLogicOperation sets the LogicOperation of a ConditionSequence
+// When setting AND the conditions will be ANDed together.
+// When setting OR the conditions will be ORed together.
+func WithLogicOperation[K any](logicOp LogicOperation) ConditionSequenceOption[K] {
+	return func(c *ConditionSequence[K]) {
+		c.logicOp = logicOp
+	}
+}
+
+// NewConditionSequence creates a new ConditionSequence with the provided Conditions and options.
+func NewConditionSequence[K any](conditions []*Condition[K], telemetrySettings component.TelemetrySettings, options ...ConditionSequenceOption[K]) ConditionSequence[K] {
+	c := ConditionSequence[K]{
+		conditions:        conditions,
+		errorMode:         PropagateError,
+		telemetrySettings: telemetrySettings,
+		logicOp:           Or,
+	}
+	for _, op := range options {
+		op(&c)
+	}
+	return c
+}
+
+// Eval evaluates the result of each Condition in the ConditionSequence.
+// The boolean logic between conditions is based on the ConditionSequence's LogicOperation.
+// If using the default OR LogicOperation, if any Condition evaluates to true, then true is returned and if all Conditions evaluate to false, then false is returned.
+// If using the AND LogicOperation, if any Condition evaluates to false, then false is returned and if all Conditions evaluate to true, then true is returned.
+// When the ErrorMode of the ConditionSequence is `propagate`, errors cause the evaluation to be false and an error is returned.
+// When the ErrorMode of the ConditionSequence is `ignore`, errors are logged and cause the evaluation to continue to the next condition.
+// When using the AND LogicOperation with the `ignore` ErrorMode the sequence will evaluate to false if all conditions error.
+func (c *ConditionSequence[K]) Eval(ctx context.Context, tCtx K) (bool, error) {
+	var atLeastOneMatch bool
+	for _, condition := range c.conditions {
+		match, err := condition.Eval(ctx, tCtx)
+		if err != nil {
+			if c.errorMode == PropagateError {
+				err = fmt.Errorf("failed to eval condition: %v, %w", condition.origText, err)
+				return false, err
+			}
+			c.telemetrySettings.Logger.Warn("failed to eval condition", zap.Error(err), zap.String("condition", condition.origText))
+			continue
+		}
+		if match {
+			if c.logicOp == Or {
+				return true, nil
+			}
+			atLeastOneMatch = true
+		}
+		if !match && c.logicOp == And {
+			return false, nil
+		}
+	}
+	// When ANDing it is possible to arrive here not because everything was true, but because everything errored and was ignored.
+	// In that situation, we don't want to return True when no conditions actually passed. In a situation when everything failed
+	// we are essentially left with an empty set, which is normally evaluated in mathematics as False. We will use that
+	// idea to return False when ANDing and everything errored. We use atLeastOneMatch here to return true if anything did match.
+	// It is not possible to get here if any condition during an AND explicitly failed.
+	return c.logicOp == And && atLeastOneMatch, nil
+}
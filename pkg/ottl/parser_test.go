@@ -46,6 +46,116 @@ func Test_parse(t *testing.T) {
 				WhereClause: nil,
 			},
 		},
+		{
+			name:      "editor with condition lambda",
+			statement: `delete_if(attributes, {key == "a"})`,
+			expected: &parsedStatement{
+				Editor: editor{
+					Function: "delete_if",
+					Arguments: []argument{
+						{
+							Value: value{
+								Literal: &mathExprLiteral{
+									Path: &Path{
+										Fields: []Field{
+											{
+												Name: "attributes",
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Value: value{
+								Lambda: &lambda{
+									Condition: &booleanExpression{
+										Left: &term{
+											Left: &booleanValue{
+												Comparison: &comparison{
+													Left: value{
+														Literal: &mathExprLiteral{
+															Path: &Path{
+																Fields: []Field{
+																	{
+																		Name: "key",
+																	},
+																},
+															},
+														},
+													},
+													Op: EQ,
+													Right: value{
+														String: ottltest.Strp("a"),
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:      "editor with statement lambda",
+			statement: `for_each(attributes, {set(value, "b")})`,
+			expected: &parsedStatement{
+				Editor: editor{
+					Function: "for_each",
+					Arguments: []argument{
+						{
+							Value: value{
+								Literal: &mathExprLiteral{
+									Path: &Path{
+										Fields: []Field{
+											{
+												Name: "attributes",
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Value: value{
+								Lambda: &lambda{
+									Statement: &parsedStatement{
+										Editor: editor{
+											Function: "set",
+											Arguments: []argument{
+												{
+													Value: value{
+														Literal: &mathExprLiteral{
+															Path: &Path{
+																Fields: []Field{
+																	{
+																		Name: "value",
+																	},
+																},
+															},
+														},
+													},
+												},
+												{
+													Value: value{
+														String: ottltest.Strp("b"),
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
 		{
 			name:      "editor with float",
 			statement: `met(1.2)`,
@@ -2233,3 +2343,100 @@ func Test_Statements_Eval_Error(t *testing.T) {
 		})
 	}
 }
+
+func Test_ConditionSequence_Eval(t *testing.T) {
+	tests := []struct {
+		name           string
+		conditions     []boolExpressionEvaluator[any]
+		logicOp        LogicOperation
+		errorMode      ErrorMode
+		expectedResult bool
+		expectedErr    bool
+	}{
+		{
+			name:           "Or True",
+			conditions:     []boolExpressionEvaluator[any]{alwaysFalse[any], alwaysTrue[any]},
+			logicOp:        Or,
+			expectedResult: true,
+		},
+		{
+			name:           "Or False",
+			conditions:     []boolExpressionEvaluator[any]{alwaysFalse[any], alwaysFalse[any]},
+			logicOp:        Or,
+			expectedResult: false,
+		},
+		{
+			name:           "And True",
+			conditions:     []boolExpressionEvaluator[any]{alwaysTrue[any], alwaysTrue[any]},
+			logicOp:        And,
+			expectedResult: true,
+		},
+		{
+			name:           "And False",
+			conditions:     []boolExpressionEvaluator[any]{alwaysTrue[any], alwaysFalse[any]},
+			logicOp:        And,
+			expectedResult: false,
+		},
+		{
+			name: "Error is ignored when using Ignore",
+			conditions: []boolExpressionEvaluator[any]{
+				func(context.Context, any) (bool, error) {
+					return true, fmt.Errorf("test")
+				},
+				alwaysTrue[any],
+			},
+			logicOp:        Or,
+			errorMode:      IgnoreError,
+			expectedResult: true,
+		},
+		{
+			name: "All errors are false when using And with Ignore",
+			conditions: []boolExpressionEvaluator[any]{
+				func(context.Context, any) (bool, error) {
+					return true, fmt.Errorf("test")
+				},
+			},
+			logicOp:        And,
+			errorMode:      IgnoreError,
+			expectedResult: false,
+		},
+		{
+			name: "Error is propagated when using Propagate",
+			conditions: []boolExpressionEvaluator[any]{
+				func(context.Context, any) (bool, error) {
+					return true, fmt.Errorf("test")
+				},
+				alwaysTrue[any],
+			},
+			logicOp:        Or,
+			errorMode:      PropagateError,
+			expectedResult: false,
+			expectedErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rawConditions []*Condition[any]
+			for _, condition := range tt.conditions {
+				rawConditions = append(rawConditions, &Condition[any]{
+					condition: BoolExpr[any]{condition},
+				})
+			}
+
+			conditions := NewConditionSequence(
+				rawConditions,
+				componenttest.NewNopTelemetrySettings(),
+				WithConditionSequenceErrorMode[any](tt.errorMode),
+				WithLogicOperation[any](tt.logicOp),
+			)
+
+			result, err := conditions.Eval(context.Background(), nil)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/antonmedv/expr/vm"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/internal/pdataconv"
+)
+
+const (
+	// ExprConditionLanguage evaluates conditions with expr. This is the default.
+	ExprConditionLanguage = "expr"
+	// OTTLConditionLanguage evaluates conditions with the OpenTelemetry Transformation Language,
+	// using the same log context as the transform and filter processors.
+	OTTLConditionLanguage = "ottl"
+)
+
+// Condition is a compiled boolean expression that is evaluated against entries.
+type Condition interface {
+	Match(ctx context.Context, entry *entry.Entry) (bool, error)
+}
+
+// CompileCondition compiles a boolean condition written in the given language.
+// An empty language defaults to expr.
+func CompileCondition(language string, condition string, logger *zap.SugaredLogger) (Condition, error) {
+	switch language {
+	case "", ExprConditionLanguage:
+		program, err := ExprCompileBool(condition)
+		if err != nil {
+			return nil, err
+		}
+		return &exprCondition{program: program}, nil
+	case OTTLConditionLanguage:
+		parser, err := ottllog.NewParser(
+			ottlfuncs.StandardConverters[ottllog.TransformContext](),
+			component.TelemetrySettings{Logger: logger.Desugar()},
+		)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parser.ParseCondition(condition)
+		if err != nil {
+			return nil, err
+		}
+		return &ottlCondition{condition: parsed}, nil
+	default:
+		return nil, fmt.Errorf("invalid condition language '%s', must be one of '%s' or '%s'", language, ExprConditionLanguage, OTTLConditionLanguage)
+	}
+}
+
+// NewExprCondition returns the condition of an expr program compiled with ExprCompileBool.
+func NewExprCondition(program *vm.Program) Condition {
+	return &exprCondition{program: program}
+}
+
+// ExprProgram returns the expr program of a condition, or nil if the condition is not written in expr.
+func ExprProgram(condition Condition) *vm.Program {
+	if c, ok := condition.(*exprCondition); ok {
+		return c.program
+	}
+	return nil
+}
+
+type exprCondition struct {
+	program *vm.Program
+}
+
+// Match runs the expr program against the entry. The program is compiled with
+// expr.AsBool, so its result is always a boolean.
+func (c *exprCondition) Match(_ context.Context, entry *entry.Entry) (bool, error) {
+	env := GetExprEnv(entry)
+	defer PutExprEnv(env)
+
+	matches, err := vm.Run(c.program, env)
+	if err != nil {
+		return false, err
+	}
+	return matches.(bool), nil
+}
+
+type ottlCondition struct {
+	condition *ottl.Condition[ottllog.TransformContext]
+}
+
+// Match evaluates the OTTL condition against the log record representation of the entry.
+func (c *ottlCondition) Match(ctx context.Context, entry *entry.Entry) (bool, error) {
+	record := plog.NewLogRecord()
+	pdataconv.ConvertInto(entry, record)
+
+	scope := pcommon.NewInstrumentationScope()
+	scope.SetName(entry.ScopeName)
+
+	resource := pcommon.NewResource()
+	pdataconv.UpsertToMap(entry.Resource, resource.Attributes())
+
+	return c.condition.Eval(ctx, ottllog.NewTransformContext(record, scope, resource))
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestCondition(t *testing.T) {
+	newEntry := func() *entry.Entry {
+		e := entry.New()
+		e.Body = map[string]any{
+			"message": "test message",
+			"nested":  map[string]any{"key": "value"},
+		}
+		e.Attributes = map[string]any{"log.file.name": "app.log", "count": 3}
+		e.Resource = map[string]any{"host.name": "my-host"}
+		e.Severity = entry.Error
+		e.ScopeName = "my.logger"
+		return e
+	}
+
+	cases := []struct {
+		name      string
+		language  string
+		condition string
+		expected  bool
+	}{
+		{"ExprBody", "", `body.message == "test message"`, true},
+		{"ExprAttribute", ExprConditionLanguage, `attributes["log.file.name"] == "app.log"`, true},
+		{"ExprNoMatch", ExprConditionLanguage, `resource["host.name"] == "other"`, false},
+		{"OTTLBody", OTTLConditionLanguage, `body["message"] == "test message"`, true},
+		{"OTTLNestedBody", OTTLConditionLanguage, `body["nested"]["key"] == "value"`, true},
+		{"OTTLAttribute", OTTLConditionLanguage, `attributes["log.file.name"] == "app.log" and attributes["count"] > 2`, true},
+		{"OTTLResource", OTTLConditionLanguage, `resource.attributes["host.name"] == "my-host"`, true},
+		{"OTTLSeverity", OTTLConditionLanguage, `severity_number >= SEVERITY_NUMBER_ERROR and severity_text == "ERROR"`, true},
+		{"OTTLScope", OTTLConditionLanguage, `instrumentation_scope.name == "my.logger"`, true},
+		{"OTTLConverter", OTTLConditionLanguage, `IsMatch(body["message"], "^test")`, true},
+		{"OTTLNoMatch", OTTLConditionLanguage, `attributes["missing"] != nil`, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			condition, err := CompileCondition(tc.language, tc.condition, testutil.Logger(t))
+			require.NoError(t, err)
+
+			matches, err := condition.Match(context.Background(), newEntry())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, matches)
+		})
+	}
+}
+
+func TestCompileConditionErrors(t *testing.T) {
+	_, err := CompileCondition(ExprConditionLanguage, `"nonbool"`, testutil.Logger(t))
+	assert.Error(t, err)
+
+	_, err = CompileCondition(OTTLConditionLanguage, `body.message == "test"`, testutil.Logger(t))
+	assert.Error(t, err)
+
+	// Editors are not allowed in conditions.
+	_, err = CompileCondition(OTTLConditionLanguage, `set(body, "test")`, testutil.Logger(t))
+	assert.Error(t, err)
+
+	_, err = CompileCondition("cel", "true", testutil.Logger(t))
+	assert.EqualError(t, err, "invalid condition language 'cel', must be one of 'expr' or 'ottl'")
+}
@@ -27,6 +27,8 @@ type TransformerConfig struct {
 	WriterConfig `mapstructure:",squash"`
 	OnError      string `mapstructure:"on_error"`
 	IfExpr       string `mapstructure:"if"`
+	// ConditionLanguage is the language of the conditions of the operator, either expr or ottl.
+	ConditionLanguage string `mapstructure:"condition_language"`
 }
 
 // Build will build a transformer operator.
@@ -52,11 +54,12 @@ func (c TransformerConfig) Build(logger *zap.SugaredLogger) (TransformerOperator
 	}
 
 	if c.IfExpr != "" {
-		compiled, err := ExprCompileBool(c.IfExpr)
+		compiled, err := CompileCondition(c.ConditionLanguage, c.IfExpr, logger)
 		if err != nil {
 			return TransformerOperator{}, fmt.Errorf("failed to compile expression '%s': %w", c.IfExpr, err)
 		}
-		transformerOperator.IfExpr = compiled
+		transformerOperator.IfCondition = compiled
+		transformerOperator.IfExpr = ExprProgram(compiled)
 	}
 
 	return transformerOperator, nil
@@ -66,7 +69,10 @@ func (c TransformerConfig) Build(logger *zap.SugaredLogger) (TransformerOperator
 type TransformerOperator struct {
 	WriterOperator
 	OnError string
-	IfExpr  *vm.Program
+	// Deprecated: [v0.90.0] Use IfCondition instead. IfExpr is only set for conditions
+	// written in expr, and is evaluated by Skip if IfCondition is not set.
+	IfExpr      *vm.Program
+	IfCondition Condition
 }
 
 // CanProcess will always return true for a transformer operator.
@@ -102,20 +108,21 @@ func (t *TransformerOperator) HandleEntryError(ctx context.Context, entry *entry
 	return err
 }
 
-func (t *TransformerOperator) Skip(_ context.Context, entry *entry.Entry) (bool, error) {
-	if t.IfExpr == nil {
+func (t *TransformerOperator) Skip(ctx context.Context, entry *entry.Entry) (bool, error) {
+	condition := t.IfCondition
+	if condition == nil && t.IfExpr != nil {
+		condition = NewExprCondition(t.IfExpr)
+	}
+	if condition == nil {
 		return false, nil
 	}
 
-	env := GetExprEnv(entry)
-	defer PutExprEnv(env)
-
-	matches, err := vm.Run(t.IfExpr, env)
+	matches, err := condition.Match(ctx, entry)
 	if err != nil {
 		return false, fmt.Errorf("running if expr: %w", err)
 	}
 
-	return !matches.(bool), nil
+	return !matches, nil
 }
 
 // TransformFunction is function that transforms an entry.
@@ -144,6 +144,7 @@ func TestTransformerIf(t *testing.T) {
 	cases := []struct {
 		name        string
 		ifExpr      string
+		language    string
 		inputBody   string
 		expected    string
 		errExpected bool
@@ -185,12 +186,27 @@ func TestTransformerIf(t *testing.T) {
 			expected:    "test",
 			errExpected: true,
 		},
+		{
+			name:      "OTTLEvaluatedTrue",
+			ifExpr:    `body == "test"`,
+			language:  OTTLConditionLanguage,
+			inputBody: "test",
+			expected:  "parsed",
+		},
+		{
+			name:      "OTTLEvaluatedFalse",
+			ifExpr:    `body == "notest"`,
+			language:  OTTLConditionLanguage,
+			inputBody: "test",
+			expected:  "test",
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			cfg := NewTransformerConfig("test", "test")
 			cfg.IfExpr = tc.ifExpr
+			cfg.ConditionLanguage = tc.language
 
 			transformer, err := cfg.Build(testutil.Logger(t))
 			require.NoError(t, err)
@@ -220,4 +236,45 @@ func TestTransformerIf(t *testing.T) {
 		_, err := cfg.Build(testutil.Logger(t))
 		require.Error(t, err)
 	})
+
+	t.Run("InvalidOTTLIf", func(t *testing.T) {
+		cfg := NewTransformerConfig("test", "test")
+		cfg.IfExpr = `body.message == "test"`
+		cfg.ConditionLanguage = OTTLConditionLanguage
+		_, err := cfg.Build(testutil.Logger(t))
+		require.Error(t, err)
+	})
+
+	t.Run("DeprecatedIfExpr", func(t *testing.T) {
+		cfg := NewTransformerConfig("test", "test")
+		cfg.IfExpr = `body == "match"`
+		op, err := cfg.Build(testutil.Logger(t))
+		require.NoError(t, err)
+		require.NotNil(t, op.IfExpr)
+
+		// Operators setting only the deprecated IfExpr field keep being skipped.
+		deprecated := TransformerOperator{IfExpr: op.IfExpr}
+		match := entry.New()
+		match.Body = "match"
+		skip, err := deprecated.Skip(context.Background(), match)
+		require.NoError(t, err)
+		require.False(t, skip)
+		skip, err = deprecated.Skip(context.Background(), entry.New())
+		require.NoError(t, err)
+		require.True(t, skip)
+
+		cfg.ConditionLanguage = OTTLConditionLanguage
+		cfg.IfExpr = `body == "match"`
+		op, err = cfg.Build(testutil.Logger(t))
+		require.NoError(t, err)
+		require.Nil(t, op.IfExpr)
+	})
+
+	t.Run("InvalidConditionLanguage", func(t *testing.T) {
+		cfg := NewTransformerConfig("test", "test")
+		cfg.IfExpr = "true"
+		cfg.ConditionLanguage = "cel"
+		_, err := cfg.Build(testutil.Logger(t))
+		require.ErrorContains(t, err, "invalid condition language 'cel'")
+	})
 }
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+// +build linux
+
+package journald // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald"
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// priorities maps the priority names accepted by journalctl to their syslog levels.
+var priorities = map[string]int{
+	"emerg":   0,
+	"alert":   1,
+	"crit":    2,
+	"err":     3,
+	"warning": 4,
+	"notice":  5,
+	"info":    6,
+	"debug":   7,
+}
+
+// unitSuffixes are the unit types journalctl recognizes before appending the default ".service" suffix.
+var unitSuffixes = []string{
+	".service", ".socket", ".target", ".device", ".mount", ".automount",
+	".swap", ".timer", ".path", ".slice", ".scope",
+}
+
+// filter evaluates the journalctl filtering options against entries read natively
+// from journal files, so both modes select the same entries.
+type filter struct {
+	units       []string
+	identifiers map[string]struct{}
+	minPriority int
+	maxPriority int
+	matches     []MatchConfig
+	grep        *regexp.Regexp
+	dmesg       bool
+}
+
+func newFilter(c Config) (*filter, error) {
+	f := &filter{
+		matches: c.Matches,
+		dmesg:   c.Dmesg,
+	}
+
+	for _, unit := range c.Units {
+		f.units = append(f.units, mangleUnit(unit))
+	}
+
+	if len(c.Identifiers) > 0 {
+		f.identifiers = make(map[string]struct{}, len(c.Identifiers))
+		for _, identifier := range c.Identifiers {
+			f.identifiers[identifier] = struct{}{}
+		}
+	}
+
+	var err error
+	if f.minPriority, f.maxPriority, err = parsePriority(c.Priority); err != nil {
+		return nil, err
+	}
+
+	if c.Grep != "" {
+		pattern := c.Grep
+		// Like journalctl, the pattern is matched case insensitively unless it contains upper case characters.
+		if strings.IndexFunc(pattern, unicode.IsUpper) < 0 {
+			pattern = "(?i)" + pattern
+		}
+		if f.grep, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid value '%s' for parameter 'grep': %w", c.Grep, err)
+		}
+	}
+
+	return f, nil
+}
+
+// parsePriority parses a single priority or a range of priorities, as names or numbers.
+func parsePriority(priority string) (int, int, error) {
+	if priority == "" {
+		return 0, 7, nil
+	}
+
+	from, to, isRange := strings.Cut(priority, "..")
+	if !isRange {
+		level, err := parsePriorityLevel(priority)
+		return 0, level, err
+	}
+
+	min, err := parsePriorityLevel(from)
+	if err != nil {
+		return 0, 0, err
+	}
+	max, err := parsePriorityLevel(to)
+	if err != nil {
+		return 0, 0, err
+	}
+	if min > max {
+		min, max = max, min
+	}
+	return min, max, nil
+}
+
+func parsePriorityLevel(level string) (int, error) {
+	if p, ok := priorities[level]; ok {
+		return p, nil
+	}
+	if p, err := strconv.Atoi(level); err == nil && p >= 0 && p <= 7 {
+		return p, nil
+	}
+	return 0, fmt.Errorf("invalid value '%s' for parameter 'priority'", level)
+}
+
+// mangleUnit appends the ".service" suffix to unit names without a unit type, like journalctl does.
+func mangleUnit(unit string) string {
+	if strings.ContainsAny(unit, "*?[") {
+		return unit
+	}
+	for _, suffix := range unitSuffixes {
+		if strings.HasSuffix(unit, suffix) {
+			return unit
+		}
+	}
+	return unit + ".service"
+}
+
+// match reports whether the entry with the given fields passes all configured filters.
+func (f *filter) match(fields map[string]string) bool {
+	if len(f.units) > 0 && !f.matchUnit(fields) {
+		return false
+	}
+
+	if f.identifiers != nil {
+		if _, ok := f.identifiers[fields["SYSLOG_IDENTIFIER"]]; !ok {
+			return false
+		}
+	}
+
+	if p, ok := fields["PRIORITY"]; ok {
+		level, err := strconv.Atoi(p)
+		if err == nil && (level < f.minPriority || level > f.maxPriority) {
+			return false
+		}
+	}
+
+	if f.dmesg && fields["_TRANSPORT"] != "kernel" {
+		return false
+	}
+
+	if len(f.matches) > 0 && !f.matchAny(fields) {
+		return false
+	}
+
+	if f.grep != nil && !f.grep.MatchString(fields["MESSAGE"]) {
+		return false
+	}
+
+	return true
+}
+
+func (f *filter) matchUnit(fields map[string]string) bool {
+	candidates := []string{fields["_SYSTEMD_UNIT"]}
+	// Messages logged by systemd itself about a unit carry the unit in the UNIT field.
+	if fields["_PID"] == "1" {
+		candidates = append(candidates, fields["UNIT"])
+	}
+	for _, unit := range f.units {
+		for _, candidate := range candidates {
+			if candidate == "" {
+				continue
+			}
+			if ok, _ := path.Match(unit, candidate); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchAny reports whether all fields of at least one match configuration are equal to the entry fields.
+func (f *filter) matchAny(fields map[string]string) bool {
+	for _, mc := range f.matches {
+		matched := true
+		for key, value := range mc {
+			if v, ok := fields[key]; !ok || v != value {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
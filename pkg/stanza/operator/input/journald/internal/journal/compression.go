@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package journal // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald/internal/journal"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+)
+
+// Compression flags of data objects.
+const (
+	objectCompressedXZ   = 1 << 0
+	objectCompressedLZ4  = 1 << 1
+	objectCompressedZSTD = 1 << 2
+)
+
+// maxDecompressedSize limits the size of a decompressed field, matching the
+// default DATA_SIZE_MAX of systemd.
+const maxDecompressedSize = 768 * 1024 * 1024
+
+var errXZUnsupported = errors.New("xz compressed journal fields are not supported")
+
+type decompressor struct {
+	zstd *zstd.Decoder
+}
+
+func newDecompressor() *decompressor {
+	return &decompressor{}
+}
+
+// decompress returns the payload of a data object according to its compression flags.
+func (d *decompressor) decompress(flags uint8, payload []byte) ([]byte, error) {
+	switch {
+	case flags&objectCompressedZSTD != 0:
+		if d.zstd == nil {
+			dec, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(maxDecompressedSize))
+			if err != nil {
+				return nil, fmt.Errorf("create zstd decoder: %w", err)
+			}
+			d.zstd = dec
+		}
+		out, err := d.zstd.DecodeAll(payload, nil)
+		if err != nil {
+			return nil, fmt.Errorf("decompress zstd field: %w", err)
+		}
+		return out, nil
+	case flags&objectCompressedLZ4 != 0:
+		// LZ4 compressed payloads start with the little endian size of the decompressed data.
+		if len(payload) < 8 {
+			return nil, errors.New("lz4 compressed field is too short")
+		}
+		size := binary.LittleEndian.Uint64(payload[:8])
+		if size > maxDecompressedSize {
+			return nil, fmt.Errorf("lz4 compressed field is too large: %d bytes", size)
+		}
+		out := make([]byte, size)
+		n, err := lz4.UncompressBlock(payload[8:], out)
+		if err != nil {
+			return nil, fmt.Errorf("decompress lz4 field: %w", err)
+		}
+		return out[:n], nil
+	case flags&objectCompressedXZ != 0:
+		return nil, errXZUnsupported
+	default:
+		return payload, nil
+	}
+}
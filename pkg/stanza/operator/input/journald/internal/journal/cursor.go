@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package journal // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald/internal/journal"
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Cursor identifies an entry in the journal. Its string representation is
+// compatible with the cursors printed and accepted by journalctl.
+type Cursor struct {
+	SeqnumID  ID
+	Seqnum    uint64
+	BootID    ID
+	Monotonic uint64
+	Realtime  uint64
+	XorHash   uint64
+}
+
+// String formats the cursor like journalctl does.
+func (c Cursor) String() string {
+	return fmt.Sprintf("s=%s;i=%x;b=%s;m=%x;t=%x;x=%x", c.SeqnumID, c.Seqnum, c.BootID, c.Monotonic, c.Realtime, c.XorHash)
+}
+
+// ParseCursor parses a cursor printed by journalctl or Cursor.String.
+func ParseCursor(s string) (Cursor, error) {
+	var c Cursor
+	var seen int
+	for _, part := range strings.Split(s, ";") {
+		key, value, found := strings.Cut(part, "=")
+		if !found {
+			return Cursor{}, fmt.Errorf("invalid cursor %q", s)
+		}
+
+		var err error
+		switch key {
+		case "s":
+			err = parseID(value, &c.SeqnumID)
+			seen |= 1 << 0
+		case "i":
+			c.Seqnum, err = strconv.ParseUint(value, 16, 64)
+			seen |= 1 << 1
+		case "b":
+			err = parseID(value, &c.BootID)
+		case "m":
+			c.Monotonic, err = strconv.ParseUint(value, 16, 64)
+		case "t":
+			c.Realtime, err = strconv.ParseUint(value, 16, 64)
+			seen |= 1 << 2
+		case "x":
+			c.XorHash, err = strconv.ParseUint(value, 16, 64)
+		}
+		if err != nil {
+			return Cursor{}, fmt.Errorf("invalid cursor field %q: %w", key, err)
+		}
+	}
+
+	if seen != 0b111 {
+		return Cursor{}, fmt.Errorf("cursor %q is missing the sequence number or realtime fields", s)
+	}
+	return c, nil
+}
+
+func parseID(s string, id *ID) error {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	if len(b) != len(id) {
+		return fmt.Errorf("invalid id length %d", len(b))
+	}
+	copy(id[:], b)
+	return nil
+}
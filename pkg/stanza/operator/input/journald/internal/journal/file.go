@@ -0,0 +1,330 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package journal reads systemd journal files without relying on libsystemd or journalctl.
+// The on-disk format is documented at https://systemd.io/JOURNAL_FILE_FORMAT/.
+package journal // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald/internal/journal"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	signature = "LPKSHHRH"
+
+	// minHeaderSize is the size of the header written by the oldest supported journal files,
+	// up to and including the tail_entry_monotonic field.
+	minHeaderSize = 208
+
+	objectHeaderSize = 16
+
+	entryObjectHeaderSize = 64
+	dataObjectHeaderSize  = 64
+	dataCompactHeaderSize = 72
+
+	// maxObjectSize limits the size of the objects read from a file, which can't exceed
+	// the size of a field plus the header of its data object.
+	maxObjectSize = maxDecompressedSize + dataCompactHeaderSize
+)
+
+// Incompatible flags of the file header.
+const (
+	incompatibleCompressedXZ   = 1 << 0
+	incompatibleCompressedLZ4  = 1 << 1
+	incompatibleKeyedHash      = 1 << 2
+	incompatibleCompressedZSTD = 1 << 3
+	incompatibleCompact        = 1 << 4
+
+	supportedIncompatibleFlags = incompatibleCompressedXZ | incompatibleCompressedLZ4 |
+		incompatibleKeyedHash | incompatibleCompressedZSTD | incompatibleCompact
+)
+
+// Object types.
+const (
+	objectUnused = 0
+	objectData   = 1
+	objectEntry  = 3
+)
+
+// ErrNotJournal is returned when a file does not start with the journal file signature.
+var ErrNotJournal = errors.New("file is not a journal file")
+
+// ID is a 128 bit identifier used for file, machine, boot and sequence number IDs.
+type ID [16]byte
+
+// String returns the identifier formatted the way journalctl does, as 32 lower case hex digits.
+func (id ID) String() string {
+	return fmt.Sprintf("%x", id[:])
+}
+
+// Header contains the fields of the journal file header needed to read entries.
+type Header struct {
+	IncompatibleFlags uint32
+	State             uint8
+	FileID            ID
+	MachineID         ID
+	SeqnumID          ID
+	HeaderSize        uint64
+	TailObjectOffset  uint64
+	NEntries          uint64
+}
+
+// Compact reports whether the file uses the compact format introduced in systemd 252.
+func (h Header) Compact() bool {
+	return h.IncompatibleFlags&incompatibleCompact != 0
+}
+
+// Entry is a single log entry of a journal file. The fields of the entry are read on demand with File.Fields.
+type Entry struct {
+	Seqnum    uint64
+	Realtime  uint64
+	Monotonic uint64
+	BootID    ID
+	XorHash   uint64
+	SeqnumID  ID
+
+	items []uint64
+}
+
+// Cursor returns the cursor identifying the entry.
+func (e *Entry) Cursor() Cursor {
+	return Cursor{
+		SeqnumID:  e.SeqnumID,
+		Seqnum:    e.Seqnum,
+		BootID:    e.BootID,
+		Monotonic: e.Monotonic,
+		Realtime:  e.Realtime,
+		XorHash:   e.XorHash,
+	}
+}
+
+// Field is a single field of an entry.
+type Field struct {
+	Name  string
+	Value []byte
+}
+
+// File reads entries sequentially from a journal file. Entries that are appended to
+// the file after it was opened are returned by subsequent calls to Next.
+type File struct {
+	f      *os.File
+	header Header
+	// offset is the position of the next object to read.
+	offset uint64
+	// read is the number of entry objects read so far.
+	read         uint64
+	decompressor *decompressor
+}
+
+// Open opens the journal file at the given path and reads its header.
+func Open(path string) (*File, error) {
+	f, err := os.Open(path) // #nosec G304 - journal file paths are provided by configuration
+	if err != nil {
+		return nil, err
+	}
+	jf := &File{f: f, decompressor: newDecompressor()}
+	if err = jf.refresh(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	jf.offset = jf.header.HeaderSize
+	return jf, nil
+}
+
+// Close closes the underlying file.
+func (jf *File) Close() error {
+	return jf.f.Close()
+}
+
+// Header returns the most recently read header of the file.
+func (jf *File) Header() Header {
+	return jf.header
+}
+
+// refresh reads the header of the file again, as it changes when entries are appended.
+func (jf *File) refresh() error {
+	buf := make([]byte, minHeaderSize)
+	if _, err := jf.f.ReadAt(buf, 0); err != nil {
+		if errors.Is(err, io.EOF) {
+			return ErrNotJournal
+		}
+		return fmt.Errorf("read header: %w", err)
+	}
+	if !bytes.Equal(buf[:8], []byte(signature)) {
+		return ErrNotJournal
+	}
+
+	h := Header{
+		IncompatibleFlags: binary.LittleEndian.Uint32(buf[12:16]),
+		State:             buf[16],
+		HeaderSize:        binary.LittleEndian.Uint64(buf[88:96]),
+		TailObjectOffset:  binary.LittleEndian.Uint64(buf[136:144]),
+		NEntries:          binary.LittleEndian.Uint64(buf[152:160]),
+	}
+	copy(h.FileID[:], buf[24:40])
+	copy(h.MachineID[:], buf[40:56])
+	copy(h.SeqnumID[:], buf[72:88])
+
+	if unsupported := h.IncompatibleFlags &^ supportedIncompatibleFlags; unsupported != 0 {
+		return fmt.Errorf("unsupported incompatible flags %#x", unsupported)
+	}
+	if h.HeaderSize < minHeaderSize {
+		return fmt.Errorf("invalid header size %d", h.HeaderSize)
+	}
+	jf.header = h
+	return nil
+}
+
+// Next returns the next entry of the file. It returns io.EOF if no further entry has been
+// written yet, in which case Next can be called again later to check for new entries.
+func (jf *File) Next() (*Entry, error) {
+	if jf.read >= jf.header.NEntries {
+		if err := jf.refresh(); err != nil {
+			return nil, err
+		}
+		if jf.read >= jf.header.NEntries {
+			return nil, io.EOF
+		}
+	}
+
+	for jf.offset <= jf.header.TailObjectOffset {
+		objectType, size, err := jf.readObjectHeader(jf.offset)
+		if err != nil {
+			return nil, err
+		}
+		if objectType == objectUnused || size < objectHeaderSize {
+			// The object is still being written.
+			return nil, io.EOF
+		}
+
+		offset := jf.offset
+		jf.offset += align64(size)
+		if objectType != objectEntry {
+			continue
+		}
+
+		entry, err := jf.readEntry(offset, size)
+		if err != nil {
+			return nil, err
+		}
+		jf.read++
+		return entry, nil
+	}
+	return nil, io.EOF
+}
+
+// Fields reads the fields of the given entry.
+func (jf *File) Fields(e *Entry) ([]Field, error) {
+	fields := make([]Field, 0, len(e.items))
+	for _, offset := range e.items {
+		payload, err := jf.readData(offset)
+		if err != nil {
+			return nil, err
+		}
+		name, value, found := bytes.Cut(payload, []byte{'='})
+		if !found {
+			return nil, fmt.Errorf("data object at offset %d is not a field", offset)
+		}
+		fields = append(fields, Field{Name: string(name), Value: value})
+	}
+	return fields, nil
+}
+
+func (jf *File) readObjectHeader(offset uint64) (uint8, uint64, error) {
+	buf := make([]byte, objectHeaderSize)
+	if _, err := jf.f.ReadAt(buf, int64(offset)); err != nil {
+		if errors.Is(err, io.EOF) {
+			return objectUnused, 0, nil
+		}
+		return 0, 0, fmt.Errorf("read object header at offset %d: %w", offset, err)
+	}
+	return buf[0], binary.LittleEndian.Uint64(buf[8:16]), nil
+}
+
+// readObject reads an object of the file. The size is read from the file, so it is checked
+// against the size of the file before allocating the buffer.
+func (jf *File) readObject(offset, size uint64) ([]byte, error) {
+	if size > maxObjectSize {
+		return nil, fmt.Errorf("object at offset %d is too large: %d bytes", offset, size)
+	}
+	info, err := jf.f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat journal file: %w", err)
+	}
+	if fileSize := uint64(info.Size()); offset > fileSize || size > fileSize-offset {
+		return nil, fmt.Errorf("object at offset %d of size %d exceeds the file size %d", offset, size, fileSize)
+	}
+	buf := make([]byte, size)
+	if _, err := jf.f.ReadAt(buf, int64(offset)); err != nil {
+		return nil, fmt.Errorf("read object at offset %d: %w", offset, err)
+	}
+	return buf, nil
+}
+
+func (jf *File) readEntry(offset, size uint64) (*Entry, error) {
+	if size < entryObjectHeaderSize {
+		return nil, fmt.Errorf("invalid entry object size %d at offset %d", size, offset)
+	}
+	buf, err := jf.readObject(offset, size)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Entry{
+		Seqnum:    binary.LittleEndian.Uint64(buf[16:24]),
+		Realtime:  binary.LittleEndian.Uint64(buf[24:32]),
+		Monotonic: binary.LittleEndian.Uint64(buf[32:40]),
+		XorHash:   binary.LittleEndian.Uint64(buf[56:64]),
+		SeqnumID:  jf.header.SeqnumID,
+	}
+	copy(e.BootID[:], buf[40:56])
+
+	items := buf[entryObjectHeaderSize:]
+	if jf.header.Compact() {
+		e.items = make([]uint64, 0, len(items)/4)
+		for i := 0; i+4 <= len(items); i += 4 {
+			e.items = append(e.items, uint64(binary.LittleEndian.Uint32(items[i:i+4])))
+		}
+	} else {
+		// Each item is the offset of the data object followed by its hash.
+		e.items = make([]uint64, 0, len(items)/16)
+		for i := 0; i+16 <= len(items); i += 16 {
+			e.items = append(e.items, binary.LittleEndian.Uint64(items[i:i+8]))
+		}
+	}
+	return e, nil
+}
+
+func (jf *File) readData(offset uint64) ([]byte, error) {
+	objectType, size, err := jf.readObjectHeader(offset)
+	if err != nil {
+		return nil, err
+	}
+	if objectType != objectData {
+		return nil, fmt.Errorf("object at offset %d is not a data object", offset)
+	}
+
+	headerSize := uint64(dataObjectHeaderSize)
+	if jf.header.Compact() {
+		headerSize = dataCompactHeaderSize
+	}
+	if size < headerSize {
+		return nil, fmt.Errorf("invalid data object size %d at offset %d", size, offset)
+	}
+
+	buf, err := jf.readObject(offset, size)
+	if err != nil {
+		return nil, err
+	}
+	return jf.decompressor.decompress(buf[1], buf[headerSize:])
+}
+
+// align64 rounds the size up to the 8 byte alignment of journal objects.
+func align64(size uint64) uint64 {
+	return (size + 7) &^ 7
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package journal
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pierrec/lz4/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixturePath(name string) string {
+	return filepath.Join("..", "..", "testdata", name)
+}
+
+func readAll(t *testing.T, jf *File) ([]*Entry, []map[string]string) {
+	var entries []*Entry
+	var fields []map[string]string
+	for {
+		e, err := jf.Next()
+		if err == io.EOF {
+			return entries, fields
+		}
+		require.NoError(t, err)
+		fs, err := jf.Fields(e)
+		require.NoError(t, err)
+		m := map[string]string{}
+		for _, f := range fs {
+			m[f.Name] = string(f.Value)
+		}
+		entries = append(entries, e)
+		fields = append(fields, m)
+	}
+}
+
+func TestReadCompactFile(t *testing.T) {
+	jf, err := Open(fixturePath("compact.journal"))
+	require.NoError(t, err)
+	defer jf.Close()
+
+	h := jf.Header()
+	assert.True(t, h.Compact())
+	assert.Equal(t, "827595023bdd49208a9f16743514754c", h.SeqnumID.String())
+	assert.Equal(t, "fed6b2924c424cf1b9a322f606b4de6d", h.MachineID.String())
+
+	entries, fields := readAll(t, jf)
+	require.Len(t, entries, 9)
+
+	messages := make([]string, 0, len(fields))
+	for _, f := range fields {
+		messages = append(messages, f["MESSAGE"])
+	}
+	assert.Equal(t, "Journal started", messages[0])
+	assert.Equal(t, []string{"hello 1", "hello 2", "hello 3", "an error", "debug line"}, messages[2:7])
+	assert.Equal(t, strings.Repeat("x", 2000), messages[7])
+	assert.Equal(t, "Journal stopped", messages[8])
+
+	assert.Equal(t, "fixture", fields[2]["SYSLOG_IDENTIFIER"])
+	assert.Equal(t, "3", fields[5]["PRIORITY"])
+
+	assert.Equal(t,
+		"s=827595023bdd49208a9f16743514754c;i=3;b=ccdb0e13c60a4769859b98673a971748;m=3d305f95;t=65e32e7ea9323;x=a1053fb331117ad2",
+		entries[2].Cursor().String())
+	assert.Equal(t, uint64(1792422592549667), entries[2].Realtime)
+}
+
+func TestReadRegularFile(t *testing.T) {
+	jf, err := Open(fixturePath("regular.journal"))
+	require.NoError(t, err)
+	defer jf.Close()
+
+	assert.False(t, jf.Header().Compact())
+
+	entries, fields := readAll(t, jf)
+	require.Len(t, entries, 5)
+	assert.Equal(t, "hello regular", fields[2]["MESSAGE"])
+	assert.Equal(t, strings.Repeat("y", 2000), fields[3]["MESSAGE"])
+	assert.Equal(t,
+		"s=c5b76f4e1ccd43fc85742e217f101d49;i=5;b=ccdb0e13c60a4769859b98673a971748;m=3dae94ce;t=65e32e868c85c;x=455481b0174c917f",
+		entries[4].Cursor().String())
+}
+
+func TestFollowAppendedEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "system.journal")
+	content, err := os.ReadFile(fixturePath("compact.journal"))
+	require.NoError(t, err)
+
+	// Pretend only the first 3 entries were committed so far.
+	setEntries := func(n uint64) {
+		binary.LittleEndian.PutUint64(content[152:160], n)
+		require.NoError(t, os.WriteFile(path, content, 0600))
+	}
+	setEntries(3)
+
+	jf, err := Open(path)
+	require.NoError(t, err)
+	defer jf.Close()
+
+	entries, _ := readAll(t, jf)
+	require.Len(t, entries, 3)
+
+	setEntries(9)
+	entries, fields := readAll(t, jf)
+	require.Len(t, entries, 6)
+	assert.Equal(t, uint64(4), entries[0].Seqnum)
+	assert.Equal(t, "hello 2", fields[0]["MESSAGE"])
+}
+
+func TestOpenInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invalid.journal")
+	require.NoError(t, os.WriteFile(path, []byte("not a journal"), 0600))
+	_, err := Open(path)
+	require.ErrorIs(t, err, ErrNotJournal)
+
+	path = filepath.Join(t.TempDir(), "short.journal")
+	require.NoError(t, os.WriteFile(path, []byte(signature), 0600))
+	_, err = Open(path)
+	require.ErrorIs(t, err, ErrNotJournal)
+
+	_, err = Open(filepath.Join(t.TempDir(), "missing.journal"))
+	require.Error(t, err)
+}
+
+func TestReadObjectSizeLimit(t *testing.T) {
+	jf, err := Open(fixturePath("compact.journal"))
+	require.NoError(t, err)
+	defer jf.Close()
+
+	_, err = jf.readObject(jf.header.HeaderSize, maxObjectSize+1)
+	require.ErrorContains(t, err, "too large")
+
+	_, err = jf.readObject(jf.header.HeaderSize, 1<<20)
+	require.ErrorContains(t, err, "exceeds the file size")
+
+	_, err = jf.readObject(1<<40, objectHeaderSize)
+	require.ErrorContains(t, err, "exceeds the file size")
+}
+
+func TestDecompress(t *testing.T) {
+	d := newDecompressor()
+
+	out, err := d.decompress(0, []byte("MESSAGE=plain"))
+	require.NoError(t, err)
+	assert.Equal(t, "MESSAGE=plain", string(out))
+
+	raw := []byte("MESSAGE=" + strings.Repeat("z", 1024))
+	compressed := make([]byte, lz4.CompressBlockBound(len(raw)))
+	n, err := lz4.CompressBlock(raw, compressed, nil)
+	require.NoError(t, err)
+	payload := binary.LittleEndian.AppendUint64(nil, uint64(len(raw)))
+	payload = append(payload, compressed[:n]...)
+	out, err = d.decompress(objectCompressedLZ4, payload)
+	require.NoError(t, err)
+	assert.Equal(t, raw, out)
+
+	_, err = d.decompress(objectCompressedLZ4, []byte{1})
+	assert.Error(t, err)
+
+	_, err = d.decompress(objectCompressedXZ, []byte{1})
+	assert.ErrorIs(t, err, errXZUnsupported)
+}
+
+func TestCursor(t *testing.T) {
+	s := "s=827595023bdd49208a9f16743514754c;i=3;b=ccdb0e13c60a4769859b98673a971748;m=3d305f95;t=65e32e7ea9323;x=a1053fb331117ad2"
+	c, err := ParseCursor(s)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), c.Seqnum)
+	assert.Equal(t, uint64(0x65e32e7ea9323), c.Realtime)
+	assert.Equal(t, s, c.String())
+
+	for _, invalid := range []string{
+		"",
+		"garbage",
+		"s=zz;i=1;t=1",
+		"s=827595023bdd49208a9f16743514754c;i=3",
+		"s=827595023bdd49208a9f16743514754c;i=x;t=1",
+	} {
+		_, err = ParseCursor(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -31,6 +31,11 @@ import (
 const operatorType = "journald_input"
 const waitDuration = 1 * time.Second
 
+const (
+	modeJournalctl = "journalctl"
+	modeNative     = "native"
+)
+
 func init() {
 	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
 }
@@ -43,9 +48,11 @@ func NewConfig() *Config {
 // NewConfigWithID creates a new input config with default values
 func NewConfigWithID(operatorID string) *Config {
 	return &Config{
-		InputConfig: helper.NewInputConfig(operatorID, operatorType),
-		StartAt:     "end",
-		Priority:    "info",
+		InputConfig:  helper.NewInputConfig(operatorID, operatorType),
+		StartAt:      "end",
+		Priority:     "info",
+		Mode:         modeJournalctl,
+		PollInterval: 200 * time.Millisecond,
 	}
 }
 
@@ -62,6 +69,10 @@ type Config struct {
 	Identifiers []string      `mapstructure:"identifiers,omitempty"`
 	Grep        string        `mapstructure:"grep,omitempty"`
 	Dmesg       bool          `mapstructure:"dmesg,omitempty"`
+
+	// Mode selects how the journal is read: by running journalctl, or by reading journal files directly.
+	Mode         string        `mapstructure:"mode,omitempty"`
+	PollInterval time.Duration `mapstructure:"poll_interval,omitempty"`
 }
 
 type MatchConfig map[string]string
@@ -73,6 +84,14 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, err
 	}
 
+	switch c.Mode {
+	case modeJournalctl:
+	case modeNative:
+		return c.buildNative(inputOperator)
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'mode'", c.Mode)
+	}
+
 	args, err := c.buildArgs()
 	if err != nil {
 		return nil, err
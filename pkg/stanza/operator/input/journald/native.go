@@ -0,0 +1,374 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+// +build linux
+
+package journald // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald/internal/journal"
+)
+
+// defaultJournalDirectories are the directories searched for journal files when
+// neither a directory nor files are configured, like journalctl does.
+var defaultJournalDirectories = []string{"/var/log/journal", "/run/log/journal"}
+
+func (c Config) buildNative(inputOperator helper.InputOperator) (operator.Operator, error) {
+	switch c.StartAt {
+	case "end", "beginning":
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'start_at'", c.StartAt)
+	}
+
+	if _, err := c.buildMatchesConfig(); err != nil {
+		return nil, err
+	}
+
+	if c.PollInterval <= 0 {
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'poll_interval'", c.PollInterval)
+	}
+
+	f, err := newFilter(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var patterns []string
+	switch {
+	case c.Directory != nil:
+		patterns = directoryPatterns(*c.Directory)
+	case len(c.Files) > 0:
+		patterns = c.Files
+	default:
+		for _, dir := range defaultJournalDirectories {
+			patterns = append(patterns, directoryPatterns(dir)...)
+		}
+	}
+
+	return &NativeInput{
+		InputOperator: inputOperator,
+		patterns:      patterns,
+		filter:        f,
+		startAtEnd:    c.StartAt == "end",
+		pollInterval:  c.PollInterval,
+		files:         map[journal.ID]*journalFile{},
+		paths:         map[string]journal.ID{},
+	}, nil
+}
+
+// directoryPatterns returns the patterns matching the journal files of a directory,
+// including the files stored in per machine subdirectories.
+func directoryPatterns(dir string) []string {
+	return []string{
+		filepath.Join(dir, "*.journal"),
+		filepath.Join(dir, "*", "*.journal"),
+	}
+}
+
+// NativeInput is an operator that reads entries directly from journal files, without journalctl
+type NativeInput struct {
+	helper.InputOperator
+
+	patterns     []string
+	filter       *filter
+	startAtEnd   bool
+	pollInterval time.Duration
+
+	// files are the open journal files, by file ID. The ID is used rather than the path
+	// as journald renames the active file when rotating it.
+	files map[journal.ID]*journalFile
+	// paths are the file IDs of the paths that were already opened.
+	paths map[string]journal.ID
+
+	persister operator.Persister
+	cursor    *journal.Cursor
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+type journalFile struct {
+	path string
+	file *journal.File
+	// next is the next entry of the file, read ahead to order entries across files.
+	next *journal.Entry
+	// skip reports whether an entry was already read before the operator was restarted.
+	skip func(*journal.Entry) bool
+}
+
+// Start will start reading entries from the journal files.
+func (operator *NativeInput) Start(persister operator.Persister) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	operator.cancel = cancel
+	operator.persister = persister
+
+	saved, err := persister.Get(ctx, lastReadCursorKey)
+	if err != nil {
+		return fmt.Errorf("failed to get journal state: %w", err)
+	}
+	if saved != nil {
+		cursor, err := journal.ParseCursor(string(saved))
+		if err != nil {
+			operator.Warnw("Ignoring invalid journal cursor", zap.Error(err))
+		} else {
+			operator.cursor = &cursor
+		}
+	}
+
+	// Entries present at startup are skipped when starting at the end, unless resuming from a cursor.
+	operator.discover(operator.startAtEnd && operator.cursor == nil)
+
+	operator.wg.Add(1)
+	go func() {
+		defer operator.wg.Done()
+
+		ticker := time.NewTicker(operator.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			operator.poll(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop will stop reading entries and close the journal files.
+func (operator *NativeInput) Stop() error {
+	if operator.cancel != nil {
+		operator.cancel()
+	}
+	operator.wg.Wait()
+	for id, jf := range operator.files {
+		_ = jf.file.Close()
+		delete(operator.files, id)
+	}
+	operator.paths = map[string]journal.ID{}
+	return nil
+}
+
+// poll discovers new journal files and emits the entries written since the last poll, ordered by time.
+// The cursor of the last entry read is persisted once the entries of the poll have been emitted.
+func (operator *NativeInput) poll(ctx context.Context) {
+	operator.discover(false)
+
+	last := operator.cursor
+	defer func() {
+		if operator.cursor == last {
+			return
+		}
+		// The context may be cancelled by Stop, the cursor of the emitted entries is persisted anyway.
+		if err := operator.persister.Set(context.Background(), lastReadCursorKey, []byte(operator.cursor.String())); err != nil {
+			operator.Warnw("Failed to set offset", zap.Error(err))
+		}
+	}()
+
+	for ctx.Err() == nil {
+		var oldest *journalFile
+		for _, jf := range operator.files {
+			if jf.next == nil {
+				jf.next = operator.advance(jf)
+			}
+			if jf.next != nil && (oldest == nil || jf.next.Realtime < oldest.next.Realtime) {
+				oldest = jf
+			}
+		}
+		if oldest == nil {
+			return
+		}
+
+		e := oldest.next
+		oldest.next = nil
+		operator.emit(ctx, oldest, e)
+
+		// The cursor also advances past the entries that were filtered out, so that they
+		// are not read again after a restart.
+		cursor := e.Cursor()
+		operator.cursor = &cursor
+	}
+}
+
+// advance returns the next entry of the file that was not read before, or nil if there is none yet.
+func (operator *NativeInput) advance(jf *journalFile) *journal.Entry {
+	for {
+		e, err := jf.file.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			operator.Warnw("Failed to read journal file", zap.String("path", jf.path), zap.Error(err))
+			operator.closeFile(jf)
+			return nil
+		}
+		if jf.skip != nil {
+			if jf.skip(e) {
+				continue
+			}
+			jf.skip = nil
+		}
+		return e
+	}
+}
+
+func (operator *NativeInput) emit(ctx context.Context, jf *journalFile, e *journal.Entry) {
+	fields, err := jf.file.Fields(e)
+	if err != nil {
+		operator.Warnw("Failed to read journal entry", zap.String("path", jf.path), zap.Error(err))
+		return
+	}
+
+	values := make(map[string]string, len(fields))
+	for _, f := range fields {
+		values[f.Name] = string(f.Value)
+	}
+	if !operator.filter.match(values) {
+		return
+	}
+
+	ent, err := operator.NewEntry(journalBody(e, e.Cursor().String(), fields))
+	if err != nil {
+		operator.Warnw("Failed to create entry", zap.Error(err))
+		return
+	}
+	ent.Timestamp = time.UnixMicro(int64(e.Realtime))
+	operator.Write(ctx, ent)
+}
+
+// journalBody builds the body of an entry with the same fields journalctl exports as JSON.
+// Fields that occur multiple times are represented as arrays, and values that are not
+// valid UTF-8 as bytes.
+func journalBody(e *journal.Entry, cursor string, fields []journal.Field) map[string]any {
+	body := make(map[string]any, len(fields)+3)
+	body["__CURSOR"] = cursor
+	body["__MONOTONIC_TIMESTAMP"] = strconv.FormatUint(e.Monotonic, 10)
+	body["_BOOT_ID"] = e.BootID.String()
+
+	for _, f := range fields {
+		// Like in journalctl, the boot ID is taken from the entry rather than from its field.
+		if f.Name == "_BOOT_ID" {
+			continue
+		}
+
+		var value any = string(f.Value)
+		if !utf8.Valid(f.Value) {
+			value = append([]byte(nil), f.Value...)
+		}
+
+		switch existing := body[f.Name].(type) {
+		case nil:
+			body[f.Name] = value
+		case []any:
+			body[f.Name] = append(existing, value)
+		default:
+			body[f.Name] = []any{existing, value}
+		}
+	}
+	return body
+}
+
+// discover opens the journal files matching the configured patterns that are not open yet.
+// If skipExisting is set, the entries already written to newly opened files are skipped.
+func (operator *NativeInput) discover(skipExisting bool) {
+	seen := map[string]struct{}{}
+	for _, pattern := range operator.patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			operator.Warnw("Invalid journal file pattern", zap.String("pattern", pattern), zap.Error(err))
+			continue
+		}
+		for _, path := range matches {
+			seen[path] = struct{}{}
+			if _, ok := operator.paths[path]; ok {
+				continue
+			}
+			operator.open(path, skipExisting)
+		}
+	}
+
+	// Forget the paths that disappeared, and close the files that are no longer found under any path.
+	for path, id := range operator.paths {
+		if _, ok := seen[path]; ok {
+			continue
+		}
+		delete(operator.paths, path)
+		if jf, ok := operator.files[id]; ok && jf.path == path {
+			operator.closeFile(jf)
+		}
+	}
+}
+
+func (operator *NativeInput) open(path string, skipExisting bool) {
+	file, err := journal.Open(path)
+	if err != nil {
+		// Files that were just created may not have a header yet, so they are retried on the next poll.
+		operator.Debugw("Failed to open journal file", zap.String("path", path), zap.Error(err))
+		return
+	}
+
+	id := file.Header().FileID
+	operator.paths[path] = id
+	if jf, ok := operator.files[id]; ok {
+		// The file was renamed, typically when journald archived it.
+		_ = file.Close()
+		jf.path = path
+		return
+	}
+
+	jf := &journalFile{path: path, file: file}
+	switch {
+	case operator.cursor != nil:
+		jf.skip = cursorSkip(*operator.cursor, file.Header().SeqnumID)
+	case skipExisting:
+		for {
+			if _, err := file.Next(); err != nil {
+				break
+			}
+		}
+	}
+	operator.files[id] = jf
+}
+
+// closeFile closes the file and forgets its paths, so that it is opened again by the next discovery
+// if it is still found, e.g. after a read error.
+func (operator *NativeInput) closeFile(jf *journalFile) {
+	id := jf.file.Header().FileID
+	_ = jf.file.Close()
+	delete(operator.files, id)
+	for path, pathID := range operator.paths {
+		if pathID == id {
+			delete(operator.paths, path)
+		}
+	}
+}
+
+// cursorSkip returns a function reporting whether an entry is at or before the cursor. Sequence
+// numbers are compared when the file shares the sequence number ID of the cursor, timestamps otherwise.
+func cursorSkip(cursor journal.Cursor, seqnumID journal.ID) func(*journal.Entry) bool {
+	if cursor.SeqnumID == seqnumID {
+		return func(e *journal.Entry) bool {
+			return e.Seqnum <= cursor.Seqnum
+		}
+	}
+	return func(e *journal.Entry) bool {
+		return e.Realtime <= cursor.Realtime
+	}
+}
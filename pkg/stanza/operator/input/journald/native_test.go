@@ -0,0 +1,402 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+// +build linux
+
+package journald
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald/internal/journal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newNativeInput(t *testing.T, configure func(cfg *Config)) (operator.Operator, *testutil.FakeOutput) {
+	cfg := NewConfigWithID("my_journald_input")
+	cfg.Mode = modeNative
+	cfg.StartAt = "beginning"
+	cfg.PollInterval = 10 * time.Millisecond
+	cfg.Files = []string{filepath.Join("testdata", "compact.journal")}
+	cfg.OutputIDs = []string{"fake"}
+	configure(cfg)
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	return op, fake
+}
+
+func receiveMessages(t *testing.T, fake *testutil.FakeOutput, n int) []string {
+	messages := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		select {
+		case e := <-fake.Received:
+			messages = append(messages, e.Body.(map[string]any)["MESSAGE"].(string))
+		case <-time.After(time.Second):
+			require.FailNow(t, "Timed out waiting for entry to be read", "received %v", messages)
+		}
+	}
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+	return messages
+}
+
+func TestNativeInputJournald(t *testing.T) {
+	op, fake := newNativeInput(t, func(cfg *Config) {})
+	require.NoError(t, op.Start(testutil.NewUnscopedMockPersister()))
+	defer func() {
+		require.NoError(t, op.Stop())
+	}()
+
+	var e *entry.Entry
+	for i := 0; i < 3; i++ {
+		select {
+		case e = <-fake.Received:
+		case <-time.After(time.Second):
+			require.FailNow(t, "Timed out waiting for entry to be read")
+		}
+	}
+
+	expected := map[string]any{
+		"_CAP_EFFECTIVE":        "1fffeffffff",
+		"_MACHINE_ID":           "fed6b2924c424cf1b9a322f606b4de6d",
+		"MESSAGE":               "hello 1",
+		"_EXE":                  "/usr/bin/cat",
+		"PRIORITY":              "6",
+		"_COMM":                 "cat",
+		"_UID":                  "0",
+		"__CURSOR":              "s=827595023bdd49208a9f16743514754c;i=3;b=ccdb0e13c60a4769859b98673a971748;m=3d305f95;t=65e32e7ea9323;x=a1053fb331117ad2",
+		"_TRANSPORT":            "stdout",
+		"_GID":                  "0",
+		"_RUNTIME_SCOPE":        "system",
+		"__MONOTONIC_TIMESTAMP": "1026580373",
+		"_SELINUX_CONTEXT":      "kernel",
+		"_BOOT_ID":              "ccdb0e13c60a4769859b98673a971748",
+		"SYSLOG_IDENTIFIER":     "fixture",
+		"_PID":                  "13932",
+		"_STREAM_ID":            "22ae5ee67db4423c8222d30c5d31b4bb",
+		"_CMDLINE":              "/bin/cat",
+		"_HOSTNAME":             "vm",
+	}
+	assert.Equal(t, expected, e.Body)
+	assert.Equal(t, time.UnixMicro(1792422592549667), e.Timestamp)
+}
+
+func TestNativeInputFilters(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Config   func(cfg *Config)
+		Expected []string
+	}{
+		{
+			Name:   "default priority",
+			Config: func(cfg *Config) {},
+			Expected: []string{
+				"Journal started", "Runtime Journal (/run/log/journal/fed6b2924c424cf1b9a322f606b4de6d) is 512.0K, max 4.0G, 3.9G free.",
+				"hello 1", "hello 2", "hello 3", "an error", "large", "Journal stopped",
+			},
+		},
+		{
+			Name: "identifiers",
+			Config: func(cfg *Config) {
+				cfg.Identifiers = []string{"fixture"}
+				cfg.Priority = "debug"
+			},
+			Expected: []string{"hello 1", "hello 2", "hello 3", "an error", "debug line"},
+		},
+		{
+			Name: "priority range",
+			Config: func(cfg *Config) {
+				cfg.Priority = "3..warning"
+			},
+			Expected: []string{"an error", "large"},
+		},
+		{
+			Name: "matches",
+			Config: func(cfg *Config) {
+				cfg.Matches = []MatchConfig{
+					{"_PID": "13934"},
+					{"SYSLOG_IDENTIFIER": "fixture", "PRIORITY": "3"},
+				}
+			},
+			Expected: []string{"hello 2", "an error"},
+		},
+		{
+			Name: "grep",
+			Config: func(cfg *Config) {
+				cfg.Grep = "^HELLO [12]"
+			},
+			Expected: []string{},
+		},
+		{
+			Name: "grep smart case",
+			Config: func(cfg *Config) {
+				cfg.Grep = "^hello [12]"
+			},
+			Expected: []string{"hello 1", "hello 2"},
+		},
+		{
+			Name: "units",
+			Config: func(cfg *Config) {
+				cfg.Units = []string{"ssh"}
+			},
+			Expected: []string{},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.Name, func(t *testing.T) {
+			op, fake := newNativeInput(t, tt.Config)
+			require.NoError(t, op.Start(testutil.NewUnscopedMockPersister()))
+			defer func() {
+				require.NoError(t, op.Stop())
+			}()
+
+			messages := receiveMessages(t, fake, len(tt.Expected))
+			for i, message := range messages {
+				if len(message) == 2000 {
+					messages[i] = "large"
+				}
+			}
+			assert.Equal(t, tt.Expected, messages)
+		})
+	}
+}
+
+func TestNativeInputMultipleFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"compact.journal", "regular.journal"} {
+		content, err := os.ReadFile(filepath.Join("testdata", name))
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "machine"), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "machine", name), content, 0600))
+	}
+
+	op, fake := newNativeInput(t, func(cfg *Config) {
+		cfg.Files = nil
+		cfg.Directory = &dir
+		cfg.Identifiers = []string{"fixture"}
+	})
+	require.NoError(t, op.Start(testutil.NewUnscopedMockPersister()))
+	defer func() {
+		require.NoError(t, op.Stop())
+	}()
+
+	// Entries are ordered by time across files.
+	assert.Equal(t, []string{"hello 1", "hello 2", "hello 3", "an error", "hello regular"}, receiveMessages(t, fake, 5))
+}
+
+func TestNativeInputFollow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "system.journal")
+	content, err := os.ReadFile(filepath.Join("testdata", "compact.journal"))
+	require.NoError(t, err)
+
+	// Pretend only some entries were committed so far, as journald updates
+	// the number of entries in the header after writing an entry.
+	setEntries := func(n uint64) {
+		binary.LittleEndian.PutUint64(content[152:160], n)
+		require.NoError(t, os.WriteFile(path, content, 0600))
+	}
+	setEntries(4)
+
+	op, fake := newNativeInput(t, func(cfg *Config) {
+		cfg.Files = []string{path}
+		cfg.StartAt = "end"
+		cfg.Identifiers = []string{"fixture"}
+	})
+	require.NoError(t, op.Start(testutil.NewUnscopedMockPersister()))
+	defer func() {
+		require.NoError(t, op.Stop())
+	}()
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	setEntries(6)
+	assert.Equal(t, []string{"hello 3", "an error"}, receiveMessages(t, fake, 2))
+}
+
+func TestNativeInputCursor(t *testing.T) {
+	persister := testutil.NewUnscopedMockPersister()
+
+	op, fake := newNativeInput(t, func(cfg *Config) {
+		cfg.Identifiers = []string{"fixture"}
+	})
+	require.NoError(t, persister.Set(context.Background(), lastReadCursorKey,
+		[]byte("s=827595023bdd49208a9f16743514754c;i=4;b=ccdb0e13c60a4769859b98673a971748;m=3d307cff;t=65e32e7ea9aef;x=0")))
+	require.NoError(t, op.Start(persister))
+	assert.Equal(t, []string{"hello 3", "an error"}, receiveMessages(t, fake, 2))
+	require.NoError(t, op.Stop())
+
+	cursor, err := persister.Get(context.Background(), lastReadCursorKey)
+	require.NoError(t, err)
+	// The cursor is the last entry of the file, which does not match the filter.
+	assert.Contains(t, string(cursor), ";i=9;")
+
+	// A cursor of another sequence number ID is compared by time.
+	op, fake = newNativeInput(t, func(cfg *Config) {
+		cfg.Identifiers = []string{"fixture"}
+	})
+	require.NoError(t, persister.Set(context.Background(), lastReadCursorKey,
+		[]byte("s=00000000000000000000000000000000;i=1;t=65e32e7ea9aef")))
+	require.NoError(t, op.Start(persister))
+	assert.Equal(t, []string{"hello 3", "an error"}, receiveMessages(t, fake, 2))
+	require.NoError(t, op.Stop())
+}
+
+func TestNativeInputRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "system.journal")
+	content, err := os.ReadFile(filepath.Join("testdata", "compact.journal"))
+	require.NoError(t, err)
+	setEntries := func(n uint64) {
+		binary.LittleEndian.PutUint64(content[152:160], n)
+		require.NoError(t, os.WriteFile(path, content, 0600))
+	}
+	setEntries(4)
+
+	persister := testutil.NewUnscopedMockPersister()
+	newOp := func() (operator.Operator, *testutil.FakeOutput) {
+		return newNativeInput(t, func(cfg *Config) {
+			cfg.Files = []string{path}
+			cfg.Identifiers = []string{"fixture"}
+		})
+	}
+
+	op, fake := newOp()
+	require.NoError(t, op.Start(persister))
+	assert.Equal(t, []string{"hello 1", "hello 2"}, receiveMessages(t, fake, 2))
+	require.NoError(t, op.Stop())
+
+	// The entries read before the restart are not emitted again.
+	setEntries(6)
+	op, fake = newOp()
+	require.NoError(t, op.Start(persister))
+	assert.Equal(t, []string{"hello 3", "an error"}, receiveMessages(t, fake, 2))
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+	require.NoError(t, op.Stop())
+}
+
+func TestNativeInputReopenAfterError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "system.journal")
+	content, err := os.ReadFile(filepath.Join("testdata", "compact.journal"))
+	require.NoError(t, err)
+	setEntries := func(n uint64, size int) {
+		binary.LittleEndian.PutUint64(content[152:160], n)
+		require.NoError(t, os.WriteFile(path, content[:size], 0600))
+	}
+	setEntries(4, len(content))
+
+	op, fake := newNativeInput(t, func(cfg *Config) {
+		cfg.Files = []string{path}
+		cfg.Identifiers = []string{"fixture"}
+	})
+	require.NoError(t, op.Start(testutil.NewUnscopedMockPersister()))
+	defer func() {
+		require.NoError(t, op.Stop())
+	}()
+	assert.Equal(t, []string{"hello 1", "hello 2"}, receiveMessages(t, fake, 2))
+
+	// The fifth entry object is cut by the end of the truncated file, which fails to be read and is closed.
+	setEntries(6, 46100)
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	// The file is opened again once it can be read, and the entries read before are skipped.
+	setEntries(6, len(content))
+	assert.Equal(t, []string{"hello 3", "an error"}, receiveMessages(t, fake, 2))
+}
+
+func TestNativeInputStartAtEnd(t *testing.T) {
+	op, fake := newNativeInput(t, func(cfg *Config) {
+		cfg.StartAt = "end"
+	})
+	require.NoError(t, op.Start(testutil.NewUnscopedMockPersister()))
+	defer func() {
+		require.NoError(t, op.Stop())
+	}()
+	fake.ExpectNoEntry(t, 200*time.Millisecond)
+}
+
+func TestBuildNativeConfig(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Config        func(cfg *Config)
+		ExpectedError string
+	}{
+		{
+			Name:          "invalid mode",
+			Config:        func(cfg *Config) { cfg.Mode = "libsystemd" },
+			ExpectedError: "invalid value 'libsystemd' for parameter 'mode'",
+		},
+		{
+			Name:          "invalid start_at",
+			Config:        func(cfg *Config) { cfg.StartAt = "middle" },
+			ExpectedError: "invalid value 'middle' for parameter 'start_at'",
+		},
+		{
+			Name:          "invalid match",
+			Config:        func(cfg *Config) { cfg.Matches = []MatchConfig{{"-SYSTEMD_UNIT": "dbus.service"}} },
+			ExpectedError: "'-SYSTEMD_UNIT' is not a valid Systemd field name",
+		},
+		{
+			Name:          "invalid priority",
+			Config:        func(cfg *Config) { cfg.Priority = "loud" },
+			ExpectedError: "invalid value 'loud' for parameter 'priority'",
+		},
+		{
+			Name:          "invalid grep",
+			Config:        func(cfg *Config) { cfg.Grep = "(" },
+			ExpectedError: "invalid value '(' for parameter 'grep'",
+		},
+		{
+			Name:          "invalid poll_interval",
+			Config:        func(cfg *Config) { cfg.PollInterval = 0 },
+			ExpectedError: "invalid value '0s' for parameter 'poll_interval'",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.Name, func(t *testing.T) {
+			cfg := NewConfigWithID("my_journald_input")
+			cfg.Mode = modeNative
+			tt.Config(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.ErrorContains(t, err, tt.ExpectedError)
+		})
+	}
+}
+
+func TestMangleUnit(t *testing.T) {
+	assert.Equal(t, "ssh.service", mangleUnit("ssh"))
+	assert.Equal(t, "dbus.socket", mangleUnit("dbus.socket"))
+	assert.Equal(t, "user@*", mangleUnit("user@*"))
+}
+
+func TestJournalBody(t *testing.T) {
+	e := &journal.Entry{Monotonic: 42}
+	body := journalBody(e, "cursor", []journal.Field{
+		{Name: "MESSAGE", Value: []byte("first")},
+		{Name: "TAG", Value: []byte("a")},
+		{Name: "TAG", Value: []byte("b")},
+		{Name: "TAG", Value: []byte("c")},
+		{Name: "BINARY", Value: []byte{0xff, 0xfe}},
+		{Name: "_BOOT_ID", Value: []byte("ignored")},
+	})
+	assert.Equal(t, map[string]any{
+		"__CURSOR":              "cursor",
+		"__MONOTONIC_TIMESTAMP": "42",
+		"_BOOT_ID":              "00000000000000000000000000000000",
+		"MESSAGE":               "first",
+		"TAG":                   []any{"a", "b", "c"},
+		"BINARY":                []byte{0xff, 0xfe},
+	}, body)
+}
@@ -0,0 +1,381 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package container // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/container"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/errors"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "container"
+
+	// Supported values of the format field.
+	autoFormat       = "auto"
+	dockerFormat     = "docker"
+	crioFormat       = "crio"
+	containerdFormat = "containerd"
+
+	// Tag used by the CRI logging format to mark partial lines.
+	criPartialTag = "P"
+
+	// Attribute and resource keys set by the parser.
+	logIOStreamAttribute         = "log.iostream"
+	k8sNamespaceResource         = "k8s.namespace.name"
+	k8sPodNameResource           = "k8s.pod.name"
+	k8sPodUIDResource            = "k8s.pod.uid"
+	k8sContainerNameResource     = "k8s.container.name"
+	k8sContainerRestartsResource = "k8s.container.restart_count"
+
+	defaultForceFlushPeriod = 5 * time.Second
+	defaultMaxLogSize       = 1024 * 1024
+)
+
+var (
+	// criRegexp matches lines of the CRI logging format, which is shared by CRI-O and containerd:
+	// <timestamp> <stream> <tag> <message>
+	criRegexp = regexp.MustCompile(`^(?P<time>[^ ]+) (?P<stream>stdout|stderr) (?P<logtag>[^ ]*) ?(?P<log>.*)$`)
+	// podPathRegexp matches the file paths used by the kubelet for pod logs:
+	// /var/log/pods/<namespace>_<pod_name>_<pod_uid>/<container_name>/<restart_count>.log
+	podPathRegexp = regexp.MustCompile(`^.*/(?P<namespace>[^_/]+)_(?P<pod_name>[^_/]+)_(?P<uid>[a-f0-9\-]+)/(?P<container_name>[^._/]+)/(?P<restart_count>\d+)\.log(\.\d{8}-\d{6})?$`)
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new container parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new container parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		TransformerConfig:       helper.NewTransformerConfig(operatorID, operatorType),
+		ParseFrom:               entry.NewBodyField(),
+		Format:                  autoFormat,
+		AddMetadataFromFilePath: true,
+		ForceFlushTimeout:       defaultForceFlushPeriod,
+		MaxLogSize:              defaultMaxLogSize,
+	}
+}
+
+// Config is the configuration of a container parser operator.
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash"`
+
+	ParseFrom               entry.Field     `mapstructure:"parse_from"`
+	Format                  string          `mapstructure:"format"`
+	AddMetadataFromFilePath bool            `mapstructure:"add_metadata_from_filepath"`
+	ForceFlushTimeout       time.Duration   `mapstructure:"force_flush_period"`
+	MaxLogSize              helper.ByteSize `mapstructure:"max_log_size,omitempty"`
+}
+
+// Build will build a container parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	transformerOperator, err := c.TransformerConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.Format {
+	case autoFormat, dockerFormat, crioFormat, containerdFormat:
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'format'", c.Format)
+	}
+
+	if c.ForceFlushTimeout <= 0 {
+		return nil, fmt.Errorf("'force_flush_period' must be positive")
+	}
+
+	return &Parser{
+		TransformerOperator:     transformerOperator,
+		parseFrom:               c.ParseFrom,
+		format:                  c.Format,
+		addMetadataFromFilePath: c.AddMetadataFromFilePath,
+		forceFlushTimeout:       c.ForceFlushTimeout,
+		maxLogSize:              int(c.MaxLogSize),
+		json:                    jsoniter.ConfigFastest,
+		pending:                 make(map[string]*partialEntry),
+	}, nil
+}
+
+// Parser is an operator that parses logs written by container runtimes.
+type Parser struct {
+	helper.TransformerOperator
+	parseFrom               entry.Field
+	format                  string
+	addMetadataFromFilePath bool
+	forceFlushTimeout       time.Duration
+	maxLogSize              int
+	json                    jsoniter.API
+
+	mu      sync.Mutex
+	pending map[string]*partialEntry
+	ticker  *time.Ticker
+	chClose chan struct{}
+	wg      sync.WaitGroup
+}
+
+// partialEntry holds the lines of a log that was split by the container runtime until
+// the final line is received.
+type partialEntry struct {
+	base     *entry.Entry
+	builder  strings.Builder
+	received time.Time
+}
+
+// dockerLog is a single line of the docker json-file logging driver.
+type dockerLog struct {
+	Log    string `json:"log"`
+	Stream string `json:"stream"`
+	Time   string `json:"time"`
+}
+
+// Start will start the loop flushing partial logs that did not receive their final line.
+func (p *Parser) Start(_ operator.Persister) error {
+	p.ticker = time.NewTicker(p.forceFlushTimeout)
+	p.chClose = make(chan struct{})
+	p.wg.Add(1)
+	go p.flushLoop()
+	return nil
+}
+
+// Stop will flush all partial logs and stop the flush loop.
+func (p *Parser) Stop() error {
+	if p.chClose != nil {
+		close(p.chClose)
+		p.wg.Wait()
+		p.chClose = nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key := range p.pending {
+		p.flush(context.Background(), key)
+	}
+	return nil
+}
+
+func (p *Parser) flushLoop() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.ticker.C:
+			p.mu.Lock()
+			now := time.Now()
+			for key, partial := range p.pending {
+				if now.Sub(partial.received) >= p.forceFlushTimeout {
+					p.flush(context.Background(), key)
+				}
+			}
+			p.mu.Unlock()
+		case <-p.chClose:
+			p.ticker.Stop()
+			return
+		}
+	}
+}
+
+// Process will parse an entry written by a container runtime.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	skip, err := p.Skip(ctx, entry)
+	if err != nil {
+		return p.HandleEntryError(ctx, entry, err)
+	}
+	if skip {
+		p.Write(ctx, entry)
+		return nil
+	}
+
+	value, ok := entry.Get(p.parseFrom)
+	if !ok {
+		err = errors.NewError(
+			"Entry is missing the expected parse_from field.",
+			"Ensure that all incoming entries contain the parse_from field.",
+			"parse_from", p.parseFrom.String(),
+		)
+		return p.HandleEntryError(ctx, entry, err)
+	}
+
+	var raw string
+	switch v := value.(type) {
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return p.HandleEntryError(ctx, entry, fmt.Errorf("type '%T' cannot be parsed as container log", value))
+	}
+
+	format := p.format
+	if format == autoFormat {
+		format = detectFormat(raw)
+	}
+
+	var partial bool
+	switch format {
+	case dockerFormat:
+		partial, err = p.parseDocker(entry, raw)
+	default:
+		partial, err = p.parseCRI(entry, raw)
+	}
+	if err != nil {
+		return p.HandleEntryError(ctx, entry, err)
+	}
+
+	if p.addMetadataFromFilePath {
+		if err = p.parseFilePath(entry); err != nil {
+			return p.HandleEntryError(ctx, entry, err)
+		}
+	}
+
+	p.recombine(ctx, entry, partial)
+	return nil
+}
+
+// detectFormat determines the format of the raw line. Lines of the docker json-file
+// logging driver are JSON objects, everything else is assumed to follow the CRI format.
+func detectFormat(raw string) string {
+	if strings.HasPrefix(raw, "{") {
+		return dockerFormat
+	}
+	return crioFormat
+}
+
+// parseDocker parses a line of the docker json-file logging driver into the entry and
+// reports whether the line is only part of a log. Docker splits logs longer than 16KiB
+// into several lines, where only the last one is terminated by a newline.
+func (p *Parser) parseDocker(e *entry.Entry, raw string) (bool, error) {
+	var parsed dockerLog
+	if err := p.json.UnmarshalFromString(raw, &parsed); err != nil {
+		return false, errors.Wrap(err, "parse docker log")
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, parsed.Time)
+	if err != nil {
+		return false, errors.Wrap(err, "parse docker log time")
+	}
+
+	partial := true
+	message := parsed.Log
+	for _, terminator := range []string{"\r\n", "\n"} {
+		if strings.HasSuffix(message, terminator) {
+			message = strings.TrimSuffix(message, terminator)
+			partial = false
+			break
+		}
+	}
+
+	e.Timestamp = ts
+	e.Body = message
+	if parsed.Stream != "" {
+		e.AddAttribute(logIOStreamAttribute, parsed.Stream)
+	}
+	return partial, nil
+}
+
+// parseCRI parses a line of the CRI logging format into the entry and reports whether
+// the line is only part of a log.
+func (p *Parser) parseCRI(e *entry.Entry, raw string) (bool, error) {
+	matches := criRegexp.FindStringSubmatch(raw)
+	if matches == nil {
+		return false, fmt.Errorf("container log does not match the CRI format")
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, matches[criRegexp.SubexpIndex("time")])
+	if err != nil {
+		return false, errors.Wrap(err, "parse CRI log time")
+	}
+
+	e.Timestamp = ts
+	e.Body = matches[criRegexp.SubexpIndex("log")]
+	e.AddAttribute(logIOStreamAttribute, matches[criRegexp.SubexpIndex("stream")])
+	return matches[criRegexp.SubexpIndex("logtag")] == criPartialTag, nil
+}
+
+// parseFilePath sets the k8s resource attributes from the pod log file path, if available.
+// Entries without the file path attribute, e.g. when include_file_path is not enabled on
+// the input operator, are left unchanged.
+func (p *Parser) parseFilePath(e *entry.Entry) error {
+	var path string
+	if err := e.Read(entry.NewAttributeField(attrs.LogFilePath), &path); err != nil || path == "" {
+		return nil
+	}
+
+	matches := podPathRegexp.FindStringSubmatch(path)
+	if matches == nil {
+		return fmt.Errorf("file path '%s' does not match the expected pod log path format", path)
+	}
+
+	if e.Resource == nil {
+		e.Resource = map[string]any{}
+	}
+	e.Resource[k8sNamespaceResource] = matches[podPathRegexp.SubexpIndex("namespace")]
+	e.Resource[k8sPodNameResource] = matches[podPathRegexp.SubexpIndex("pod_name")]
+	e.Resource[k8sPodUIDResource] = matches[podPathRegexp.SubexpIndex("uid")]
+	e.Resource[k8sContainerNameResource] = matches[podPathRegexp.SubexpIndex("container_name")]
+	e.Resource[k8sContainerRestartsResource] = matches[podPathRegexp.SubexpIndex("restart_count")]
+	return nil
+}
+
+// recombine buffers partial lines per source file and stream, and writes the combined
+// log once the final line has been received.
+func (p *Parser) recombine(ctx context.Context, e *entry.Entry, partial bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := sourceKey(e)
+	pending, ok := p.pending[key]
+	if !ok {
+		if !partial {
+			p.Write(ctx, e)
+			return
+		}
+		pending = &partialEntry{base: e, received: time.Now()}
+		p.pending[key] = pending
+	}
+
+	body, _ := e.Body.(string)
+	pending.builder.WriteString(body)
+
+	if !partial || (p.maxLogSize > 0 && pending.builder.Len() >= p.maxLogSize) {
+		p.flush(ctx, key)
+	}
+}
+
+// flush writes the combined log of the given source. The caller must hold the lock.
+func (p *Parser) flush(ctx context.Context, key string) {
+	pending, ok := p.pending[key]
+	if !ok {
+		return
+	}
+	delete(p.pending, key)
+
+	pending.base.Body = pending.builder.String()
+	p.Write(ctx, pending.base)
+}
+
+// sourceKey identifies the source of an entry, so lines of different files and streams
+// are never combined together.
+func sourceKey(e *entry.Entry) string {
+	var path, stream string
+	_ = e.Read(entry.NewAttributeField(attrs.LogFilePath), &path)
+	_ = e.Read(entry.NewAttributeField(logIOStreamAttribute), &stream)
+	return path + "\x00" + stream
+}
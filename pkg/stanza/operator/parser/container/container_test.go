@@ -0,0 +1,338 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const podLogPath = "/var/log/pods/some_kube-scheduler-kind-control-plane_49cc7c1fd3702c40b2686ea7486091d3/kube-scheduler44/1.log"
+
+func newTestParser(t *testing.T, cfg *Config) (*Parser, *testutil.FakeOutput) {
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(testutil.NewMockPersister("test")))
+	t.Cleanup(func() { require.NoError(t, op.Stop()) })
+	return op.(*Parser), fake
+}
+
+func newTestEntry(body string) *entry.Entry {
+	e := entry.New()
+	e.Body = body
+	e.Attributes = map[string]any{"log.file.path": podLogPath}
+	return e
+}
+
+func expectedResource() map[string]any {
+	return map[string]any{
+		"k8s.namespace.name":          "some",
+		"k8s.pod.name":                "kube-scheduler-kind-control-plane",
+		"k8s.pod.uid":                 "49cc7c1fd3702c40b2686ea7486091d3",
+		"k8s.container.name":          "kube-scheduler44",
+		"k8s.container.restart_count": "1",
+	}
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("container")
+	require.True(t, ok, "expected container to be registered")
+	require.Equal(t, "container", builder().Type())
+}
+
+func TestConfigBuild(t *testing.T) {
+	config := NewConfigWithID("test")
+	op, err := config.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	cases := []struct {
+		name      string
+		modify    func(*Config)
+		expectErr string
+	}{
+		{
+			"invalid_on_error",
+			func(cfg *Config) { cfg.OnError = "invalid_on_error" },
+			"invalid `on_error` field",
+		},
+		{
+			"invalid_format",
+			func(cfg *Config) { cfg.Format = "invalid" },
+			"invalid value 'invalid' for parameter 'format'",
+		},
+		{
+			"invalid_force_flush_period",
+			func(cfg *Config) { cfg.ForceFlushTimeout = 0 },
+			"'force_flush_period' must be positive",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := NewConfigWithID("test")
+			tc.modify(config)
+			_, err := config.Build(testutil.Logger(t))
+			require.ErrorContains(t, err, tc.expectErr)
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	cases := []struct {
+		name     string
+		format   string
+		input    string
+		expected *entry.Entry
+	}{
+		{
+			"docker",
+			"docker",
+			`{"log":"INFO: log line here\n","stream":"stdout","time":"2029-03-30T08:31:20.545192187Z"}`,
+			&entry.Entry{
+				Timestamp: time.Date(2029, time.March, 30, 8, 31, 20, 545192187, time.UTC),
+				Body:      "INFO: log line here",
+				Attributes: map[string]any{
+					"log.iostream":  "stdout",
+					"log.file.path": podLogPath,
+				},
+				Resource: expectedResource(),
+			},
+		},
+		{
+			"docker_auto",
+			"auto",
+			`{"log":"INFO: log line here\r\n","stream":"stderr","time":"2029-03-30T08:31:20.545192187Z"}`,
+			&entry.Entry{
+				Timestamp: time.Date(2029, time.March, 30, 8, 31, 20, 545192187, time.UTC),
+				Body:      "INFO: log line here",
+				Attributes: map[string]any{
+					"log.iostream":  "stderr",
+					"log.file.path": podLogPath,
+				},
+				Resource: expectedResource(),
+			},
+		},
+		{
+			"crio",
+			"crio",
+			"2024-04-13T07:59:37.505201169-10:00 stdout F standalone crio line which is awesome",
+			&entry.Entry{
+				Timestamp: time.Date(2024, time.April, 13, 7, 59, 37, 505201169, time.FixedZone("", -10*60*60)),
+				Body:      "standalone crio line which is awesome",
+				Attributes: map[string]any{
+					"log.iostream":  "stdout",
+					"log.file.path": podLogPath,
+				},
+				Resource: expectedResource(),
+			},
+		},
+		{
+			"containerd_auto",
+			"auto",
+			"2023-06-22T10:27:25.813799277Z stderr F standalone containerd line that is super awesome",
+			&entry.Entry{
+				Timestamp: time.Date(2023, time.June, 22, 10, 27, 25, 813799277, time.UTC),
+				Body:      "standalone containerd line that is super awesome",
+				Attributes: map[string]any{
+					"log.iostream":  "stderr",
+					"log.file.path": podLogPath,
+				},
+				Resource: expectedResource(),
+			},
+		},
+		{
+			"containerd_empty_line",
+			"containerd",
+			"2023-06-22T10:27:25.813799277Z stdout F",
+			&entry.Entry{
+				Timestamp: time.Date(2023, time.June, 22, 10, 27, 25, 813799277, time.UTC),
+				Body:      "",
+				Attributes: map[string]any{
+					"log.iostream":  "stdout",
+					"log.file.path": podLogPath,
+				},
+				Resource: expectedResource(),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.Format = tc.format
+			op, fake := newTestParser(t, cfg)
+
+			e := newTestEntry(tc.input)
+			require.NoError(t, op.Process(context.Background(), e))
+
+			select {
+			case got := <-fake.Received:
+				require.Equal(t, tc.expected.Timestamp.UnixNano(), got.Timestamp.UnixNano())
+				require.Equal(t, tc.expected.Body, got.Body)
+				require.Equal(t, tc.expected.Attributes, got.Attributes)
+				require.Equal(t, tc.expected.Resource, got.Resource)
+			case <-time.After(time.Second):
+				require.FailNow(t, "Timed out waiting for entry")
+			}
+		})
+	}
+}
+
+func TestProcessInvalid(t *testing.T) {
+	cases := []struct {
+		name   string
+		format string
+		input  any
+	}{
+		{"docker_invalid_json", "docker", `{"log":`},
+		{"docker_invalid_time", "docker", `{"log":"line\n","stream":"stdout","time":"invalid"}`},
+		{"cri_no_match", "crio", "not a container log"},
+		{"cri_invalid_time", "containerd", "invalid stdout F line"},
+		{"unsupported_type", "auto", 123},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.Format = tc.format
+			cfg.OnError = "drop"
+			op, fake := newTestParser(t, cfg)
+
+			e := entry.New()
+			e.Body = tc.input
+			e.Attributes = map[string]any{"log.file.path": podLogPath}
+			require.Error(t, op.Process(context.Background(), e))
+			fake.ExpectNoEntry(t, 100*time.Millisecond)
+		})
+	}
+}
+
+func TestProcessWithoutFilePathMetadata(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.AddMetadataFromFilePath = false
+	op, fake := newTestParser(t, cfg)
+
+	e := entry.New()
+	e.Body = "2023-06-22T10:27:25.813799277Z stdout F no file path"
+	require.NoError(t, op.Process(context.Background(), e))
+
+	got := <-fake.Received
+	require.Equal(t, "no file path", got.Body)
+	require.Empty(t, got.Resource)
+}
+
+func TestProcessMissingFilePath(t *testing.T) {
+	op, fake := newTestParser(t, NewConfigWithID("test"))
+
+	e := entry.New()
+	e.Body = "2023-06-22T10:27:25.813799277Z stdout F no file path"
+	require.NoError(t, op.Process(context.Background(), e))
+
+	got := <-fake.Received
+	require.Equal(t, "no file path", got.Body)
+	require.Empty(t, got.Resource)
+}
+
+func TestProcessInvalidFilePath(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	op, _ := newTestParser(t, cfg)
+
+	e := entry.New()
+	e.Body = "2023-06-22T10:27:25.813799277Z stdout F line"
+	e.Attributes = map[string]any{"log.file.path": "/var/log/syslog"}
+	require.ErrorContains(t, op.Process(context.Background(), e), "does not match the expected pod log path format")
+}
+
+func TestRecombineCRI(t *testing.T) {
+	op, fake := newTestParser(t, NewConfigWithID("test"))
+	ctx := context.Background()
+
+	lines := []string{
+		"2023-06-22T10:27:25.813799277Z stdout P multiline containerd line that i",
+		"2023-06-22T10:27:25.813799278Z stderr F interleaved stderr line",
+		"2023-06-22T10:27:25.813799279Z stdout P s super awesomne",
+		"2023-06-22T10:27:25.813799280Z stdout F  and continues",
+	}
+	for _, line := range lines {
+		require.NoError(t, op.Process(ctx, newTestEntry(line)))
+	}
+
+	got := <-fake.Received
+	require.Equal(t, "interleaved stderr line", got.Body)
+	require.Equal(t, "stderr", got.Attributes["log.iostream"])
+
+	got = <-fake.Received
+	require.Equal(t, "multiline containerd line that is super awesomne and continues", got.Body)
+	require.Equal(t, "stdout", got.Attributes["log.iostream"])
+	require.Equal(t, time.Date(2023, time.June, 22, 10, 27, 25, 813799277, time.UTC), got.Timestamp.UTC())
+	require.Equal(t, expectedResource(), got.Resource)
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestRecombineDocker(t *testing.T) {
+	op, fake := newTestParser(t, NewConfigWithID("test"))
+	ctx := context.Background()
+
+	lines := []string{
+		`{"log":"partial docker ","stream":"stdout","time":"2029-03-30T08:31:20.545192187Z"}`,
+		`{"log":"line\n","stream":"stdout","time":"2029-03-30T08:31:20.545192188Z"}`,
+	}
+	for _, line := range lines {
+		require.NoError(t, op.Process(ctx, newTestEntry(line)))
+	}
+
+	fake.ExpectBody(t, "partial docker line")
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestRecombineMaxLogSize(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.MaxLogSize = 10
+	op, fake := newTestParser(t, cfg)
+	ctx := context.Background()
+
+	require.NoError(t, op.Process(ctx, newTestEntry("2023-06-22T10:27:25.813799277Z stdout P 0123456789")))
+	fake.ExpectBody(t, "0123456789")
+
+	require.NoError(t, op.Process(ctx, newTestEntry("2023-06-22T10:27:25.813799278Z stdout F end")))
+	fake.ExpectBody(t, "end")
+}
+
+func TestRecombineForceFlush(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.ForceFlushTimeout = 50 * time.Millisecond
+	op, fake := newTestParser(t, cfg)
+
+	require.NoError(t, op.Process(context.Background(), newTestEntry("2023-06-22T10:27:25.813799277Z stdout P never finished")))
+	fake.ExpectBody(t, "never finished")
+}
+
+func TestRecombineFlushOnStop(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(testutil.NewMockPersister("test")))
+
+	require.NoError(t, op.Process(context.Background(), newTestEntry("2023-06-22T10:27:25.813799277Z stdout P pending")))
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	require.NoError(t, op.Stop())
+	fake.ExpectBody(t, "pending")
+}
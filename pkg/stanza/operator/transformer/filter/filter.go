@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"math/big"
 
-	"github.com/antonmedv/expr/vm"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
@@ -55,7 +54,7 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, err
 	}
 
-	compiledExpression, err := helper.ExprCompileBool(c.Expression)
+	compiledExpression, err := helper.CompileCondition(c.ConditionLanguage, c.Expression, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile expression '%s': %w", c.Expression, err)
 	}
@@ -74,27 +73,18 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 // Transformer is an operator that filters entries based on matching expressions
 type Transformer struct {
 	helper.TransformerOperator
-	expression *vm.Program
+	expression helper.Condition
 	dropCutoff *big.Int // [0..1000)
 }
 
 // Process will drop incoming entries that match the filter expression
 func (f *Transformer) Process(ctx context.Context, entry *entry.Entry) error {
-	env := helper.GetExprEnv(entry)
-	defer helper.PutExprEnv(env)
-
-	matches, err := vm.Run(f.expression, env)
+	filtered, err := f.expression.Match(ctx, entry)
 	if err != nil {
 		f.Errorf("Running expressing returned an error", zap.Error(err))
 		return nil
 	}
 
-	filtered, ok := matches.(bool)
-	if !ok {
-		f.Errorf("Expression did not compile as a boolean")
-		return nil
-	}
-
 	if !filtered {
 		f.Write(ctx, entry)
 		return nil
@@ -8,12 +8,14 @@ import (
 	"io"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
 
@@ -224,3 +226,30 @@ func TestFilterDropRatio(t *testing.T) {
 
 	require.Equal(t, 10, processedEntries)
 }
+
+func TestTransformerOTTL(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.ConditionLanguage = helper.OTTLConditionLanguage
+	cfg.Expression = `body["message"] == "test_message" and attributes["key"] == "value"`
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	filterOperator := op.(*Transformer)
+	filterOperator.OutputOperators = []operator.Operator{fake}
+
+	dropped := &entry.Entry{
+		Body:       map[string]any{"message": "test_message"},
+		Attributes: map[string]any{"key": "value"},
+	}
+	require.NoError(t, filterOperator.Process(context.Background(), dropped))
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	kept := &entry.Entry{
+		Body:       map[string]any{"message": "test_message"},
+		Attributes: map[string]any{"key": "other"},
+	}
+	require.NoError(t, filterOperator.Process(context.Background(), kept))
+	fake.ExpectEntry(t, kept)
+}
@@ -10,8 +10,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/antonmedv/expr"
-	"github.com/antonmedv/expr/vm"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
@@ -77,16 +75,16 @@ func (c *Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 	}
 
 	var matchesFirst bool
-	var prog *vm.Program
+	var condition helper.Condition
 	if c.IsFirstEntry != "" {
 		matchesFirst = true
-		prog, err = helper.ExprCompileBool(c.IsFirstEntry)
+		condition, err = helper.CompileCondition(c.ConditionLanguage, c.IsFirstEntry, logger)
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile is_first_entry: %w", err)
 		}
 	} else {
 		matchesFirst = false
-		prog, err = helper.ExprCompileBool(c.IsLastEntry)
+		condition, err = helper.CompileCondition(c.ConditionLanguage, c.IsLastEntry, logger)
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile is_last_entry: %w", err)
 		}
@@ -109,7 +107,7 @@ func (c *Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 	return &Transformer{
 		TransformerOperator: transformer,
 		matchFirstLine:      matchesFirst,
-		prog:                prog,
+		condition:           condition,
 		maxBatchSize:        c.MaxBatchSize,
 		maxSources:          c.MaxSources,
 		overwriteWithOldest: overwriteWithOldest,
@@ -137,7 +135,7 @@ func (c *Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 type Transformer struct {
 	helper.TransformerOperator
 	matchFirstLine      bool
-	prog                *vm.Program
+	condition           helper.Condition
 	maxBatchSize        int
 	maxSources          int
 	overwriteWithOldest bool
@@ -212,20 +210,14 @@ func (r *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 	r.Lock()
 	defer r.Unlock()
 
-	// Get the environment for executing the expression.
 	// In the future, we may want to provide access to the currently
 	// batched entries so users can do comparisons to other entries
 	// rather than just use absolute rules.
-	env := helper.GetExprEnv(e)
-	defer helper.PutExprEnv(env)
-
-	m, err := expr.Run(r.prog, env)
+	matches, err := r.condition.Match(ctx, e)
 	if err != nil {
 		return r.HandleEntryError(ctx, e, err)
 	}
 
-	// this is guaranteed to be a boolean because of expr.AsBool
-	matches := m.(bool)
 	var s string
 	err = e.Read(r.sourceIdentifier, &s)
 	if err != nil {
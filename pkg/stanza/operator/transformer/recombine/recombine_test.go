@@ -112,6 +112,22 @@ func TestTransformer(t *testing.T) {
 			},
 			[]*entry.Entry{entryWithBody(t1, "test1\ntest2")},
 		},
+		{
+			"TwoEntriesLastOTTL",
+			func() *Config {
+				cfg := NewConfig()
+				cfg.CombineField = entry.NewBodyField()
+				cfg.ConditionLanguage = helper.OTTLConditionLanguage
+				cfg.IsLastEntry = `IsMatch(body, "2$")`
+				cfg.OutputIDs = []string{"fake"}
+				return cfg
+			}(),
+			[]*entry.Entry{
+				entryWithBody(t1, "test1"),
+				entryWithBody(t2, "test2"),
+			},
+			[]*entry.Entry{entryWithBody(t1, "test1\ntest2")},
+		},
 		{
 			"ThreeEntriesFirstNewest",
 			func() *Config {
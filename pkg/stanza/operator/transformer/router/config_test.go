@@ -86,6 +86,19 @@ func TestRouterGoldenConfig(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name: "routes_ottl",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ConditionLanguage = helper.OTTLConditionLanguage
+					newRoute := &RouteConfig{
+						Expression: `attributes["format"] == "json"`,
+						OutputIDs:  []string{"my_json_parser"},
+					}
+					cfg.Routes = append(cfg.Routes, newRoute)
+					return cfg
+				}(),
+			},
 		},
 	}.Run(t)
 }
@@ -38,6 +38,7 @@ type Config struct {
 	helper.BasicConfig `mapstructure:",squash"`
 	Routes             []*RouteConfig `mapstructure:"routes"`
 	Default            []string       `mapstructure:"default"`
+	ConditionLanguage  string         `mapstructure:"condition_language"`
 }
 
 // RouteConfig is the configuration of a route on a router operator
@@ -54,8 +55,9 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, err
 	}
 
+	var defaultRoute *RouteConfig
 	if c.Default != nil {
-		defaultRoute := &RouteConfig{
+		defaultRoute = &RouteConfig{
 			Expression: "true",
 			OutputIDs:  c.Default,
 		}
@@ -64,7 +66,13 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 
 	routes := make([]*Route, 0, len(c.Routes))
 	for _, routeConfig := range c.Routes {
-		compiled, err := helper.ExprCompileBool(routeConfig.Expression)
+		var compiled helper.Condition
+		if routeConfig == defaultRoute {
+			// The default route always matches, whatever the condition language is.
+			compiled, err = helper.CompileCondition(helper.ExprConditionLanguage, routeConfig.Expression, logger)
+		} else {
+			compiled, err = helper.CompileCondition(c.ConditionLanguage, routeConfig.Expression, logger)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile expression '%s': %w", routeConfig.Expression, err)
 		}
@@ -76,7 +84,8 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 
 		route := Route{
 			Attributer: attributer,
-			Expression: compiled,
+			Expression: helper.ExprProgram(compiled),
+			Condition:  compiled,
 			OutputIDs:  routeConfig.OutputIDs,
 		}
 		routes = append(routes, &route)
@@ -97,7 +106,10 @@ type Transformer struct {
 // Route is a route on a router operator
 type Route struct {
 	helper.Attributer
+	// Deprecated: [v0.90.0] Use Condition instead. Expression is only set for conditions
+	// written in expr, and is evaluated by Process if Condition is not set.
 	Expression      *vm.Program
+	Condition       helper.Condition
 	OutputIDs       []string
 	OutputOperators []operator.Operator
 }
@@ -109,18 +121,18 @@ func (p *Transformer) CanProcess() bool {
 
 // Process will route incoming entries based on matching expressions
 func (p *Transformer) Process(ctx context.Context, entry *entry.Entry) error {
-	env := helper.GetExprEnv(entry)
-	defer helper.PutExprEnv(env)
-
 	for _, route := range p.routes {
-		matches, err := vm.Run(route.Expression, env)
+		condition := route.Condition
+		if condition == nil {
+			condition = helper.NewExprCondition(route.Expression)
+		}
+		matches, err := condition.Match(ctx, entry)
 		if err != nil {
 			p.Warnw("Running expression returned an error", zap.Error(err))
 			continue
 		}
 
-		// we compile the expression with "AsBool", so this should be safe
-		if matches.(bool) {
+		if matches {
 			if err := route.Attribute(entry); err != nil {
 				p.Errorf("Failed to label entry: %s", err)
 				return err
@@ -217,3 +217,37 @@ func TestTransformer(t *testing.T) {
 		})
 	}
 }
+
+func TestTransformerOTTL(t *testing.T) {
+	cfg := NewConfigWithID("test_operator_id")
+	cfg.ConditionLanguage = helper.OTTLConditionLanguage
+	cfg.Routes = []*RouteConfig{
+		{
+			AttributerConfig: helper.NewAttributerConfig(),
+			Expression:       `attributes["format"] == "json"`,
+			OutputIDs:        []string{"output1"},
+		},
+	}
+	cfg.Default = []string{"output2"}
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	results := map[string]int{}
+	mock1 := testutil.NewMockOperator("output1")
+	mock1.On("Process", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		results["output1"]++
+	})
+	mock2 := testutil.NewMockOperator("output2")
+	mock2.On("Process", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		results["output2"]++
+	})
+	require.NoError(t, op.SetOutputs([]operator.Operator{mock1, mock2}))
+
+	json := entry.New()
+	json.Attributes = map[string]any{"format": "json"}
+	require.NoError(t, op.Process(context.Background(), json))
+	require.NoError(t, op.Process(context.Background(), entry.New()))
+
+	require.Equal(t, map[string]int{"output1": 1, "output2": 1}, results)
+}
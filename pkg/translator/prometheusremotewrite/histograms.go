@@ -14,7 +14,42 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
-const defaultZeroThreshold = 1e-128
+const (
+	defaultZeroThreshold = 1e-128
+
+	// minNativeHistogramScale and maxNativeHistogramScale are the lowest and highest
+	// scales of the native histograms supported by Prometheus.
+	minNativeHistogramScale = -4
+	maxNativeHistogramScale = 8
+)
+
+// nativeHistogramMaxScale returns the highest scale of the native histograms according to the settings.
+func nativeHistogramMaxScale(settings Settings) int32 {
+	if settings.ExponentialHistogramMaxScale == nil || *settings.ExponentialHistogramMaxScale > maxNativeHistogramScale {
+		return maxNativeHistogramScale
+	}
+	if *settings.ExponentialHistogramMaxScale < minNativeHistogramScale {
+		return minNativeHistogramScale
+	}
+	return *settings.ExponentialHistogramMaxScale
+}
+
+// addExponentialHistogramDataPoint converts pt to a native histogram, or to a classic
+// histogram when ConvertExponentialHistogramsToClassic is set.
+func addExponentialHistogramDataPoint(
+	metric pmetric.Metric,
+	name string,
+	pt pmetric.ExponentialHistogramDataPoint,
+	resource pcommon.Resource,
+	settings Settings,
+	series map[string]*prompb.TimeSeries,
+) error {
+	if settings.ConvertExponentialHistogramsToClassic {
+		addSingleHistogramDataPoint(exponentialToExplicitHistogram(pt, nativeHistogramMaxScale(settings)), resource, metric, settings, series)
+		return nil
+	}
+	return addSingleExponentialHistogramDataPoint(name, pt, resource, settings, series)
+}
 
 func addSingleExponentialHistogramDataPoint(
 	metric string,
@@ -42,7 +77,7 @@ func addSingleExponentialHistogramDataPoint(
 		series[sig] = ts
 	}
 
-	histogram, err := exponentialToNativeHistogram(pt)
+	histogram, err := exponentialToNativeHistogram(pt, nativeHistogramMaxScale(settings))
 	if err != nil {
 		return err
 	}
@@ -55,19 +90,19 @@ func addSingleExponentialHistogramDataPoint(
 }
 
 // exponentialToNativeHistogram  translates OTel Exponential Histogram data point
-// to Prometheus Native Histogram.
-func exponentialToNativeHistogram(p pmetric.ExponentialHistogramDataPoint) (prompb.Histogram, error) {
+// to Prometheus Native Histogram. The histograms with a scale higher than maxScale are downscaled.
+func exponentialToNativeHistogram(p pmetric.ExponentialHistogramDataPoint, maxScale int32) (prompb.Histogram, error) {
 	scale := p.Scale()
-	if scale < -4 {
+	if scale < minNativeHistogramScale {
 		return prompb.Histogram{},
 			fmt.Errorf("cannot convert exponential to native histogram."+
-				" Scale must be >= -4, was %d", scale)
+				" Scale must be >= %d, was %d", minNativeHistogramScale, scale)
 	}
 
 	var scaleDown int32
-	if scale > 8 {
-		scaleDown = scale - 8
-		scale = 8
+	if scale > maxScale {
+		scaleDown = scale - maxScale
+		scale = maxScale
 	}
 
 	pSpans, pDeltas := convertBucketsLayout(p.Positive(), scaleDown)
@@ -204,3 +239,84 @@ func convertBucketsLayout(buckets pmetric.ExponentialHistogramDataPointBuckets,
 
 	return spans, deltas
 }
+
+// exponentialToExplicitHistogram converts an OTel Exponential Histogram data point to
+// an OTel Histogram data point with explicit bounds, for the receivers that don't
+// support native histograms. The histograms with a scale higher than maxScale are
+// downscaled first, to bound the number of buckets.
+//
+// The negative buckets come first, from the lowest to the highest bound, then the zero
+// bucket bounded by 0, then the positive buckets. An exponential histogram bucket
+// covers (base^index, base^(index+1)] so its upper bound is base^(index+1) for the
+// positive buckets and -base^index for the negative ones.
+func exponentialToExplicitHistogram(p pmetric.ExponentialHistogramDataPoint, maxScale int32) pmetric.HistogramDataPoint {
+	pt := pmetric.NewHistogramDataPoint()
+	p.Attributes().CopyTo(pt.Attributes())
+	p.Exemplars().CopyTo(pt.Exemplars())
+	pt.SetStartTimestamp(p.StartTimestamp())
+	pt.SetTimestamp(p.Timestamp())
+	pt.SetFlags(p.Flags())
+	pt.SetCount(p.Count())
+	if p.HasSum() {
+		pt.SetSum(p.Sum())
+	}
+	if p.HasMin() {
+		pt.SetMin(p.Min())
+	}
+	if p.HasMax() {
+		pt.SetMax(p.Max())
+	}
+
+	scale := p.Scale()
+	var scaleDown int32
+	if scale > maxScale {
+		scaleDown = scale - maxScale
+		scale = maxScale
+	}
+	nOffset, nCounts := downscaleBuckets(p.Negative(), scaleDown)
+	pOffset, pCounts := downscaleBuckets(p.Positive(), scaleDown)
+
+	bounds := make([]float64, 0, len(nCounts)+len(pCounts)+1)
+	counts := make([]uint64, 0, len(nCounts)+len(pCounts)+2)
+	for i := len(nCounts) - 1; i >= 0; i-- {
+		bounds = append(bounds, -lowerBoundary(nOffset+int32(i), scale))
+		counts = append(counts, nCounts[i])
+	}
+	bounds = append(bounds, 0)
+	counts = append(counts, p.ZeroCount())
+	for i, count := range pCounts {
+		bounds = append(bounds, lowerBoundary(pOffset+int32(i)+1, scale))
+		counts = append(counts, count)
+	}
+	// All the observations are in the buckets above, the +Inf one is empty.
+	counts = append(counts, 0)
+
+	pt.ExplicitBounds().FromRaw(bounds)
+	pt.BucketCounts().FromRaw(counts)
+	return pt
+}
+
+// downscaleBuckets merges 2^scaleDown consecutive buckets into one and returns the
+// offset and counts of the merged buckets.
+func downscaleBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDown int32) (int32, []uint64) {
+	bucketCounts := buckets.BucketCounts()
+	if bucketCounts.Len() == 0 {
+		return 0, nil
+	}
+	offset := buckets.Offset() >> scaleDown
+	last := (buckets.Offset() + int32(bucketCounts.Len()) - 1) >> scaleDown
+	counts := make([]uint64, last-offset+1)
+	for i := 0; i < bucketCounts.Len(); i++ {
+		counts[(buckets.Offset()+int32(i))>>scaleDown-offset] += bucketCounts.At(i)
+	}
+	return offset, counts
+}
+
+// lowerBoundary returns the lower boundary of the exponential histogram bucket
+// with the given index, which is base^index with base = 2^(2^-scale).
+func lowerBoundary(index, scale int32) float64 {
+	if scale <= 0 {
+		return math.Ldexp(1, int(index)<<-scale)
+	}
+	return math.Exp2(math.Ldexp(float64(index), -int(scale)))
+}
@@ -553,7 +553,7 @@ func TestExponentialToNativeHistogram(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			validateExponentialHistogramCount(t, tt.exponentialHist()) // Sanity check.
-			got, err := exponentialToNativeHistogram(tt.exponentialHist())
+			got, err := exponentialToNativeHistogram(tt.exponentialHist(), maxNativeHistogramScale)
 			if tt.wantErrMessage != "" {
 				assert.ErrorContains(t, err, tt.wantErrMessage)
 				return
@@ -755,3 +755,127 @@ func TestAddSingleExponentialHistogramDataPoint(t *testing.T) {
 		})
 	}
 }
+
+func TestNativeHistogramMaxScale(t *testing.T) {
+	scale := func(s int32) *int32 { return &s }
+	assert.Equal(t, int32(8), nativeHistogramMaxScale(Settings{}))
+	assert.Equal(t, int32(3), nativeHistogramMaxScale(Settings{ExponentialHistogramMaxScale: scale(3)}))
+	assert.Equal(t, int32(0), nativeHistogramMaxScale(Settings{ExponentialHistogramMaxScale: scale(0)}))
+	assert.Equal(t, int32(8), nativeHistogramMaxScale(Settings{ExponentialHistogramMaxScale: scale(12)}))
+	assert.Equal(t, int32(-4), nativeHistogramMaxScale(Settings{ExponentialHistogramMaxScale: scale(-10)}))
+}
+
+func TestExponentialToNativeHistogramMaxScale(t *testing.T) {
+	pt := pmetric.NewExponentialHistogramDataPoint()
+	pt.SetCount(10)
+	pt.SetScale(3)
+	pt.Positive().SetOffset(0)
+	pt.Positive().BucketCounts().FromRaw([]uint64{1, 2, 3, 4})
+
+	got, err := exponentialToNativeHistogram(pt, 1)
+	require.NoError(t, err)
+	// The 4 buckets are merged into the bucket of index 0 at scale 1.
+	assert.Equal(t, int32(1), got.Schema)
+	assert.Equal(t, []prompb.BucketSpan{{Offset: 1, Length: 1}}, got.PositiveSpans)
+	assert.Equal(t, []int64{10}, got.PositiveDeltas)
+	validateNativeHistogramCount(t, got)
+}
+
+func TestExponentialToExplicitHistogram(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxScale   int32
+		wantBounds []float64
+		wantCounts []uint64
+	}{
+		{
+			name:       "no downscaling",
+			maxScale:   8,
+			wantBounds: []float64{-2, 0, 2, 4},
+			wantCounts: []uint64{3, 1, 1, 2, 0},
+		},
+		{
+			name:       "downscaling",
+			maxScale:   -1,
+			wantBounds: []float64{-1, 0, 4},
+			wantCounts: []uint64{3, 1, 3, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pt := pmetric.NewExponentialHistogramDataPoint()
+			pt.SetStartTimestamp(pcommon.Timestamp(100))
+			pt.SetTimestamp(pcommon.Timestamp(500))
+			pt.Attributes().PutStr("attr", "test_attr")
+			pt.Exemplars().AppendEmpty().SetDoubleValue(3)
+			pt.SetCount(7)
+			pt.SetSum(-2.5)
+			pt.SetScale(0)
+			pt.SetZeroCount(1)
+			// (1, 2] and (2, 4]
+			pt.Positive().SetOffset(0)
+			pt.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+			// [-4, -2)
+			pt.Negative().SetOffset(1)
+			pt.Negative().BucketCounts().FromRaw([]uint64{3})
+
+			got := exponentialToExplicitHistogram(pt, tt.maxScale)
+			assert.Equal(t, tt.wantBounds, got.ExplicitBounds().AsRaw())
+			assert.Equal(t, tt.wantCounts, got.BucketCounts().AsRaw())
+			assert.Equal(t, uint64(7), got.Count())
+			assert.Equal(t, -2.5, got.Sum())
+			assert.Equal(t, pt.StartTimestamp(), got.StartTimestamp())
+			assert.Equal(t, pt.Timestamp(), got.Timestamp())
+			assert.Equal(t, map[string]any{"attr": "test_attr"}, got.Attributes().AsRaw())
+			assert.Equal(t, 1, got.Exemplars().Len())
+		})
+	}
+}
+
+func TestAddExponentialHistogramDataPointAsClassic(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetName("test_hist")
+	metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	pt := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	pt.SetCount(3)
+	pt.SetSum(5)
+	pt.SetScale(0)
+	pt.Positive().SetOffset(0)
+	pt.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+
+	gotSeries := make(map[string]*prompb.TimeSeries)
+	err := addExponentialHistogramDataPoint(
+		metric,
+		prometheustranslator.BuildCompliantName(metric, "", true),
+		pt,
+		pcommon.NewResource(),
+		Settings{ConvertExponentialHistogramsToClassic: true},
+		gotSeries,
+	)
+	require.NoError(t, err)
+
+	got := make(map[string]float64)
+	for _, ts := range gotSeries {
+		require.Empty(t, ts.Histograms)
+		require.Len(t, ts.Samples, 1)
+		name := ""
+		le := ""
+		for _, l := range ts.Labels {
+			switch l.Name {
+			case model.MetricNameLabel:
+				name = l.Value
+			case model.BucketLabel:
+				le = l.Value
+			}
+		}
+		got[name+le] = ts.Samples[0].Value
+	}
+	assert.Equal(t, map[string]float64{
+		"test_hist_sum":        5,
+		"test_hist_count":      3,
+		"test_hist_bucket0":    0,
+		"test_hist_bucket2":    1,
+		"test_hist_bucket4":    3,
+		"test_hist_bucket+Inf": 3,
+	}, got)
+}
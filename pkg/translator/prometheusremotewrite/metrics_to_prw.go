@@ -22,6 +22,14 @@ type Settings struct {
 	ExportCreatedMetric bool
 	AddMetricSuffixes   bool
 	SendMetadata        bool
+
+	// ExponentialHistogramMaxScale caps the scale of the histograms converted from
+	// exponential histograms, the ones with a higher scale are downscaled. It defaults
+	// to 8, the highest scale supported by Prometheus, when nil.
+	ExponentialHistogramMaxScale *int32
+	// ConvertExponentialHistogramsToClassic converts the exponential histograms to classic
+	// histograms with explicit buckets instead of native histograms.
+	ConvertExponentialHistogramsToClassic bool
 }
 
 // FromMetrics converts pmetric.Metrics to prometheus remote write format.
@@ -86,7 +94,8 @@ func FromMetrics(md pmetric.Metrics, settings Settings) (tsMap map[string]*promp
 					for x := 0; x < dataPoints.Len(); x++ {
 						errs = multierr.Append(
 							errs,
-							addSingleExponentialHistogramDataPoint(
+							addExponentialHistogramDataPoint(
+								metric,
 								name,
 								dataPoints.At(x),
 								resource,
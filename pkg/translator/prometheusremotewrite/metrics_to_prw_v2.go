@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+)
+
+// FromMetricsV2 converts pmetric.Metrics to the series of a prometheus remote write 2.0 request.
+// The labels, help and units of the series are interned in the returned symbols table. Every
+// series carries the metadata of its metric and, for the cumulative ones, the start timestamp
+// of its data points as created timestamp. The SendMetadata setting is ignored since the
+// metadata is always sent.
+func FromMetricsV2(md pmetric.Metrics, settings Settings) (tsMap map[string]*writev2.TimeSeries, symbols writev2.SymbolsTable, errs error) {
+	tsMap = make(map[string]*writev2.TimeSeries)
+	symbols = writev2.NewSymbolTable()
+	// The series of every data point are converted to the remote write 1.0 format first,
+	// so that they can be given the created timestamp of their data point.
+	dpSeries := make(map[string]*prompb.TimeSeries)
+
+	resourceMetricsSlice := md.ResourceMetrics()
+	for i := 0; i < resourceMetricsSlice.Len(); i++ {
+		resourceMetrics := resourceMetricsSlice.At(i)
+		resource := resourceMetrics.Resource()
+		scopeMetricsSlice := resourceMetrics.ScopeMetrics()
+		// keep track of the most recent timestamp in the ResourceMetrics for
+		// use with the "target" info metric
+		var mostRecentTimestamp pcommon.Timestamp
+		for j := 0; j < scopeMetricsSlice.Len(); j++ {
+			metricSlice := scopeMetricsSlice.At(j).Metrics()
+
+			for k := 0; k < metricSlice.Len(); k++ {
+				metric := metricSlice.At(k)
+				mostRecentTimestamp = maxTimestamp(mostRecentTimestamp, mostRecentTimestampInMetric(metric))
+
+				if !isValidAggregationTemporality(metric) {
+					errs = multierr.Append(errs, fmt.Errorf("invalid temporality and type combination for metric %q", metric.Name()))
+					continue
+				}
+
+				metadata := writev2.Metadata{
+					Type:    writev2.MetricType(otelMetricTypeToPromMetricType(metric)),
+					HelpRef: symbols.Symbolize(metric.Description()),
+					UnitRef: symbols.Symbolize(metric.Unit()),
+				}
+
+				// handle individual metric based on type
+				//exhaustive:enforce
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					dataPoints := metric.Gauge().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						addSingleGaugeNumberDataPoint(dataPoints.At(x), resource, metric, settings, dpSeries)
+						addSeriesV2(tsMap, &symbols, dpSeries, metadata, 0)
+					}
+				case pmetric.MetricTypeSum:
+					dataPoints := metric.Sum().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						pt := dataPoints.At(x)
+						addSingleSumNumberDataPoint(pt, resource, metric, settings, dpSeries)
+						var createdTimestamp pcommon.Timestamp
+						if metric.Sum().IsMonotonic() {
+							createdTimestamp = pt.StartTimestamp()
+						}
+						addSeriesV2(tsMap, &symbols, dpSeries, metadata, createdTimestamp)
+					}
+				case pmetric.MetricTypeHistogram:
+					dataPoints := metric.Histogram().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						pt := dataPoints.At(x)
+						addSingleHistogramDataPoint(pt, resource, metric, settings, dpSeries)
+						addSeriesV2(tsMap, &symbols, dpSeries, metadata, pt.StartTimestamp())
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dataPoints := metric.ExponentialHistogram().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					name := prometheustranslator.BuildCompliantName(metric, settings.Namespace, settings.AddMetricSuffixes)
+					for x := 0; x < dataPoints.Len(); x++ {
+						pt := dataPoints.At(x)
+						errs = multierr.Append(errs, addExponentialHistogramDataPoint(metric, name, pt, resource, settings, dpSeries))
+						addSeriesV2(tsMap, &symbols, dpSeries, metadata, pt.StartTimestamp())
+					}
+				case pmetric.MetricTypeSummary:
+					dataPoints := metric.Summary().DataPoints()
+					if dataPoints.Len() == 0 {
+						errs = multierr.Append(errs, fmt.Errorf("empty data points. %s is dropped", metric.Name()))
+					}
+					for x := 0; x < dataPoints.Len(); x++ {
+						pt := dataPoints.At(x)
+						addSingleSummaryDataPoint(pt, resource, metric, settings, dpSeries)
+						addSeriesV2(tsMap, &symbols, dpSeries, metadata, pt.StartTimestamp())
+					}
+				default:
+					errs = multierr.Append(errs, errors.New("unsupported metric type"))
+				}
+			}
+		}
+		addResourceTargetInfo(resource, settings, mostRecentTimestamp, dpSeries)
+		addSeriesV2(tsMap, &symbols, dpSeries, writev2.Metadata{Type: writev2.MetricTypeGauge}, 0)
+	}
+
+	return
+}
+
+// addSeriesV2 converts the remote write 1.0 series to remote write 2.0 series with
+// the given metadata and created timestamp, and adds them to tsMap. The converted
+// series are removed from series so that it can be reused.
+func addSeriesV2(
+	tsMap map[string]*writev2.TimeSeries,
+	symbols *writev2.SymbolsTable,
+	series map[string]*prompb.TimeSeries,
+	metadata writev2.Metadata,
+	createdTimestamp pcommon.Timestamp,
+) {
+	for sig, ts := range series {
+		v2, ok := tsMap[sig]
+		if !ok {
+			v2 = &writev2.TimeSeries{
+				LabelsRefs:       symbols.SymbolizeLabels(ts.Labels, nil),
+				Metadata:         metadata,
+				CreatedTimestamp: convertTimeStamp(createdTimestamp),
+			}
+			tsMap[sig] = v2
+		}
+		for _, s := range ts.Samples {
+			v2.Samples = append(v2.Samples, writev2.Sample{Value: s.Value, Timestamp: s.Timestamp})
+		}
+		v2.Histograms = append(v2.Histograms, ts.Histograms...)
+		for _, e := range ts.Exemplars {
+			v2.Exemplars = append(v2.Exemplars, writev2.Exemplar{
+				LabelsRefs: symbols.SymbolizeLabels(e.Labels, nil),
+				Value:      e.Value,
+				Timestamp:  e.Timestamp,
+			})
+		}
+		delete(series, sig)
+	}
+}
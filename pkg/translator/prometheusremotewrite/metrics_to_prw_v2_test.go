@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+)
+
+func TestFromMetricsV2(t *testing.T) {
+	const (
+		startTime = pcommon.Timestamp(1600000000000000000)
+		time      = pcommon.Timestamp(1700000000000000000)
+	)
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr(conventions.AttributeServiceName, "app")
+	rm.Resource().Attributes().PutStr("cloud.region", "eu-west-1")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("temperature")
+	gauge.SetDescription("Temperature")
+	gauge.SetUnit("Cel")
+	pt := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	pt.SetTimestamp(time)
+	pt.SetDoubleValue(21.5)
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetDescription("Requests")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	pt = sum.Sum().DataPoints().AppendEmpty()
+	pt.SetStartTimestamp(startTime)
+	pt.SetTimestamp(time)
+	pt.SetIntValue(10)
+	pt.Attributes().PutStr("code", "200")
+	pt.Exemplars().AppendEmpty().SetDoubleValue(1)
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	hpt := histogram.Histogram().DataPoints().AppendEmpty()
+	hpt.SetStartTimestamp(startTime)
+	hpt.SetTimestamp(time)
+	hpt.SetCount(3)
+	hpt.SetSum(4)
+	hpt.ExplicitBounds().FromRaw([]float64{1})
+	hpt.BucketCounts().FromRaw([]uint64{1, 2})
+
+	expHistogram := metrics.AppendEmpty()
+	expHistogram.SetName("size")
+	expHistogram.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	ept := expHistogram.ExponentialHistogram().DataPoints().AppendEmpty()
+	ept.SetStartTimestamp(startTime)
+	ept.SetTimestamp(time)
+	ept.SetCount(3)
+	ept.SetScale(0)
+	ept.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+
+	tsMap, symbols, err := FromMetricsV2(md, Settings{})
+	require.NoError(t, err)
+
+	type seriesInfo struct {
+		metadata         writev2.Metadata
+		createdTimestamp int64
+		samples          int
+		histograms       int
+		exemplars        int
+	}
+	got := make(map[string]seriesInfo)
+	for _, ts := range tsMap {
+		labels, err := writev2.DesymbolizeLabels(ts.LabelsRefs, symbols.Symbols())
+		require.NoError(t, err)
+		name := ""
+		for _, l := range labels {
+			if l.Name == model.MetricNameLabel {
+				name = l.Value
+			}
+			if l.Name == model.BucketLabel {
+				name += "{le=" + l.Value + "}"
+			}
+		}
+		got[name] = seriesInfo{
+			metadata:         ts.Metadata,
+			createdTimestamp: ts.CreatedTimestamp,
+			samples:          len(ts.Samples),
+			histograms:       len(ts.Histograms),
+			exemplars:        len(ts.Exemplars),
+		}
+	}
+
+	ref := func(s string) uint32 { return symbols.Symbolize(s) }
+	const created = int64(1600000000000)
+	assert.Equal(t, map[string]seriesInfo{
+		"temperature": {
+			metadata: writev2.Metadata{Type: writev2.MetricTypeGauge, HelpRef: ref("Temperature"), UnitRef: ref("Cel")},
+			samples:  1,
+		},
+		"requests": {
+			metadata:         writev2.Metadata{Type: writev2.MetricTypeCounter, HelpRef: ref("Requests")},
+			createdTimestamp: created,
+			samples:          1,
+			exemplars:        1,
+		},
+		"latency_sum":          {metadata: writev2.Metadata{Type: writev2.MetricTypeHistogram}, createdTimestamp: created, samples: 1},
+		"latency_count":        {metadata: writev2.Metadata{Type: writev2.MetricTypeHistogram}, createdTimestamp: created, samples: 1},
+		"latency_bucket{le=1}": {metadata: writev2.Metadata{Type: writev2.MetricTypeHistogram}, createdTimestamp: created, samples: 1},
+		"latency_bucket{le=+Inf}": {
+			metadata:         writev2.Metadata{Type: writev2.MetricTypeHistogram},
+			createdTimestamp: created,
+			samples:          1,
+		},
+		"size": {metadata: writev2.Metadata{Type: writev2.MetricTypeHistogram}, createdTimestamp: created, histograms: 1},
+		"target_info": {
+			metadata: writev2.Metadata{Type: writev2.MetricTypeGauge},
+			samples:  1,
+		},
+	}, got)
+
+	// The series are the same as the remote write 1.0 ones.
+	v1Map, err := FromMetrics(md, Settings{})
+	require.NoError(t, err)
+	require.Len(t, tsMap, len(v1Map))
+	for sig, v1 := range v1Map {
+		v2, ok := tsMap[sig]
+		require.True(t, ok)
+		labels, err := writev2.DesymbolizeLabels(v2.LabelsRefs, symbols.Symbols())
+		require.NoError(t, err)
+		assert.Equal(t, v1.Labels, labels)
+		require.Len(t, v2.Samples, len(v1.Samples))
+		for i, s := range v1.Samples {
+			assert.Equal(t, writev2.Sample{Value: s.Value, Timestamp: s.Timestamp}, v2.Samples[i])
+		}
+		assert.Equal(t, v1.Histograms, v2.Histograms)
+	}
+}
+
+func TestFromMetricsV2SameSeries(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("temperature")
+	dps := metric.SetEmptyGauge().DataPoints()
+	for i := 1; i <= 3; i++ {
+		pt := dps.AppendEmpty()
+		pt.SetTimestamp(pcommon.Timestamp(i * 1e6))
+		pt.SetDoubleValue(float64(i))
+	}
+
+	tsMap, _, err := FromMetricsV2(md, Settings{})
+	require.NoError(t, err)
+	require.Len(t, tsMap, 1)
+	for _, ts := range tsMap {
+		assert.Equal(t, []writev2.Sample{
+			{Value: 1, Timestamp: 1},
+			{Value: 2, Timestamp: 2},
+			{Value: 3, Timestamp: 3},
+		}, ts.Samples)
+	}
+}
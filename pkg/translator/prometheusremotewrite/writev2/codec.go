@@ -0,0 +1,376 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package writev2 // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/prometheus/prometheus/prompb"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of the messages, see types.proto.
+const (
+	fieldRequestSymbols    protowire.Number = 4
+	fieldRequestTimeseries protowire.Number = 5
+
+	fieldTimeSeriesLabelsRefs       protowire.Number = 1
+	fieldTimeSeriesSamples          protowire.Number = 2
+	fieldTimeSeriesHistograms       protowire.Number = 3
+	fieldTimeSeriesExemplars        protowire.Number = 4
+	fieldTimeSeriesMetadata         protowire.Number = 5
+	fieldTimeSeriesCreatedTimestamp protowire.Number = 6
+
+	fieldSampleValue     protowire.Number = 1
+	fieldSampleTimestamp protowire.Number = 2
+
+	fieldExemplarLabelsRefs protowire.Number = 1
+	fieldExemplarValue      protowire.Number = 2
+	fieldExemplarTimestamp  protowire.Number = 3
+
+	fieldMetadataType    protowire.Number = 1
+	fieldMetadataHelpRef protowire.Number = 3
+	fieldMetadataUnitRef protowire.Number = 4
+)
+
+var errInvalidWireType = errors.New("invalid wire type")
+
+// Size returns the size of the encoded request.
+func (m *Request) Size() int {
+	n := 0
+	for _, s := range m.Symbols {
+		n += sizeString(fieldRequestSymbols, s)
+	}
+	for i := range m.Timeseries {
+		n += sizeMessage(fieldRequestTimeseries, m.Timeseries[i].Size())
+	}
+	return n
+}
+
+// Marshal encodes the request in the protobuf wire format.
+func (m *Request) Marshal() ([]byte, error) {
+	b := make([]byte, 0, m.Size())
+	for _, s := range m.Symbols {
+		b = protowire.AppendTag(b, fieldRequestSymbols, protowire.BytesType)
+		b = protowire.AppendString(b, s)
+	}
+	var err error
+	for i := range m.Timeseries {
+		ts := &m.Timeseries[i]
+		b = protowire.AppendTag(b, fieldRequestTimeseries, protowire.BytesType)
+		b = protowire.AppendVarint(b, uint64(ts.Size()))
+		if b, err = ts.appendTo(b); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// Unmarshal decodes a request encoded in the protobuf wire format.
+func (m *Request) Unmarshal(b []byte) error {
+	*m = Request{}
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		switch num {
+		case fieldRequestSymbols:
+			m.Symbols = append(m.Symbols, string(v))
+		case fieldRequestTimeseries:
+			var ts TimeSeries
+			if err := ts.unmarshal(v); err != nil {
+				return err
+			}
+			m.Timeseries = append(m.Timeseries, ts)
+		}
+		return nil
+	})
+}
+
+// Size returns the size of the encoded series.
+func (m *TimeSeries) Size() int {
+	n := sizePackedUint32(fieldTimeSeriesLabelsRefs, m.LabelsRefs)
+	for i := range m.Samples {
+		n += sizeMessage(fieldTimeSeriesSamples, m.Samples[i].size())
+	}
+	for i := range m.Histograms {
+		n += sizeMessage(fieldTimeSeriesHistograms, m.Histograms[i].Size())
+	}
+	for i := range m.Exemplars {
+		n += sizeMessage(fieldTimeSeriesExemplars, m.Exemplars[i].size())
+	}
+	n += sizeMessage(fieldTimeSeriesMetadata, m.Metadata.size())
+	n += sizeVarint(fieldTimeSeriesCreatedTimestamp, uint64(m.CreatedTimestamp))
+	return n
+}
+
+func (m *TimeSeries) appendTo(b []byte) ([]byte, error) {
+	b = appendPackedUint32(b, fieldTimeSeriesLabelsRefs, m.LabelsRefs)
+	for i := range m.Samples {
+		b = protowire.AppendTag(b, fieldTimeSeriesSamples, protowire.BytesType)
+		b = protowire.AppendVarint(b, uint64(m.Samples[i].size()))
+		b = m.Samples[i].appendTo(b)
+	}
+	for i := range m.Histograms {
+		h, err := m.Histograms[i].Marshal()
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, fieldTimeSeriesHistograms, protowire.BytesType)
+		b = protowire.AppendBytes(b, h)
+	}
+	for i := range m.Exemplars {
+		b = protowire.AppendTag(b, fieldTimeSeriesExemplars, protowire.BytesType)
+		b = protowire.AppendVarint(b, uint64(m.Exemplars[i].size()))
+		b = m.Exemplars[i].appendTo(b)
+	}
+	b = protowire.AppendTag(b, fieldTimeSeriesMetadata, protowire.BytesType)
+	b = protowire.AppendVarint(b, uint64(m.Metadata.size()))
+	b = m.Metadata.appendTo(b)
+	b = appendVarint(b, fieldTimeSeriesCreatedTimestamp, uint64(m.CreatedTimestamp))
+	return b, nil
+}
+
+func (m *TimeSeries) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		var err error
+		switch num {
+		case fieldTimeSeriesLabelsRefs:
+			m.LabelsRefs, err = consumeUint32s(m.LabelsRefs, typ, v)
+		case fieldTimeSeriesSamples:
+			var s Sample
+			err = s.unmarshal(v)
+			m.Samples = append(m.Samples, s)
+		case fieldTimeSeriesHistograms:
+			var h prompb.Histogram
+			err = h.Unmarshal(v)
+			m.Histograms = append(m.Histograms, h)
+		case fieldTimeSeriesExemplars:
+			var e Exemplar
+			err = e.unmarshal(v)
+			m.Exemplars = append(m.Exemplars, e)
+		case fieldTimeSeriesMetadata:
+			err = m.Metadata.unmarshal(v)
+		case fieldTimeSeriesCreatedTimestamp:
+			m.CreatedTimestamp, err = consumeInt64(typ, v)
+		}
+		return err
+	})
+}
+
+func (m *Sample) size() int {
+	return sizeDouble(fieldSampleValue, m.Value) + sizeVarint(fieldSampleTimestamp, uint64(m.Timestamp))
+}
+
+func (m *Sample) appendTo(b []byte) []byte {
+	b = appendDouble(b, fieldSampleValue, m.Value)
+	return appendVarint(b, fieldSampleTimestamp, uint64(m.Timestamp))
+}
+
+func (m *Sample) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		var err error
+		switch num {
+		case fieldSampleValue:
+			m.Value, err = consumeDouble(typ, v)
+		case fieldSampleTimestamp:
+			m.Timestamp, err = consumeInt64(typ, v)
+		}
+		return err
+	})
+}
+
+func (m *Exemplar) size() int {
+	return sizePackedUint32(fieldExemplarLabelsRefs, m.LabelsRefs) +
+		sizeDouble(fieldExemplarValue, m.Value) +
+		sizeVarint(fieldExemplarTimestamp, uint64(m.Timestamp))
+}
+
+func (m *Exemplar) appendTo(b []byte) []byte {
+	b = appendPackedUint32(b, fieldExemplarLabelsRefs, m.LabelsRefs)
+	b = appendDouble(b, fieldExemplarValue, m.Value)
+	return appendVarint(b, fieldExemplarTimestamp, uint64(m.Timestamp))
+}
+
+func (m *Exemplar) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		var err error
+		switch num {
+		case fieldExemplarLabelsRefs:
+			m.LabelsRefs, err = consumeUint32s(m.LabelsRefs, typ, v)
+		case fieldExemplarValue:
+			m.Value, err = consumeDouble(typ, v)
+		case fieldExemplarTimestamp:
+			m.Timestamp, err = consumeInt64(typ, v)
+		}
+		return err
+	})
+}
+
+func (m *Metadata) size() int {
+	return sizeVarint(fieldMetadataType, uint64(m.Type)) +
+		sizeVarint(fieldMetadataHelpRef, uint64(m.HelpRef)) +
+		sizeVarint(fieldMetadataUnitRef, uint64(m.UnitRef))
+}
+
+func (m *Metadata) appendTo(b []byte) []byte {
+	b = appendVarint(b, fieldMetadataType, uint64(m.Type))
+	b = appendVarint(b, fieldMetadataHelpRef, uint64(m.HelpRef))
+	return appendVarint(b, fieldMetadataUnitRef, uint64(m.UnitRef))
+}
+
+func (m *Metadata) unmarshal(b []byte) error {
+	return consumeFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		if num != fieldMetadataType && num != fieldMetadataHelpRef && num != fieldMetadataUnitRef {
+			return nil
+		}
+		x, err := consumeInt64(typ, v)
+		switch num {
+		case fieldMetadataType:
+			m.Type = MetricType(x)
+		case fieldMetadataHelpRef:
+			m.HelpRef = uint32(x)
+		case fieldMetadataUnitRef:
+			m.UnitRef = uint32(x)
+		}
+		return err
+	})
+}
+
+// The scalar fields holding their zero value are omitted, as done by the protobuf encoders.
+
+func sizeVarint(num protowire.Number, v uint64) int {
+	if v == 0 {
+		return 0
+	}
+	return protowire.SizeTag(num) + protowire.SizeVarint(v)
+}
+
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func sizeDouble(num protowire.Number, v float64) int {
+	if math.Float64bits(v) == 0 {
+		return 0
+	}
+	return protowire.SizeTag(num) + protowire.SizeFixed64()
+}
+
+func appendDouble(b []byte, num protowire.Number, v float64) []byte {
+	if math.Float64bits(v) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, math.Float64bits(v))
+}
+
+func sizeString(num protowire.Number, s string) int {
+	return protowire.SizeTag(num) + protowire.SizeBytes(len(s))
+}
+
+func sizeMessage(num protowire.Number, size int) int {
+	return protowire.SizeTag(num) + protowire.SizeBytes(size)
+}
+
+func packedUint32Size(vs []uint32) int {
+	n := 0
+	for _, v := range vs {
+		n += protowire.SizeVarint(uint64(v))
+	}
+	return n
+}
+
+func sizePackedUint32(num protowire.Number, vs []uint32) int {
+	if len(vs) == 0 {
+		return 0
+	}
+	return sizeMessage(num, packedUint32Size(vs))
+}
+
+func appendPackedUint32(b []byte, num protowire.Number, vs []uint32) []byte {
+	if len(vs) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	b = protowire.AppendVarint(b, uint64(packedUint32Size(vs)))
+	for _, v := range vs {
+		b = protowire.AppendVarint(b, uint64(v))
+	}
+	return b
+}
+
+// consumeFields calls fn with the number, type and value of every field of the message,
+// the value of the varint and fixed fields being their encoded bytes.
+func consumeFields(b []byte, fn func(num protowire.Number, typ protowire.Type, v []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		var v []byte
+		if typ == protowire.BytesType {
+			v, n = protowire.ConsumeBytes(b)
+		} else {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n >= 0 {
+				v = b[:n]
+			}
+		}
+		if n < 0 {
+			return fmt.Errorf("field %d: %w", num, protowire.ParseError(n))
+		}
+		b = b[n:]
+		if err := fn(num, typ, v); err != nil {
+			return fmt.Errorf("field %d: %w", num, err)
+		}
+	}
+	return nil
+}
+
+func consumeInt64(typ protowire.Type, v []byte) (int64, error) {
+	if typ != protowire.VarintType {
+		return 0, errInvalidWireType
+	}
+	x, n := protowire.ConsumeVarint(v)
+	if n < 0 {
+		return 0, protowire.ParseError(n)
+	}
+	return int64(x), nil
+}
+
+func consumeDouble(typ protowire.Type, v []byte) (float64, error) {
+	if typ != protowire.Fixed64Type {
+		return 0, errInvalidWireType
+	}
+	x, n := protowire.ConsumeFixed64(v)
+	if n < 0 {
+		return 0, protowire.ParseError(n)
+	}
+	return math.Float64frombits(x), nil
+}
+
+// consumeUint32s appends the values of a packed or non packed repeated uint32 field to vs.
+func consumeUint32s(vs []uint32, typ protowire.Type, v []byte) ([]uint32, error) {
+	if typ == protowire.VarintType {
+		x, err := consumeInt64(typ, v)
+		return append(vs, uint32(x)), err
+	}
+	if typ != protowire.BytesType {
+		return vs, errInvalidWireType
+	}
+	for len(v) > 0 {
+		x, n := protowire.ConsumeVarint(v)
+		if n < 0 {
+			return vs, protowire.ParseError(n)
+		}
+		vs = append(vs, uint32(x))
+		v = v[n:]
+	}
+	return vs, nil
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package writev2
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestMarshalUnmarshal(t *testing.T) {
+	req := &Request{
+		Symbols: []string{"", "__name__", "http_requests_total", "job", "app", "trace_id", "abc", "Number of requests", "1"},
+		Timeseries: []TimeSeries{
+			{
+				LabelsRefs: []uint32{1, 2, 3, 4},
+				Samples: []Sample{
+					{Value: 10, Timestamp: 1700000000000},
+					{Value: 0, Timestamp: 1700000015000},
+				},
+				Exemplars: []Exemplar{
+					{LabelsRefs: []uint32{5, 6}, Value: 1.5, Timestamp: 1700000000000},
+				},
+				Metadata:         Metadata{Type: MetricTypeCounter, HelpRef: 7, UnitRef: 8},
+				CreatedTimestamp: 1600000000000,
+			},
+			{
+				LabelsRefs: []uint32{1, 2},
+				Histograms: []prompb.Histogram{
+					{
+						Count:          &prompb.Histogram_CountInt{CountInt: 12},
+						Sum:            18.4,
+						Schema:         1,
+						ZeroThreshold:  0.001,
+						ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 2},
+						PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 2}, {Offset: 1, Length: 2}},
+						PositiveDeltas: []int64{1, 1, -1, 0},
+						NegativeSpans:  []prompb.BucketSpan{{Offset: -3, Length: 1}},
+						NegativeDeltas: []int64{3},
+						Timestamp:      1700000000000,
+					},
+				},
+				Metadata: Metadata{Type: MetricTypeHistogram},
+			},
+		},
+	}
+
+	data, err := req.Marshal()
+	require.NoError(t, err)
+	assert.Len(t, data, req.Size())
+
+	var got Request
+	require.NoError(t, got.Unmarshal(data))
+	assert.Equal(t, req.Symbols, got.Symbols)
+	require.Len(t, got.Timeseries, 2)
+	assert.Equal(t, req.Timeseries[0], got.Timeseries[0])
+
+	// The histograms hold unexported fields once decoded, they are compared on their encoding.
+	assert.Equal(t, req.Timeseries[1].LabelsRefs, got.Timeseries[1].LabelsRefs)
+	assert.Equal(t, req.Timeseries[1].Metadata, got.Timeseries[1].Metadata)
+	require.Len(t, got.Timeseries[1].Histograms, 1)
+	want, err := req.Timeseries[1].Histograms[0].Marshal()
+	require.NoError(t, err)
+	gotHistogram, err := got.Timeseries[1].Histograms[0].Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, want, gotHistogram)
+}
+
+func TestSampleWireCompatibility(t *testing.T) {
+	// The samples of remote write 2.0 have the same encoding as the ones of remote write 1.0.
+	s := Sample{Value: 3.25, Timestamp: 1700000000000}
+	want, err := (&prompb.Sample{Value: s.Value, Timestamp: s.Timestamp}).Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, want, s.appendTo(nil))
+	assert.Equal(t, len(want), s.size())
+}
+
+func TestUnmarshalPackedAndUnpackedRefs(t *testing.T) {
+	// labels_refs = [1, 2] packed, then 3 and 4 unpacked.
+	ts := []byte{0x0a, 0x02, 0x01, 0x02, 0x08, 0x03, 0x08, 0x04}
+	data := append([]byte{0x2a, byte(len(ts))}, ts...)
+
+	var req Request
+	require.NoError(t, req.Unmarshal(data))
+	require.Len(t, req.Timeseries, 1)
+	assert.Equal(t, []uint32{1, 2, 3, 4}, req.Timeseries[0].LabelsRefs)
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "truncated tag",
+			data: []byte{0xff},
+		},
+		{
+			name: "truncated series",
+			data: []byte{0x2a, 0x05, 0x0a},
+		},
+		{
+			name: "invalid sample value wire type",
+			data: []byte{0x2a, 0x04, 0x12, 0x02, 0x08, 0x01},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req Request
+			assert.Error(t, req.Unmarshal(tt.data))
+		})
+	}
+}
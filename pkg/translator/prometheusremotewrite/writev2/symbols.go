@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package writev2 // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+
+import (
+	"fmt"
+
+	"github.com/prometheus/prometheus/prompb"
+)
+
+// SymbolsTable interns the strings of a request.
+type SymbolsTable struct {
+	symbols    []string
+	symbolsMap map[string]uint32
+}
+
+// NewSymbolTable returns a symbols table holding the empty string, as mandated by the protocol.
+func NewSymbolTable() SymbolsTable {
+	t := SymbolsTable{symbolsMap: make(map[string]uint32)}
+	t.Symbolize("")
+	return t
+}
+
+// Symbolize returns the reference of the string, interning it if needed.
+func (t *SymbolsTable) Symbolize(str string) uint32 {
+	if ref, ok := t.symbolsMap[str]; ok {
+		return ref
+	}
+	ref := uint32(len(t.symbols))
+	t.symbols = append(t.symbols, str)
+	t.symbolsMap[str] = ref
+	return ref
+}
+
+// SymbolizeLabels appends the references of the names and values of the labels to buf.
+func (t *SymbolsTable) SymbolizeLabels(labels []prompb.Label, buf []uint32) []uint32 {
+	for _, l := range labels {
+		buf = append(buf, t.Symbolize(l.Name), t.Symbolize(l.Value))
+	}
+	return buf
+}
+
+// Symbols returns the interned strings, indexed by their reference.
+func (t *SymbolsTable) Symbols() []string {
+	return t.symbols
+}
+
+// Size returns the size of the symbols once encoded in a request.
+func (t *SymbolsTable) Size() int {
+	n := 0
+	for _, s := range t.symbols {
+		n += sizeString(fieldRequestSymbols, s)
+	}
+	return n
+}
+
+// DesymbolizeLabels returns the labels referenced by refs.
+func DesymbolizeLabels(refs []uint32, symbols []string) ([]prompb.Label, error) {
+	if len(refs)%2 != 0 {
+		return nil, fmt.Errorf("odd number of label references: %d", len(refs))
+	}
+	labels := make([]prompb.Label, 0, len(refs)/2)
+	for i := 0; i < len(refs); i += 2 {
+		if int(refs[i]) >= len(symbols) || int(refs[i+1]) >= len(symbols) {
+			return nil, fmt.Errorf("label references %d and %d out of a symbols table of length %d", refs[i], refs[i+1], len(symbols))
+		}
+		labels = append(labels, prompb.Label{Name: symbols[refs[i]], Value: symbols[refs[i+1]]})
+	}
+	return labels, nil
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package writev2
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSymbolsTable(t *testing.T) {
+	symbols := NewSymbolTable()
+	assert.Equal(t, []string{""}, symbols.Symbols())
+	assert.Equal(t, uint32(0), symbols.Symbolize(""))
+
+	labels := []prompb.Label{
+		{Name: "__name__", Value: "up"},
+		{Name: "job", Value: "up"},
+	}
+	refs := symbols.SymbolizeLabels(labels, nil)
+	assert.Equal(t, []uint32{1, 2, 3, 2}, refs)
+	assert.Equal(t, []string{"", "__name__", "up", "job"}, symbols.Symbols())
+	assert.Equal(t, uint32(3), symbols.Symbolize("job"))
+
+	req := Request{Symbols: symbols.Symbols()}
+	assert.Equal(t, req.Size(), symbols.Size())
+
+	got, err := DesymbolizeLabels(refs, symbols.Symbols())
+	require.NoError(t, err)
+	assert.Equal(t, labels, got)
+}
+
+func TestDesymbolizeLabelsErrors(t *testing.T) {
+	symbols := []string{"", "__name__", "up"}
+
+	_, err := DesymbolizeLabels([]uint32{1}, symbols)
+	assert.Error(t, err)
+
+	_, err = DesymbolizeLabels([]uint32{1, 3}, symbols)
+	assert.Error(t, err)
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package writev2 contains the messages of the Prometheus remote write 2.0 protocol.
+// They mirror io.prometheus.write.v2.Request, see
+// https://github.com/prometheus/prometheus/blob/main/prompb/io/prometheus/write/v2/types.proto,
+// which isn't available in the version of the Prometheus module used by the collector.
+package writev2 // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite/writev2"
+
+import (
+	"github.com/prometheus/prometheus/prompb"
+)
+
+const (
+	// ContentType is the content type of the remote write 2.0 requests.
+	ContentType = "application/x-protobuf;proto=io.prometheus.write.v2.Request"
+	// Version is the value of the X-Prometheus-Remote-Write-Version header of the remote write 2.0 requests.
+	Version = "2.0.0"
+)
+
+// Request is a remote write 2.0 request. The labels, help and unit of its series
+// are references to its symbols.
+type Request struct {
+	// Symbols holds the interned strings of the request, the first one must be an empty string.
+	Symbols    []string
+	Timeseries []TimeSeries
+}
+
+// TimeSeries is a series of samples or native histograms with its labels, exemplars and metadata.
+type TimeSeries struct {
+	// LabelsRefs holds the references of the name and value of every label,
+	// hence it has an even length.
+	LabelsRefs []uint32
+	Samples    []Sample
+	// Histograms holds the native histograms of the series, they are wire compatible
+	// with the ones of the remote write 1.0 protocol.
+	Histograms []prompb.Histogram
+	Exemplars  []Exemplar
+	Metadata   Metadata
+	// CreatedTimestamp is the time in milliseconds at which the cumulative series was
+	// created or reset, zero if unknown.
+	CreatedTimestamp int64
+}
+
+// Sample is a value of a series at a time in milliseconds.
+type Sample struct {
+	Value     float64
+	Timestamp int64
+}
+
+// Exemplar is an exemplar of a series, its labels are references to the symbols.
+type Exemplar struct {
+	LabelsRefs []uint32
+	Value      float64
+	Timestamp  int64
+}
+
+// Metadata is the metadata of a series, the help and unit are references to the symbols.
+type Metadata struct {
+	Type    MetricType
+	HelpRef uint32
+	UnitRef uint32
+}
+
+// MetricType is the type of the metric of a series.
+type MetricType int32
+
+// The metric types share their values with prompb.MetricMetadata_MetricType.
+const (
+	MetricTypeUnspecified    MetricType = 0
+	MetricTypeCounter        MetricType = 1
+	MetricTypeGauge          MetricType = 2
+	MetricTypeHistogram      MetricType = 3
+	MetricTypeGaugeHistogram MetricType = 4
+	MetricTypeSummary        MetricType = 5
+	MetricTypeInfo           MetricType = 6
+	MetricTypeStateset       MetricType = 7
+)
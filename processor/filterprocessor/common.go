@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
+)
+
+// newSkipResourceAndScopeExprs creates the expressions used to drop whole resources and scopes.
+// An expression is nil if no conditions are provided for it.
+func newSkipResourceAndScopeExprs(resourceConditions []string, scopeConditions []string, errorMode ottl.ErrorMode, set component.TelemetrySettings) (expr.BoolExpr[ottlresource.TransformContext], expr.BoolExpr[ottlscope.TransformContext], error) {
+	var (
+		skipResourceExpr expr.BoolExpr[ottlresource.TransformContext]
+		skipScopeExpr    expr.BoolExpr[ottlscope.TransformContext]
+		err              error
+	)
+	if resourceConditions != nil {
+		skipResourceExpr, err = filterottl.NewBoolExprForResource(resourceConditions, filterottl.StandardResourceFuncs(), errorMode, set)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if scopeConditions != nil {
+		skipScopeExpr, err = filterottl.NewBoolExprForScope(scopeConditions, filterottl.StandardScopeFuncs(), errorMode, set)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return skipResourceExpr, skipScopeExpr, nil
+}
+
+// skipResource returns true if the resource, and all the telemetry under it, must be dropped.
+func skipResource(ctx context.Context, skipExpr expr.BoolExpr[ottlresource.TransformContext], resource pcommon.Resource) (bool, error) {
+	if skipExpr == nil {
+		return false, nil
+	}
+	return skipExpr.Eval(ctx, ottlresource.NewTransformContext(resource))
+}
+
+// skipScope returns true if the scope, and all the telemetry under it, must be dropped.
+func skipScope(ctx context.Context, skipExpr expr.BoolExpr[ottlscope.TransformContext], scope pcommon.InstrumentationScope, resource pcommon.Resource) (bool, error) {
+	if skipExpr == nil {
+		return false, nil
+	}
+	return skipExpr.Eval(ctx, ottlscope.NewTransformContext(scope, resource))
+}
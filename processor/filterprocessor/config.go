@@ -53,6 +53,16 @@ type MetricFilters struct {
 	// RegexpConfig specifies options for the regexp match type
 	RegexpConfig *regexp.Config `mapstructure:"regexp"`
 
+	// ResourceConditions is a list of OTTL conditions for an ottlresource context.
+	// If any condition resolves to true, the whole resource, including all of its metrics, will be dropped.
+	// Supports `and`, `or`, and `()`
+	ResourceConditions []string `mapstructure:"resource"`
+
+	// ScopeConditions is a list of OTTL conditions for an ottlscope context.
+	// If any condition resolves to true, the whole scope, including all of its metrics, will be dropped.
+	// Supports `and`, `or`, and `()`
+	ScopeConditions []string `mapstructure:"scope"`
+
 	// MetricConditions is a list of OTTL conditions for an ottlmetric context.
 	// If any condition resolves to true, the metric will be dropped.
 	// Supports `and`, `or`, and `()`
@@ -66,6 +76,16 @@ type MetricFilters struct {
 
 // TraceFilters filters by OTTL conditions
 type TraceFilters struct {
+	// ResourceConditions is a list of OTTL conditions for an ottlresource context.
+	// If any condition resolves to true, the whole resource, including all of its spans, will be dropped.
+	// Supports `and`, `or`, and `()`
+	ResourceConditions []string `mapstructure:"resource"`
+
+	// ScopeConditions is a list of OTTL conditions for an ottlscope context.
+	// If any condition resolves to true, the whole scope, including all of its spans, will be dropped.
+	// Supports `and`, `or`, and `()`
+	ScopeConditions []string `mapstructure:"scope"`
+
 	// SpanConditions is a list of OTTL conditions for an ottlspan context.
 	// If any condition resolves to true, the span will be dropped.
 	// Supports `and`, `or`, and `()`
@@ -88,6 +108,16 @@ type LogFilters struct {
 	// If both Include and Exclude are specified, Include filtering occurs first.
 	Exclude *LogMatchProperties `mapstructure:"exclude"`
 
+	// ResourceConditions is a list of OTTL conditions for an ottlresource context.
+	// If any condition resolves to true, the whole resource, including all of its log records, will be dropped.
+	// Supports `and`, `or`, and `()`
+	ResourceConditions []string `mapstructure:"resource"`
+
+	// ScopeConditions is a list of OTTL conditions for an ottlscope context.
+	// If any condition resolves to true, the whole scope, including all of its log records, will be dropped.
+	// Supports `and`, `or`, and `()`
+	ScopeConditions []string `mapstructure:"scope"`
+
 	// LogConditions is a list of OTTL conditions for an ottllog context.
 	// If any condition resolves to true, the log event will be dropped.
 	// Supports `and`, `or`, and `()`
@@ -266,18 +296,21 @@ var _ component.Config = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
-	if (cfg.Traces.SpanConditions != nil || cfg.Traces.SpanEventConditions != nil) && (cfg.Spans.Include != nil || cfg.Spans.Exclude != nil) {
+	if cfg.Traces.hasConditions() && (cfg.Spans.Include != nil || cfg.Spans.Exclude != nil) {
 		return fmt.Errorf("cannot use ottl conditions and include/exclude for spans at the same time")
 	}
-	if (cfg.Metrics.MetricConditions != nil || cfg.Metrics.DataPointConditions != nil) && (cfg.Metrics.Include != nil || cfg.Metrics.Exclude != nil) {
+	if cfg.Metrics.hasConditions() && (cfg.Metrics.Include != nil || cfg.Metrics.Exclude != nil) {
 		return fmt.Errorf("cannot use ottl conditions and include/exclude for metrics at the same time")
 	}
-	if cfg.Logs.LogConditions != nil && (cfg.Logs.Include != nil || cfg.Logs.Exclude != nil) {
+	if cfg.Logs.hasConditions() && (cfg.Logs.Include != nil || cfg.Logs.Exclude != nil) {
 		return fmt.Errorf("cannot use ottl conditions and include/exclude for logs at the same time")
 	}
 
 	var errors error
 
+	errors = multierr.Append(errors, validateResourceConditions(cfg.Traces.ResourceConditions))
+	errors = multierr.Append(errors, validateScopeConditions(cfg.Traces.ScopeConditions))
+
 	if cfg.Traces.SpanConditions != nil {
 		_, err := filterottl.NewBoolExprForSpan(cfg.Traces.SpanConditions, filterottl.StandardSpanFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
 		errors = multierr.Append(errors, err)
@@ -288,6 +321,9 @@ func (cfg *Config) Validate() error {
 		errors = multierr.Append(errors, err)
 	}
 
+	errors = multierr.Append(errors, validateResourceConditions(cfg.Metrics.ResourceConditions))
+	errors = multierr.Append(errors, validateScopeConditions(cfg.Metrics.ScopeConditions))
+
 	if cfg.Metrics.MetricConditions != nil {
 		_, err := filterottl.NewBoolExprForMetric(cfg.Metrics.MetricConditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
 		errors = multierr.Append(errors, err)
@@ -298,6 +334,9 @@ func (cfg *Config) Validate() error {
 		errors = multierr.Append(errors, err)
 	}
 
+	errors = multierr.Append(errors, validateResourceConditions(cfg.Logs.ResourceConditions))
+	errors = multierr.Append(errors, validateScopeConditions(cfg.Logs.ScopeConditions))
+
 	if cfg.Logs.LogConditions != nil {
 		_, err := filterottl.NewBoolExprForLog(cfg.Logs.LogConditions, filterottl.StandardLogFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
 		errors = multierr.Append(errors, err)
@@ -313,3 +352,34 @@ func (cfg *Config) Validate() error {
 
 	return errors
 }
+
+func validateResourceConditions(conditions []string) error {
+	if conditions == nil {
+		return nil
+	}
+	_, err := filterottl.NewBoolExprForResource(conditions, filterottl.StandardResourceFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+	return err
+}
+
+func validateScopeConditions(conditions []string) error {
+	if conditions == nil {
+		return nil
+	}
+	_, err := filterottl.NewBoolExprForScope(conditions, filterottl.StandardScopeFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+	return err
+}
+
+// hasConditions returns true if any OTTL condition is configured for metrics.
+func (mf MetricFilters) hasConditions() bool {
+	return mf.ResourceConditions != nil || mf.ScopeConditions != nil || mf.MetricConditions != nil || mf.DataPointConditions != nil
+}
+
+// hasConditions returns true if any OTTL condition is configured for traces.
+func (tf TraceFilters) hasConditions() bool {
+	return tf.ResourceConditions != nil || tf.ScopeConditions != nil || tf.SpanConditions != nil || tf.SpanEventConditions != nil
+}
+
+// hasConditions returns true if any OTTL condition is configured for logs.
+func (lf LogFilters) hasConditions() bool {
+	return lf.ResourceConditions != nil || lf.ScopeConditions != nil || lf.LogConditions != nil
+}
@@ -868,6 +868,36 @@ func TestLoadingConfigOTTL(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName("filter", "resource_scope"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				Traces: TraceFilters{
+					ResourceConditions: []string{
+						`attributes["k8s.namespace.name"] == "noisy"`,
+					},
+					ScopeConditions: []string{
+						`name == "noisy.library"`,
+					},
+				},
+				Metrics: MetricFilters{
+					ResourceConditions: []string{
+						`attributes["k8s.namespace.name"] == "noisy"`,
+					},
+					ScopeConditions: []string{
+						`name == "noisy.library"`,
+					},
+				},
+				Logs: LogFilters{
+					ResourceConditions: []string{
+						`attributes["k8s.namespace.name"] == "noisy"`,
+					},
+					ScopeConditions: []string{
+						`name == "noisy.library"`,
+					},
+				},
+			},
+		},
 		{
 			id: component.NewIDWithName("filter", "multiline"),
 			expected: &Config{
@@ -907,6 +937,16 @@ func TestLoadingConfigOTTL(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_log"),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_syntax_resource"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_syntax_scope"),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "resource_mix_config"),
+			errorMessage: "cannot use ottl conditions and include/exclude for logs at the same time",
+		},
 	}
 
 	for _, tt := range tests {
@@ -18,23 +18,33 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterlog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
 )
 
 type filterLogProcessor struct {
-	skipExpr expr.BoolExpr[ottllog.TransformContext]
-	logger   *zap.Logger
+	skipResourceExpr expr.BoolExpr[ottlresource.TransformContext]
+	skipScopeExpr    expr.BoolExpr[ottlscope.TransformContext]
+	skipExpr         expr.BoolExpr[ottllog.TransformContext]
+	logger           *zap.Logger
 }
 
 func newFilterLogsProcessor(set component.TelemetrySettings, cfg *Config) (*filterLogProcessor, error) {
 	flp := &filterLogProcessor{
 		logger: set.Logger,
 	}
-	if cfg.Logs.LogConditions != nil {
-		skipExpr, err := filterottl.NewBoolExprForLog(cfg.Logs.LogConditions, filterottl.StandardLogFuncs(), cfg.ErrorMode, set)
+	if cfg.Logs.hasConditions() {
+		var err error
+		flp.skipResourceExpr, flp.skipScopeExpr, err = newSkipResourceAndScopeExprs(cfg.Logs.ResourceConditions, cfg.Logs.ScopeConditions, cfg.ErrorMode, set)
 		if err != nil {
 			return nil, err
 		}
-		flp.skipExpr = skipExpr
+		if cfg.Logs.LogConditions != nil {
+			flp.skipExpr, err = filterottl.NewBoolExprForLog(cfg.Logs.LogConditions, filterottl.StandardLogFuncs(), cfg.ErrorMode, set)
+			if err != nil {
+				return nil, err
+			}
+		}
 		return flp, nil
 	}
 
@@ -57,15 +67,26 @@ func newFilterLogsProcessor(set component.TelemetrySettings, cfg *Config) (*filt
 }
 
 func (flp *filterLogProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
-	if flp.skipExpr == nil {
+	if flp.skipResourceExpr == nil && flp.skipScopeExpr == nil && flp.skipExpr == nil {
 		return ld, nil
 	}
 
 	var errors error
 	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
 		resource := rl.Resource()
+		if skip, err := skipResource(ctx, flp.skipResourceExpr, resource); err != nil || skip {
+			errors = multierr.Append(errors, err)
+			return skip
+		}
 		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
 			scope := sl.Scope()
+			if skip, err := skipScope(ctx, flp.skipScopeExpr, scope, resource); err != nil || skip {
+				errors = multierr.Append(errors, err)
+				return skip
+			}
+			if flp.skipExpr == nil {
+				return false
+			}
 			lrs := sl.LogRecords()
 			lrs.RemoveIf(func(lr plog.LogRecord) bool {
 				skip, err := flp.skipExpr.Eval(ctx, ottllog.NewTransformContext(lr, scope, resource))
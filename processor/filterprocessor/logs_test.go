@@ -700,15 +700,17 @@ var (
 func TestFilterLogProcessorWithOTTL(t *testing.T) {
 	tests := []struct {
 		name             string
-		conditions       []string
+		conditions       LogFilters
 		filterEverything bool
 		want             func(ld plog.Logs)
 		errorMode        ottl.ErrorMode
 	}{
 		{
 			name: "drop logs",
-			conditions: []string{
-				`body == "operationA"`,
+			conditions: LogFilters{
+				LogConditions: []string{
+					`body == "operationA"`,
+				},
 			},
 			want: func(ld plog.Logs) {
 				ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().RemoveIf(func(log plog.LogRecord) bool {
@@ -722,25 +724,55 @@ func TestFilterLogProcessorWithOTTL(t *testing.T) {
 		},
 		{
 			name: "drop everything by dropping all logs",
-			conditions: []string{
-				`IsMatch(body, "operation.*")`,
+			conditions: LogFilters{
+				LogConditions: []string{
+					`IsMatch(body, "operation.*")`,
+				},
 			},
 			filterEverything: true,
 			errorMode:        ottl.IgnoreError,
 		},
 		{
 			name: "multiple conditions",
-			conditions: []string{
-				`IsMatch(body, "wrong name")`,
-				`IsMatch(body, "operation.*")`,
+			conditions: LogFilters{
+				LogConditions: []string{
+					`IsMatch(body, "wrong name")`,
+					`IsMatch(body, "operation.*")`,
+				},
+			},
+			filterEverything: true,
+			errorMode:        ottl.IgnoreError,
+		},
+		{
+			name: "drop everything by dropping the resource",
+			conditions: LogFilters{
+				ResourceConditions: []string{
+					`attributes["host.name"] == "localhost"`,
+				},
 			},
 			filterEverything: true,
 			errorMode:        ottl.IgnoreError,
 		},
+		{
+			name: "drop scope",
+			conditions: LogFilters{
+				ScopeConditions: []string{
+					`name == "scope2"`,
+				},
+			},
+			want: func(ld plog.Logs) {
+				ld.ResourceLogs().At(0).ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+					return sl.Scope().Name() == "scope2"
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
 		{
 			name: "with error conditions",
-			conditions: []string{
-				`Substring("", 0, 100) == "test"`,
+			conditions: LogFilters{
+				LogConditions: []string{
+					`Substring("", 0, 100) == "test"`,
+				},
 			},
 			want:      func(ld plog.Logs) {},
 			errorMode: ottl.IgnoreError,
@@ -748,7 +780,7 @@ func TestFilterLogProcessorWithOTTL(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			processor, err := newFilterLogsProcessor(componenttest.NewNopTelemetrySettings(), &Config{Logs: LogFilters{LogConditions: tt.conditions}})
+			processor, err := newFilterLogsProcessor(componenttest.NewNopTelemetrySettings(), &Config{Logs: tt.conditions, ErrorMode: tt.errorMode})
 			assert.NoError(t, err)
 
 			got, err := processor.processLogs(context.Background(), constructLogs())
@@ -22,10 +22,12 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
 )
 
 type filterMetricProcessor struct {
 	skipResourceExpr  expr.BoolExpr[ottlresource.TransformContext]
+	skipScopeExpr     expr.BoolExpr[ottlscope.TransformContext]
 	skipMetricExpr    expr.BoolExpr[ottlmetric.TransformContext]
 	skipDataPointExpr expr.BoolExpr[ottldatapoint.TransformContext]
 	logger            *zap.Logger
@@ -36,7 +38,11 @@ func newFilterMetricProcessor(set component.TelemetrySettings, cfg *Config) (*fi
 	fsp := &filterMetricProcessor{
 		logger: set.Logger,
 	}
-	if cfg.Metrics.MetricConditions != nil || cfg.Metrics.DataPointConditions != nil {
+	if cfg.Metrics.hasConditions() {
+		fsp.skipResourceExpr, fsp.skipScopeExpr, err = newSkipResourceAndScopeExprs(cfg.Metrics.ResourceConditions, cfg.Metrics.ScopeConditions, cfg.ErrorMode, set)
+		if err != nil {
+			return nil, err
+		}
 		if cfg.Metrics.MetricConditions != nil {
 			fsp.skipMetricExpr, err = filterottl.NewBoolExprForMetric(cfg.Metrics.MetricConditions, filterottl.StandardMetricFuncs(), cfg.ErrorMode, set)
 			if err != nil {
@@ -103,25 +109,26 @@ func newFilterMetricProcessor(set component.TelemetrySettings, cfg *Config) (*fi
 
 // processMetrics filters the given metrics based off the filterMetricProcessor's filters.
 func (fmp *filterMetricProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
-	if fmp.skipResourceExpr == nil && fmp.skipMetricExpr == nil && fmp.skipDataPointExpr == nil {
+	if fmp.skipResourceExpr == nil && fmp.skipScopeExpr == nil && fmp.skipMetricExpr == nil && fmp.skipDataPointExpr == nil {
 		return md, nil
 	}
 
 	var errors error
 	md.ResourceMetrics().RemoveIf(func(rmetrics pmetric.ResourceMetrics) bool {
 		resource := rmetrics.Resource()
-		if fmp.skipResourceExpr != nil {
-			skip, err := fmp.skipResourceExpr.Eval(ctx, ottlresource.NewTransformContext(resource))
-			if err != nil {
-				errors = multierr.Append(errors, err)
-				return false
-			}
-			if skip {
-				return true
-			}
+		if skip, err := skipResource(ctx, fmp.skipResourceExpr, resource); err != nil || skip {
+			errors = multierr.Append(errors, err)
+			return skip
 		}
 		rmetrics.ScopeMetrics().RemoveIf(func(smetrics pmetric.ScopeMetrics) bool {
 			scope := smetrics.Scope()
+			if skip, err := skipScope(ctx, fmp.skipScopeExpr, scope, resource); err != nil || skip {
+				errors = multierr.Append(errors, err)
+				return skip
+			}
+			if fmp.skipMetricExpr == nil && fmp.skipDataPointExpr == nil {
+				return false
+			}
 			smetrics.Metrics().RemoveIf(func(metric pmetric.Metric) bool {
 				if fmp.skipMetricExpr != nil {
 					skip, err := fmp.skipMetricExpr.Eval(ctx, ottlmetric.NewTransformContext(metric, smetrics.Metrics(), scope, resource))
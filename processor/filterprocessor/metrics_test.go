@@ -725,6 +725,56 @@ func TestFilterMetricProcessorWithOTTL(t *testing.T) {
 			filterEverything: true,
 			errorMode:        ottl.IgnoreError,
 		},
+		{
+			name: "drop everything by dropping the resource",
+			conditions: MetricFilters{
+				ResourceConditions: []string{
+					`attributes["host.name"] == "myhost"`,
+				},
+			},
+			filterEverything: true,
+			errorMode:        ottl.IgnoreError,
+		},
+		{
+			name: "drop everything by dropping the scope",
+			conditions: MetricFilters{
+				ScopeConditions: []string{
+					`name == "scope"`,
+				},
+			},
+			filterEverything: true,
+			errorMode:        ottl.IgnoreError,
+		},
+		{
+			name: "keep resource and scope that do not match",
+			conditions: MetricFilters{
+				ResourceConditions: []string{
+					`attributes["host.name"] == "otherhost"`,
+				},
+				ScopeConditions: []string{
+					`name == "otherscope"`,
+				},
+			},
+			want:      func(md pmetric.Metrics) {},
+			errorMode: ottl.IgnoreError,
+		},
+		{
+			name: "drop metrics of a kept scope",
+			conditions: MetricFilters{
+				ScopeConditions: []string{
+					`name == "otherscope"`,
+				},
+				MetricConditions: []string{
+					`name == "operationA"`,
+				},
+			},
+			want: func(md pmetric.Metrics) {
+				md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().RemoveIf(func(metric pmetric.Metric) bool {
+					return metric.Name() == "operationA"
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -15,11 +15,15 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
 )
 
 type filterSpanProcessor struct {
+	skipResourceExpr  expr.BoolExpr[ottlresource.TransformContext]
+	skipScopeExpr     expr.BoolExpr[ottlscope.TransformContext]
 	skipSpanExpr      expr.BoolExpr[ottlspan.TransformContext]
 	skipSpanEventExpr expr.BoolExpr[ottlspanevent.TransformContext]
 	logger            *zap.Logger
@@ -30,7 +34,11 @@ func newFilterSpansProcessor(set component.TelemetrySettings, cfg *Config) (*fil
 	fsp := &filterSpanProcessor{
 		logger: set.Logger,
 	}
-	if cfg.Traces.SpanConditions != nil || cfg.Traces.SpanEventConditions != nil {
+	if cfg.Traces.hasConditions() {
+		fsp.skipResourceExpr, fsp.skipScopeExpr, err = newSkipResourceAndScopeExprs(cfg.Traces.ResourceConditions, cfg.Traces.ScopeConditions, cfg.ErrorMode, set)
+		if err != nil {
+			return nil, err
+		}
 		if cfg.Traces.SpanConditions != nil {
 			fsp.skipSpanExpr, err = filterottl.NewBoolExprForSpan(cfg.Traces.SpanConditions, filterottl.StandardSpanFuncs(), cfg.ErrorMode, set)
 			if err != nil {
@@ -71,15 +79,26 @@ func newFilterSpansProcessor(set component.TelemetrySettings, cfg *Config) (*fil
 
 // processTraces filters the given spans of a traces based off the filterSpanProcessor's filters.
 func (fsp *filterSpanProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
-	if fsp.skipSpanExpr == nil && fsp.skipSpanEventExpr == nil {
+	if fsp.skipResourceExpr == nil && fsp.skipScopeExpr == nil && fsp.skipSpanExpr == nil && fsp.skipSpanEventExpr == nil {
 		return td, nil
 	}
 
 	var errors error
 	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
 		resource := rs.Resource()
+		if skip, err := skipResource(ctx, fsp.skipResourceExpr, resource); err != nil || skip {
+			errors = multierr.Append(errors, err)
+			return skip
+		}
 		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
 			scope := ss.Scope()
+			if skip, err := skipScope(ctx, fsp.skipScopeExpr, scope, resource); err != nil || skip {
+				errors = multierr.Append(errors, err)
+				return skip
+			}
+			if fsp.skipSpanExpr == nil && fsp.skipSpanEventExpr == nil {
+				return false
+			}
 			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
 				if fsp.skipSpanExpr != nil {
 					skip, err := fsp.skipSpanExpr.Eval(ctx, ottlspan.NewTransformContext(span, scope, resource))
@@ -251,6 +251,50 @@ func TestFilterTraceProcessorWithOTTL(t *testing.T) {
 			filterEverything: true,
 			errorMode:        ottl.IgnoreError,
 		},
+		{
+			name: "drop everything by dropping the resource",
+			conditions: TraceFilters{
+				ResourceConditions: []string{
+					`attributes["host.name"] == "localhost"`,
+				},
+			},
+			filterEverything: true,
+			errorMode:        ottl.IgnoreError,
+		},
+		{
+			name: "drop scope",
+			conditions: TraceFilters{
+				ScopeConditions: []string{
+					`name == "scope1"`,
+				},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+					return ss.Scope().Name() == "scope1"
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
+		{
+			name: "drop scope and spans",
+			conditions: TraceFilters{
+				ScopeConditions: []string{
+					`name == "scope1"`,
+				},
+				SpanConditions: []string{
+					`name == "operationA"`,
+				},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+					return ss.Scope().Name() == "scope1"
+				})
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().RemoveIf(func(span ptrace.Span) bool {
+					return span.Name() == "operationA"
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
 		{
 			name: "with error conditions",
 			conditions: TraceFilters{
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defaults
+const (
+	// defaultInterval is the default export interval.
+	defaultInterval = 10 * time.Second
+
+	// defaultLogCountAttribute is the default log count attribute
+	defaultLogCountAttribute = "log.count"
+
+	// defaultTimezone is the default timezone
+	defaultTimezone = "UTC"
+
+	// bodyField is the name of the body field
+	bodyField = "body"
+
+	// attributeField is the name of the attribute field
+	attributeField = "attributes"
+)
+
+// Config errors
+var (
+	errInvalidLogCountAttribute = errors.New("log_count_attribute must be set")
+	errInvalidInterval          = errors.New("interval must be greater than 0")
+	errCannotExcludeBody        = errors.New("cannot exclude the entire body")
+)
+
+// Config is the config of the processor.
+type Config struct {
+	// LogCountAttribute is the name of the attribute holding the number of
+	// occurrences of the deduplicated log record.
+	LogCountAttribute string `mapstructure:"log_count_attribute"`
+
+	// Interval is the interval at which deduplicated log records are emitted.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Timezone is the timezone of the first and last observed timestamp attributes.
+	Timezone string `mapstructure:"timezone"`
+
+	// IncludeAttributes restricts the attributes compared between log records, and kept
+	// on the deduplicated log record, to the given keys. All attributes are compared if empty.
+	IncludeAttributes []string `mapstructure:"include_attributes"`
+
+	// ExcludeFields are the body and attribute fields that are not compared between log records.
+	// They are removed from the deduplicated log record.
+	ExcludeFields []string `mapstructure:"exclude_fields"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// createDefaultConfig returns the default config for the processor.
+func createDefaultConfig() component.Config {
+	return &Config{
+		LogCountAttribute: defaultLogCountAttribute,
+		Interval:          defaultInterval,
+		Timezone:          defaultTimezone,
+		IncludeAttributes: []string{},
+		ExcludeFields:     []string{},
+	}
+}
+
+// Validate validates the configuration
+func (c Config) Validate() error {
+	if c.Interval <= 0 {
+		return errInvalidInterval
+	}
+
+	if c.LogCountAttribute == "" {
+		return errInvalidLogCountAttribute
+	}
+
+	if _, err := time.LoadLocation(c.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone: %w", err)
+	}
+
+	return c.validateExcludeFields()
+}
+
+// validateExcludeFields validates the exclude fields
+func (c Config) validateExcludeFields() error {
+	knownExcludeFields := make(map[string]struct{})
+
+	for _, field := range c.ExcludeFields {
+		// Special check to make sure the entire body is not excluded
+		if field == bodyField {
+			return errCannotExcludeBody
+		}
+
+		// Split and ensure the field is within `body` or `attributes`
+		parts := splitField(field)
+		if len(parts) < 2 || (parts[0] != bodyField && parts[0] != attributeField) {
+			return fmt.Errorf("an excluded field must start with %s or %s followed by a key: %s", bodyField, attributeField, field)
+		}
+
+		// If a field is valid make sure we haven't already seen it
+		if _, ok := knownExcludeFields[field]; ok {
+			return fmt.Errorf("duplicate exclude_field %s", field)
+		}
+
+		knownExcludeFields[field] = struct{}{}
+	}
+
+	return nil
+}
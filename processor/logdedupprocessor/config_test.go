@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id:       component.NewIDWithName(metadata.Type, ""),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				LogCountAttribute: "dedup_count",
+				Interval:          30 * time.Second,
+				Timezone:          "America/Los_Angeles",
+				IncludeAttributes: []string{"host.name", "service"},
+				ExcludeFields:     []string{"body.timestamp", `attributes.request\.id`},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_interval"),
+			errorMessage: errInvalidInterval.Error(),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "empty_log_count_attribute"),
+			errorMessage: errInvalidLogCountAttribute.Error(),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_timezone"),
+			errorMessage: "invalid timezone: unknown time zone Not/A_Timezone",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "exclude_body"),
+			errorMessage: errCannotExcludeBody.Error(),
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_exclude_field"),
+			errorMessage: "an excluded field must start with body or attributes followed by a key: resource.host",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "duplicate_exclude_field"),
+			errorMessage: "duplicate exclude_field attributes.id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+// Attributes names for first and last observed timestamps
+const (
+	firstObservedTSAttr = "first_observed_timestamp"
+	lastObservedTSAttr  = "last_observed_timestamp"
+)
+
+// timeNow can be reassigned for testing
+var timeNow = time.Now
+
+// logAggregator tracks the number of times a specific logRecord has been seen,
+// grouped by resource and scope.
+type logAggregator struct {
+	resources         map[[16]byte]*resourceAggregator
+	logCountAttribute string
+	timezone          *time.Location
+	includeAttributes map[string]struct{}
+}
+
+// newLogAggregator creates a new logAggregator.
+func newLogAggregator(logCountAttribute string, timezone *time.Location, includeAttributes []string) *logAggregator {
+	la := &logAggregator{
+		resources:         make(map[[16]byte]*resourceAggregator),
+		logCountAttribute: logCountAttribute,
+		timezone:          timezone,
+	}
+	if len(includeAttributes) > 0 {
+		la.includeAttributes = make(map[string]struct{}, len(includeAttributes))
+		for _, key := range includeAttributes {
+			la.includeAttributes[key] = struct{}{}
+		}
+	}
+	return la
+}
+
+// Export exports the counts of the deduplicated log records as plog.Logs.
+func (l *logAggregator) Export() plog.Logs {
+	logs := plog.NewLogs()
+	exportTimestamp := pcommon.NewTimestampFromTime(timeNow())
+
+	for _, resource := range l.resources {
+		resourceLogs := logs.ResourceLogs().AppendEmpty()
+		resource.resource.CopyTo(resourceLogs.Resource())
+
+		for _, scope := range resource.scopes {
+			scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
+			scope.scope.CopyTo(scopeLogs.Scope())
+
+			for _, counter := range scope.logCounters {
+				lr := scopeLogs.LogRecords().AppendEmpty()
+				counter.logRecord.CopyTo(lr)
+				lr.SetObservedTimestamp(exportTimestamp)
+				lr.SetTimestamp(exportTimestamp)
+
+				lr.Attributes().PutInt(l.logCountAttribute, counter.count)
+				lr.Attributes().PutStr(firstObservedTSAttr, counter.firstObservedTimestamp.In(l.timezone).Format(time.RFC3339))
+				lr.Attributes().PutStr(lastObservedTSAttr, counter.lastObservedTimestamp.In(l.timezone).Format(time.RFC3339))
+			}
+		}
+	}
+
+	return logs
+}
+
+// Add adds the logRecord to the resource aggregator that is identified by the resource attributes.
+// The log record must not be modified after it was added.
+func (l *logAggregator) Add(resource pcommon.Resource, scope pcommon.InstrumentationScope, logRecord plog.LogRecord) {
+	key := pdatautil.MapHash(resource.Attributes())
+	resourceCounter, ok := l.resources[key]
+	if !ok {
+		resourceCounter = newResourceAggregator(resource)
+		l.resources[key] = resourceCounter
+	}
+
+	if l.includeAttributes != nil {
+		logRecord.Attributes().RemoveIf(func(key string, _ pcommon.Value) bool {
+			_, ok := l.includeAttributes[key]
+			return !ok
+		})
+	}
+
+	resourceCounter.Add(scope, logRecord)
+}
+
+// Reset resets the counters.
+func (l *logAggregator) Reset() {
+	l.resources = make(map[[16]byte]*resourceAggregator)
+}
+
+// resourceAggregator groups the log records of a resource by scope.
+type resourceAggregator struct {
+	resource pcommon.Resource
+	scopes   map[[16]byte]*scopeAggregator
+}
+
+// newResourceAggregator creates a new resourceAggregator with a copy of the resource.
+func newResourceAggregator(resource pcommon.Resource) *resourceAggregator {
+	r := pcommon.NewResource()
+	resource.CopyTo(r)
+	return &resourceAggregator{
+		resource: r,
+		scopes:   make(map[[16]byte]*scopeAggregator),
+	}
+}
+
+// Add increments the counter that the logRecord matches.
+func (r *resourceAggregator) Add(scope pcommon.InstrumentationScope, logRecord plog.LogRecord) {
+	key := getScopeKey(scope)
+	scopeCounter, ok := r.scopes[key]
+	if !ok {
+		scopeCounter = newScopeAggregator(scope)
+		r.scopes[key] = scopeCounter
+	}
+
+	scopeCounter.Add(logRecord)
+}
+
+// scopeAggregator counts the identical log records of a scope.
+type scopeAggregator struct {
+	scope       pcommon.InstrumentationScope
+	logCounters map[[16]byte]*logCounter
+}
+
+// newScopeAggregator creates a new scopeAggregator with a copy of the scope.
+func newScopeAggregator(scope pcommon.InstrumentationScope) *scopeAggregator {
+	s := pcommon.NewInstrumentationScope()
+	scope.CopyTo(s)
+	return &scopeAggregator{
+		scope:       s,
+		logCounters: make(map[[16]byte]*logCounter),
+	}
+}
+
+// Add increments the counter that the logRecord matches.
+func (s *scopeAggregator) Add(logRecord plog.LogRecord) {
+	key := getLogKey(logRecord)
+	lc, ok := s.logCounters[key]
+	if !ok {
+		lc = newLogCounter(logRecord)
+		s.logCounters[key] = lc
+	}
+	lc.Increment(logRecord)
+}
+
+// logCounter is a counter for a log record.
+type logCounter struct {
+	logRecord              plog.LogRecord
+	firstObservedTimestamp time.Time
+	lastObservedTimestamp  time.Time
+	count                  int64
+}
+
+// newLogCounter creates a new logCounter for the first occurrence of a log record.
+func newLogCounter(logRecord plog.LogRecord) *logCounter {
+	return &logCounter{
+		logRecord:              logRecord,
+		count:                  0,
+		firstObservedTimestamp: observedTime(logRecord),
+	}
+}
+
+// Increment increments the counter for another occurrence of the log record.
+func (a *logCounter) Increment(logRecord plog.LogRecord) {
+	a.lastObservedTimestamp = observedTime(logRecord)
+	a.count++
+}
+
+// observedTime returns the observed timestamp of the log record, or the current time if it is not set.
+func observedTime(logRecord plog.LogRecord) time.Time {
+	if ts := logRecord.ObservedTimestamp(); ts != 0 {
+		return ts.AsTime()
+	}
+	return timeNow().UTC()
+}
+
+// getScopeKey creates a unique key for the scope from its name, version and attributes.
+func getScopeKey(scope pcommon.InstrumentationScope) [16]byte {
+	m := pcommon.NewMap()
+	m.EnsureCapacity(3)
+	m.PutStr("name", scope.Name())
+	m.PutStr("version", scope.Version())
+	scope.Attributes().CopyTo(m.PutEmptyMap("attributes"))
+	return pdatautil.MapHash(m)
+}
+
+// getLogKey creates a unique key for the log record from its body, severity and attributes.
+func getLogKey(logRecord plog.LogRecord) [16]byte {
+	m := pcommon.NewMap()
+	m.EnsureCapacity(4)
+	logRecord.Body().CopyTo(m.PutEmpty(bodyField))
+	m.PutInt("severity_number", int64(logRecord.SeverityNumber()))
+	m.PutStr("severity_text", logRecord.SeverityText())
+	logRecord.Attributes().CopyTo(m.PutEmptyMap(attributeField))
+	return pdatautil.MapHash(m)
+}
@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+func TestLogAggregatorAdd(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("host.name", "host")
+	scope := pcommon.NewInstrumentationScope()
+	scope.SetName("scope")
+
+	aggregator := newLogAggregator(defaultLogCountAttribute, time.UTC, nil)
+
+	// Two identical log records and one with a different severity
+	aggregator.Add(resource, scope, newTestLogRecord("message", plog.SeverityNumberInfo))
+	aggregator.Add(resource, scope, newTestLogRecord("message", plog.SeverityNumberInfo))
+	aggregator.Add(resource, scope, newTestLogRecord("message", plog.SeverityNumberError))
+
+	// A different scope of the same resource
+	otherScope := pcommon.NewInstrumentationScope()
+	otherScope.SetName("other")
+	aggregator.Add(resource, otherScope, newTestLogRecord("message", plog.SeverityNumberInfo))
+
+	// A different resource
+	otherResource := pcommon.NewResource()
+	otherResource.Attributes().PutStr("host.name", "other")
+	aggregator.Add(otherResource, scope, newTestLogRecord("message", plog.SeverityNumberInfo))
+
+	require.Len(t, aggregator.resources, 2)
+	resourceAggregator := aggregator.resources[pdatautil.MapHash(resource.Attributes())]
+	require.NotNil(t, resourceAggregator)
+	require.Len(t, resourceAggregator.scopes, 2)
+
+	scopeAggregator := resourceAggregator.scopes[getScopeKey(scope)]
+	require.NotNil(t, scopeAggregator)
+	require.Len(t, scopeAggregator.logCounters, 2)
+
+	counter := scopeAggregator.logCounters[getLogKey(newTestLogRecord("message", plog.SeverityNumberInfo))]
+	require.NotNil(t, counter)
+	require.Equal(t, int64(2), counter.count)
+}
+
+func TestLogAggregatorIncludeAttributes(t *testing.T) {
+	resource := pcommon.NewResource()
+	scope := pcommon.NewInstrumentationScope()
+
+	aggregator := newLogAggregator(defaultLogCountAttribute, time.UTC, []string{"service"})
+
+	first := newTestLogRecord("message", plog.SeverityNumberInfo)
+	first.Attributes().PutStr("service", "api")
+	first.Attributes().PutStr("request_id", "1")
+	second := newTestLogRecord("message", plog.SeverityNumberInfo)
+	second.Attributes().PutStr("service", "api")
+	second.Attributes().PutStr("request_id", "2")
+
+	aggregator.Add(resource, scope, first)
+	aggregator.Add(resource, scope, second)
+
+	logs := aggregator.Export()
+	require.Equal(t, 1, logs.LogRecordCount())
+
+	attrs := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes()
+	_, ok := attrs.Get("request_id")
+	require.False(t, ok)
+	service, ok := attrs.Get("service")
+	require.True(t, ok)
+	require.Equal(t, "api", service.Str())
+	count, ok := attrs.Get(defaultLogCountAttribute)
+	require.True(t, ok)
+	require.Equal(t, int64(2), count.Int())
+}
+
+func TestLogAggregatorExport(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	firstTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	lastTime := firstTime.Add(5 * time.Second)
+	exportTime := lastTime.Add(5 * time.Second)
+
+	oldTimeNow := timeNow
+	defer func() { timeNow = oldTimeNow }()
+	timeNow = func() time.Time { return exportTime }
+
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("host.name", "host")
+	scope := pcommon.NewInstrumentationScope()
+	scope.SetName("scope")
+	scope.SetVersion("1.0.0")
+
+	aggregator := newLogAggregator("count", location, nil)
+
+	first := newTestLogRecord("message", plog.SeverityNumberInfo)
+	first.SetObservedTimestamp(pcommon.NewTimestampFromTime(firstTime))
+	aggregator.Add(resource, scope, first)
+
+	last := newTestLogRecord("message", plog.SeverityNumberInfo)
+	last.SetObservedTimestamp(pcommon.NewTimestampFromTime(lastTime))
+	aggregator.Add(resource, scope, last)
+
+	logs := aggregator.Export()
+	require.Equal(t, 1, logs.ResourceLogs().Len())
+
+	resourceLogs := logs.ResourceLogs().At(0)
+	require.Equal(t, resource.Attributes().AsRaw(), resourceLogs.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, resourceLogs.ScopeLogs().Len())
+
+	scopeLogs := resourceLogs.ScopeLogs().At(0)
+	require.Equal(t, "scope", scopeLogs.Scope().Name())
+	require.Equal(t, "1.0.0", scopeLogs.Scope().Version())
+	require.Equal(t, 1, scopeLogs.LogRecords().Len())
+
+	logRecord := scopeLogs.LogRecords().At(0)
+	require.Equal(t, "message", logRecord.Body().Str())
+	require.Equal(t, plog.SeverityNumberInfo, logRecord.SeverityNumber())
+	require.Equal(t, pcommon.NewTimestampFromTime(exportTime), logRecord.ObservedTimestamp())
+	require.Equal(t, pcommon.NewTimestampFromTime(exportTime), logRecord.Timestamp())
+	require.Equal(t, map[string]any{
+		"count":             int64(2),
+		firstObservedTSAttr: "2023-01-01T07:00:00-05:00",
+		lastObservedTSAttr:  "2023-01-01T07:00:05-05:00",
+	}, logRecord.Attributes().AsRaw())
+
+	aggregator.Reset()
+	require.Equal(t, 0, aggregator.Export().LogRecordCount())
+}
+
+func TestObservedTimeDefaultsToNow(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	oldTimeNow := timeNow
+	defer func() { timeNow = oldTimeNow }()
+	timeNow = func() time.Time { return now }
+
+	require.Equal(t, now, observedTime(plog.NewLogRecord()))
+}
+
+func newTestLogRecord(body string, severity plog.SeverityNumber) plog.LogRecord {
+	logRecord := plog.NewLogRecord()
+	logRecord.Body().SetStr(body)
+	logRecord.SetSeverityNumber(severity)
+	return logRecord
+}
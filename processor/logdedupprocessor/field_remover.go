@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	// fieldDelimiter is the delimiter used to split a field key into its parts.
+	fieldDelimiter = "."
+
+	// fieldEscapeKeyReplacement is the string used to temporarily replace escaped delimiters while splitting a field key.
+	fieldEscapeKeyReplacement = "{TEMP_REPLACE}"
+)
+
+// fieldRemover removes the excluded fields from log records.
+type fieldRemover struct {
+	fields []*field
+}
+
+// field is the path of a field to remove, within the body or the attributes.
+type field struct {
+	keyParts []string
+}
+
+// newFieldRemover creates a fieldRemover for the given fields, which are validated by the config.
+func newFieldRemover(fieldKeys []string) *fieldRemover {
+	fe := &fieldRemover{
+		fields: make([]*field, 0, len(fieldKeys)),
+	}
+
+	for _, f := range fieldKeys {
+		fe.fields = append(fe.fields, &field{
+			keyParts: splitField(f),
+		})
+	}
+
+	return fe
+}
+
+// RemoveFields removes the excluded fields from the log record.
+func (fe *fieldRemover) RemoveFields(logRecord plog.LogRecord) {
+	for _, f := range fe.fields {
+		f.removeField(logRecord)
+	}
+}
+
+// removeField removes the field from the log record if it exists.
+func (f *field) removeField(logRecord plog.LogRecord) {
+	firstPart, remainingParts := f.keyParts[0], f.keyParts[1:]
+
+	switch firstPart {
+	case bodyField:
+		// The body can only have fields removed if it is a map
+		if logRecord.Body().Type() != pcommon.ValueTypeMap {
+			return
+		}
+		removeFieldFromMap(logRecord.Body().Map(), remainingParts)
+	case attributeField:
+		removeFieldFromMap(logRecord.Attributes(), remainingParts)
+	}
+}
+
+// removeFieldFromMap removes the field at the given path from the map.
+func removeFieldFromMap(valueMap pcommon.Map, keyParts []string) {
+	if len(keyParts) == 0 {
+		return
+	}
+
+	nextKeyPart, remainingParts := keyParts[0], keyParts[1:]
+	if len(remainingParts) == 0 {
+		valueMap.Remove(nextKeyPart)
+		return
+	}
+
+	value, ok := valueMap.Get(nextKeyPart)
+	if !ok || value.Type() != pcommon.ValueTypeMap {
+		return
+	}
+	removeFieldFromMap(value.Map(), remainingParts)
+}
+
+// splitField splits a field key into its parts.
+// Delimiters that are escaped with a backslash are kept in the key parts.
+func splitField(fieldKey string) []string {
+	escapedKey := strings.ReplaceAll(fieldKey, "\\"+fieldDelimiter, fieldEscapeKeyReplacement)
+
+	keyParts := strings.Split(escapedKey, fieldDelimiter)
+	for i, part := range keyParts {
+		keyParts[i] = strings.ReplaceAll(part, fieldEscapeKeyReplacement, fieldDelimiter)
+	}
+
+	return keyParts
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestSplitField(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		field    string
+		expected []string
+	}{
+		{
+			desc:     "simple",
+			field:    "body.field",
+			expected: []string{"body", "field"},
+		},
+		{
+			desc:     "nested",
+			field:    "attributes.nested.field",
+			expected: []string{"attributes", "nested", "field"},
+		},
+		{
+			desc:     "escaped delimiter",
+			field:    `attributes.k8s\.pod\.name`,
+			expected: []string{"attributes", "k8s.pod.name"},
+		},
+		{
+			desc:     "single part",
+			field:    "body",
+			expected: []string{"body"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			require.Equal(t, tc.expected, splitField(tc.field))
+		})
+	}
+}
+
+func TestRemoveFields(t *testing.T) {
+	remover := newFieldRemover([]string{
+		"body.timestamp",
+		"body.nested.id",
+		"body.missing.field",
+		"attributes.request_id",
+		`attributes.k8s\.pod\.name`,
+	})
+
+	logRecord := plog.NewLogRecord()
+	body := logRecord.Body().SetEmptyMap()
+	body.PutStr("timestamp", "2023-01-01T00:00:00Z")
+	body.PutStr("message", "hello")
+	nested := body.PutEmptyMap("nested")
+	nested.PutStr("id", "1234")
+	nested.PutStr("keep", "value")
+	body.PutStr("missing", "not a map")
+	logRecord.Attributes().PutStr("request_id", "abcd")
+	logRecord.Attributes().PutStr("k8s.pod.name", "pod")
+	logRecord.Attributes().PutStr("host", "localhost")
+
+	remover.RemoveFields(logRecord)
+
+	expectedBody := pcommon.NewMap()
+	expectedBody.PutStr("message", "hello")
+	expectedBody.PutEmptyMap("nested").PutStr("keep", "value")
+	expectedBody.PutStr("missing", "not a map")
+	require.Equal(t, expectedBody.AsRaw(), logRecord.Body().Map().AsRaw())
+	require.Equal(t, map[string]any{"host": "localhost"}, logRecord.Attributes().AsRaw())
+}
+
+func TestRemoveFieldsStringBody(t *testing.T) {
+	remover := newFieldRemover([]string{"body.field"})
+
+	logRecord := plog.NewLogRecord()
+	logRecord.Body().SetStr("body.field")
+
+	remover.RemoveFields(logRecord)
+	require.Equal(t, "body.field", logRecord.Body().Str())
+}
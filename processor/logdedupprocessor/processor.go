@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logdedupprocessor"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+// logDedupProcessor is a logDedupProcessor that counts duplicate instances of logs.
+type logDedupProcessor struct {
+	emitInterval time.Duration
+	aggregator   *logAggregator
+	remover      *fieldRemover
+	consumer     consumer.Logs
+	logger       *zap.Logger
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+	mux          sync.Mutex
+}
+
+func newProcessor(cfg *Config, consumer consumer.Logs, logger *zap.Logger) (*logDedupProcessor, error) {
+	// This should not happen due to config validation but we check anyways.
+	timezone, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %w", err)
+	}
+
+	return &logDedupProcessor{
+		emitInterval: cfg.Interval,
+		aggregator:   newLogAggregator(cfg.LogCountAttribute, timezone, cfg.IncludeAttributes),
+		remover:      newFieldRemover(cfg.ExcludeFields),
+		consumer:     consumer,
+		logger:       logger,
+	}, nil
+}
+
+// Start starts the processor.
+func (p *logDedupProcessor) Start(ctx context.Context, _ component.Host) error {
+	ctx, cancel := context.WithCancel(ctx)
+	p.cancel = cancel
+
+	p.wg.Add(1)
+	go p.handleExportInterval(ctx)
+
+	return nil
+}
+
+// Capabilities returns the consumer's capabilities.
+func (p *logDedupProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// Shutdown stops the processor and emits the log records that were not emitted yet.
+func (p *logDedupProcessor) Shutdown(ctx context.Context) error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+
+	doneChan := make(chan struct{})
+	go func() {
+		defer close(doneChan)
+		p.wg.Wait()
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-doneChan:
+	}
+
+	p.exportLogs(ctx)
+	return nil
+}
+
+// ConsumeLogs processes the logs.
+func (p *logDedupProcessor) ConsumeLogs(_ context.Context, pl plog.Logs) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	for i := 0; i < pl.ResourceLogs().Len(); i++ {
+		resourceLogs := pl.ResourceLogs().At(i)
+		resource := resourceLogs.Resource()
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			scopeLogs := resourceLogs.ScopeLogs().At(j)
+			scope := scopeLogs.Scope()
+			logs := scopeLogs.LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				// The incoming logs are not mutated, so the log records are copied
+				// before the excluded fields are removed.
+				logRecord := plog.NewLogRecord()
+				logs.At(k).CopyTo(logRecord)
+
+				p.remover.RemoveFields(logRecord)
+				p.aggregator.Add(resource, scope, logRecord)
+			}
+		}
+	}
+
+	return nil
+}
+
+// handleExportInterval sends the deduplicated logs to the next consumer at every interval.
+func (p *logDedupProcessor) handleExportInterval(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.emitInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.exportLogs(ctx)
+		}
+	}
+}
+
+// exportLogs sends the deduplicated logs to the next consumer and resets the counters.
+func (p *logDedupProcessor) exportLogs(ctx context.Context) {
+	p.mux.Lock()
+	logs := p.aggregator.Export()
+	p.aggregator.Reset()
+	p.mux.Unlock()
+
+	if logs.LogRecordCount() == 0 {
+		return
+	}
+
+	if err := p.consumer.ConsumeLogs(ctx, logs); err != nil {
+		p.logger.Error("failed to consume logs", zap.Error(err))
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logdedupprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+func TestProcessorConsumeLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = 1 * time.Hour
+	cfg.ExcludeFields = []string{"attributes.request_id"}
+
+	sink := new(consumertest.LogsSink)
+	p, err := newProcessor(cfg, sink, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	resourceLogs.Resource().Attributes().PutStr("host.name", "host")
+	logRecords := resourceLogs.ScopeLogs().AppendEmpty().LogRecords()
+	for _, requestID := range []string{"1", "2", "3"} {
+		lr := logRecords.AppendEmpty()
+		lr.Body().SetStr("request failed")
+		lr.SetSeverityNumber(plog.SeverityNumberError)
+		lr.Attributes().PutStr("request_id", requestID)
+	}
+	other := logRecords.AppendEmpty()
+	other.Body().SetStr("request succeeded")
+	other.SetSeverityNumber(plog.SeverityNumberInfo)
+
+	require.NoError(t, p.ConsumeLogs(context.Background(), logs))
+
+	// The incoming logs are not modified
+	_, ok := logRecords.At(0).Attributes().Get("request_id")
+	require.True(t, ok)
+
+	// Nothing is emitted before the interval elapses
+	require.Empty(t, sink.AllLogs())
+
+	// The remaining logs are emitted on shutdown
+	require.NoError(t, p.Shutdown(context.Background()))
+	require.Len(t, sink.AllLogs(), 1)
+
+	emitted := sink.AllLogs()[0]
+	require.Equal(t, 2, emitted.LogRecordCount())
+
+	counts := map[string]int64{}
+	emittedRecords := emitted.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	for i := 0; i < emittedRecords.Len(); i++ {
+		lr := emittedRecords.At(i)
+		_, ok := lr.Attributes().Get("request_id")
+		require.False(t, ok)
+		count, ok := lr.Attributes().Get(defaultLogCountAttribute)
+		require.True(t, ok)
+		counts[lr.Body().Str()] = count.Int()
+	}
+	require.Equal(t, map[string]int64{"request failed": 3, "request succeeded": 1}, counts)
+}
+
+func TestProcessorExportInterval(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Interval = 10 * time.Millisecond
+
+	sink := new(consumertest.LogsSink)
+	p, err := newProcessor(cfg, sink, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	logs := plog.NewLogs()
+	lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStr("message")
+	require.NoError(t, p.ConsumeLogs(context.Background(), logs))
+
+	require.Eventually(t, func() bool {
+		return len(sink.AllLogs()) == 1
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestProcessorConsumerError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+
+	p, err := newProcessor(cfg, consumertest.NewErr(errors.New("failed")), zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("message")
+	require.NoError(t, p.ConsumeLogs(context.Background(), logs))
+
+	// Errors of the next consumer are logged and not returned
+	require.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestProcessorCapabilities(t *testing.T) {
+	p, err := newProcessor(createDefaultConfig().(*Config), consumertest.NewNop(), zap.NewNop())
+	require.NoError(t, err)
+	require.False(t, p.Capabilities().MutatesData)
+}
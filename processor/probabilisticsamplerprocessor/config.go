@@ -7,6 +7,8 @@ import (
 	"fmt"
 
 	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor/internal/sampling"
 )
 
 type AttributeSource string
@@ -46,6 +48,16 @@ type Config struct {
 	// SamplingPriority (logs only) allows to use a log record attribute designed by the `sampling_priority` key
 	// to be used as the sampling priority of the log record.
 	SamplingPriority string `mapstructure:"sampling_priority"`
+
+	// Mode selects how the sampling decision is made. The allowed values are `hash_seed`, `proportional` and
+	// `equalizing`. Default is `hash_seed`. The `proportional` and `equalizing` modes use the randomness and
+	// threshold of the W3C tracestate of spans, or of the `sampling.randomness` and `sampling.threshold`
+	// attributes of log records, and record the threshold they sampled with so that adjusted counts stay correct.
+	Mode SamplerMode `mapstructure:"mode"`
+
+	// SamplingPrecision is the number of hex digits used to encode the sampling threshold, in addition to its
+	// leading "f" digits, in the `proportional` and `equalizing` modes. The allowed values are 1 to 14. Default is 4.
+	SamplingPrecision int `mapstructure:"sampling_precision"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -58,5 +70,13 @@ func (cfg *Config) Validate() error {
 	if cfg.AttributeSource != "" && !validAttributeSource[cfg.AttributeSource] {
 		return fmt.Errorf("invalid attribute source: %v. Expected: %v or %v", cfg.AttributeSource, traceIDAttributeSource, recordAttributeSource)
 	}
+	if !validModes[cfg.Mode] {
+		return fmt.Errorf("invalid sampler mode: %v. Expected: %v, %v or %v", cfg.Mode, HashSeed, Proportional, Equalizing)
+	}
+	// The precision is only used by the modes encoding the sampling threshold.
+	consistent := cfg.Mode == Proportional || cfg.Mode == Equalizing
+	if consistent && (cfg.SamplingPrecision < 1 || cfg.SamplingPrecision > sampling.NumHexDigits) {
+		return fmt.Errorf("invalid sampling precision: %d. Expected a value between 1 and %d", cfg.SamplingPrecision, sampling.NumHexDigits)
+	}
 	return nil
 }
@@ -28,6 +28,8 @@ func TestLoadConfig(t *testing.T) {
 				SamplingPercentage: 15.3,
 				HashSeed:           22,
 				AttributeSource:    "traceID",
+				Mode:               HashSeed,
+				SamplingPrecision:  4,
 			},
 		},
 		{
@@ -38,6 +40,17 @@ func TestLoadConfig(t *testing.T) {
 				AttributeSource:    "record",
 				FromAttribute:      "foo",
 				SamplingPriority:   "bar",
+				Mode:               HashSeed,
+				SamplingPrecision:  4,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "proportional"),
+			expected: &Config{
+				SamplingPercentage: 25,
+				AttributeSource:    "traceID",
+				Mode:               Proportional,
+				SamplingPrecision:  6,
 			},
 		},
 	}
@@ -72,3 +85,36 @@ func TestLoadInvalidConfig(t *testing.T) {
 	_, err = otelcoltest.LoadConfigAndValidate(filepath.Join("testdata", "invalid.yaml"), factories)
 	require.ErrorContains(t, err, "negative sampling rate: -15.30")
 }
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		err  string
+	}{
+		{
+			name: "invalid mode",
+			cfg:  &Config{Mode: "random", SamplingPrecision: defaultPrecision},
+			err:  "invalid sampler mode: random",
+		},
+		{
+			name: "precision too low",
+			cfg:  &Config{Mode: Equalizing},
+			err:  "invalid sampling precision: 0",
+		},
+		{
+			name: "precision too high",
+			cfg:  &Config{Mode: Equalizing, SamplingPrecision: 15},
+			err:  "invalid sampling precision: 15",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, tt.cfg.Validate(), tt.err)
+		})
+	}
+
+	// The precision is not used by the hash_seed mode.
+	assert.NoError(t, (&Config{SamplingPercentage: 15}).Validate())
+	assert.NoError(t, (&Config{Mode: HashSeed}).Validate())
+}
@@ -36,7 +36,9 @@ func NewFactory() processor.Factory {
 
 func createDefaultConfig() component.Config {
 	return &Config{
-		AttributeSource: defaultAttributeSource,
+		AttributeSource:   defaultAttributeSource,
+		Mode:              defaultMode,
+		SamplingPrecision: defaultPrecision,
 	}
 }
 
@@ -16,6 +16,15 @@ func computeHash(b []byte, seed uint32) uint32 {
 	return hash.Sum32()
 }
 
+// computeHash64 creates a 64 bit hash using the FNV-1a algorithm
+func computeHash64(b []byte, seed uint32) uint64 {
+	hash := fnv.New64a()
+	// the implementation fnv.Write() does not return an error, see hash/fnv/fnv.go
+	_, _ = hash.Write(i32tob(seed))
+	_, _ = hash.Write(b)
+	return hash.Sum64()
+}
+
 // i32tob converts a seed to a byte array to be used as part of fnv.Write()
 func i32tob(val uint32) []byte {
 	r := make([]byte, 4)
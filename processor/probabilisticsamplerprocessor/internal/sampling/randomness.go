@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor/internal/sampling"
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// ErrRValueSize is returned for a randomness value that doesn't have exactly 14 hex digits.
+var ErrRValueSize = errors.New("randomness must have exactly 14 hex digits")
+
+const randomnessMask = MaxAdjustedCount - 1
+
+// Randomness is the 56 bit random value that is compared to a Threshold
+// to make a consistent sampling decision.
+type Randomness struct {
+	unsigned uint64
+}
+
+// TraceIDToRandomness returns the randomness of the trace ID, which is
+// made of its least significant 56 bits as defined by W3C Trace Context Level 2.
+func TraceIDToRandomness(id pcommon.TraceID) Randomness {
+	return Randomness{unsigned: binary.BigEndian.Uint64(id[8:]) & randomnessMask}
+}
+
+// RValueToRandomness parses the hex encoded randomness of the `rv` tracestate key.
+func RValueToRandomness(s string) (Randomness, error) {
+	if len(s) != NumHexDigits {
+		return Randomness{}, ErrRValueSize
+	}
+	v, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return Randomness{}, err
+	}
+	return Randomness{unsigned: v}, nil
+}
+
+// UnsignedToRandomness returns the randomness made of the 56 least significant bits of x.
+func UnsignedToRandomness(x uint64) Randomness {
+	return Randomness{unsigned: x & randomnessMask}
+}
+
+// RValue returns the encoding of the randomness for the `rv` tracestate key.
+func (r Randomness) RValue() string {
+	// Adding 2^56 pads the encoding with zeros up to 14 digits, after the leading 1.
+	return strconv.FormatUint(r.unsigned+MaxAdjustedCount, 16)[1:]
+}
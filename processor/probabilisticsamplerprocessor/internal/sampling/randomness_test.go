@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestTraceIDToRandomness(t *testing.T) {
+	id := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 0xa, 0xb, 0xc, 0xd, 0xe, 0xf, 0x10})
+	assert.Equal(t, "0a0b0c0d0e0f10", TraceIDToRandomness(id).RValue())
+}
+
+func TestRValueToRandomness(t *testing.T) {
+	r, err := RValueToRandomness("00000000000001")
+	require.NoError(t, err)
+	assert.Equal(t, UnsignedToRandomness(1), r)
+	assert.Equal(t, "00000000000001", r.RValue())
+
+	_, err = RValueToRandomness("1")
+	assert.ErrorIs(t, err, ErrRValueSize)
+	_, err = RValueToRandomness("0000000000000g")
+	assert.Error(t, err)
+}
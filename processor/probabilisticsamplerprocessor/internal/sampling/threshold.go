@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor/internal/sampling"
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// NumHexDigits is the number of hex digits of the 56 bits
+	// that randomness values and thresholds are made of.
+	NumHexDigits = 56 / 4
+
+	// MaxAdjustedCount is the adjusted count of the smallest sampling probability, 2^56.
+	// It is also the number of distinct randomness values.
+	MaxAdjustedCount = 1 << 56
+)
+
+var (
+	// ErrTValueSize is returned for a threshold with an invalid number of hex digits.
+	ErrTValueSize = errors.New("threshold must have between 1 and 14 hex digits")
+
+	// ErrProbabilityRange is returned for a sampling probability outside of (0, 1].
+	ErrProbabilityRange = errors.New("sampling probability out of the range (0, 1]")
+
+	// ErrPrecisionRange is returned for a precision outside of [1, 14].
+	ErrPrecisionRange = errors.New("sampling precision must be between 1 and 14")
+)
+
+// Threshold is the rejection threshold of consistent probability sampling,
+// as defined by the OpenTelemetry tracestate specification.
+// An item is sampled when its randomness is greater than or equal to the threshold.
+type Threshold struct {
+	unsigned uint64
+}
+
+var (
+	// AlwaysSampleThreshold is the threshold that samples all items, encoded as "th:0".
+	AlwaysSampleThreshold = Threshold{unsigned: 0}
+
+	// NeverSampleThreshold is the threshold that rejects all items. It cannot be encoded.
+	NeverSampleThreshold = Threshold{unsigned: MaxAdjustedCount}
+)
+
+// TValueToThreshold parses the hex encoded threshold of the `th` tracestate key.
+// Trailing zeros are omitted from the encoding, so "8" is the 50% threshold.
+func TValueToThreshold(s string) (Threshold, error) {
+	if len(s) == 0 || len(s) > NumHexDigits {
+		return AlwaysSampleThreshold, ErrTValueSize
+	}
+	v, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return AlwaysSampleThreshold, err
+	}
+	return Threshold{unsigned: v << (4 * (NumHexDigits - len(s)))}, nil
+}
+
+// ProbabilityToThresholdWithPrecision returns the threshold of the sampling probability,
+// rounded so that it is encoded using precision hex digits in addition to its leading "f" digits,
+// which keeps the relative error of small probabilities low.
+func ProbabilityToThresholdWithPrecision(probability float64, precision int) (Threshold, error) {
+	if !(probability > 0 && probability <= 1) {
+		return AlwaysSampleThreshold, ErrProbabilityRange
+	}
+	if precision < 1 || precision > NumHexDigits {
+		return AlwaysSampleThreshold, ErrPrecisionRange
+	}
+
+	threshold := MaxAdjustedCount - uint64(math.Round(probability*MaxAdjustedCount))
+
+	// Each leading "f" digit is an order of magnitude of the probability
+	// that would otherwise be lost to rounding.
+	for shift := 4 * (NumHexDigits - 1); shift >= 0 && precision < NumHexDigits; shift -= 4 {
+		if (threshold>>shift)&0xf != 0xf {
+			break
+		}
+		precision++
+	}
+
+	if shift := 4 * (NumHexDigits - precision); shift > 0 {
+		threshold = (threshold + 1<<(shift-1)) >> shift << shift
+		if threshold >= MaxAdjustedCount {
+			// The probability is positive, so at least one value must be sampled.
+			threshold = MaxAdjustedCount - 1<<shift
+		}
+	}
+	return Threshold{unsigned: threshold}, nil
+}
+
+// TValue returns the encoding of the threshold for the `th` tracestate key.
+// It must not be called on the NeverSampleThreshold.
+func (t Threshold) TValue() string {
+	if t == AlwaysSampleThreshold {
+		return "0"
+	}
+	// Adding 2^56 pads the encoding with zeros up to 14 digits, after the leading 1.
+	return strings.TrimRight(strconv.FormatUint(t.unsigned+MaxAdjustedCount, 16)[1:], "0")
+}
+
+// ShouldSample returns true if the item with the randomness is sampled by the threshold.
+func (t Threshold) ShouldSample(r Randomness) bool {
+	return r.unsigned >= t.unsigned
+}
+
+// Probability returns the sampling probability of the threshold.
+func (t Threshold) Probability() float64 {
+	return float64(MaxAdjustedCount-t.unsigned) / MaxAdjustedCount
+}
+
+// AdjustedCount returns the number of items that each sampled item represents, or 0 if no item is sampled.
+func (t Threshold) AdjustedCount() float64 {
+	if t == NeverSampleThreshold {
+		return 0
+	}
+	return MaxAdjustedCount / float64(MaxAdjustedCount-t.unsigned)
+}
+
+// ThresholdGreater returns true if a samples fewer items than b.
+func ThresholdGreater(a, b Threshold) bool {
+	return a.unsigned > b.unsigned
+}
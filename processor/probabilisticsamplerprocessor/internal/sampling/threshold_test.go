@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTValueToThreshold(t *testing.T) {
+	tests := []struct {
+		in          string
+		probability float64
+		err         bool
+	}{
+		{in: "0", probability: 1},
+		{in: "8", probability: 0.5},
+		{in: "c", probability: 0.25},
+		{in: "c0000000000000", probability: 0.25},
+		{in: "f", probability: 0.0625},
+		{in: "", err: true},
+		{in: "123456789abcdef", err: true},
+		{in: "g", err: true},
+		{in: "-1", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			th, err := TValueToThreshold(tt.in)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.probability, th.Probability())
+		})
+	}
+}
+
+func TestThresholdTValue(t *testing.T) {
+	for _, in := range []string{"0", "8", "c", "fd", "01", "123456789abcde"} {
+		th, err := TValueToThreshold(in)
+		require.NoError(t, err)
+		assert.Equal(t, in, th.TValue())
+	}
+}
+
+func TestProbabilityToThresholdWithPrecision(t *testing.T) {
+	tests := []struct {
+		name        string
+		probability float64
+		precision   int
+		tvalue      string
+	}{
+		{name: "always", probability: 1, precision: 4, tvalue: "0"},
+		{name: "half", probability: 0.5, precision: 4, tvalue: "8"},
+		{name: "quarter", probability: 0.25, precision: 1, tvalue: "c"},
+		{name: "third", probability: 1.0 / 3, precision: 4, tvalue: "aaab"},
+		{name: "third low precision", probability: 1.0 / 3, precision: 1, tvalue: "b"},
+		{name: "small", probability: 0.001, precision: 3, tvalue: "ffbe7"},
+		{name: "tiny", probability: 1e-17, precision: 2, tvalue: "ffffffffffffff"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th, err := ProbabilityToThresholdWithPrecision(tt.probability, tt.precision)
+			require.NoError(t, err)
+			assert.Equal(t, tt.tvalue, th.TValue())
+		})
+	}
+
+	_, err := ProbabilityToThresholdWithPrecision(0, 4)
+	assert.ErrorIs(t, err, ErrProbabilityRange)
+	_, err = ProbabilityToThresholdWithPrecision(1.5, 4)
+	assert.ErrorIs(t, err, ErrProbabilityRange)
+	_, err = ProbabilityToThresholdWithPrecision(0.5, 15)
+	assert.ErrorIs(t, err, ErrPrecisionRange)
+}
+
+func TestThresholdShouldSample(t *testing.T) {
+	th, err := TValueToThreshold("8")
+	require.NoError(t, err)
+
+	low, err := RValueToRandomness("7fffffffffffff")
+	require.NoError(t, err)
+	high, err := RValueToRandomness("80000000000000")
+	require.NoError(t, err)
+
+	assert.False(t, th.ShouldSample(low))
+	assert.True(t, th.ShouldSample(high))
+	assert.True(t, AlwaysSampleThreshold.ShouldSample(low))
+	assert.False(t, NeverSampleThreshold.ShouldSample(UnsignedToRandomness(MaxAdjustedCount-1)))
+}
+
+func TestThresholdAdjustedCount(t *testing.T) {
+	th, err := TValueToThreshold("c")
+	require.NoError(t, err)
+	assert.Equal(t, 4.0, th.AdjustedCount())
+	assert.Equal(t, 1.0, AlwaysSampleThreshold.AdjustedCount())
+	assert.Equal(t, 0.0, NeverSampleThreshold.AdjustedCount())
+
+	assert.True(t, ThresholdGreater(th, AlwaysSampleThreshold))
+	assert.False(t, ThresholdGreater(th, th))
+}
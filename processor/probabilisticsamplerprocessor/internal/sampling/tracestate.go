@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor/internal/sampling"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// OpenTelemetryVendorKey is the W3C tracestate key of the OpenTelemetry values.
+	OpenTelemetryVendorKey = "ot"
+
+	rValueKey = "rv"
+	tValueKey = "th"
+
+	maxListMembers = 32
+)
+
+var (
+	// ErrTraceStateSyntax is returned for a malformed tracestate.
+	ErrTraceStateSyntax = errors.New("invalid tracestate syntax")
+
+	// ErrTraceStateDuplicateKey is returned for a tracestate with a key set more than once.
+	ErrTraceStateDuplicateKey = errors.New("duplicate tracestate key")
+)
+
+// W3CTraceState is a parsed W3C tracestate, where the OpenTelemetry
+// values are parsed and all the other list members are kept as is.
+type W3CTraceState struct {
+	otel   OpenTelemetryTraceState
+	others []string
+}
+
+// NewW3CTraceState parses the W3C tracestate.
+func NewW3CTraceState(input string) (W3CTraceState, error) {
+	var w W3CTraceState
+	members := strings.Split(input, ",")
+	if len(members) > maxListMembers {
+		return w, fmt.Errorf("%w: more than %d list members", ErrTraceStateSyntax, maxListMembers)
+	}
+	seen := map[string]bool{}
+	for _, member := range members {
+		member = strings.Trim(member, " \t")
+		if member == "" {
+			// Empty list members are allowed.
+			continue
+		}
+		key, value, ok := strings.Cut(member, "=")
+		if !ok || key == "" || value == "" {
+			return w, fmt.Errorf("%w: %q", ErrTraceStateSyntax, member)
+		}
+		if seen[key] {
+			return w, fmt.Errorf("%w: %q", ErrTraceStateDuplicateKey, key)
+		}
+		seen[key] = true
+
+		if key != OpenTelemetryVendorKey {
+			w.others = append(w.others, member)
+			continue
+		}
+		otel, err := NewOpenTelemetryTraceState(value)
+		if err != nil {
+			return w, err
+		}
+		w.otel = otel
+	}
+	return w, nil
+}
+
+// OTelValue returns the OpenTelemetry values of the tracestate, which can be updated in place.
+func (w *W3CTraceState) OTelValue() *OpenTelemetryTraceState {
+	return &w.otel
+}
+
+// String returns the encoding of the tracestate. The OpenTelemetry
+// list member is first, since it is the one that is updated.
+func (w *W3CTraceState) String() string {
+	members := make([]string, 0, len(w.others)+1)
+	if otel := w.otel.String(); otel != "" {
+		members = append(members, OpenTelemetryVendorKey+"="+otel)
+	}
+	return strings.Join(append(members, w.others...), ",")
+}
+
+// OpenTelemetryTraceState is the parsed value of the `ot` tracestate key,
+// where the sampling randomness and threshold are parsed and all the other values are kept as is.
+type OpenTelemetryTraceState struct {
+	randomness    Randomness
+	hasRandomness bool
+	threshold     Threshold
+	hasThreshold  bool
+	others        []string
+}
+
+// NewOpenTelemetryTraceState parses the value of the `ot` tracestate key.
+func NewOpenTelemetryTraceState(input string) (OpenTelemetryTraceState, error) {
+	var o OpenTelemetryTraceState
+	if input == "" {
+		return o, nil
+	}
+	seen := map[string]bool{}
+	for _, member := range strings.Split(input, ";") {
+		key, value, ok := strings.Cut(member, ":")
+		if !ok || key == "" {
+			return o, fmt.Errorf("%w: %q", ErrTraceStateSyntax, member)
+		}
+		if seen[key] {
+			return o, fmt.Errorf("%w: %q", ErrTraceStateDuplicateKey, key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case rValueKey:
+			o.randomness, err = RValueToRandomness(value)
+			o.hasRandomness = true
+		case tValueKey:
+			o.threshold, err = TValueToThreshold(value)
+			o.hasThreshold = true
+		default:
+			o.others = append(o.others, member)
+		}
+		if err != nil {
+			return o, fmt.Errorf("invalid %q tracestate value %q: %w", key, value, err)
+		}
+	}
+	return o, nil
+}
+
+// RValueRandomness returns the randomness of the `rv` key, if it is set.
+func (o *OpenTelemetryTraceState) RValueRandomness() (Randomness, bool) {
+	return o.randomness, o.hasRandomness
+}
+
+// TValueThreshold returns the threshold of the `th` key, if it is set.
+func (o *OpenTelemetryTraceState) TValueThreshold() (Threshold, bool) {
+	return o.threshold, o.hasThreshold
+}
+
+// UpdateTValueWithSampling sets the `th` key to the threshold the item was sampled with.
+func (o *OpenTelemetryTraceState) UpdateTValueWithSampling(t Threshold) {
+	o.threshold = t
+	o.hasThreshold = true
+}
+
+// ClearTValue removes the `th` key.
+func (o *OpenTelemetryTraceState) ClearTValue() {
+	o.threshold = Threshold{}
+	o.hasThreshold = false
+}
+
+// String returns the encoding of the value of the `ot` tracestate key.
+func (o *OpenTelemetryTraceState) String() string {
+	members := make([]string, 0, len(o.others)+2)
+	if o.hasThreshold {
+		members = append(members, tValueKey+":"+o.threshold.TValue())
+	}
+	if o.hasRandomness {
+		members = append(members, rValueKey+":"+o.randomness.RValue())
+	}
+	return strings.Join(append(members, o.others...), ";")
+}
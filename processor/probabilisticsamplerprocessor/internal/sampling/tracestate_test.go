@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestW3CTraceState(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         string
+		out        string
+		randomness string
+		threshold  string
+		err        error
+	}{
+		{name: "empty", in: "", out: ""},
+		{name: "other vendors", in: "a=b, c=d", out: "a=b,c=d"},
+		{name: "threshold", in: "ot=th:8", out: "ot=th:8", threshold: "8"},
+		{
+			name:       "randomness and threshold",
+			in:         "ot=rv:0123456789abcd;th:c;ext:x",
+			out:        "ot=th:c;rv:0123456789abcd;ext:x",
+			randomness: "0123456789abcd",
+			threshold:  "c",
+		},
+		{name: "otel moved first", in: "a=b,ot=th:4", out: "ot=th:4,a=b", threshold: "4"},
+		{name: "syntax", in: "a", err: ErrTraceStateSyntax},
+		{name: "duplicate vendor", in: "a=b,a=c", err: ErrTraceStateDuplicateKey},
+		{name: "duplicate otel key", in: "ot=th:8;th:4", err: ErrTraceStateDuplicateKey},
+		{name: "otel syntax", in: "ot=th", err: ErrTraceStateSyntax},
+		{name: "invalid threshold", in: "ot=th:123456789abcdef", err: ErrTValueSize},
+		{name: "invalid randomness", in: "ot=rv:123", err: ErrRValueSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := NewW3CTraceState(tt.in)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.out, w.String())
+
+			rnd, ok := w.OTelValue().RValueRandomness()
+			assert.Equal(t, tt.randomness != "", ok)
+			if ok {
+				assert.Equal(t, tt.randomness, rnd.RValue())
+			}
+			th, ok := w.OTelValue().TValueThreshold()
+			assert.Equal(t, tt.threshold != "", ok)
+			if ok {
+				assert.Equal(t, tt.threshold, th.TValue())
+			}
+		})
+	}
+}
+
+func TestOpenTelemetryTraceStateUpdate(t *testing.T) {
+	w, err := NewW3CTraceState("a=b,ot=rv:0123456789abcd")
+	require.NoError(t, err)
+
+	th, err := TValueToThreshold("e")
+	require.NoError(t, err)
+	w.OTelValue().UpdateTValueWithSampling(th)
+	assert.Equal(t, "ot=th:e;rv:0123456789abcd,a=b", w.String())
+
+	w.OTelValue().ClearTValue()
+	assert.Equal(t, "ot=rv:0123456789abcd,a=b", w.String())
+}
@@ -15,11 +15,24 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor/internal/sampling"
+)
+
+const (
+	// randomnessAttribute is the log record attribute holding the hex encoded
+	// randomness of the consistent sampling modes, equivalent to the `rv` tracestate key.
+	randomnessAttribute = "sampling.randomness"
+	// thresholdAttribute is the log record attribute holding the hex encoded
+	// sampling threshold of the consistent sampling modes, equivalent to the `th` tracestate key.
+	thresholdAttribute = "sampling.threshold"
 )
 
 type logSamplerProcessor struct {
 	scaledSamplingRate uint32
 	hashSeed           uint32
+	samplingPercentage float64
+	consistent         *consistentSampler
 	traceIDEnabled     bool
 	samplingSource     string
 	samplingPriority   string
@@ -33,6 +46,8 @@ func newLogsProcessor(ctx context.Context, set processor.CreateSettings, nextCon
 	lsp := &logSamplerProcessor{
 		scaledSamplingRate: uint32(cfg.SamplingPercentage * percentageScaleFactor),
 		hashSeed:           cfg.HashSeed,
+		samplingPercentage: float64(cfg.SamplingPercentage),
+		consistent:         newConsistentSampler(cfg),
 		traceIDEnabled:     cfg.AttributeSource == traceIDAttributeSource,
 		samplingPriority:   cfg.SamplingPriority,
 		samplingSource:     cfg.FromAttribute,
@@ -52,6 +67,17 @@ func (lsp *logSamplerProcessor) processLogs(ctx context.Context, ld plog.Logs) (
 	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
 		rl.ScopeLogs().RemoveIf(func(ill plog.ScopeLogs) bool {
 			ill.LogRecords().RemoveIf(func(l plog.LogRecord) bool {
+				if lsp.consistent != nil {
+					sampled := lsp.sampleConsistent(l)
+					if err := stats.RecordWithTags(
+						ctx,
+						[]tag.Mutator{tag.Upsert(tagPolicyKey, string(lsp.consistent.mode)), tag.Upsert(tagSampledKey, strconv.FormatBool(sampled))},
+						statCountLogsSampled.M(int64(1)),
+					); err != nil {
+						lsp.logger.Error(err.Error())
+					}
+					return !sampled
+				}
 
 				tagPolicyValue := "always_sampling"
 				// pick the sampling source.
@@ -103,6 +129,70 @@ func (lsp *logSamplerProcessor) processLogs(ctx context.Context, ld plog.Logs) (
 	return ld, nil
 }
 
+// sampleConsistent makes the consistent probability sampling decision of the log record and records
+// the threshold in its attributes if it is sampled. The randomness is taken from the `sampling.randomness`
+// attribute, else from the trace ID or else from hashing the from_attribute value.
+// Log records without randomness are not sampled.
+func (lsp *logSamplerProcessor) sampleConsistent(l plog.LogRecord) bool {
+	rnd, ok := lsp.logRandomness(l)
+	if !ok {
+		lsp.logger.Debug("Log record has no sampling randomness, dropping it")
+		return false
+	}
+
+	var (
+		prior    sampling.Threshold
+		hasPrior bool
+	)
+	if value, ok := l.Attributes().Get(thresholdAttribute); ok {
+		th, err := sampling.TValueToThreshold(value.AsString())
+		if err != nil {
+			lsp.logger.Debug("Invalid sampling threshold attribute, ignoring it", zap.Error(err))
+		} else {
+			prior, hasPrior = th, true
+		}
+	}
+
+	percentage := lsp.samplingPercentage
+	if lsp.samplingPriority != "" {
+		if localPriority, ok := l.Attributes().Get(lsp.samplingPriority); ok {
+			switch localPriority.Type() {
+			case pcommon.ValueTypeDouble:
+				percentage = localPriority.Double()
+			case pcommon.ValueTypeInt:
+				percentage = float64(localPriority.Int())
+			}
+		}
+	}
+
+	threshold := lsp.consistent.threshold(percentage, prior, hasPrior)
+	if !threshold.ShouldSample(rnd) {
+		return false
+	}
+	l.Attributes().PutStr(thresholdAttribute, threshold.TValue())
+	return true
+}
+
+// logRandomness returns the randomness used to sample the log record, if it has any.
+func (lsp *logSamplerProcessor) logRandomness(l plog.LogRecord) (sampling.Randomness, bool) {
+	if value, ok := l.Attributes().Get(randomnessAttribute); ok {
+		rnd, err := sampling.RValueToRandomness(value.AsString())
+		if err == nil {
+			return rnd, true
+		}
+		lsp.logger.Debug("Invalid sampling randomness attribute, ignoring it", zap.Error(err))
+	}
+	if lsp.traceIDEnabled && !l.TraceID().IsEmpty() {
+		return sampling.TraceIDToRandomness(l.TraceID()), true
+	}
+	if lsp.samplingSource != "" {
+		if value, ok := l.Attributes().Get(lsp.samplingSource); ok {
+			return sampling.UnsignedToRandomness(computeHash64(getBytesFromValue(value), lsp.hashSeed)), true
+		}
+	}
+	return sampling.Randomness{}, false
+}
+
 func getBytesFromValue(value pcommon.Value) []byte {
 	if value.Type() == pcommon.ValueTypeBytes {
 		return value.Bytes().AsRaw()
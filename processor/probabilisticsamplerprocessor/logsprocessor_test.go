@@ -180,3 +180,88 @@ func TestLogsSampling(t *testing.T) {
 		})
 	}
 }
+
+func TestLogsConsistentSampling(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        *Config
+		attributes map[string]any
+		traceID    [16]byte
+		threshold  string
+		sampled    bool
+	}{
+		{
+			name:      "trace_id_randomness",
+			cfg:       &Config{SamplingPercentage: 50, AttributeSource: traceIDAttributeSource},
+			traceID:   [16]byte{8: 0xff, 9: 0x80},
+			threshold: "8",
+			sampled:   true,
+		},
+		{
+			name:    "trace_id_randomness_not_sampled",
+			cfg:     &Config{SamplingPercentage: 50, AttributeSource: traceIDAttributeSource},
+			traceID: [16]byte{8: 0xff, 9: 0x7f},
+		},
+		{
+			name:       "randomness_attribute",
+			cfg:        &Config{SamplingPercentage: 50, AttributeSource: traceIDAttributeSource},
+			attributes: map[string]any{"sampling.randomness": "c0000000000000"},
+			traceID:    [16]byte{8: 0xff, 9: 0x10},
+			threshold:  "8",
+			sampled:    true,
+		},
+		{
+			name:       "prior_threshold",
+			cfg:        &Config{SamplingPercentage: 50, AttributeSource: traceIDAttributeSource},
+			attributes: map[string]any{"sampling.threshold": "8"},
+			traceID:    [16]byte{9: 0xd0},
+			threshold:  "c",
+			sampled:    true,
+		},
+		{
+			name:       "sampling_priority",
+			cfg:        &Config{SamplingPercentage: 0, AttributeSource: traceIDAttributeSource, SamplingPriority: "priority"},
+			attributes: map[string]any{"priority": 100},
+			traceID:    [16]byte{9: 0x01},
+			threshold:  "0",
+			sampled:    true,
+		},
+		{
+			name:       "from_attribute",
+			cfg:        &Config{SamplingPercentage: 100, AttributeSource: recordAttributeSource, FromAttribute: "foo"},
+			attributes: map[string]any{"foo": "bar"},
+			threshold:  "0",
+			sampled:    true,
+		},
+		{
+			name: "no_randomness",
+			cfg:  &Config{SamplingPercentage: 100, AttributeSource: recordAttributeSource, FromAttribute: "foo"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Mode = Proportional
+			tt.cfg.SamplingPrecision = defaultPrecision
+			sink := new(consumertest.LogsSink)
+			processor, err := newLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), sink, tt.cfg)
+			require.NoError(t, err)
+
+			logs := plog.NewLogs()
+			record := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			record.SetTraceID(tt.traceID)
+			require.NoError(t, record.Attributes().FromRaw(tt.attributes))
+
+			require.NoError(t, processor.ConsumeLogs(context.Background(), logs))
+
+			if !tt.sampled {
+				assert.Equal(t, 0, sink.LogRecordCount())
+				return
+			}
+			require.Equal(t, 1, sink.LogRecordCount())
+			sampled := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+			threshold, ok := sampled.Attributes().Get("sampling.threshold")
+			require.True(t, ok)
+			assert.Equal(t, tt.threshold, threshold.Str())
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor/internal/sampling"
+)
+
+// SamplerMode determines how the sampling decision is made and whether it is recorded in the telemetry.
+type SamplerMode string
+
+const (
+	// HashSeed samples by hashing the trace ID, or the from_attribute value of logs, with the hash seed.
+	// The sampling decision is not recorded in the telemetry.
+	HashSeed SamplerMode = "hash_seed"
+
+	// Proportional samples using the randomness of the telemetry, reducing its prior
+	// sampling probability by the configured percentage, and records the threshold used.
+	Proportional SamplerMode = "proportional"
+
+	// Equalizing samples using the randomness of the telemetry, applying the configured percentage
+	// unless the telemetry was already sampled with a lower probability, and records the threshold used.
+	Equalizing SamplerMode = "equalizing"
+
+	modeUnset SamplerMode = ""
+
+	defaultMode      = HashSeed
+	defaultPrecision = 4
+)
+
+var validModes = map[SamplerMode]bool{
+	modeUnset:    true,
+	HashSeed:     true,
+	Proportional: true,
+	Equalizing:   true,
+}
+
+// consistentSampler makes consistent probability sampling decisions, as described by
+// https://opentelemetry.io/docs/specs/otel/trace/tracestate-probability-sampling/.
+type consistentSampler struct {
+	mode      SamplerMode
+	precision int
+}
+
+// newConsistentSampler returns the consistent sampler of the configuration,
+// or nil if the configuration uses the hash_seed mode.
+func newConsistentSampler(cfg *Config) *consistentSampler {
+	if cfg.Mode == modeUnset || cfg.Mode == HashSeed {
+		return nil
+	}
+	return &consistentSampler{
+		mode:      cfg.Mode,
+		precision: cfg.SamplingPrecision,
+	}
+}
+
+// threshold returns the threshold to sample an item with, given the sampling percentage
+// and the threshold the item was previously sampled with, if any.
+// The returned threshold is never lower than the prior one, since an item
+// cannot be sampled with a higher probability than it already was.
+func (c *consistentSampler) threshold(percentage float64, prior sampling.Threshold, hasPrior bool) sampling.Threshold {
+	probability := percentage / 100
+	if c.mode == Proportional && hasPrior {
+		probability *= prior.Probability()
+	}
+
+	var threshold sampling.Threshold
+	switch {
+	case probability >= 1:
+		threshold = sampling.AlwaysSampleThreshold
+	case probability <= 0:
+		return sampling.NeverSampleThreshold
+	default:
+		var err error
+		threshold, err = sampling.ProbabilityToThresholdWithPrecision(probability, c.precision)
+		if err != nil {
+			// The probability is in range and the precision is validated with the configuration.
+			return sampling.NeverSampleThreshold
+		}
+	}
+
+	if hasPrior && sampling.ThresholdGreater(prior, threshold) {
+		return prior
+	}
+	return threshold
+}
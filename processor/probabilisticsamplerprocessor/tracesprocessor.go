@@ -15,6 +15,8 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor/internal/sampling"
 )
 
 // samplingPriority has the semantic result of parsing the "sampling.priority"
@@ -44,6 +46,8 @@ const (
 type traceSamplerProcessor struct {
 	scaledSamplingRate uint32
 	hashSeed           uint32
+	samplingPercentage float64
+	consistent         *consistentSampler
 	logger             *zap.Logger
 }
 
@@ -54,6 +58,8 @@ func newTracesProcessor(ctx context.Context, set processor.CreateSettings, cfg *
 		// Adjust sampling percentage on private so recalculations are avoided.
 		scaledSamplingRate: uint32(cfg.SamplingPercentage * percentageScaleFactor),
 		hashSeed:           cfg.HashSeed,
+		samplingPercentage: float64(cfg.SamplingPercentage),
+		consistent:         newConsistentSampler(cfg),
 		logger:             set.Logger,
 	}
 
@@ -89,6 +95,16 @@ func (tsp *traceSamplerProcessor) processTraces(ctx context.Context, td ptrace.T
 					statCountTracesSampled.M(int64(1)),
 				)
 
+				if tsp.consistent != nil {
+					sampled := tsp.sampleConsistent(s, sp == mustSampleSpan)
+					_ = stats.RecordWithTags(
+						ctx,
+						[]tag.Mutator{tag.Upsert(tagPolicyKey, string(tsp.consistent.mode)), tag.Upsert(tagSampledKey, strconv.FormatBool(sampled))},
+						statCountTracesSampled.M(int64(1)),
+					)
+					return !sampled
+				}
+
 				// If one assumes random trace ids hashing may seems avoidable, however, traces can be coming from sources
 				// with various different criteria to generate trace id and perhaps were already sampled without hashing.
 				// Hashing here prevents bias due to such systems.
@@ -115,6 +131,44 @@ func (tsp *traceSamplerProcessor) processTraces(ctx context.Context, td ptrace.T
 	return td, nil
 }
 
+// sampleConsistent makes the consistent probability sampling decision of the span, using the randomness
+// of its tracestate or else of its trace ID, and records the threshold in its tracestate if it is sampled.
+func (tsp *traceSamplerProcessor) sampleConsistent(s ptrace.Span, mustSample bool) bool {
+	ts, err := sampling.NewW3CTraceState(s.TraceState().AsRaw())
+	valid := err == nil
+	if !valid {
+		// The invalid tracestate is left unchanged if the span is sampled, so that
+		// the entries of other vendors are not lost.
+		tsp.logger.Debug("Invalid tracestate, ignoring it", zap.Error(err))
+		ts = sampling.W3CTraceState{}
+	}
+	otts := ts.OTelValue()
+
+	rnd, ok := otts.RValueRandomness()
+	if !ok {
+		rnd = sampling.TraceIDToRandomness(s.TraceID())
+	}
+	prior, hasPrior := otts.TValueThreshold()
+
+	threshold := tsp.consistent.threshold(tsp.samplingPercentage, prior, hasPrior)
+	if mustSample {
+		// Keep the prior threshold when it is consistent with the decision.
+		threshold = sampling.AlwaysSampleThreshold
+		if hasPrior && prior.ShouldSample(rnd) {
+			threshold = prior
+		}
+	}
+	if !threshold.ShouldSample(rnd) {
+		return false
+	}
+
+	if valid {
+		otts.UpdateTValueWithSampling(threshold)
+		s.TraceState().FromRaw(ts.String())
+	}
+	return true
+}
+
 // parseSpanSamplingPriority checks if the span has the "sampling.priority" tag to
 // decide if the span should be sampled or not. The usage of the tag follows the
 // OpenTracing semantic tags:
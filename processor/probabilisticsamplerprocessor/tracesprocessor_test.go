@@ -5,6 +5,7 @@ package probabilisticsamplerprocessor
 
 import (
 	"context"
+	"encoding/binary"
 	"math"
 	"math/rand"
 	"testing"
@@ -310,6 +311,129 @@ func Test_tracesamplerprocessor_SpanSamplingPriority(t *testing.T) {
 	}
 }
 
+func Test_tracesamplerprocessor_ConsistentModes(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       SamplerMode
+		percentage float32
+		randomness uint64
+		traceState string
+		priority   int64
+		expected   string
+		sampled    bool
+	}{
+		{
+			name:       "proportional_sampled",
+			mode:       Proportional,
+			percentage: 50,
+			randomness: 0x80000000000000,
+			expected:   "ot=th:8",
+			sampled:    true,
+		},
+		{
+			name:       "proportional_not_sampled",
+			mode:       Proportional,
+			percentage: 50,
+			randomness: 0x7fffffffffffff,
+		},
+		{
+			name:       "proportional_prior_threshold",
+			mode:       Proportional,
+			percentage: 50,
+			randomness: 0xd0000000000000,
+			traceState: "ot=th:8",
+			expected:   "ot=th:c",
+			sampled:    true,
+		},
+		{
+			name:       "equalizing_higher_prior_threshold",
+			mode:       Equalizing,
+			percentage: 50,
+			randomness: 0xd0000000000000,
+			traceState: "ot=th:c",
+			expected:   "ot=th:c",
+			sampled:    true,
+		},
+		{
+			name:       "equalizing_lower_prior_threshold",
+			mode:       Equalizing,
+			percentage: 50,
+			randomness: 0x90000000000000,
+			traceState: "ot=th:4",
+			expected:   "ot=th:8",
+			sampled:    true,
+		},
+		{
+			name:       "explicit_randomness",
+			mode:       Equalizing,
+			percentage: 50,
+			randomness: 0x10000000000000,
+			traceState: "ot=rv:f0000000000000",
+			expected:   "ot=th:8;rv:f0000000000000",
+			sampled:    true,
+		},
+		{
+			name:       "other_vendors_kept",
+			mode:       Proportional,
+			percentage: 50,
+			randomness: 0x80000000000000,
+			traceState: "a=b,c=d",
+			expected:   "ot=th:8,a=b,c=d",
+			sampled:    true,
+		},
+		{
+			name:       "invalid_tracestate_kept",
+			mode:       Proportional,
+			percentage: 50,
+			randomness: 0x80000000000000,
+			traceState: "a=b,ot=th:xyz;th:1",
+			expected:   "a=b,ot=th:xyz;th:1",
+			sampled:    true,
+		},
+		{
+			name:       "sampling_priority",
+			mode:       Proportional,
+			percentage: 0,
+			randomness: 0x10000000000000,
+			priority:   1,
+			expected:   "ot=th:0",
+			sampled:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.TracesSink)
+			cfg := &Config{
+				SamplingPercentage: tt.percentage,
+				Mode:               tt.mode,
+				SamplingPrecision:  defaultPrecision,
+			}
+			tsp, err := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+			require.NoError(t, err)
+
+			td := ptrace.NewTraces()
+			span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			var traceID [16]byte
+			binary.BigEndian.PutUint64(traceID[8:], tt.randomness)
+			span.SetTraceID(traceID)
+			span.TraceState().FromRaw(tt.traceState)
+			if tt.priority != 0 {
+				span.Attributes().PutInt("sampling.priority", tt.priority)
+			}
+
+			require.NoError(t, tsp.ConsumeTraces(context.Background(), td))
+
+			if !tt.sampled {
+				assert.Equal(t, 0, sink.SpanCount())
+				return
+			}
+			require.Equal(t, 1, sink.SpanCount())
+			sampled := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			assert.Equal(t, tt.expected, sampled.TraceState().AsRaw())
+		})
+	}
+}
+
 // Test_parseSpanSamplingPriority ensures that the function parsing the attributes is taking "sampling.priority"
 // attribute correctly.
 func Test_parseSpanSamplingPriority(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
@@ -31,6 +32,18 @@ type Config struct {
 	// translated to, allowing older and newer formats
 	// to conform to the target schema identifier.
 	Targets []string `mapstructure:"targets"`
+
+	// SchemaDirectory is a local directory that is checked for schema files
+	// before fetching them from their schema URL. Schema files are stored
+	// using the host and path of the schema URL,
+	// ie. `https://opentelemetry.io/schemas/1.9.0` is read from
+	// `<schema_directory>/opentelemetry.io/schemas/1.9.0`. (Optional field)
+	SchemaDirectory string `mapstructure:"schema_directory"`
+
+	// StorageID is the ID of a storage extension that is used to cache
+	// the fetched schema files so they do not have to be fetched
+	// again when the collector restarts. (Optional field)
+	StorageID *component.ID `mapstructure:"storage"`
 }
 
 func (c *Config) Validate() error {
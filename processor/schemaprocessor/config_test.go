@@ -30,6 +30,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, component.UnmarshalConfig(sub, cfg))
 
+	storageID := component.NewID("file_storage")
 	assert.Equal(t, &Config{
 		HTTPClientSettings: confighttp.NewDefaultHTTPClientSettings(),
 		Prefetch: []string{
@@ -39,6 +40,8 @@ func TestLoadConfig(t *testing.T) {
 			"https://opentelemetry.io/schemas/1.4.2",
 			"https://example.com/otel/schemas/1.2.0",
 		},
+		SchemaDirectory: "./schemas",
+		StorageID:       &storageID,
 	}, cfg)
 }
 
@@ -47,7 +47,7 @@ func (f factory) createLogsProcessor(
 	cfg component.Config,
 	next consumer.Logs,
 ) (processor.Logs, error) {
-	transformer, err := newTransformer(ctx, cfg, set)
+	transformer, err := newTransformer(ctx, cfg, set, component.DataTypeLogs)
 	if err != nil {
 		return nil, err
 	}
@@ -59,6 +59,7 @@ func (f factory) createLogsProcessor(
 		transformer.processLogs,
 		processorhelper.WithCapabilities(processorCapabilities),
 		processorhelper.WithStart(transformer.start),
+		processorhelper.WithShutdown(transformer.shutdown),
 	)
 }
 
@@ -68,7 +69,7 @@ func (f factory) createMetricsProcessor(
 	cfg component.Config,
 	next consumer.Metrics,
 ) (processor.Metrics, error) {
-	transformer, err := newTransformer(ctx, cfg, set)
+	transformer, err := newTransformer(ctx, cfg, set, component.DataTypeMetrics)
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +81,7 @@ func (f factory) createMetricsProcessor(
 		transformer.processMetrics,
 		processorhelper.WithCapabilities(processorCapabilities),
 		processorhelper.WithStart(transformer.start),
+		processorhelper.WithShutdown(transformer.shutdown),
 	)
 }
 
@@ -89,7 +91,7 @@ func (f factory) createTracesProcessor(
 	cfg component.Config,
 	next consumer.Traces,
 ) (processor.Traces, error) {
-	transformer, err := newTransformer(ctx, cfg, set)
+	transformer, err := newTransformer(ctx, cfg, set, component.DataTypeTraces)
 	if err != nil {
 		return nil, err
 	}
@@ -101,5 +103,6 @@ func (f factory) createTracesProcessor(
 		transformer.processTraces,
 		processorhelper.WithCapabilities(processorCapabilities),
 		processorhelper.WithStart(transformer.start),
+		processorhelper.WithShutdown(transformer.shutdown),
 	)
 }
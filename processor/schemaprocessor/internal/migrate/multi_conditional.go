@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/migrate"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/otel/schema/v1.0/ast"
+	"go.uber.org/multierr"
+)
+
+// MultiConditionalAttributeSet is a `ConditionalAttributeSet` that is
+// only applied when all of its named conditions are matched.
+// It is used for changes such as span event attributes, where
+// `apply_to_spans` and `apply_to_events` are logically AND-ed.
+type MultiConditionalAttributeSet struct {
+	on    map[string]map[string]struct{}
+	attrs *AttributeChangeSet
+}
+
+type MultiConditionalAttributeSetSlice []*MultiConditionalAttributeSet
+
+// NewMultiConditionalAttributeSet creates a change set that is applied when each of the
+// provided conditions matches. A condition with no values matches any value.
+func NewMultiConditionalAttributeSet(mappings ast.AttributeMap, matches map[string][]string) *MultiConditionalAttributeSet {
+	on := make(map[string]map[string]struct{}, len(matches))
+	for name, values := range matches {
+		if len(values) == 0 {
+			continue
+		}
+		on[name] = make(map[string]struct{}, len(values))
+		for _, v := range values {
+			on[name][v] = struct{}{}
+		}
+	}
+	return &MultiConditionalAttributeSet{
+		on:    on,
+		attrs: NewAttributeChangeSet(mappings),
+	}
+}
+
+func (mc *MultiConditionalAttributeSet) Apply(attrs pcommon.Map, values map[string]string) (errs error) {
+	if mc.check(values) {
+		errs = mc.attrs.Apply(attrs)
+	}
+	return errs
+}
+
+func (mc *MultiConditionalAttributeSet) Rollback(attrs pcommon.Map, values map[string]string) (errs error) {
+	if mc.check(values) {
+		errs = mc.attrs.Rollback(attrs)
+	}
+	return errs
+}
+
+func (mc *MultiConditionalAttributeSet) check(values map[string]string) bool {
+	for name, expected := range mc.on {
+		if _, ok := expected[values[name]]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func NewMultiConditionalAttributeSetSlice(conditions ...*MultiConditionalAttributeSet) *MultiConditionalAttributeSetSlice {
+	values := new(MultiConditionalAttributeSetSlice)
+	for _, c := range conditions {
+		(*values) = append((*values), c)
+	}
+	return values
+}
+
+func (slice *MultiConditionalAttributeSetSlice) Apply(attrs pcommon.Map, values map[string]string) error {
+	return slice.do(StateSelectorApply, attrs, values)
+}
+
+func (slice *MultiConditionalAttributeSetSlice) Rollback(attrs pcommon.Map, values map[string]string) error {
+	return slice.do(StateSelectorRollback, attrs, values)
+}
+
+func (slice *MultiConditionalAttributeSetSlice) do(ss StateSelector, attrs pcommon.Map, values map[string]string) (errs error) {
+	for i := 0; i < len((*slice)); i++ {
+		switch ss {
+		case StateSelectorApply:
+			errs = multierr.Append(errs, (*slice)[i].Apply(attrs, values))
+		case StateSelectorRollback:
+			errs = multierr.Append(errs, (*slice)[len((*slice))-i-1].Rollback(attrs, values))
+		}
+	}
+	return errs
+}
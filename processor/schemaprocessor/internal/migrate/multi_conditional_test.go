@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestMultiConditionalAttributeSet(t *testing.T) {
+	t.Parallel()
+
+	cond := NewMultiConditionalAttributeSet(
+		map[string]string{
+			"service.version": "application.version",
+		},
+		map[string][]string{
+			"span":  {"application start"},
+			"event": {"started", "restarted"},
+			"none":  {},
+		},
+	)
+
+	for _, tc := range []struct {
+		name    string
+		values  map[string]string
+		applied bool
+	}{
+		{
+			name:    "All conditions matched",
+			values:  map[string]string{"span": "application start", "event": "restarted"},
+			applied: true,
+		},
+		{
+			name:    "One condition not matched",
+			values:  map[string]string{"span": "application start", "event": "stopped"},
+			applied: false,
+		},
+		{
+			name:    "Missing condition value",
+			values:  map[string]string{"event": "started"},
+			applied: false,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			attrs := testHelperBuildMap(func(m pcommon.Map) {
+				m.PutStr("service.version", "v0.0.0")
+			})
+			assert.NoError(t, cond.Apply(attrs, tc.values))
+
+			key := "service.version"
+			if tc.applied {
+				key = "application.version"
+			}
+			assert.Equal(t, map[string]any{key: "v0.0.0"}, attrs.AsRaw(), "Must match the expected value")
+
+			assert.NoError(t, cond.Rollback(attrs, tc.values))
+			assert.Equal(t, map[string]any{"service.version": "v0.0.0"}, attrs.AsRaw(), "Must restore the original value")
+		})
+	}
+}
+
+func TestMultiConditionalAttributeSetSlice(t *testing.T) {
+	t.Parallel()
+
+	slice := NewMultiConditionalAttributeSetSlice(
+		NewMultiConditionalAttributeSet(
+			map[string]string{"service_version": "service.version"},
+			map[string][]string{},
+		),
+		NewMultiConditionalAttributeSet(
+			map[string]string{"service.version": "application.version"},
+			map[string][]string{"span": {"application start"}},
+		),
+	)
+
+	attrs := testHelperBuildMap(func(m pcommon.Map) {
+		m.PutStr("service_version", "v0.0.0")
+	})
+	values := map[string]string{"span": "application start"}
+
+	assert.NoError(t, slice.Apply(attrs, values))
+	assert.Equal(t, map[string]any{"application.version": "v0.0.0"}, attrs.AsRaw())
+
+	assert.NoError(t, slice.Rollback(attrs, values))
+	assert.Equal(t, map[string]any{"service_version": "v0.0.0"}, attrs.AsRaw())
+}
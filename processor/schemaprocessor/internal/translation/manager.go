@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var errNilProvider = errors.New("no schema file provider set")
+
+// Manager is responsible for ensuring that the schema files are fetched
+// and kept in memory so the translations can be requested concurrently.
+type Manager interface {
+	// RequestTranslation will provide either the defined Translation
+	// if the schema family of schemaURL is a known target, or a noop variation.
+	// In the event that the required schema file is not cached yet,
+	// the request will block while the file is retrieved.
+	RequestTranslation(ctx context.Context, schemaURL string) (Translation, error)
+
+	// Prefetch retrieves and caches the schema file of the schemaURL
+	// so that processing signals with that schema does not block later on.
+	Prefetch(ctx context.Context, schemaURL string) error
+
+	// SetProvider updates the provider that is used to retrieve schema files.
+	SetProvider(p Provider) error
+}
+
+type target struct {
+	schemaURL string
+	version   *Version
+}
+
+const (
+	// minRetryBackoff and maxRetryBackoff bound the time during which
+	// the failure to retrieve a schema file is returned without retrying.
+	minRetryBackoff = 5 * time.Second
+	maxRetryBackoff = 5 * time.Minute
+)
+
+// fetch is the retrieval of a schema file, shared by the requests
+// made while it is in progress.
+type fetch struct {
+	done chan struct{}
+
+	// The fields below are set before done is closed.
+	translation *translator
+	err         error
+	failures    int
+	retryAt     time.Time
+}
+
+type manager struct {
+	log     *zap.Logger
+	targets map[string]*target // map from schema family to target
+	now     func() time.Time
+
+	rw       sync.RWMutex
+	provider Provider
+	fetches  map[string]*fetch // map from schema file URL to its retrieval
+}
+
+var _ Manager = (*manager)(nil)
+
+// NewManager creates a manager that will translate the
+// schema families of the provided targets to their version.
+func NewManager(targets []string, log *zap.Logger) (Manager, error) {
+	m := &manager{
+		log:     log,
+		targets: make(map[string]*target, len(targets)),
+		now:     time.Now,
+		fetches: make(map[string]*fetch),
+	}
+	for _, schemaURL := range targets {
+		family, version, err := GetFamilyAndVersion(schemaURL)
+		if err != nil {
+			return nil, err
+		}
+		m.targets[family] = &target{schemaURL: schemaURL, version: version}
+	}
+	return m, nil
+}
+
+func (m *manager) RequestTranslation(ctx context.Context, schemaURL string) (Translation, error) {
+	family, version, err := GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		m.log.Debug("No valid schema url was provided, using no-op schema",
+			zap.String("schema-url", schemaURL),
+		)
+		return nopTranslation{}, nil
+	}
+	tgt, ok := m.targets[family]
+	if !ok || tgt.version.Equal(version) {
+		return nopTranslation{}, nil
+	}
+
+	// The schema file of the newest version is required since it contains
+	// the changes of all the previous versions, allowing for both
+	// upgrading to the target and downgrading from a newer version.
+	fileURL := tgt.schemaURL
+	if version.GreaterThan(tgt.version) {
+		fileURL = schemaURL
+	}
+	return m.translation(ctx, fileURL, tgt)
+}
+
+func (m *manager) Prefetch(ctx context.Context, schemaURL string) error {
+	family, _, err := GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		return err
+	}
+	tgt, ok := m.targets[family]
+	if !ok {
+		return fmt.Errorf("schema family %q is not a target", family)
+	}
+	_, err = m.translation(ctx, schemaURL, tgt)
+	return err
+}
+
+func (m *manager) SetProvider(p Provider) error {
+	if p == nil {
+		return errNilProvider
+	}
+	m.rw.Lock()
+	defer m.rw.Unlock()
+	m.provider = p
+	// The failures of the previous provider are not relevant to the new one.
+	for fileURL, f := range m.fetches {
+		if isDone(f) && f.err != nil {
+			delete(m.fetches, fileURL)
+		}
+	}
+	return nil
+}
+
+// translation returns the cached translation of the schema file,
+// retrieving the schema file if it has not been cached yet. The schema file
+// is retrieved once by concurrent requests, without holding the lock, and a
+// failure is returned without retrying until its backoff has elapsed.
+func (m *manager) translation(ctx context.Context, fileURL string, tgt *target) (*translator, error) {
+	m.rw.RLock()
+	f, ok := m.fetches[fileURL]
+	m.rw.RUnlock()
+	if ok && isDone(f) && f.err == nil {
+		return f.translation, nil
+	}
+
+	m.rw.Lock()
+	f, ok = m.fetches[fileURL]
+	if ok && (!isDone(f) || f.err == nil || m.now().Before(f.retryAt)) {
+		m.rw.Unlock()
+		return wait(ctx, f)
+	}
+	if m.provider == nil {
+		m.rw.Unlock()
+		return nil, errNilProvider
+	}
+	failures := 0
+	if ok {
+		failures = f.failures
+	}
+	f = &fetch{done: make(chan struct{}), failures: failures}
+	m.fetches[fileURL] = f
+	provider := m.provider
+	m.rw.Unlock()
+
+	f.translation, f.err = m.retrieve(ctx, provider, fileURL, tgt)
+	if f.err != nil {
+		f.failures++
+		f.retryAt = m.now().Add(retryBackoff(f.failures))
+		if ctx.Err() != nil {
+			// The request was cancelled, the next one retries immediately.
+			f.retryAt = time.Time{}
+		}
+		m.log.Warn("Failed to retrieve schema file",
+			zap.String("schema-url", fileURL),
+			zap.Time("retry-after", f.retryAt),
+			zap.Error(f.err),
+		)
+	}
+	close(f.done)
+	return f.translation, f.err
+}
+
+func (m *manager) retrieve(ctx context.Context, provider Provider, fileURL string, tgt *target) (*translator, error) {
+	m.log.Info("Fetching schema file", zap.String("schema-url", fileURL))
+	content, err := provider.Lookup(ctx, fileURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve schema file %s: %w", fileURL, err)
+	}
+	t, err := newTranslatorFromReader(tgt.schemaURL, bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema file %s: %w", fileURL, err)
+	}
+	return t, nil
+}
+
+// wait returns the result of the retrieval, once it is done.
+func wait(ctx context.Context, f *fetch) (*translator, error) {
+	select {
+	case <-f.done:
+		return f.translation, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func isDone(f *fetch) bool {
+	select {
+	case <-f.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryBackoff returns the time to wait before retrying a schema file
+// that failed to be retrieved the given number of consecutive times.
+func retryBackoff(failures int) time.Duration {
+	backoff := minRetryBackoff
+	for i := 1; i < failures && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	return backoff
+}
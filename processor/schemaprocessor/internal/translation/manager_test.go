@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/fixture"
+)
+
+// countingProvider records the number of lookups made to the wrapped provider.
+type countingProvider struct {
+	next    Provider
+	lookups atomic.Int64
+}
+
+func (cp *countingProvider) Lookup(ctx context.Context, schemaURL string) ([]byte, error) {
+	cp.lookups.Add(1)
+	return cp.next.Lookup(ctx, schemaURL)
+}
+
+func newTestManager(t *testing.T, targets ...string) (Manager, *countingProvider) {
+	m, err := NewManager(targets, zaptest.NewLogger(t))
+	require.NoError(t, err, "Must not error when creating manager")
+
+	p := &countingProvider{next: NewFileProvider(filepath.Join("testdata", "schemas"))}
+	require.NoError(t, m.SetProvider(p))
+	return m, p
+}
+
+func TestNewManagerInvalidTarget(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewManager([]string{"https://example.com/schemas"}, zaptest.NewLogger(t))
+	assert.ErrorIs(t, err, ErrInvalidVersion)
+}
+
+func TestManagerRequestTranslation(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name      string
+		schemaURL string
+		nop       bool
+		fetched   bool
+	}{
+		{name: "not a valid schema url", schemaURL: "", nop: true},
+		{name: "not a target family", schemaURL: "https://opentelemetry.io/schemas/1.9.0", nop: true},
+		{name: "same version as target", schemaURL: testSchemaV110, nop: true},
+		{name: "older version than target", schemaURL: testSchemaV100, fetched: true},
+		{name: "newer version than target", schemaURL: testSchemaV120, fetched: true},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, p := newTestManager(t, testSchemaV110)
+			tn, err := m.RequestTranslation(context.Background(), tc.schemaURL)
+			require.NoError(t, err)
+			if tc.nop {
+				assert.Equal(t, nopTranslation{}, tn)
+			} else {
+				assert.IsType(t, &translator{}, tn)
+			}
+			if tc.fetched {
+				assert.EqualValues(t, 1, p.lookups.Load())
+			} else {
+				assert.EqualValues(t, 0, p.lookups.Load())
+			}
+		})
+	}
+}
+
+func TestManagerCachesTranslations(t *testing.T) {
+	t.Parallel()
+
+	m, p := newTestManager(t, testSchemaV120)
+	require.NoError(t, m.Prefetch(context.Background(), testSchemaV120))
+
+	fixture.ParallelRaceCompute(t, 10, func() error {
+		_, err := m.RequestTranslation(context.Background(), testSchemaV100)
+		return err
+	})
+	tn, err := m.RequestTranslation(context.Background(), testSchemaV110)
+	require.NoError(t, err)
+	assert.True(t, tn.SupportedVersion(&Version{1, 1, 0}))
+	assert.EqualValues(t, 1, p.lookups.Load(), "Must only retrieve the schema file once")
+}
+
+func TestManagerErrors(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewManager([]string{testSchemaV120}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	_, err = m.RequestTranslation(context.Background(), testSchemaV100)
+	assert.ErrorIs(t, err, errNilProvider, "Must error when no provider is set")
+	assert.ErrorIs(t, m.SetProvider(nil), errNilProvider)
+
+	require.NoError(t, m.SetProvider(NewFileProvider(filepath.Join("testdata", "missing"))))
+	_, err = m.RequestTranslation(context.Background(), testSchemaV100)
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+
+	assert.Error(t, m.Prefetch(context.Background(), "https://opentelemetry.io/schemas/1.9.0"), "Must error when prefetching a family that is not a target")
+}
+
+// blockingProvider blocks the lookups until it is released.
+type blockingProvider struct {
+	next     Provider
+	lookups  atomic.Int64
+	started  chan struct{}
+	released chan struct{}
+}
+
+func (bp *blockingProvider) Lookup(ctx context.Context, schemaURL string) ([]byte, error) {
+	bp.lookups.Add(1)
+	bp.started <- struct{}{}
+	select {
+	case <-bp.released:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return bp.next.Lookup(ctx, schemaURL)
+}
+
+func TestManagerSingleFlight(t *testing.T) {
+	t.Parallel()
+
+	m, _ := newTestManager(t, testSchemaV110)
+	require.NoError(t, m.Prefetch(context.Background(), testSchemaV110))
+	p := &blockingProvider{
+		next:     NewFileProvider(filepath.Join("testdata", "schemas")),
+		started:  make(chan struct{}, 10),
+		released: make(chan struct{}),
+	}
+	require.NoError(t, m.SetProvider(p))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := m.RequestTranslation(context.Background(), testSchemaV120)
+			assert.NoError(t, err)
+		}()
+	}
+	<-p.started
+
+	// The lock is not held while the schema file is retrieved, so that the
+	// translations already cached are returned without waiting.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	tn, err := m.RequestTranslation(ctx, testSchemaV100)
+	require.NoError(t, err)
+	assert.IsType(t, &translator{}, tn)
+	_, err = m.RequestTranslation(ctx, testSchemaV120)
+	require.ErrorIs(t, err, context.DeadlineExceeded, "Must stop waiting when the context is done")
+
+	close(p.released)
+	wg.Wait()
+	assert.EqualValues(t, 1, p.lookups.Load(), "Must only retrieve the schema file once")
+}
+
+// failingProvider fails the lookups until it is fixed.
+type failingProvider struct {
+	next    Provider
+	lookups atomic.Int64
+	fixed   atomic.Bool
+}
+
+func (fp *failingProvider) Lookup(ctx context.Context, schemaURL string) ([]byte, error) {
+	fp.lookups.Add(1)
+	if !fp.fixed.Load() {
+		return nil, errors.New("unavailable")
+	}
+	return fp.next.Lookup(ctx, schemaURL)
+}
+
+func TestManagerFailureBackoff(t *testing.T) {
+	t.Parallel()
+
+	mgr, err := NewManager([]string{testSchemaV110}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	m := mgr.(*manager)
+	now := time.Unix(1000, 0)
+	m.now = func() time.Time { return now }
+
+	p := &failingProvider{next: NewFileProvider(filepath.Join("testdata", "schemas"))}
+	require.NoError(t, m.SetProvider(p))
+
+	for i := 0; i < 3; i++ {
+		_, err = m.RequestTranslation(context.Background(), testSchemaV120)
+		require.ErrorContains(t, err, "unavailable")
+	}
+	assert.EqualValues(t, 1, p.lookups.Load(), "Must not retry before the backoff elapsed")
+
+	now = now.Add(minRetryBackoff)
+	_, err = m.RequestTranslation(context.Background(), testSchemaV120)
+	require.Error(t, err)
+	assert.EqualValues(t, 2, p.lookups.Load())
+
+	// The backoff doubles with the consecutive failures.
+	now = now.Add(minRetryBackoff)
+	_, err = m.RequestTranslation(context.Background(), testSchemaV120)
+	require.Error(t, err)
+	assert.EqualValues(t, 2, p.lookups.Load())
+
+	p.fixed.Store(true)
+	now = now.Add(minRetryBackoff)
+	tn, err := m.RequestTranslation(context.Background(), testSchemaV120)
+	require.NoError(t, err)
+	assert.IsType(t, &translator{}, tn)
+	assert.EqualValues(t, 3, p.lookups.Load())
+}
+
+func TestManagerSetProviderResetsFailures(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewManager([]string{testSchemaV110}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, m.SetProvider(&failingProvider{}))
+	_, err = m.RequestTranslation(context.Background(), testSchemaV120)
+	require.Error(t, err)
+
+	p := &countingProvider{next: NewFileProvider(filepath.Join("testdata", "schemas"))}
+	require.NoError(t, m.SetProvider(p))
+	_, err = m.RequestTranslation(context.Background(), testSchemaV120)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, p.lookups.Load())
+}
+
+func TestRetryBackoff(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, minRetryBackoff, retryBackoff(1))
+	assert.Equal(t, 2*minRetryBackoff, retryBackoff(2))
+	assert.Equal(t, maxRetryBackoff, retryBackoff(100))
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// ErrSchemaNotFound is returned by a Provider when it has no content for the schema URL.
+var ErrSchemaNotFound = errors.New("schema file not found")
+
+// Provider allows for the schema file content to be retrieved
+// from different sources, such as a remote server or the local file system.
+type Provider interface {
+	// Lookup returns the content of the schema file for the provided schemaURL.
+	// In the event that it is unable to, an error is returned.
+	Lookup(ctx context.Context, schemaURL string) ([]byte, error)
+}
+
+type httpProvider struct {
+	client *http.Client
+}
+
+var _ Provider = (*httpProvider)(nil)
+
+// NewHTTPProvider returns a Provider that fetches schema files
+// from the server that is hosting the schema URL.
+func NewHTTPProvider(client *http.Client) Provider {
+	return &httpProvider{client: client}
+}
+
+func (hp *httpProvider) Lookup(ctx context.Context, schemaURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, schemaURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := hp.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return content, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%s: %w", schemaURL, ErrSchemaNotFound)
+	}
+	return nil, fmt.Errorf("invalid status code returned for %s: %d", schemaURL, resp.StatusCode)
+}
+
+type fileProvider struct {
+	dir string
+}
+
+var _ Provider = (*fileProvider)(nil)
+
+// NewFileProvider returns a Provider that reads schema files from the directory,
+// where each file is stored using the host and path of the schema URL.
+// For example, `https://opentelemetry.io/schemas/1.9.0` is read from `<dir>/opentelemetry.io/schemas/1.9.0`.
+func NewFileProvider(dir string) Provider {
+	return &fileProvider{dir: dir}
+}
+
+func (fp *fileProvider) Lookup(_ context.Context, schemaURL string) ([]byte, error) {
+	u, err := url.Parse(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(filepath.Join(fp.dir, u.Host, filepath.FromSlash(u.Path)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", schemaURL, ErrSchemaNotFound)
+	}
+	return content, err
+}
+
+type chainProvider []Provider
+
+var _ Provider = (chainProvider)(nil)
+
+// NewChainProvider returns a Provider that returns the content
+// of the first provider that is able to lookup the schema URL.
+func NewChainProvider(providers ...Provider) Provider {
+	return chainProvider(providers)
+}
+
+func (cp chainProvider) Lookup(ctx context.Context, schemaURL string) ([]byte, error) {
+	var errs error
+	for _, p := range cp {
+		content, err := p.Lookup(ctx, schemaURL)
+		if err == nil {
+			return content, nil
+		}
+		errs = multierr.Append(errs, err)
+	}
+	if errs == nil {
+		return nil, fmt.Errorf("%s: %w", schemaURL, ErrSchemaNotFound)
+	}
+	return nil, errs
+}
+
+type storageProvider struct {
+	client storage.Client
+	next   Provider
+	log    *zap.Logger
+}
+
+var _ Provider = (*storageProvider)(nil)
+
+// NewStorageProvider returns a Provider that caches the schema files
+// retrieved by the next provider using the storage client, so that they
+// are available after a restart without having to be fetched again.
+func NewStorageProvider(client storage.Client, next Provider, log *zap.Logger) Provider {
+	return &storageProvider{client: client, next: next, log: log}
+}
+
+func (sp *storageProvider) Lookup(ctx context.Context, schemaURL string) ([]byte, error) {
+	content, err := sp.client.Get(ctx, schemaURL)
+	if err == nil && len(content) > 0 {
+		return content, nil
+	}
+	content, err = sp.next.Lookup(ctx, schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := sp.client.Set(ctx, schemaURL, content); err != nil {
+		// The content is still usable even if it could not be cached.
+		sp.log.Warn("Unable to cache schema file", zap.String("schema-url", schemaURL), zap.Error(err))
+	}
+	return content, nil
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestHTTPProvider(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile(filepath.Join("testdata", "schemas", "example.com", "schemas", "1.2.0"))
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/schemas/1.2.0":
+			_, _ = w.Write(content)
+		case "/schemas/error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	p := NewHTTPProvider(srv.Client())
+
+	data, err := p.Lookup(context.Background(), srv.URL+"/schemas/1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	_, err = p.Lookup(context.Background(), srv.URL+"/schemas/1.0.0")
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+
+	_, err = p.Lookup(context.Background(), srv.URL+"/schemas/error")
+	assert.ErrorContains(t, err, "invalid status code")
+}
+
+func TestFileProvider(t *testing.T) {
+	t.Parallel()
+
+	p := NewFileProvider(filepath.Join("testdata", "schemas"))
+
+	data, err := p.Lookup(context.Background(), testSchemaV110)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "schema_url: "+testSchemaV110)
+
+	_, err = p.Lookup(context.Background(), testSchemaFamily+"/2.0.0")
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+}
+
+func TestChainProvider(t *testing.T) {
+	t.Parallel()
+
+	p := NewChainProvider(
+		NewFileProvider(filepath.Join("testdata", "missing")),
+		NewFileProvider(filepath.Join("testdata", "schemas")),
+	)
+	data, err := p.Lookup(context.Background(), testSchemaV110)
+	require.NoError(t, err)
+	assert.NotEmpty(t, data)
+
+	_, err = p.Lookup(context.Background(), testSchemaFamily+"/2.0.0")
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+
+	_, err = NewChainProvider().Lookup(context.Background(), testSchemaV110)
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+}
+
+// memoryClient is a minimal in memory storage.Client.
+type memoryClient struct {
+	storage.Client
+	values map[string][]byte
+	setErr error
+}
+
+func (mc *memoryClient) Get(_ context.Context, key string) ([]byte, error) {
+	return mc.values[key], nil
+}
+
+func (mc *memoryClient) Set(_ context.Context, key string, value []byte) error {
+	if mc.setErr != nil {
+		return mc.setErr
+	}
+	mc.values[key] = value
+	return nil
+}
+
+func TestStorageProvider(t *testing.T) {
+	t.Parallel()
+
+	client := &memoryClient{values: map[string][]byte{}}
+	next := &countingProvider{next: NewFileProvider(filepath.Join("testdata", "schemas"))}
+	p := NewStorageProvider(client, next, zaptest.NewLogger(t))
+
+	for i := 0; i < 2; i++ {
+		data, err := p.Lookup(context.Background(), testSchemaV110)
+		require.NoError(t, err)
+		assert.NotEmpty(t, data)
+	}
+	assert.EqualValues(t, 1, next.lookups.Load(), "Must use the cached schema file")
+	assert.Contains(t, client.values, testSchemaV110)
+
+	_, err := p.Lookup(context.Background(), testSchemaFamily+"/2.0.0")
+	assert.ErrorIs(t, err, ErrSchemaNotFound)
+
+	client.setErr = errors.New("storage full")
+	data, err := p.Lookup(context.Background(), testSchemaV120)
+	assert.NoError(t, err, "Must not error when the schema file can not be cached")
+	assert.NotEmpty(t, data)
+}
@@ -26,15 +26,15 @@ func TestNewRevision(t *testing.T) {
 			inVersion:    &Version{1, 1, 1},
 			inDefinition: ast.VersionDef{},
 			expect: &RevisionV1{
-				ver:              &Version{1, 1, 1},
-				all:              migrate.NewAttributeChangeSetSlice(),
-				resource:         migrate.NewAttributeChangeSetSlice(),
-				spans:            migrate.NewConditionalAttributeSetSlice(),
-				eventNames:       migrate.NewSignalNameChangeSlice(),
-				eventAttrsOnSpan: migrate.NewConditionalAttributeSetSlice(),
-				eventAttrsOnName: migrate.NewConditionalAttributeSetSlice(),
-				metricsAttrs:     migrate.NewConditionalAttributeSetSlice(),
-				metricNames:      migrate.NewSignalNameChangeSlice(),
+				ver:          &Version{1, 1, 1},
+				all:          migrate.NewAttributeChangeSetSlice(),
+				resource:     migrate.NewAttributeChangeSetSlice(),
+				spans:        migrate.NewConditionalAttributeSetSlice(),
+				eventNames:   migrate.NewSignalNameChangeSlice(),
+				eventAttrs:   migrate.NewMultiConditionalAttributeSetSlice(),
+				logsAttrs:    migrate.NewAttributeChangeSetSlice(),
+				metricsAttrs: migrate.NewConditionalAttributeSetSlice(),
+				metricNames:  migrate.NewSignalNameChangeSlice(),
 			},
 		},
 		{
@@ -171,22 +171,22 @@ func TestNewRevision(t *testing.T) {
 						"started": "application started",
 					}),
 				),
-				eventAttrsOnSpan: migrate.NewConditionalAttributeSetSlice(
-					migrate.NewConditionalAttributeSet(
+				eventAttrs: migrate.NewMultiConditionalAttributeSetSlice(
+					migrate.NewMultiConditionalAttributeSet(
 						map[string]string{
 							"service.app.name": "service.name",
 						},
-						"service running",
-					),
-				),
-				eventAttrsOnName: migrate.NewConditionalAttributeSetSlice(
-					migrate.NewConditionalAttributeSet(
-						map[string]string{
-							"service.app.name": "service.name",
+						map[string][]string{
+							conditionSpanName:  {"service running"},
+							conditionEventName: {"service errored"},
 						},
-						"service errored",
 					),
 				),
+				logsAttrs: migrate.NewAttributeChangeSetSlice(
+					migrate.NewAttributeChangeSet(map[string]string{
+						"ERROR": "error",
+					}),
+				),
 				metricsAttrs: migrate.NewConditionalAttributeSetSlice(
 					migrate.NewConditionalAttributeSet(
 						map[string]string{
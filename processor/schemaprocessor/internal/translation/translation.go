@@ -0,0 +1,306 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	schema "go.opentelemetry.io/otel/schema/v1.0"
+	"go.opentelemetry.io/otel/schema/v1.0/ast"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/migrate"
+)
+
+// Translation defines the complete abstraction of schema translation file
+// that is defined as part of the https://opentelemetry.io/docs/specs/otel/schemas/file_format_v1.0.0/
+// Each instance of Translation is "Target Aware", meaning that given a schemaURL as an input
+// it will convert from the given input, to the configured target.
+//
+// Note: as an optimisation, once a Translation is returned from the manager,
+// there is no checking the incoming signals if the schema family is a match.
+type Translation interface {
+	// SupportedVersion checks to see if the provided version is defined as part
+	// of this translation since it is useful to know it the translation is missing
+	// updates.
+	SupportedVersion(v *Version) bool
+
+	// ApplyAllResourceChanges will modify the resource part of the incoming signals
+	// and update the schema URL of the resource to the target.
+	ApplyAllResourceChanges(in alias.Resource, inSchemaURL string) error
+
+	// ApplyScopeSpanChanges will modify all spans and span events within the scope
+	// and update the schema URL of the scope to the target if it was set.
+	ApplyScopeSpanChanges(in ptrace.ScopeSpans, inSchemaURL string) error
+
+	// ApplyScopeLogChanges will modify all log records within the scope
+	// and update the schema URL of the scope to the target if it was set.
+	ApplyScopeLogChanges(in plog.ScopeLogs, inSchemaURL string) error
+
+	// ApplyScopeMetricChanges will modify all metrics and their data points within the scope
+	// and update the schema URL of the scope to the target if it was set.
+	ApplyScopeMetricChanges(in pmetric.ScopeMetrics, inSchemaURL string) error
+}
+
+type translator struct {
+	targetSchemaURL string
+	target          *Version
+	indexes         map[Version]int // map from version to index in revisions
+	revisions       []*RevisionV1
+}
+
+var _ Translation = (*translator)(nil)
+
+func newTranslatorFromSchema(targetSchemaURL string, content *ast.Schema) (*translator, error) {
+	_, target, err := GetFamilyAndVersion(targetSchemaURL)
+	if err != nil {
+		return nil, err
+	}
+	t := &translator{
+		targetSchemaURL: targetSchemaURL,
+		target:          target,
+		indexes:         make(map[Version]int, len(content.Versions)),
+		revisions:       make([]*RevisionV1, 0, len(content.Versions)),
+	}
+	for v, def := range content.Versions {
+		version, err := NewVersion(string(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema file version %q: %w", v, err)
+		}
+		t.revisions = append(t.revisions, NewRevision(version, def))
+	}
+	sort.Slice(t.revisions, func(i, j int) bool {
+		return t.revisions[i].ver.LessThan(t.revisions[j].ver)
+	})
+	for i, rev := range t.revisions {
+		t.indexes[*rev.ver] = i
+	}
+	return t, nil
+}
+
+// newTranslatorFromReader parses the schema file content to create a translation
+// that converts signals to the target schema URL.
+func newTranslatorFromReader(targetSchemaURL string, content io.Reader) (*translator, error) {
+	def, err := schema.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	return newTranslatorFromSchema(targetSchemaURL, def)
+}
+
+func (t *translator) SupportedVersion(v *Version) bool {
+	_, ok := t.indexes[*v]
+	return ok
+}
+
+// changes returns the revisions that need to be applied to convert
+// signals from the provided schema URL to the target version,
+// along with the direction they must be applied in.
+func (t *translator) changes(inSchemaURL string) ([]*RevisionV1, migrate.StateSelector, error) {
+	_, from, err := GetFamilyAndVersion(inSchemaURL)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !t.SupportedVersion(from) {
+		return nil, 0, fmt.Errorf("unsupported schema version %s: %w", from, ErrInvalidVersion)
+	}
+
+	var (
+		start = t.indexes[*from]
+		revs  []*RevisionV1
+	)
+	switch {
+	case from.LessThan(t.target):
+		for i := start + 1; i < len(t.revisions) && !t.revisions[i].ver.GreaterThan(t.target); i++ {
+			revs = append(revs, t.revisions[i])
+		}
+		return revs, migrate.StateSelectorApply, nil
+	case from.GreaterThan(t.target):
+		for i := start; i >= 0 && t.revisions[i].ver.GreaterThan(t.target); i-- {
+			revs = append(revs, t.revisions[i])
+		}
+		return revs, migrate.StateSelectorRollback, nil
+	}
+	return nil, migrate.StateSelectorApply, nil
+}
+
+func (t *translator) ApplyAllResourceChanges(in alias.Resource, inSchemaURL string) (errs error) {
+	revs, ss, err := t.changes(inSchemaURL)
+	if err != nil {
+		return err
+	}
+	attrs := in.Resource().Attributes()
+	for _, rev := range revs {
+		switch ss {
+		case migrate.StateSelectorApply:
+			errs = multierr.Append(errs, rev.all.Apply(attrs))
+			errs = multierr.Append(errs, rev.resource.Apply(attrs))
+		case migrate.StateSelectorRollback:
+			errs = multierr.Append(errs, rev.resource.Rollback(attrs))
+			errs = multierr.Append(errs, rev.all.Rollback(attrs))
+		}
+	}
+	in.SetSchemaUrl(t.targetSchemaURL)
+	return errs
+}
+
+func (t *translator) ApplyScopeSpanChanges(in ptrace.ScopeSpans, inSchemaURL string) (errs error) {
+	revs, ss, err := t.changes(inSchemaURL)
+	if err != nil {
+		return err
+	}
+	for _, rev := range revs {
+		for i := 0; i < in.Spans().Len(); i++ {
+			span := in.Spans().At(i)
+			switch ss {
+			case migrate.StateSelectorApply:
+				errs = multierr.Append(errs, rev.all.Apply(span.Attributes()))
+				errs = multierr.Append(errs, rev.spans.Apply(span.Attributes(), span.Name()))
+			case migrate.StateSelectorRollback:
+				errs = multierr.Append(errs, rev.spans.Rollback(span.Attributes(), span.Name()))
+				errs = multierr.Append(errs, rev.all.Rollback(span.Attributes()))
+			}
+			for j := 0; j < span.Events().Len(); j++ {
+				errs = multierr.Append(errs, t.applySpanEventChanges(rev, ss, span.Name(), span.Events().At(j)))
+			}
+		}
+	}
+	if in.SchemaUrl() != "" {
+		in.SetSchemaUrl(t.targetSchemaURL)
+	}
+	return errs
+}
+
+func (t *translator) applySpanEventChanges(rev *RevisionV1, ss migrate.StateSelector, spanName string, event ptrace.SpanEvent) (errs error) {
+	switch ss {
+	case migrate.StateSelectorApply:
+		errs = multierr.Append(errs, rev.all.Apply(event.Attributes()))
+		rev.eventNames.Apply(event)
+		errs = multierr.Append(errs, rev.eventAttrs.Apply(event.Attributes(), map[string]string{
+			conditionSpanName:  spanName,
+			conditionEventName: event.Name(),
+		}))
+	case migrate.StateSelectorRollback:
+		errs = multierr.Append(errs, rev.eventAttrs.Rollback(event.Attributes(), map[string]string{
+			conditionSpanName:  spanName,
+			conditionEventName: event.Name(),
+		}))
+		rev.eventNames.Rollback(event)
+		errs = multierr.Append(errs, rev.all.Rollback(event.Attributes()))
+	}
+	return errs
+}
+
+func (t *translator) ApplyScopeLogChanges(in plog.ScopeLogs, inSchemaURL string) (errs error) {
+	revs, ss, err := t.changes(inSchemaURL)
+	if err != nil {
+		return err
+	}
+	for _, rev := range revs {
+		for i := 0; i < in.LogRecords().Len(); i++ {
+			attrs := in.LogRecords().At(i).Attributes()
+			switch ss {
+			case migrate.StateSelectorApply:
+				errs = multierr.Append(errs, rev.all.Apply(attrs))
+				errs = multierr.Append(errs, rev.logsAttrs.Apply(attrs))
+			case migrate.StateSelectorRollback:
+				errs = multierr.Append(errs, rev.logsAttrs.Rollback(attrs))
+				errs = multierr.Append(errs, rev.all.Rollback(attrs))
+			}
+		}
+	}
+	if in.SchemaUrl() != "" {
+		in.SetSchemaUrl(t.targetSchemaURL)
+	}
+	return errs
+}
+
+func (t *translator) ApplyScopeMetricChanges(in pmetric.ScopeMetrics, inSchemaURL string) (errs error) {
+	revs, ss, err := t.changes(inSchemaURL)
+	if err != nil {
+		return err
+	}
+	for _, rev := range revs {
+		for i := 0; i < in.Metrics().Len(); i++ {
+			metric := in.Metrics().At(i)
+			switch ss {
+			case migrate.StateSelectorApply:
+				rev.metricNames.Apply(metric)
+				forEachDataPointAttributes(metric, func(attrs pcommon.Map) {
+					errs = multierr.Append(errs, rev.all.Apply(attrs))
+					errs = multierr.Append(errs, rev.metricsAttrs.Apply(attrs, metric.Name()))
+				})
+			case migrate.StateSelectorRollback:
+				forEachDataPointAttributes(metric, func(attrs pcommon.Map) {
+					errs = multierr.Append(errs, rev.metricsAttrs.Rollback(attrs, metric.Name()))
+					errs = multierr.Append(errs, rev.all.Rollback(attrs))
+				})
+				rev.metricNames.Rollback(metric)
+			}
+		}
+	}
+	if in.SchemaUrl() != "" {
+		in.SetSchemaUrl(t.targetSchemaURL)
+	}
+	return errs
+}
+
+// forEachDataPointAttributes calls fn with the attributes of every data point of the metric.
+func forEachDataPointAttributes(metric pmetric.Metric, fn func(attrs pcommon.Map)) {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			fn(metric.Gauge().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			fn(metric.Sum().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			fn(metric.Histogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			fn(metric.ExponentialHistogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len(); i++ {
+			fn(metric.Summary().DataPoints().At(i).Attributes())
+		}
+	}
+}
+
+// nopTranslation is used when the schema family of the signal
+// is not a target, so the signal is passed through unmodified.
+type nopTranslation struct{}
+
+var _ Translation = (*nopTranslation)(nil)
+
+func (nopTranslation) SupportedVersion(_ *Version) bool {
+	return true
+}
+
+func (nopTranslation) ApplyAllResourceChanges(_ alias.Resource, _ string) error {
+	return nil
+}
+
+func (nopTranslation) ApplyScopeSpanChanges(_ ptrace.ScopeSpans, _ string) error {
+	return nil
+}
+
+func (nopTranslation) ApplyScopeLogChanges(_ plog.ScopeLogs, _ string) error {
+	return nil
+}
+
+func (nopTranslation) ApplyScopeMetricChanges(_ pmetric.ScopeMetrics, _ string) error {
+	return nil
+}
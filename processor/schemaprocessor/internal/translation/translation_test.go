@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package translation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	testSchemaFamily = "https://example.com/schemas"
+	testSchemaV100   = testSchemaFamily + "/1.0.0"
+	testSchemaV110   = testSchemaFamily + "/1.1.0"
+	testSchemaV120   = testSchemaFamily + "/1.2.0"
+)
+
+func newTestTranslator(t *testing.T, target string) *translator {
+	f, err := os.Open(filepath.Join("testdata", "schemas", "example.com", "schemas", "1.2.0"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	tn, err := newTranslatorFromReader(target, f)
+	require.NoError(t, err, "Must not error when parsing schema file")
+	return tn
+}
+
+func TestTranslatorSupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	tn := newTestTranslator(t, testSchemaV120)
+	assert.True(t, tn.SupportedVersion(&Version{1, 0, 0}))
+	assert.True(t, tn.SupportedVersion(&Version{1, 1, 0}))
+	assert.True(t, tn.SupportedVersion(&Version{1, 2, 0}))
+	assert.False(t, tn.SupportedVersion(&Version{1, 3, 0}))
+}
+
+func TestTranslatorInvalidSchemaFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := newTranslatorFromReader(testSchemaV120, strings.NewReader(""))
+	assert.Error(t, err, "Must error when the schema file is empty")
+}
+
+func TestTranslatorResourceChanges(t *testing.T) {
+	t.Parallel()
+
+	old := map[string]any{
+		"service_name": "app",
+		"test.name":    "resource",
+		"test.version": "v1",
+	}
+	updated := map[string]any{
+		"service.name":    "app",
+		"test.identifier": "resource",
+		"test.release":    "v1",
+	}
+
+	for _, tc := range []struct {
+		name      string
+		target    string
+		inSchema  string
+		in        map[string]any
+		expect    map[string]any
+		expectErr bool
+	}{
+		{name: "upgrade", target: testSchemaV120, inSchema: testSchemaV100, in: old, expect: updated},
+		{name: "downgrade", target: testSchemaV100, inSchema: testSchemaV120, in: updated, expect: old},
+		{
+			name:     "partial upgrade",
+			target:   testSchemaV110,
+			inSchema: testSchemaV100,
+			in:       old,
+			expect: map[string]any{
+				"service_name": "app",
+				"test.name":    "resource",
+				"test.release": "v1",
+			},
+		},
+		{name: "same version", target: testSchemaV120, inSchema: testSchemaV120, in: updated, expect: updated},
+		{name: "unsupported version", target: testSchemaV120, inSchema: testSchemaFamily + "/0.9.0", in: old, expect: old, expectErr: true},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tn := newTestTranslator(t, tc.target)
+
+			rl := plog.NewResourceLogs()
+			rl.SetSchemaUrl(tc.inSchema)
+			require.NoError(t, rl.Resource().Attributes().FromRaw(tc.in))
+
+			err := tn.ApplyAllResourceChanges(rl, tc.inSchema)
+			if tc.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidVersion)
+				assert.Equal(t, tc.inSchema, rl.SchemaUrl(), "Must not update the schema url")
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.target, rl.SchemaUrl(), "Must update the schema url")
+			}
+			assert.Equal(t, tc.expect, rl.Resource().Attributes().AsRaw())
+		})
+	}
+}
+
+func TestTranslatorSpanChanges(t *testing.T) {
+	t.Parallel()
+
+	newScopeSpans := func(statusAttr, eventName, peerAttr string) ptrace.ScopeSpans {
+		ss := ptrace.NewScopeSpans()
+		for _, name := range []string{"HTTP GET", "HTTP POST"} {
+			span := ss.Spans().AppendEmpty()
+			span.SetName(name)
+			span.Attributes().PutInt(statusAttr, 200)
+			event := span.Events().AppendEmpty()
+			event.SetName(eventName)
+			event.Attributes().PutStr(peerAttr, "database")
+		}
+		return ss
+	}
+
+	t.Run("upgrade", func(t *testing.T) {
+		t.Parallel()
+
+		tn := newTestTranslator(t, testSchemaV120)
+		ss := newScopeSpans("http.status", "exception.stacktrace", "peer.service")
+		ss.SetSchemaUrl(testSchemaV100)
+		require.NoError(t, tn.ApplyScopeSpanChanges(ss, testSchemaV100))
+
+		assert.Equal(t, testSchemaV120, ss.SchemaUrl())
+
+		get := ss.Spans().At(0)
+		assert.Equal(t, map[string]any{"http.status_code": int64(200)}, get.Attributes().AsRaw())
+		assert.Equal(t, "exception.stack_trace", get.Events().At(0).Name())
+		assert.Equal(t, map[string]any{"peer.service.name": "database"}, get.Events().At(0).Attributes().AsRaw())
+
+		post := ss.Spans().At(1)
+		assert.Equal(t, map[string]any{"http.status": int64(200)}, post.Attributes().AsRaw(), "Must only rename attributes of matching spans")
+		assert.Equal(t, "exception.stack_trace", post.Events().At(0).Name())
+	})
+
+	t.Run("downgrade", func(t *testing.T) {
+		t.Parallel()
+
+		tn := newTestTranslator(t, testSchemaV100)
+		ss := newScopeSpans("http.status_code", "exception.stack_trace", "peer.service.name")
+		require.NoError(t, tn.ApplyScopeSpanChanges(ss, testSchemaV120))
+
+		assert.Empty(t, ss.SchemaUrl(), "Must not set the schema url when it is inherited from the resource")
+
+		get := ss.Spans().At(0)
+		assert.Equal(t, map[string]any{"http.status": int64(200)}, get.Attributes().AsRaw())
+		assert.Equal(t, "exception.stacktrace", get.Events().At(0).Name())
+		assert.Equal(t, map[string]any{"peer.service": "database"}, get.Events().At(0).Attributes().AsRaw())
+	})
+}
+
+func TestTranslatorMetricChanges(t *testing.T) {
+	t.Parallel()
+
+	tn := newTestTranslator(t, testSchemaV120)
+
+	sm := pmetric.NewScopeMetrics()
+	sm.SetSchemaUrl(testSchemaV110)
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("container.cpu.usage.total")
+	dp := sum.SetEmptySum().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("status", "idle")
+	dp.Attributes().PutStr("test.name", "metric")
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("memory.usage")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr("status", "used")
+
+	require.NoError(t, tn.ApplyScopeMetricChanges(sm, testSchemaV110))
+	assert.Equal(t, testSchemaV120, sm.SchemaUrl())
+
+	assert.Equal(t, "cpu.usage.total", sum.Name())
+	assert.Equal(t, map[string]any{
+		"state":           "idle",
+		"test.identifier": "metric",
+	}, sum.Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, "memory.usage", gauge.Name())
+	assert.Equal(t, map[string]any{"status": "used"}, gauge.Gauge().DataPoints().At(0).Attributes().AsRaw(), "Must only rename attributes of matching metrics")
+
+	// Translating back to the original version must restore the metrics.
+	require.NoError(t, newTestTranslator(t, testSchemaV110).ApplyScopeMetricChanges(sm, testSchemaV120))
+	assert.Equal(t, "container.cpu.usage.total", sum.Name())
+	assert.Equal(t, map[string]any{
+		"status":    "idle",
+		"test.name": "metric",
+	}, sum.Sum().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestTranslatorLogChanges(t *testing.T) {
+	t.Parallel()
+
+	tn := newTestTranslator(t, testSchemaV120)
+
+	sl := plog.NewScopeLogs()
+	lr := sl.LogRecords().AppendEmpty()
+	lr.Attributes().PutStr("process.executable_name", "otelcol")
+	lr.Attributes().PutStr("test.version", "v1")
+
+	require.NoError(t, tn.ApplyScopeLogChanges(sl, testSchemaV100))
+	assert.Equal(t, map[string]any{
+		"process.executable.name": "otelcol",
+		"test.release":            "v1",
+	}, lr.Attributes().AsRaw())
+}
@@ -6,52 +6,207 @@ package schemaprocessor // import "github.com/open-telemetry/opentelemetry-colle
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
 )
 
 type transformer struct {
-	targets []string
-	log     *zap.Logger
+	targets  []string
+	prefetch []string
+	log      *zap.Logger
+
+	config        *Config
+	settings      processor.CreateSettings
+	dataType      component.DataType
+	manager       translation.Manager
+	storageClient storage.Client
 }
 
 func newTransformer(
 	_ context.Context,
 	conf component.Config,
 	set processor.CreateSettings,
+	dataType component.DataType,
 ) (*transformer, error) {
 	cfg, ok := conf.(*Config)
 	if !ok {
 		return nil, errors.New("invalid configuration provided")
 	}
+	m, err := translation.NewManager(cfg.Targets, set.Logger)
+	if err != nil {
+		return nil, err
+	}
 	return &transformer{
-		log:     set.Logger,
-		targets: cfg.Targets,
+		log:      set.Logger,
+		targets:  cfg.Targets,
+		prefetch: cfg.Prefetch,
+		config:   cfg,
+		settings: set,
+		dataType: dataType,
+		manager:  m,
 	}, nil
 }
 
-func (t transformer) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+func (t transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	for rl := 0; rl < ld.ResourceLogs().Len(); rl++ {
+		rLog := ld.ResourceLogs().At(rl)
+		resourceSchemaURL := rLog.SchemaUrl()
+		t.applyResourceChanges(ctx, rLog, resourceSchemaURL)
+		for sl := 0; sl < rLog.ScopeLogs().Len(); sl++ {
+			scopeLog := rLog.ScopeLogs().At(sl)
+			schemaURL := scopeSchemaURL(scopeLog.SchemaUrl(), resourceSchemaURL)
+			tn, ok := t.requestTranslation(ctx, schemaURL)
+			if !ok {
+				continue
+			}
+			if err := tn.ApplyScopeLogChanges(scopeLog, schemaURL); err != nil {
+				t.logTranslationError(schemaURL, err)
+			}
+		}
+	}
 	return ld, nil
 }
 
-func (t transformer) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+func (t transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	for rm := 0; rm < md.ResourceMetrics().Len(); rm++ {
+		rMetric := md.ResourceMetrics().At(rm)
+		resourceSchemaURL := rMetric.SchemaUrl()
+		t.applyResourceChanges(ctx, rMetric, resourceSchemaURL)
+		for sm := 0; sm < rMetric.ScopeMetrics().Len(); sm++ {
+			scopeMetric := rMetric.ScopeMetrics().At(sm)
+			schemaURL := scopeSchemaURL(scopeMetric.SchemaUrl(), resourceSchemaURL)
+			tn, ok := t.requestTranslation(ctx, schemaURL)
+			if !ok {
+				continue
+			}
+			if err := tn.ApplyScopeMetricChanges(scopeMetric, schemaURL); err != nil {
+				t.logTranslationError(schemaURL, err)
+			}
+		}
+	}
 	return md, nil
 }
 
-func (t transformer) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+func (t transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	for rt := 0; rt < td.ResourceSpans().Len(); rt++ {
+		rTrace := td.ResourceSpans().At(rt)
+		resourceSchemaURL := rTrace.SchemaUrl()
+		t.applyResourceChanges(ctx, rTrace, resourceSchemaURL)
+		for ss := 0; ss < rTrace.ScopeSpans().Len(); ss++ {
+			scopeTrace := rTrace.ScopeSpans().At(ss)
+			schemaURL := scopeSchemaURL(scopeTrace.SchemaUrl(), resourceSchemaURL)
+			tn, ok := t.requestTranslation(ctx, schemaURL)
+			if !ok {
+				continue
+			}
+			if err := tn.ApplyScopeSpanChanges(scopeTrace, schemaURL); err != nil {
+				t.logTranslationError(schemaURL, err)
+			}
+		}
+	}
 	return td, nil
 }
 
+// applyResourceChanges translates the resource attributes of the signal.
+// The resource schema URL must be read before calling since it is updated to the target.
+func (t transformer) applyResourceChanges(ctx context.Context, in alias.Resource, schemaURL string) {
+	tn, ok := t.requestTranslation(ctx, schemaURL)
+	if !ok {
+		return
+	}
+	if err := tn.ApplyAllResourceChanges(in, schemaURL); err != nil {
+		t.logTranslationError(schemaURL, err)
+	}
+}
+
+// requestTranslation returns the translation for the schema URL,
+// or false if it is not available and the signal must be left unchanged.
+func (t transformer) requestTranslation(ctx context.Context, schemaURL string) (translation.Translation, bool) {
+	tn, err := t.manager.RequestTranslation(ctx, schemaURL)
+	if err != nil {
+		t.log.Warn("Unable to translate signal, leaving it unchanged",
+			zap.String("schema-url", schemaURL),
+			zap.Error(err),
+		)
+		return nil, false
+	}
+	return tn, true
+}
+
+func (t transformer) logTranslationError(schemaURL string, err error) {
+	t.log.Debug("Issues translating signal",
+		zap.String("schema-url", schemaURL),
+		zap.Error(err),
+	)
+}
+
+// scopeSchemaURL returns the schema URL that applies to the scope,
+// which falls back to the resource schema URL if it isn't set.
+func scopeSchemaURL(scope, resource string) string {
+	if scope != "" {
+		return scope
+	}
+	return resource
+}
+
 // start will load the remote file definition if it isn't already cached
 // and resolve the schema translation file
-func (t *transformer) start(_ context.Context, _ component.Host) error {
-	for _, target := range t.targets {
-		t.log.Info("Fetching remote schema url", zap.String("schema-url", target))
+func (t *transformer) start(ctx context.Context, host component.Host) error {
+	client, err := t.config.ToClient(host, t.settings.TelemetrySettings)
+	if err != nil {
+		return err
+	}
+	var provider translation.Provider = translation.NewHTTPProvider(client)
+	if t.config.SchemaDirectory != "" {
+		provider = translation.NewChainProvider(translation.NewFileProvider(t.config.SchemaDirectory), provider)
+	}
+	if t.config.StorageID != nil {
+		t.storageClient, err = getStorageClient(ctx, host, *t.config.StorageID, t.settings.ID, t.dataType)
+		if err != nil {
+			return err
+		}
+		provider = translation.NewStorageProvider(t.storageClient, provider, t.log)
+	}
+	if err = t.manager.SetProvider(provider); err != nil {
+		return err
+	}
+
+	for _, schemaURL := range append(append([]string{}, t.targets...), t.prefetch...) {
+		t.log.Info("Fetching remote schema url", zap.String("schema-url", schemaURL))
+		// Failing to prefetch is not fatal, the schema file
+		// is fetched again once a signal requires it.
+		if err := t.manager.Prefetch(ctx, schemaURL); err != nil {
+			t.log.Warn("Unable to prefetch schema url", zap.String("schema-url", schemaURL), zap.Error(err))
+		}
 	}
 	return nil
 }
+
+func (t *transformer) shutdown(ctx context.Context) error {
+	if t.storageClient == nil {
+		return nil
+	}
+	return t.storageClient.Close(ctx)
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID component.ID, componentID component.ID, dataType component.DataType) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+	storageExtension, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+	return storageExtension.GetClient(ctx, component.KindProcessor, componentID, string(dataType))
+}
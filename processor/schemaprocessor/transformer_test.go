@@ -6,28 +6,50 @@ package schemaprocessor
 import (
 	"context"
 	_ "embed"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/metadata"
 )
 
 func newTestTransformer(t *testing.T) *transformer {
-	trans, err := newTransformer(context.Background(), newDefaultConfiguration(), processor.CreateSettings{
+	return newTestTransformerWithConfig(t, newDefaultConfiguration().(*Config))
+}
+
+func newTestTransformerWithConfig(t *testing.T, cfg *Config) *transformer {
+	trans, err := newTransformer(context.Background(), cfg, processor.CreateSettings{
+		ID: component.NewID(metadata.Type),
 		TelemetrySettings: component.TelemetrySettings{
 			Logger: zaptest.NewLogger(t),
 		},
-	})
+	}, component.DataTypeTraces)
 	require.NoError(t, err, "Must not error when creating default transformer")
 	return trans
 }
 
+func newTestTranslatingTransformer(t *testing.T) *transformer {
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{"https://example.com/schemas/1.1.0"}
+	cfg.SchemaDirectory = filepath.Join("testdata", "schemas")
+
+	trans := newTestTransformerWithConfig(t, cfg)
+	require.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, trans.shutdown(context.Background()))
+	})
+	return trans
+}
+
 func TestTransformerStart(t *testing.T) {
 	t.Parallel()
 
@@ -52,7 +74,7 @@ func TestTransformerProcessing(t *testing.T) {
 
 		out, err := trans.processMetrics(context.Background(), in)
 		assert.NoError(t, err, "Must not error when processing metrics")
-		assert.Equal(t, in, out, "Must return the same data (subject to change)")
+		assert.Equal(t, in, out, "Must return the same data when the schema family is not a target")
 	})
 
 	t.Run("traces", func(t *testing.T) {
@@ -68,7 +90,7 @@ func TestTransformerProcessing(t *testing.T) {
 
 		out, err := trans.processTraces(context.Background(), in)
 		assert.NoError(t, err, "Must not error when processing metrics")
-		assert.Equal(t, in, out, "Must return the same data (subject to change)")
+		assert.Equal(t, in, out, "Must return the same data when the schema family is not a target")
 	})
 
 	t.Run("logs", func(t *testing.T) {
@@ -83,6 +105,108 @@ func TestTransformerProcessing(t *testing.T) {
 
 		out, err := trans.processLogs(context.Background(), in)
 		assert.NoError(t, err, "Must not error when processing metrics")
-		assert.Equal(t, in, out, "Must return the same data (subject to change)")
+		assert.Equal(t, in, out, "Must return the same data when the schema family is not a target")
+	})
+}
+
+func TestTransformerStartMissingStorage(t *testing.T) {
+	t.Parallel()
+
+	cfg := newDefaultConfiguration().(*Config)
+	storageID := component.NewID("file_storage")
+	cfg.StorageID = &storageID
+
+	trans := newTestTransformerWithConfig(t, cfg)
+	assert.ErrorContains(t, trans.start(context.Background(), componenttest.NewNopHost()), "storage extension 'file_storage' not found")
+}
+
+func TestTransformerTranslation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("metrics", func(t *testing.T) {
+		t.Parallel()
+
+		trans := newTestTranslatingTransformer(t)
+
+		in := pmetric.NewMetrics()
+		rMetric := in.ResourceMetrics().AppendEmpty()
+		rMetric.SetSchemaUrl("https://example.com/schemas/1.2.0")
+		rMetric.Resource().Attributes().PutStr("service.name", "app")
+		m := rMetric.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("cpu.usage.total")
+		m.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr("state", "idle")
+
+		out, err := trans.processMetrics(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing metrics")
+
+		rMetric = out.ResourceMetrics().At(0)
+		assert.Equal(t, "https://example.com/schemas/1.1.0", rMetric.SchemaUrl())
+		assert.Equal(t, map[string]any{"service_name": "app"}, rMetric.Resource().Attributes().AsRaw())
+		m = rMetric.ScopeMetrics().At(0).Metrics().At(0)
+		assert.Equal(t, "container.cpu.usage.total", m.Name())
+		assert.Equal(t, map[string]any{"status": "idle"}, m.Gauge().DataPoints().At(0).Attributes().AsRaw())
+	})
+
+	t.Run("traces", func(t *testing.T) {
+		t.Parallel()
+
+		trans := newTestTranslatingTransformer(t)
+
+		in := ptrace.NewTraces()
+		rSpan := in.ResourceSpans().AppendEmpty()
+		rSpan.SetSchemaUrl("https://example.com/schemas/1.0.0")
+		rSpan.Resource().Attributes().PutStr("test.version", "v1")
+		scopeSpans := rSpan.ScopeSpans().AppendEmpty()
+		scopeSpans.SetSchemaUrl("https://example.com/schemas/1.2.0")
+		s := scopeSpans.Spans().AppendEmpty()
+		s.SetName("HTTP GET")
+		s.Attributes().PutInt("http.status_code", 200)
+
+		out, err := trans.processTraces(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing traces")
+
+		rSpan = out.ResourceSpans().At(0)
+		assert.Equal(t, "https://example.com/schemas/1.1.0", rSpan.SchemaUrl())
+		assert.Equal(t, map[string]any{"test.release": "v1"}, rSpan.Resource().Attributes().AsRaw())
+		scopeSpans = rSpan.ScopeSpans().At(0)
+		assert.Equal(t, "https://example.com/schemas/1.1.0", scopeSpans.SchemaUrl())
+		assert.Equal(t, map[string]any{"http.status": int64(200)}, scopeSpans.Spans().At(0).Attributes().AsRaw())
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		t.Parallel()
+
+		trans := newTestTranslatingTransformer(t)
+
+		in := plog.NewLogs()
+		rLog := in.ResourceLogs().AppendEmpty()
+		rLog.SetSchemaUrl("https://example.com/schemas/1.0.0")
+		lr := rLog.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		lr.Attributes().PutStr("test.version", "v1")
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err, "Must not error when processing logs")
+
+		rLog = out.ResourceLogs().At(0)
+		assert.Equal(t, "https://example.com/schemas/1.1.0", rLog.SchemaUrl())
+		assert.Equal(t, map[string]any{"test.release": "v1"}, rLog.ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+	})
+
+	t.Run("unavailable schema file", func(t *testing.T) {
+		t.Parallel()
+
+		trans := newTestTranslatingTransformer(t)
+
+		in := plog.NewLogs()
+		rLog := in.ResourceLogs().AppendEmpty()
+		rLog.SetSchemaUrl("https://example.com/schemas/2.0.0")
+		rLog.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutStr("test.release", "v1")
+
+		expect := plog.NewLogs()
+		in.CopyTo(expect)
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err, "Must not error when the schema file is unavailable")
+		assert.Equal(t, expect, out, "Must leave the signal unchanged")
 	})
 }
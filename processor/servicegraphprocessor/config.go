@@ -38,6 +38,11 @@ type Config struct {
 	// MetricsFlushInterval is the interval at which metrics are flushed to the exporter.
 	// If set to 0, metrics are flushed on every received batch of traces.
 	MetricsFlushInterval time.Duration `mapstructure:"metrics_flush_interval"`
+
+	// AggregationCardinalityLimit is the maximum number of series of each metric for each client service.
+	// Once reached, requests of new series are aggregated into a single series of the client service with the
+	// `otel.metric.overflow` attribute set to true. If set to 0, there is no limit.
+	AggregationCardinalityLimit int `mapstructure:"aggregation_cardinality_limit"`
 }
 
 type StoreConfig struct {
@@ -40,9 +40,10 @@ func TestLoadConfig(t *testing.T) {
 				TTL:      time.Second,
 				MaxItems: 10,
 			},
-			CacheLoop:                 2 * time.Minute,
-			StoreExpirationLoop:       10 * time.Second,
-			VirtualNodePeerAttributes: []string{"db.name", "rpc.service"},
+			CacheLoop:                   2 * time.Minute,
+			StoreExpirationLoop:         10 * time.Second,
+			VirtualNodePeerAttributes:   []string{"db.name", "rpc.service"},
+			AggregationCardinalityLimit: 500,
 		},
 		cfg.Processors[component.NewID(metadata.Type)],
 	)
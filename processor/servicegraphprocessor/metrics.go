@@ -12,9 +12,10 @@ import (
 )
 
 var (
-	statDroppedSpans = stats.Int64("dropped_spans", "Number of spans dropped when trying to add edges", stats.UnitDimensionless)
-	statTotalEdges   = stats.Int64("total_edges", "Total number of unique edges", stats.UnitDimensionless)
-	statExpiredEdges = stats.Int64("expired_edges", "Number of edges that expired before finding its matching span", stats.UnitDimensionless)
+	statDroppedSpans  = stats.Int64("dropped_spans", "Number of spans dropped when trying to add edges", stats.UnitDimensionless)
+	statTotalEdges    = stats.Int64("total_edges", "Total number of unique edges", stats.UnitDimensionless)
+	statExpiredEdges  = stats.Int64("expired_edges", "Number of edges that expired before finding its matching span", stats.UnitDimensionless)
+	statOverflowEdges = stats.Int64("overflow_edges", "Number of edges aggregated into the overflow series because the cardinality limit was reached", stats.UnitDimensionless)
 )
 
 func serviceGraphProcessorViews() []*view.View {
@@ -36,10 +37,17 @@ func serviceGraphProcessorViews() []*view.View {
 		Measure:     statExpiredEdges,
 		Aggregation: view.Count(),
 	}
+	overflowEdgesView := &view.View{
+		Name:        processorhelper.BuildCustomMetricName(metadata.Type, statOverflowEdges.Name()),
+		Description: statOverflowEdges.Description(),
+		Measure:     statOverflowEdges,
+		Aggregation: view.Count(),
+	}
 
 	return []*view.View{
 		droppedSpansView,
 		totalEdgesView,
 		expiredEdgesView,
+		overflowEdgesView,
 	}
 }
@@ -30,6 +30,8 @@ const (
 	metricKeySeparator = string(byte(0))
 	clientKind         = "client"
 	serverKind         = "server"
+
+	overflowAttribute = "otel.metric.overflow"
 )
 
 var (
@@ -47,7 +49,8 @@ var (
 
 type metricSeries struct {
 	dimensions  pcommon.Map
-	lastUpdated int64 // Used to remove stale series
+	lastUpdated int64  // Used to remove stale series
+	client      string // Used to count the series of each client service
 }
 
 var _ processor.Traces = (*serviceGraphProcessor)(nil)
@@ -73,8 +76,9 @@ type serviceGraphProcessor struct {
 	reqServerDurationSecondsBucketCounts map[string][]uint64
 	reqDurationBounds                    []float64
 
-	metricMutex sync.RWMutex
-	keyToMetric map[string]metricSeries
+	metricMutex    sync.RWMutex
+	keyToMetric    map[string]metricSeries
+	clientToSeries map[string]int
 
 	shutdownCh chan any
 }
@@ -116,6 +120,7 @@ func newProcessor(logger *zap.Logger, config component.Config) *serviceGraphProc
 		reqServerDurationSecondsBucketCounts: make(map[string][]uint64),
 		reqDurationBounds:                    bounds,
 		keyToMetric:                          make(map[string]metricSeries),
+		clientToSeries:                       make(map[string]int),
 		shutdownCh:                           make(chan any),
 	}
 }
@@ -372,11 +377,20 @@ func (p *serviceGraphProcessor) onExpire(e *store.Edge) {
 
 func (p *serviceGraphProcessor) aggregateMetricsForEdge(e *store.Edge) {
 	metricKey := p.buildMetricKey(e.ClientService, e.ServerService, string(e.ConnectionType), e.Dimensions)
-	dimensions := buildDimensions(e)
 
 	p.seriesMutex.Lock()
 	defer p.seriesMutex.Unlock()
-	p.updateSeries(metricKey, dimensions)
+	var dimensions pcommon.Map
+	if p.isCardinalityLimitReached(metricKey, e.ClientService) {
+		stats.Record(context.Background(), statOverflowEdges.M(1))
+		metricKey = overflowKey(e.ClientService)
+		dimensions = pcommon.NewMap()
+		dimensions.PutStr("client", e.ClientService)
+		dimensions.PutBool(overflowAttribute, true)
+	} else {
+		dimensions = buildDimensions(e)
+	}
+	p.updateSeries(metricKey, e.ClientService, dimensions)
 	p.updateCountMetrics(metricKey)
 	if e.Failed {
 		p.updateErrorMetrics(metricKey)
@@ -384,16 +398,41 @@ func (p *serviceGraphProcessor) aggregateMetricsForEdge(e *store.Edge) {
 	p.updateDurationMetrics(metricKey, e.ServerLatencySec, e.ClientLatencySec)
 }
 
-func (p *serviceGraphProcessor) updateSeries(key string, dimensions pcommon.Map) {
+func (p *serviceGraphProcessor) updateSeries(key, client string, dimensions pcommon.Map) {
 	p.metricMutex.Lock()
 	defer p.metricMutex.Unlock()
+	if _, ok := p.keyToMetric[key]; !ok {
+		p.clientToSeries[client]++
+	}
 	// Overwrite the series if it already exists
 	p.keyToMetric[key] = metricSeries{
 		dimensions:  dimensions,
 		lastUpdated: time.Now().UnixMilli(),
+		client:      client,
 	}
 }
 
+// isCardinalityLimitReached returns true if the key is a new series
+// and the number of series of the client service has reached the configured limit.
+func (p *serviceGraphProcessor) isCardinalityLimitReached(key, client string) bool {
+	if p.config.AggregationCardinalityLimit <= 0 {
+		return false
+	}
+	p.metricMutex.RLock()
+	defer p.metricMutex.RUnlock()
+	if _, ok := p.keyToMetric[key]; ok {
+		return false
+	}
+	return p.clientToSeries[client] >= p.config.AggregationCardinalityLimit
+}
+
+// overflowKey returns the key of the series that the requests of the client service are aggregated into
+// once its cardinality limit is reached. It cannot collide with the keys built by buildMetricKey,
+// "overflow" not being a connection type.
+func overflowKey(client string) string {
+	return client + metricKeySeparator + metricKeySeparator + "overflow"
+}
+
 func (p *serviceGraphProcessor) dimensionsForSeries(key string) (pcommon.Map, bool) {
 	p.metricMutex.RLock()
 	defer p.metricMutex.RUnlock()
@@ -644,6 +683,10 @@ func (p *serviceGraphProcessor) cleanCache() {
 
 	p.metricMutex.Lock()
 	for _, key := range staleSeries {
+		client := p.keyToMetric[key].client
+		if p.clientToSeries[client]--; p.clientToSeries[client] <= 0 {
+			delete(p.clientToSeries, client)
+		}
 		delete(p.keyToMetric, key)
 	}
 	p.metricMutex.Unlock()
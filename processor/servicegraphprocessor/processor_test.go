@@ -28,6 +28,8 @@ import (
 	"go.opentelemetry.io/collector/processor/processortest"
 	semconv "go.opentelemetry.io/collector/semconv/v1.13.0"
 	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/servicegraphprocessor/internal/store"
 )
 
 func TestProcessorStart(t *testing.T) {
@@ -629,3 +631,46 @@ func TestStaleSeriesCleanup(t *testing.T) {
 	// Shutdown the processor
 	assert.NoError(t, p.Shutdown(context.Background()))
 }
+
+func TestAggregationCardinalityLimit(t *testing.T) {
+	p := newProcessor(zaptest.NewLogger(t), &Config{AggregationCardinalityLimit: 2})
+
+	for _, edge := range []struct{ client, server string }{
+		{"client", "a"}, {"client", "b"}, {"client", "a"}, {"client", "c"}, {"client", "d"},
+		{"other", "a"}, {"other", "b"},
+	} {
+		p.aggregateMetricsForEdge(&store.Edge{
+			ClientService:  edge.client,
+			ServerService:  edge.server,
+			ConnectionType: store.Unknown,
+			Dimensions:     map[string]string{},
+		})
+	}
+
+	assert.Len(t, p.keyToMetric, 5)
+	assert.Equal(t, int64(2), p.reqTotal[p.buildMetricKey("client", "a", "", nil)])
+	assert.Equal(t, int64(1), p.reqTotal[p.buildMetricKey("client", "b", "", nil)])
+	assert.Equal(t, int64(2), p.reqTotal[overflowKey("client")])
+	// The series of the other client service are not limited by those of the first one.
+	assert.Equal(t, int64(1), p.reqTotal[p.buildMetricKey("other", "a", "", nil)])
+	assert.Equal(t, int64(1), p.reqTotal[p.buildMetricKey("other", "b", "", nil)])
+	assert.NotContains(t, p.reqTotal, overflowKey("other"))
+
+	dimensions, ok := p.dimensionsForSeries(overflowKey("client"))
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"client": "client", "otel.metric.overflow": true}, dimensions.AsRaw())
+
+	md, err := p.buildMetrics()
+	require.NoError(t, err)
+	assert.Greater(t, md.MetricCount(), 0)
+
+	// The stale series no longer count towards the limit.
+	for key, series := range p.keyToMetric {
+		series.lastUpdated = 0
+		p.keyToMetric[key] = series
+	}
+	p.cleanCache()
+	assert.Empty(t, p.clientToSeries)
+	p.aggregateMetricsForEdge(&store.Edge{ClientService: "client", ServerService: "e", Dimensions: map[string]string{}})
+	assert.Equal(t, int64(1), p.reqTotal[p.buildMetricKey("client", "e", "", nil)])
+}
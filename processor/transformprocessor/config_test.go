@@ -92,6 +92,34 @@ func TestLoadConfig(t *testing.T) {
 				LogStatements:    []common.ContextStatements{},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "conditions"),
+			expected: &Config{
+				ErrorMode:        ottl.PropagateError,
+				TraceStatements:  []common.ContextStatements{},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements: []common.ContextStatements{
+					{
+						Context: "log",
+						Conditions: []string{
+							`attributes["http.path"] == "/animal"`,
+						},
+						Statements: []string{
+							`set(body, "bear")`,
+						},
+						ElseStatements: []string{
+							`set(body, "unknown")`,
+						},
+					},
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "else_without_conditions"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_syntax_condition"),
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_trace"),
 		},
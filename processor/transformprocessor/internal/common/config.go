@@ -32,6 +32,11 @@ func (c *ContextID) UnmarshalText(text []byte) error {
 }
 
 type ContextStatements struct {
-	Context    ContextID `mapstructure:"context"`
-	Statements []string  `mapstructure:"statements"`
+	Context ContextID `mapstructure:"context"`
+	// Conditions are evaluated once per item of the context before executing the statements.
+	// The statements are only executed when at least one condition matches.
+	Conditions []string `mapstructure:"conditions"`
+	Statements []string `mapstructure:"statements"`
+	// ElseStatements are executed instead of the statements when none of the conditions match.
+	ElseStatements []string `mapstructure:"else_statements"`
 }
@@ -19,7 +19,7 @@ import (
 var _ consumer.Logs = &logStatements{}
 
 type logStatements struct {
-	ottl.Statements[ottllog.TransformContext]
+	statementGroup[ottllog.TransformContext]
 }
 
 func (l logStatements) Capabilities() consumer.Capabilities {
@@ -101,11 +101,10 @@ func NewLogParserCollection(settings component.TelemetrySettings, options ...Log
 func (pc LogParserCollection) ParseContextStatements(contextStatements ContextStatements) (consumer.Logs, error) {
 	switch contextStatements.Context {
 	case Log:
-		parsedStatements, err := pc.logParser.ParseStatements(contextStatements.Statements)
+		lStatements, err := parseStatementGroup(&pc.logParser, contextStatements, pc.settings, pc.errorMode)
 		if err != nil {
 			return nil, err
 		}
-		lStatements := ottllog.NewStatements(parsedStatements, pc.settings, ottllog.WithErrorMode(pc.errorMode))
 		return logStatements{lStatements}, nil
 	default:
 		statements, err := pc.parseCommonContextStatements(contextStatements)
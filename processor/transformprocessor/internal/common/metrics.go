@@ -21,7 +21,7 @@ import (
 var _ consumer.Metrics = &metricStatements{}
 
 type metricStatements struct {
-	ottl.Statements[ottlmetric.TransformContext]
+	statementGroup[ottlmetric.TransformContext]
 }
 
 func (m metricStatements) Capabilities() consumer.Capabilities {
@@ -36,22 +36,37 @@ func (m metricStatements) ConsumeMetrics(ctx context.Context, md pmetric.Metrics
 		for j := 0; j < rmetrics.ScopeMetrics().Len(); j++ {
 			smetrics := rmetrics.ScopeMetrics().At(j)
 			metrics := smetrics.Metrics()
+			deferred := pmetric.NewMetricSlice()
+			deferredCtx := context.WithValue(ctx, deferredMetricsKey{}, deferred)
 			for k := 0; k < metrics.Len(); k++ {
 				tCtx := ottlmetric.NewTransformContext(metrics.At(k), smetrics.Metrics(), smetrics.Scope(), rmetrics.Resource())
-				err := m.Execute(ctx, tCtx)
+				err := m.Execute(deferredCtx, tCtx)
 				if err != nil {
 					return err
 				}
 			}
+			deferred.MoveAndAppendTo(metrics)
 		}
 	}
 	return nil
 }
 
+type deferredMetricsKey struct{}
+
+// AppendEmptyMetric appends an empty metric to the metrics. While the metrics are processed by the
+// statements of a metric context, the metric is only appended once all of them are processed,
+// so that the statements are not executed on the new metric.
+func AppendEmptyMetric(ctx context.Context, metrics pmetric.MetricSlice) pmetric.Metric {
+	if deferred, ok := ctx.Value(deferredMetricsKey{}).(pmetric.MetricSlice); ok {
+		return deferred.AppendEmpty()
+	}
+	return metrics.AppendEmpty()
+}
+
 var _ consumer.Metrics = &dataPointStatements{}
 
 type dataPointStatements struct {
-	ottl.Statements[ottldatapoint.TransformContext]
+	statementGroup[ottldatapoint.TransformContext]
 }
 
 func (d dataPointStatements) Capabilities() consumer.Capabilities {
@@ -202,18 +217,16 @@ func NewMetricParserCollection(settings component.TelemetrySettings, options ...
 func (pc MetricParserCollection) ParseContextStatements(contextStatements ContextStatements) (consumer.Metrics, error) {
 	switch contextStatements.Context {
 	case Metric:
-		parseStatements, err := pc.metricParser.ParseStatements(contextStatements.Statements)
+		mStatements, err := parseStatementGroup(&pc.metricParser, contextStatements, pc.settings, pc.errorMode)
 		if err != nil {
 			return nil, err
 		}
-		mStatements := ottlmetric.NewStatements(parseStatements, pc.settings, ottlmetric.WithErrorMode(pc.errorMode))
 		return metricStatements{mStatements}, nil
 	case DataPoint:
-		parsedStatements, err := pc.dataPointParser.ParseStatements(contextStatements.Statements)
+		dpStatements, err := parseStatementGroup(&pc.dataPointParser, contextStatements, pc.settings, pc.errorMode)
 		if err != nil {
 			return nil, err
 		}
-		dpStatements := ottldatapoint.NewStatements(parsedStatements, pc.settings, ottldatapoint.WithErrorMode(pc.errorMode))
 		return dataPointStatements{dpStatements}, nil
 	default:
 		statements, err := pc.parseCommonContextStatements(contextStatements)
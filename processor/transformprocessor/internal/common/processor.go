@@ -24,7 +24,7 @@ var _ consumer.Logs = &resourceStatements{}
 var _ baseContext = &resourceStatements{}
 
 type resourceStatements struct {
-	ottl.Statements[ottlresource.TransformContext]
+	statementGroup[ottlresource.TransformContext]
 }
 
 func (r resourceStatements) Capabilities() consumer.Capabilities {
@@ -75,7 +75,7 @@ var _ consumer.Logs = &scopeStatements{}
 var _ baseContext = &scopeStatements{}
 
 type scopeStatements struct {
-	ottl.Statements[ottlscope.TransformContext]
+	statementGroup[ottlscope.TransformContext]
 }
 
 func (s scopeStatements) Capabilities() consumer.Capabilities {
@@ -145,18 +145,16 @@ type baseContext interface {
 func (pc parserCollection) parseCommonContextStatements(contextStatement ContextStatements) (baseContext, error) {
 	switch contextStatement.Context {
 	case Resource:
-		parsedStatements, err := pc.resourceParser.ParseStatements(contextStatement.Statements)
+		rStatements, err := parseStatementGroup(&pc.resourceParser, contextStatement, pc.settings, pc.errorMode)
 		if err != nil {
 			return nil, err
 		}
-		rStatements := ottlresource.NewStatements(parsedStatements, pc.settings, ottlresource.WithErrorMode(pc.errorMode))
 		return resourceStatements{rStatements}, nil
 	case Scope:
-		parsedStatements, err := pc.scopeParser.ParseStatements(contextStatement.Statements)
+		sStatements, err := parseStatementGroup(&pc.scopeParser, contextStatement, pc.settings, pc.errorMode)
 		if err != nil {
 			return nil, err
 		}
-		sStatements := ottlscope.NewStatements(parsedStatements, pc.settings, ottlscope.WithErrorMode(pc.errorMode))
 		return scopeStatements{sStatements}, nil
 	default:
 		return nil, fmt.Errorf("unknown context %v", contextStatement.Context)
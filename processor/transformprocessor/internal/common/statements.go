@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+var errElseWithoutConditions = errors.New("else_statements can only be used together with conditions")
+
+// statementGroup executes the statements of a ContextStatements entry.
+// When the group has conditions, they are evaluated once per item and
+// the else statements are executed instead if none of them match.
+type statementGroup[K any] struct {
+	statements     ottl.Statements[K]
+	elseStatements ottl.Statements[K]
+	conditions     *ottl.ConditionSequence[K]
+}
+
+func (g statementGroup[K]) Execute(ctx context.Context, tCtx K) error {
+	if g.conditions != nil {
+		match, err := g.conditions.Eval(ctx, tCtx)
+		if err != nil {
+			return err
+		}
+		if !match {
+			return g.elseStatements.Execute(ctx, tCtx)
+		}
+	}
+	return g.statements.Execute(ctx, tCtx)
+}
+
+func parseStatementGroup[K any](parser *ottl.Parser[K], contextStatements ContextStatements, settings component.TelemetrySettings, errorMode ottl.ErrorMode) (statementGroup[K], error) {
+	var group statementGroup[K]
+	if len(contextStatements.ElseStatements) > 0 && len(contextStatements.Conditions) == 0 {
+		return group, errElseWithoutConditions
+	}
+
+	parsedStatements, err := parser.ParseStatements(contextStatements.Statements)
+	if err != nil {
+		return group, err
+	}
+	group.statements = ottl.NewStatements(parsedStatements, settings, ottl.WithErrorMode[K](errorMode))
+
+	if len(contextStatements.Conditions) == 0 {
+		return group, nil
+	}
+	parsedConditions, err := parser.ParseConditions(contextStatements.Conditions)
+	if err != nil {
+		return group, err
+	}
+	conditions := ottl.NewConditionSequence(parsedConditions, settings, ottl.WithConditionSequenceErrorMode[K](errorMode))
+	group.conditions = &conditions
+
+	parsedElseStatements, err := parser.ParseStatements(contextStatements.ElseStatements)
+	if err != nil {
+		return group, err
+	}
+	group.elseStatements = ottl.NewStatements(parsedElseStatements, settings, ottl.WithErrorMode[K](errorMode))
+	return group, nil
+}
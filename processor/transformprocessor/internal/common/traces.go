@@ -20,7 +20,7 @@ import (
 var _ consumer.Traces = &traceStatements{}
 
 type traceStatements struct {
-	ottl.Statements[ottlspan.TransformContext]
+	statementGroup[ottlspan.TransformContext]
 }
 
 func (t traceStatements) Capabilities() consumer.Capabilities {
@@ -50,7 +50,7 @@ func (t traceStatements) ConsumeTraces(ctx context.Context, td ptrace.Traces) er
 var _ consumer.Traces = &spanEventStatements{}
 
 type spanEventStatements struct {
-	ottl.Statements[ottlspanevent.TransformContext]
+	statementGroup[ottlspanevent.TransformContext]
 }
 
 func (s spanEventStatements) Capabilities() consumer.Capabilities {
@@ -148,18 +148,16 @@ func NewTraceParserCollection(settings component.TelemetrySettings, options ...T
 func (pc TraceParserCollection) ParseContextStatements(contextStatements ContextStatements) (consumer.Traces, error) {
 	switch contextStatements.Context {
 	case Span:
-		parsedStatements, err := pc.spanParser.ParseStatements(contextStatements.Statements)
+		sStatements, err := parseStatementGroup(&pc.spanParser, contextStatements, pc.settings, pc.errorMode)
 		if err != nil {
 			return nil, err
 		}
-		sStatements := ottlspan.NewStatements(parsedStatements, pc.settings, ottlspan.WithErrorMode(pc.errorMode))
 		return traceStatements{sStatements}, nil
 	case SpanEvent:
-		parsedStatements, err := pc.spanEventParser.ParseStatements(contextStatements.Statements)
+		seStatements, err := parseStatementGroup(&pc.spanEventParser, contextStatements, pc.settings, pc.errorMode)
 		if err != nil {
 			return nil, err
 		}
-		seStatements := ottlspanevent.NewStatements(parsedStatements, pc.settings, ottlspanevent.WithErrorMode(pc.errorMode))
 		return spanEventStatements{seStatements}, nil
 	default:
 		return pc.parseCommonContextStatements(contextStatements)
@@ -465,6 +465,90 @@ func Test_ProcessLogs_MixContext(t *testing.T) {
 	}
 }
 
+func Test_ProcessLogs_Conditions(t *testing.T) {
+	tests := []struct {
+		name             string
+		contextStatement common.ContextStatements
+		want             func(td plog.Logs)
+	}{
+		{
+			name: "statements only executed when conditions match",
+			contextStatement: common.ContextStatements{
+				Context:    "log",
+				Conditions: []string{`body == "operationA"`},
+				Statements: []string{`set(attributes["test"], "pass")`},
+			},
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			name: "conditions are ORed",
+			contextStatement: common.ContextStatements{
+				Context:    "log",
+				Conditions: []string{`body == "operationA"`, `body == "operationB"`},
+				Statements: []string{`set(attributes["test"], "pass")`},
+			},
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("test", "pass")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			name: "else statements executed when conditions do not match",
+			contextStatement: common.ContextStatements{
+				Context:        "log",
+				Conditions:     []string{`body == "operationA"`},
+				Statements:     []string{`set(attributes["test"], "pass")`},
+				ElseStatements: []string{`set(attributes["test"], "fail")`},
+			},
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("test", "pass")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutStr("test", "fail")
+			},
+		},
+		{
+			name: "resource conditions",
+			contextStatement: common.ContextStatements{
+				Context:        "resource",
+				Conditions:     []string{`attributes["host.name"] == "wrong"`},
+				Statements:     []string{`set(attributes["test"], "pass")`},
+				ElseStatements: []string{`set(attributes["test"], "fail")`},
+			},
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).Resource().Attributes().PutStr("test", "fail")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := constructLogs()
+			processor, err := NewProcessor([]common.ContextStatements{tt.contextStatement}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessLogs(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructLogs()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
+func Test_NewProcessor_ElseStatementsWithoutConditions(t *testing.T) {
+	_, err := NewProcessor([]common.ContextStatements{
+		{
+			Context:        "log",
+			Statements:     []string{`set(attributes["test"], "pass")`},
+			ElseStatements: []string{`set(attributes["test"], "fail")`},
+		},
+	}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+	assert.Error(t, err)
+}
+
 func Test_ProcessTraces_Error(t *testing.T) {
 	tests := []struct {
 		statement string
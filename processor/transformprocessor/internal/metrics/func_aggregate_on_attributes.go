@@ -0,0 +1,367 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+type aggregationType string
+
+const (
+	sumAggregation   aggregationType = "sum"
+	avgAggregation   aggregationType = "avg"
+	minAggregation   aggregationType = "min"
+	maxAggregation   aggregationType = "max"
+	countAggregation aggregationType = "count"
+)
+
+type aggregateOnAttributesArguments struct {
+	AggregationFunction string
+	Attributes          ottl.Optional[[]string]
+}
+
+func newAggregateOnAttributesFactory() ottl.Factory[ottlmetric.TransformContext] {
+	return ottl.NewFactory("aggregate_on_attributes", &aggregateOnAttributesArguments{}, createAggregateOnAttributesFunction)
+}
+
+func createAggregateOnAttributesFunction(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+	args, ok := oArgs.(*aggregateOnAttributesArguments)
+
+	if !ok {
+		return nil, fmt.Errorf("AggregateOnAttributesFactory args must be of type *AggregateOnAttributesArguments")
+	}
+
+	return aggregateOnAttributes(aggregationType(args.AggregationFunction), args.Attributes)
+}
+
+func aggregateOnAttributes(aggregation aggregationType, attributes ottl.Optional[[]string]) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+	switch aggregation {
+	case sumAggregation, avgAggregation, minAggregation, maxAggregation, countAggregation:
+	default:
+		return nil, fmt.Errorf("invalid aggregation function: %q, valid functions are: %q, %q, %q, %q, %q",
+			aggregation, sumAggregation, avgAggregation, minAggregation, maxAggregation, countAggregation)
+	}
+
+	keep := map[string]bool{}
+	if !attributes.IsEmpty() {
+		for _, k := range attributes.Get() {
+			keep[k] = true
+		}
+	}
+
+	return func(_ context.Context, tCtx ottlmetric.TransformContext) (any, error) {
+		metric := tCtx.GetMetric()
+		switch metric.Type() {
+		case pmetric.MetricTypeSum:
+			aggregateNumberDataPoints(metric.Sum().DataPoints(), keep, aggregation)
+		case pmetric.MetricTypeGauge:
+			aggregateNumberDataPoints(metric.Gauge().DataPoints(), keep, aggregation)
+		case pmetric.MetricTypeHistogram:
+			if aggregation != sumAggregation {
+				return nil, fmt.Errorf("aggregate_on_attributes only supports the %q function for histograms", sumAggregation)
+			}
+			return nil, aggregateHistogramDataPoints(metric.Histogram().DataPoints(), keep)
+		case pmetric.MetricTypeExponentialHistogram:
+			if aggregation != sumAggregation {
+				return nil, fmt.Errorf("aggregate_on_attributes only supports the %q function for exponential histograms", sumAggregation)
+			}
+			return nil, aggregateExponentialHistogramDataPoints(metric.ExponentialHistogram().DataPoints(), keep)
+		default:
+			return nil, fmt.Errorf("aggregate_on_attributes requires an input metric of type Sum, Gauge, Histogram or ExponentialHistogram, got %s", metric.Type())
+		}
+		return nil, nil
+	}, nil
+}
+
+// dataPoint is implemented by all the data point types that can be aggregated.
+type dataPoint interface {
+	Attributes() pcommon.Map
+	StartTimestamp() pcommon.Timestamp
+	Timestamp() pcommon.Timestamp
+}
+
+// groupDataPoints groups the indexes of the data points whose attributes are equal once
+// only the attributes to keep remain. The groups are returned in the order of their first data point.
+func groupDataPoints(length int, at func(int) dataPoint, keep map[string]bool) ([]pcommon.Map, [][]int) {
+	var (
+		attrs  []pcommon.Map
+		groups [][]int
+		index  = map[string]int{}
+	)
+	for i := 0; i < length; i++ {
+		filtered := pcommon.NewMap()
+		at(i).Attributes().CopyTo(filtered)
+		filtered.RemoveIf(func(k string, _ pcommon.Value) bool {
+			return !keep[k]
+		})
+		key := attributesKey(filtered)
+		g, ok := index[key]
+		if !ok {
+			g = len(groups)
+			index[key] = g
+			attrs = append(attrs, filtered)
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return attrs, groups
+}
+
+func attributesKey(attrs pcommon.Map) string {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		fmt.Fprintf(&b, "%q=%s:%q;", k, v.Type(), v.AsString())
+	}
+	return b.String()
+}
+
+// setGroupTimestamps sets the earliest start timestamp and the latest timestamp of the group on the aggregated data point.
+func setGroupTimestamps(group []int, at func(int) dataPoint, setStart func(pcommon.Timestamp), set func(pcommon.Timestamp)) {
+	var start, ts pcommon.Timestamp
+	for _, i := range group {
+		dp := at(i)
+		if s := dp.StartTimestamp(); s != 0 && (start == 0 || s < start) {
+			start = s
+		}
+		if t := dp.Timestamp(); t > ts {
+			ts = t
+		}
+	}
+	setStart(start)
+	set(ts)
+}
+
+func aggregateNumberDataPoints(dps pmetric.NumberDataPointSlice, keep map[string]bool, aggregation aggregationType) {
+	at := func(i int) dataPoint { return dps.At(i) }
+	attrs, groups := groupDataPoints(dps.Len(), at, keep)
+
+	aggregated := pmetric.NewNumberDataPointSlice()
+	aggregated.EnsureCapacity(len(groups))
+	for g, group := range groups {
+		dp := aggregated.AppendEmpty()
+		attrs[g].CopyTo(dp.Attributes())
+		setGroupTimestamps(group, at, dp.SetStartTimestamp, dp.SetTimestamp)
+
+		allInts := true
+		values := make([]float64, 0, len(group))
+		ints := make([]int64, 0, len(group))
+		for _, i := range group {
+			switch dps.At(i).ValueType() {
+			case pmetric.NumberDataPointValueTypeInt:
+				values = append(values, float64(dps.At(i).IntValue()))
+				ints = append(ints, dps.At(i).IntValue())
+			case pmetric.NumberDataPointValueTypeDouble:
+				values = append(values, dps.At(i).DoubleValue())
+				allInts = false
+			}
+		}
+
+		switch {
+		case aggregation == countAggregation:
+			dp.SetIntValue(int64(len(values)))
+		case aggregation == avgAggregation:
+			dp.SetDoubleValue(aggregateFloats(values, sumAggregation) / float64(len(values)))
+		case allInts:
+			dp.SetIntValue(aggregateInts(ints, aggregation))
+		default:
+			dp.SetDoubleValue(aggregateFloats(values, aggregation))
+		}
+	}
+	dps.RemoveIf(func(pmetric.NumberDataPoint) bool { return true })
+	aggregated.MoveAndAppendTo(dps)
+}
+
+func aggregateInts(values []int64, aggregation aggregationType) int64 {
+	var result int64
+	for i, v := range values {
+		switch {
+		case i == 0:
+			result = v
+		case aggregation == sumAggregation:
+			result += v
+		case aggregation == minAggregation && v < result:
+			result = v
+		case aggregation == maxAggregation && v > result:
+			result = v
+		}
+	}
+	return result
+}
+
+func aggregateFloats(values []float64, aggregation aggregationType) float64 {
+	var result float64
+	for i, v := range values {
+		switch {
+		case i == 0:
+			result = v
+		case aggregation == sumAggregation:
+			result += v
+		case aggregation == minAggregation:
+			result = math.Min(result, v)
+		case aggregation == maxAggregation:
+			result = math.Max(result, v)
+		}
+	}
+	return result
+}
+
+func aggregateHistogramDataPoints(dps pmetric.HistogramDataPointSlice, keep map[string]bool) error {
+	at := func(i int) dataPoint { return dps.At(i) }
+	attrs, groups := groupDataPoints(dps.Len(), at, keep)
+
+	aggregated := pmetric.NewHistogramDataPointSlice()
+	aggregated.EnsureCapacity(len(groups))
+	for g, group := range groups {
+		dp := aggregated.AppendEmpty()
+		attrs[g].CopyTo(dp.Attributes())
+		setGroupTimestamps(group, at, dp.SetStartTimestamp, dp.SetTimestamp)
+
+		first := dps.At(group[0])
+		first.ExplicitBounds().CopyTo(dp.ExplicitBounds())
+		dp.BucketCounts().FromRaw(make([]uint64, first.BucketCounts().Len()))
+		hasSum, hasMin, hasMax := true, true, true
+		for _, i := range group {
+			in := dps.At(i)
+			if !equalBounds(in.ExplicitBounds(), dp.ExplicitBounds()) || in.BucketCounts().Len() != dp.BucketCounts().Len() {
+				return fmt.Errorf("aggregate_on_attributes requires histogram data points with the same explicit bounds")
+			}
+			for b := 0; b < in.BucketCounts().Len(); b++ {
+				dp.BucketCounts().SetAt(b, dp.BucketCounts().At(b)+in.BucketCounts().At(b))
+			}
+			dp.SetCount(dp.Count() + in.Count())
+			hasSum = hasSum && in.HasSum()
+			if hasSum {
+				dp.SetSum(dp.Sum() + in.Sum())
+			}
+			hasMin = hasMin && in.HasMin()
+			if hasMin && (i == group[0] || in.Min() < dp.Min()) {
+				dp.SetMin(in.Min())
+			}
+			hasMax = hasMax && in.HasMax()
+			if hasMax && (i == group[0] || in.Max() > dp.Max()) {
+				dp.SetMax(in.Max())
+			}
+		}
+		if !hasSum {
+			dp.RemoveSum()
+		}
+		if !hasMin {
+			dp.RemoveMin()
+		}
+		if !hasMax {
+			dp.RemoveMax()
+		}
+	}
+	dps.RemoveIf(func(pmetric.HistogramDataPoint) bool { return true })
+	aggregated.MoveAndAppendTo(dps)
+	return nil
+}
+
+func equalBounds(a, b pcommon.Float64Slice) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for i := 0; i < a.Len(); i++ {
+		if a.At(i) != b.At(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func aggregateExponentialHistogramDataPoints(dps pmetric.ExponentialHistogramDataPointSlice, keep map[string]bool) error {
+	at := func(i int) dataPoint { return dps.At(i) }
+	attrs, groups := groupDataPoints(dps.Len(), at, keep)
+
+	aggregated := pmetric.NewExponentialHistogramDataPointSlice()
+	aggregated.EnsureCapacity(len(groups))
+	for g, group := range groups {
+		dp := aggregated.AppendEmpty()
+		attrs[g].CopyTo(dp.Attributes())
+		setGroupTimestamps(group, at, dp.SetStartTimestamp, dp.SetTimestamp)
+
+		first := dps.At(group[0])
+		dp.SetScale(first.Scale())
+		hasSum, hasMin, hasMax := true, true, true
+		for _, i := range group {
+			in := dps.At(i)
+			if in.Scale() != dp.Scale() {
+				return fmt.Errorf("aggregate_on_attributes requires exponential histogram data points with the same scale")
+			}
+			dp.SetCount(dp.Count() + in.Count())
+			dp.SetZeroCount(dp.ZeroCount() + in.ZeroCount())
+			mergeExponentialBuckets(dp.Positive(), in.Positive())
+			mergeExponentialBuckets(dp.Negative(), in.Negative())
+			hasSum = hasSum && in.HasSum()
+			if hasSum {
+				dp.SetSum(dp.Sum() + in.Sum())
+			}
+			hasMin = hasMin && in.HasMin()
+			if hasMin && (i == group[0] || in.Min() < dp.Min()) {
+				dp.SetMin(in.Min())
+			}
+			hasMax = hasMax && in.HasMax()
+			if hasMax && (i == group[0] || in.Max() > dp.Max()) {
+				dp.SetMax(in.Max())
+			}
+		}
+		if !hasSum {
+			dp.RemoveSum()
+		}
+		if !hasMin {
+			dp.RemoveMin()
+		}
+		if !hasMax {
+			dp.RemoveMax()
+		}
+	}
+	dps.RemoveIf(func(pmetric.ExponentialHistogramDataPoint) bool { return true })
+	aggregated.MoveAndAppendTo(dps)
+	return nil
+}
+
+// mergeExponentialBuckets adds the bucket counts of in to dest, which must have the same scale.
+func mergeExponentialBuckets(dest, in pmetric.ExponentialHistogramDataPointBuckets) {
+	if in.BucketCounts().Len() == 0 {
+		return
+	}
+	if dest.BucketCounts().Len() == 0 {
+		in.CopyTo(dest)
+		return
+	}
+	offset, end := dest.Offset(), dest.Offset()+int32(dest.BucketCounts().Len())
+	if in.Offset() < offset {
+		offset = in.Offset()
+	}
+	if inEnd := in.Offset() + int32(in.BucketCounts().Len()); inEnd > end {
+		end = inEnd
+	}
+	counts := make([]uint64, end-offset)
+	for i := 0; i < dest.BucketCounts().Len(); i++ {
+		counts[dest.Offset()-offset+int32(i)] += dest.BucketCounts().At(i)
+	}
+	for i := 0; i < in.BucketCounts().Len(); i++ {
+		counts[in.Offset()-offset+int32(i)] += in.BucketCounts().At(i)
+	}
+	dest.SetOffset(offset)
+	dest.BucketCounts().FromRaw(counts)
+}
@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+func getTestSumMetricWithSeries() pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetName("requests")
+	metric.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	for i, series := range []struct {
+		method string
+		pod    string
+		value  int64
+	}{
+		{"GET", "a", 1},
+		{"GET", "b", 2},
+		{"POST", "a", 3},
+		{"GET", "c", 4},
+	} {
+		dp := metric.Sum().DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("method", series.method)
+		dp.Attributes().PutStr("pod", series.pod)
+		dp.SetIntValue(series.value)
+		dp.SetStartTimestamp(pcommon.Timestamp(10 + i))
+		dp.SetTimestamp(pcommon.Timestamp(20 + i))
+	}
+	return metric
+}
+
+func Test_aggregateOnAttributes_Sum(t *testing.T) {
+	tests := []struct {
+		name        string
+		aggregation aggregationType
+		attributes  []string
+		want        func(dps pmetric.NumberDataPointSlice)
+	}{
+		{
+			name:        "sum",
+			aggregation: sumAggregation,
+			attributes:  []string{"method"},
+			want: func(dps pmetric.NumberDataPointSlice) {
+				dp := dps.AppendEmpty()
+				dp.Attributes().PutStr("method", "GET")
+				dp.SetIntValue(7)
+				dp.SetStartTimestamp(10)
+				dp.SetTimestamp(23)
+				dp = dps.AppendEmpty()
+				dp.Attributes().PutStr("method", "POST")
+				dp.SetIntValue(3)
+				dp.SetStartTimestamp(12)
+				dp.SetTimestamp(22)
+			},
+		},
+		{
+			name:        "avg of all series",
+			aggregation: avgAggregation,
+			want: func(dps pmetric.NumberDataPointSlice) {
+				dp := dps.AppendEmpty()
+				dp.SetDoubleValue(2.5)
+				dp.SetStartTimestamp(10)
+				dp.SetTimestamp(23)
+			},
+		},
+		{
+			name:        "min",
+			aggregation: minAggregation,
+			attributes:  []string{"pod"},
+			want: func(dps pmetric.NumberDataPointSlice) {
+				for _, series := range []struct {
+					pod   string
+					value int64
+					start pcommon.Timestamp
+					ts    pcommon.Timestamp
+				}{{"a", 1, 10, 22}, {"b", 2, 11, 21}, {"c", 4, 13, 23}} {
+					dp := dps.AppendEmpty()
+					dp.Attributes().PutStr("pod", series.pod)
+					dp.SetIntValue(series.value)
+					dp.SetStartTimestamp(series.start)
+					dp.SetTimestamp(series.ts)
+				}
+			},
+		},
+		{
+			name:        "max",
+			aggregation: maxAggregation,
+			attributes:  []string{"method", "missing"},
+			want: func(dps pmetric.NumberDataPointSlice) {
+				dp := dps.AppendEmpty()
+				dp.Attributes().PutStr("method", "GET")
+				dp.SetIntValue(4)
+				dp.SetStartTimestamp(10)
+				dp.SetTimestamp(23)
+				dp = dps.AppendEmpty()
+				dp.Attributes().PutStr("method", "POST")
+				dp.SetIntValue(3)
+				dp.SetStartTimestamp(12)
+				dp.SetTimestamp(22)
+			},
+		},
+		{
+			name:        "count",
+			aggregation: countAggregation,
+			attributes:  []string{"method"},
+			want: func(dps pmetric.NumberDataPointSlice) {
+				dp := dps.AppendEmpty()
+				dp.Attributes().PutStr("method", "GET")
+				dp.SetIntValue(3)
+				dp.SetStartTimestamp(10)
+				dp.SetTimestamp(23)
+				dp = dps.AppendEmpty()
+				dp.Attributes().PutStr("method", "POST")
+				dp.SetIntValue(1)
+				dp.SetStartTimestamp(12)
+				dp.SetTimestamp(22)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metric := getTestSumMetricWithSeries()
+			attributes := ottl.Optional[[]string]{}
+			if tt.attributes != nil {
+				attributes = ottl.NewTestingOptional[[]string](tt.attributes)
+			}
+
+			evaluate, err := aggregateOnAttributes(tt.aggregation, attributes)
+			require.NoError(t, err)
+			_, err = evaluate(context.Background(), ottlmetric.NewTransformContext(metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+			require.NoError(t, err)
+
+			expected := pmetric.NewNumberDataPointSlice()
+			tt.want(expected)
+			assert.Equal(t, expected, metric.Sum().DataPoints())
+		})
+	}
+}
+
+func Test_aggregateOnAttributes_DoubleGauge(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetEmptyGauge()
+	for _, v := range []float64{1.5, 2} {
+		dp := metric.Gauge().DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("cpu", "0")
+		dp.SetDoubleValue(v)
+	}
+	dp := metric.Gauge().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("cpu", "1")
+	dp.SetIntValue(3)
+
+	evaluate, err := aggregateOnAttributes(sumAggregation, ottl.Optional[[]string]{})
+	require.NoError(t, err)
+	_, err = evaluate(context.Background(), ottlmetric.NewTransformContext(metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+	require.NoError(t, err)
+
+	require.Equal(t, 1, metric.Gauge().DataPoints().Len())
+	assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, metric.Gauge().DataPoints().At(0).ValueType())
+	assert.Equal(t, 6.5, metric.Gauge().DataPoints().At(0).DoubleValue())
+}
+
+func Test_aggregateOnAttributes_Histogram(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetEmptyHistogram()
+	for i, pod := range []string{"a", "b"} {
+		dp := metric.Histogram().DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("pod", pod)
+		dp.Attributes().PutStr("method", "GET")
+		dp.ExplicitBounds().FromRaw([]float64{1, 10})
+		dp.BucketCounts().FromRaw([]uint64{1, uint64(i), 2})
+		dp.SetCount(3 + uint64(i))
+		dp.SetSum(float64(10 * (i + 1)))
+		dp.SetMin(float64(i))
+		dp.SetMax(float64(20 + i))
+	}
+
+	evaluate, err := aggregateOnAttributes(sumAggregation, ottl.NewTestingOptional[[]string]([]string{"method"}))
+	require.NoError(t, err)
+	_, err = evaluate(context.Background(), ottlmetric.NewTransformContext(metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+	require.NoError(t, err)
+
+	expected := pmetric.NewHistogramDataPointSlice()
+	dp := expected.AppendEmpty()
+	dp.Attributes().PutStr("method", "GET")
+	dp.ExplicitBounds().FromRaw([]float64{1, 10})
+	dp.BucketCounts().FromRaw([]uint64{2, 1, 4})
+	dp.SetCount(7)
+	dp.SetSum(30)
+	dp.SetMin(0)
+	dp.SetMax(21)
+	assert.Equal(t, expected, metric.Histogram().DataPoints())
+
+	// Histograms with different bounds cannot be merged.
+	metric.Histogram().DataPoints().AppendEmpty().Attributes().PutStr("method", "GET")
+	_, err = evaluate(context.Background(), ottlmetric.NewTransformContext(metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+	assert.Error(t, err)
+}
+
+func Test_aggregateOnAttributes_ExponentialHistogram(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetEmptyExponentialHistogram()
+	dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("pod", "a")
+	dp.SetScale(2)
+	dp.SetCount(4)
+	dp.SetZeroCount(1)
+	dp.Positive().SetOffset(1)
+	dp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+	dp = metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("pod", "b")
+	dp.SetScale(2)
+	dp.SetCount(3)
+	dp.Positive().SetOffset(0)
+	dp.Positive().BucketCounts().FromRaw([]uint64{1, 1})
+	dp.Negative().BucketCounts().FromRaw([]uint64{1})
+
+	evaluate, err := aggregateOnAttributes(sumAggregation, ottl.Optional[[]string]{})
+	require.NoError(t, err)
+	_, err = evaluate(context.Background(), ottlmetric.NewTransformContext(metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+	require.NoError(t, err)
+
+	require.Equal(t, 1, metric.ExponentialHistogram().DataPoints().Len())
+	actual := metric.ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, int32(2), actual.Scale())
+	assert.Equal(t, uint64(7), actual.Count())
+	assert.Equal(t, uint64(1), actual.ZeroCount())
+	assert.Equal(t, int32(0), actual.Positive().Offset())
+	assert.Equal(t, []uint64{1, 2, 2}, actual.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, []uint64{1}, actual.Negative().BucketCounts().AsRaw())
+	assert.False(t, actual.HasSum())
+}
+
+func Test_aggregateOnAttributes_Invalid(t *testing.T) {
+	_, err := aggregateOnAttributes("median", ottl.Optional[[]string]{})
+	assert.Error(t, err)
+
+	evaluate, err := aggregateOnAttributes(avgAggregation, ottl.Optional[[]string]{})
+	require.NoError(t, err)
+	for _, metric := range []pmetric.Metric{getTestHistogramMetric(), getTestExponentialHistogramMetric(), getTestSummaryMetric()} {
+		_, err = evaluate(context.Background(), ottlmetric.NewTransformContext(metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+		assert.Error(t, err, metric.Type().String())
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
+)
+
+type copyMetricArguments struct {
+	Name        ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]]
+	Description ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]]
+	Unit        ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]]
+}
+
+func newCopyMetricFactory() ottl.Factory[ottlmetric.TransformContext] {
+	return ottl.NewFactory("copy_metric", &copyMetricArguments{}, createCopyMetricFunction)
+}
+
+func createCopyMetricFunction(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+	args, ok := oArgs.(*copyMetricArguments)
+
+	if !ok {
+		return nil, fmt.Errorf("copyMetricFactory args must be of type *copyMetricArguments")
+	}
+
+	return copyMetric(args.Name, args.Description, args.Unit), nil
+}
+
+func copyMetric(name ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]], desc ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]], unit ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]]) ottl.ExprFunc[ottlmetric.TransformContext] {
+	return func(ctx context.Context, tCtx ottlmetric.TransformContext) (any, error) {
+		// The values are retrieved before the copy is added, so they are evaluated against the original metric.
+		var n, d, u *string
+		for _, arg := range []struct {
+			getter ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]]
+			dest   **string
+		}{{name, &n}, {desc, &d}, {unit, &u}} {
+			if arg.getter.IsEmpty() {
+				continue
+			}
+			val, err := arg.getter.Get().Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			*arg.dest = &val
+		}
+
+		metric := tCtx.GetMetric()
+		// The copy is added once the statements are executed on all the metrics of the scope, so that the
+		// copy is not copied again when it matches the where clause of the statement.
+		newMetric := common.AppendEmptyMetric(ctx, tCtx.GetMetrics())
+		metric.CopyTo(newMetric)
+		if n != nil {
+			newMetric.SetName(*n)
+		}
+		if d != nil {
+			newMetric.SetDescription(*d)
+		}
+		if u != nil {
+			newMetric.SetUnit(*u)
+		}
+		return nil, nil
+	}
+}
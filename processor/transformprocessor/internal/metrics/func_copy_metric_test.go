@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+func Test_copyMetric(t *testing.T) {
+	literal := func(val string) ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]] {
+		return ottl.NewTestingOptional[ottl.StringGetter[ottlmetric.TransformContext]](ottl.StandardStringGetter[ottlmetric.TransformContext]{
+			Getter: func(context.Context, ottlmetric.TransformContext) (any, error) {
+				return val, nil
+			},
+		})
+	}
+	none := ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]]{}
+
+	tests := []struct {
+		name string
+		args [3]ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]]
+		want func(pmetric.Metric)
+	}{
+		{
+			name: "exact copy",
+			args: [3]ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]]{none, none, none},
+			want: func(pmetric.Metric) {},
+		},
+		{
+			name: "new name",
+			args: [3]ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]]{literal("gauge_copy"), none, none},
+			want: func(m pmetric.Metric) {
+				m.SetName("gauge_copy")
+			},
+		},
+		{
+			name: "new name, description and unit",
+			args: [3]ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]]{literal("gauge_copy"), literal("a copy"), literal("ms")},
+			want: func(m pmetric.Metric) {
+				m.SetName("gauge_copy")
+				m.SetDescription("a copy")
+				m.SetUnit("ms")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := pmetric.NewMetricSlice()
+			input := metrics.AppendEmpty()
+			getTestGaugeMetric().CopyTo(input)
+
+			evaluate := copyMetric(tt.args[0], tt.args[1], tt.args[2])
+			_, err := evaluate(context.Background(), ottlmetric.NewTransformContext(input, metrics, pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+			require.NoError(t, err)
+
+			expected := pmetric.NewMetricSlice()
+			getTestGaugeMetric().CopyTo(expected.AppendEmpty())
+			copied := expected.AppendEmpty()
+			getTestGaugeMetric().CopyTo(copied)
+			tt.want(copied)
+			assert.Equal(t, expected, metrics)
+		})
+	}
+}
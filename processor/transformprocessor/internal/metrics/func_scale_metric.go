@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metrics"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+type scaleMetricArguments struct {
+	Factor float64
+	Unit   ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]]
+}
+
+func newScaleMetricFactory() ottl.Factory[ottlmetric.TransformContext] {
+	return ottl.NewFactory("scale_metric", &scaleMetricArguments{}, createScaleMetricFunction)
+}
+
+func createScaleMetricFunction(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+	args, ok := oArgs.(*scaleMetricArguments)
+
+	if !ok {
+		return nil, fmt.Errorf("scaleMetricFactory args must be of type *scaleMetricArguments")
+	}
+
+	return scaleMetric(args.Factor, args.Unit)
+}
+
+func scaleMetric(factor float64, unit ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]]) (ottl.ExprFunc[ottlmetric.TransformContext], error) {
+	if factor <= 0 {
+		return nil, fmt.Errorf("scale_metric requires a positive factor, got %v", factor)
+	}
+	return func(ctx context.Context, tCtx ottlmetric.TransformContext) (any, error) {
+		metric := tCtx.GetMetric()
+		switch metric.Type() {
+		case pmetric.MetricTypeGauge:
+			scaleNumberDataPoints(metric.Gauge().DataPoints(), factor)
+		case pmetric.MetricTypeSum:
+			scaleNumberDataPoints(metric.Sum().DataPoints(), factor)
+		case pmetric.MetricTypeHistogram:
+			scaleHistogramDataPoints(metric.Histogram().DataPoints(), factor)
+		case pmetric.MetricTypeSummary:
+			scaleSummaryDataPoints(metric.Summary().DataPoints(), factor)
+		default:
+			return nil, fmt.Errorf("scale_metric requires an input metric of type Gauge, Sum, Histogram or Summary, got %s", metric.Type())
+		}
+
+		if !unit.IsEmpty() {
+			u, err := unit.Get().Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			metric.SetUnit(u)
+		}
+		return nil, nil
+	}, nil
+}
+
+func scaleNumberDataPoints(dps pmetric.NumberDataPointSlice, factor float64) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			dp.SetIntValue(int64(float64(dp.IntValue()) * factor))
+		case pmetric.NumberDataPointValueTypeDouble:
+			dp.SetDoubleValue(dp.DoubleValue() * factor)
+		}
+		scaleExemplars(dp.Exemplars(), factor)
+	}
+}
+
+func scaleHistogramDataPoints(dps pmetric.HistogramDataPointSlice, factor float64) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		if dp.HasSum() {
+			dp.SetSum(dp.Sum() * factor)
+		}
+		if dp.HasMin() {
+			dp.SetMin(dp.Min() * factor)
+		}
+		if dp.HasMax() {
+			dp.SetMax(dp.Max() * factor)
+		}
+		for b := 0; b < dp.ExplicitBounds().Len(); b++ {
+			dp.ExplicitBounds().SetAt(b, dp.ExplicitBounds().At(b)*factor)
+		}
+		scaleExemplars(dp.Exemplars(), factor)
+	}
+}
+
+func scaleSummaryDataPoints(dps pmetric.SummaryDataPointSlice, factor float64) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		dp.SetSum(dp.Sum() * factor)
+		for q := 0; q < dp.QuantileValues().Len(); q++ {
+			qv := dp.QuantileValues().At(q)
+			qv.SetValue(qv.Value() * factor)
+		}
+	}
+}
+
+func scaleExemplars(exemplars pmetric.ExemplarSlice, factor float64) {
+	for i := 0; i < exemplars.Len(); i++ {
+		e := exemplars.At(i)
+		switch e.ValueType() {
+		case pmetric.ExemplarValueTypeInt:
+			e.SetIntValue(int64(float64(e.IntValue()) * factor))
+		case pmetric.ExemplarValueTypeDouble:
+			e.SetDoubleValue(e.DoubleValue() * factor)
+		}
+	}
+}
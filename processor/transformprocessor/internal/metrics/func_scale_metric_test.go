@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+)
+
+func Test_scaleMetric(t *testing.T) {
+	unit := ottl.NewTestingOptional[ottl.StringGetter[ottlmetric.TransformContext]](ottl.StandardStringGetter[ottlmetric.TransformContext]{
+		Getter: func(context.Context, ottlmetric.TransformContext) (any, error) {
+			return "ms", nil
+		},
+	})
+
+	tests := []struct {
+		name   string
+		input  func() pmetric.Metric
+		factor float64
+		unit   ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]]
+		want   func(pmetric.Metric)
+	}{
+		{
+			name:   "int gauge",
+			input:  getTestGaugeMetric,
+			factor: 2.5,
+			want: func(m pmetric.Metric) {
+				m.Gauge().DataPoints().At(0).SetIntValue(30)
+			},
+		},
+		{
+			name: "double sum with unit",
+			input: func() pmetric.Metric {
+				m := pmetric.NewMetric()
+				dp := m.SetEmptySum().DataPoints().AppendEmpty()
+				dp.SetDoubleValue(1.5)
+				dp.Exemplars().AppendEmpty().SetDoubleValue(0.5)
+				m.SetUnit("s")
+				return m
+			},
+			factor: 1000,
+			unit:   unit,
+			want: func(m pmetric.Metric) {
+				m.Sum().DataPoints().At(0).SetDoubleValue(1500)
+				m.Sum().DataPoints().At(0).Exemplars().At(0).SetDoubleValue(500)
+				m.SetUnit("ms")
+			},
+		},
+		{
+			name:   "histogram",
+			input:  getTestHistogramMetric,
+			factor: 10,
+			want: func(m pmetric.Metric) {
+				dp := m.Histogram().DataPoints().At(0)
+				dp.SetSum(123.4)
+				dp.ExplicitBounds().FromRaw([]float64{10})
+			},
+		},
+		{
+			name:   "summary",
+			input:  getTestSummaryMetric,
+			factor: 2,
+			want: func(m pmetric.Metric) {
+				dp := m.Summary().DataPoints().At(0)
+				dp.SetSum(24.68)
+				dp.QuantileValues().At(0).SetValue(2)
+				dp.QuantileValues().At(1).SetValue(4)
+				dp.QuantileValues().At(2).SetValue(6)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metric := tt.input()
+			evaluate, err := scaleMetric(tt.factor, tt.unit)
+			require.NoError(t, err)
+			_, err = evaluate(context.Background(), ottlmetric.NewTransformContext(metric, pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+			require.NoError(t, err)
+
+			expected := tt.input()
+			tt.want(expected)
+			assert.Equal(t, expected, metric)
+		})
+	}
+}
+
+func Test_scaleMetric_Invalid(t *testing.T) {
+	_, err := scaleMetric(0, ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]]{})
+	assert.Error(t, err)
+
+	evaluate, err := scaleMetric(2, ottl.Optional[ottl.StringGetter[ottlmetric.TransformContext]]{})
+	require.NoError(t, err)
+	_, err = evaluate(context.Background(), ottlmetric.NewTransformContext(getTestExponentialHistogramMetric(), pmetric.NewMetricSlice(), pcommon.NewInstrumentationScope(), pcommon.NewResource()))
+	assert.Error(t, err)
+}
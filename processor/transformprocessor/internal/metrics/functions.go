@@ -48,6 +48,9 @@ func MetricFunctions() map[string]ottl.Factory[ottlmetric.TransformContext] {
 	metricFunctions := ottl.CreateFactoryMap(
 		newExtractSumMetricFactory(),
 		newExtractCountMetricFactory(),
+		newAggregateOnAttributesFactory(),
+		newCopyMetricFactory(),
+		newScaleMetricFactory(),
 	)
 
 	if useConvertBetweenSumAndGaugeMetricContext.IsEnabled() {
@@ -36,6 +36,9 @@ func Test_MetricFunctions(t *testing.T) {
 	expected["convert_gauge_to_sum"] = newConvertGaugeToSumFactory()
 	expected["extract_sum_metric"] = newExtractSumMetricFactory()
 	expected["extract_count_metric"] = newExtractCountMetricFactory()
+	expected["aggregate_on_attributes"] = newAggregateOnAttributesFactory()
+	expected["copy_metric"] = newCopyMetricFactory()
+	expected["scale_metric"] = newScaleMetricFactory()
 
 	defer testutil.SetFeatureGateForTest(t, useConvertBetweenSumAndGaugeMetricContext, true)()
 	actual := MetricFunctions()
@@ -179,6 +179,19 @@ func Test_ProcessMetrics_MetricContext(t *testing.T) {
 				countDp1.SetStartTimestamp(StartTimestamp)
 			},
 		},
+		{ // this checks that the copy is neither copied again nor passed to the subsequent statements
+			statements: []string{
+				`copy_metric(description="copy") where name == "operationA"`,
+				`set(unit, "new unit") where name == "operationA"`,
+			},
+			want: func(td pmetric.Metrics) {
+				metrics := td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+				metrics.At(0).SetUnit("new unit")
+				copied := metrics.AppendEmpty()
+				fillMetricOne(copied)
+				copied.SetDescription("copy")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -763,6 +776,75 @@ func Test_ProcessMetrics_MixContext(t *testing.T) {
 	}
 }
 
+func Test_ProcessMetrics_Conditions(t *testing.T) {
+	tests := []struct {
+		name             string
+		contextStatement common.ContextStatements
+		want             func(td pmetric.Metrics)
+	}{
+		{
+			name: "metric statements only executed when conditions match",
+			contextStatement: common.ContextStatements{
+				Context:    "metric",
+				Conditions: []string{`name == "operationA"`},
+				Statements: []string{`set(description, "pass")`},
+			},
+			want: func(td pmetric.Metrics) {
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetDescription("pass")
+			},
+		},
+		{
+			name: "metric else statements",
+			contextStatement: common.ContextStatements{
+				Context:        "metric",
+				Conditions:     []string{`name == "operationA"`, `name == "operationB"`},
+				Statements:     []string{`set(description, "pass")`},
+				ElseStatements: []string{`set(description, "fail")`},
+			},
+			want: func(td pmetric.Metrics) {
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetDescription("pass")
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).SetDescription("pass")
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(2).SetDescription("fail")
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(3).SetDescription("fail")
+			},
+		},
+		{
+			name: "datapoint else statements",
+			contextStatement: common.ContextStatements{
+				Context:        "datapoint",
+				Conditions:     []string{`metric.name == "operationA"`},
+				Statements:     []string{`set(attributes["test"], "pass")`},
+				ElseStatements: []string{`set(attributes["test"], "fail")`},
+			},
+			want: func(td pmetric.Metrics) {
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes().PutStr("test", "pass")
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(1).Attributes().PutStr("test", "pass")
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).Histogram().DataPoints().At(0).Attributes().PutStr("test", "fail")
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).Histogram().DataPoints().At(1).Attributes().PutStr("test", "fail")
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(2).ExponentialHistogram().DataPoints().At(0).Attributes().PutStr("test", "fail")
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(2).ExponentialHistogram().DataPoints().At(1).Attributes().PutStr("test", "fail")
+				td.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(3).Summary().DataPoints().At(0).Attributes().PutStr("test", "fail")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := constructMetrics()
+			processor, err := NewProcessor([]common.ContextStatements{tt.contextStatement}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessMetrics(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructMetrics()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
 func Test_ProcessMetrics_Error(t *testing.T) {
 	tests := []struct {
 		statement string
@@ -540,6 +540,68 @@ func Test_ProcessTraces_MixContext(t *testing.T) {
 	}
 }
 
+func Test_ProcessTraces_Conditions(t *testing.T) {
+	tests := []struct {
+		name             string
+		contextStatement common.ContextStatements
+		want             func(td ptrace.Traces)
+	}{
+		{
+			name: "span statements only executed when conditions match",
+			contextStatement: common.ContextStatements{
+				Context:    "span",
+				Conditions: []string{`name == "operationA"`},
+				Statements: []string{`set(attributes["test"], "pass")`},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			name: "span else statements",
+			contextStatement: common.ContextStatements{
+				Context:        "span",
+				Conditions:     []string{`name == "operationA"`},
+				Statements:     []string{`set(attributes["test"], "pass")`},
+				ElseStatements: []string{`set(attributes["test"], "fail")`},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutStr("test", "pass")
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Attributes().PutStr("test", "fail")
+			},
+		},
+		{
+			name: "spanevent else statements",
+			contextStatement: common.ContextStatements{
+				Context:        "spanevent",
+				Conditions:     []string{`name == "eventA"`},
+				Statements:     []string{`set(attributes["test"], "pass")`},
+				ElseStatements: []string{`set(attributes["test"], "fail")`},
+			},
+			want: func(td ptrace.Traces) {
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Events().At(0).Attributes().PutStr("test", "pass")
+				td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Events().At(0).Attributes().PutStr("test", "fail")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := constructTraces()
+			processor, err := NewProcessor([]common.ContextStatements{tt.contextStatement}, ottl.IgnoreError, componenttest.NewNopTelemetrySettings())
+			assert.NoError(t, err)
+
+			_, err = processor.ProcessTraces(context.Background(), td)
+			assert.NoError(t, err)
+
+			exTd := constructTraces()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+}
+
 func Test_ProcessTraces_Error(t *testing.T) {
 	tests := []struct {
 		statement string
@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpjsonreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpjsonreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/ohler55/ojg/jp"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/multierr"
+)
+
+// Predefined error responses for configuration validation failures
+var (
+	errMissingEndpoint = errors.New(`"endpoint" must be specified`)
+	errInvalidEndpoint = errors.New(`"endpoint" must be in the form of <scheme>://<hostname>[:<port>]`)
+)
+
+// Config defines the configuration for the various elements of the receiver agent.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	Targets                                 []*Target `mapstructure:"targets"`
+}
+
+// Target is an HTTP endpoint returning a JSON document, and how the document
+// is mapped to metrics and logs.
+type Target struct {
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+	Metrics                       []MetricCfg `mapstructure:"metrics"`
+	Logs                          []LogsCfg   `mapstructure:"logs"`
+}
+
+// Validate validates the configuration by checking for missing or invalid fields
+func (cfg *Config) Validate() error {
+	var err error
+
+	if len(cfg.Targets) == 0 {
+		err = multierr.Append(err, errors.New("no targets configured"))
+	}
+
+	for _, target := range cfg.Targets {
+		err = multierr.Append(err, target.Validate())
+	}
+
+	return err
+}
+
+// Validate validates the configuration by checking for missing or invalid fields
+func (t *Target) Validate() error {
+	var err error
+
+	if t.Endpoint == "" {
+		err = multierr.Append(err, errMissingEndpoint)
+	} else {
+		_, parseErr := url.ParseRequestURI(t.Endpoint)
+		if parseErr != nil {
+			err = multierr.Append(err, fmt.Errorf("%s: %w", errInvalidEndpoint.Error(), parseErr))
+		}
+	}
+
+	if len(t.Metrics) == 0 && len(t.Logs) == 0 {
+		err = multierr.Append(err, errors.New("at least one of 'metrics' and 'logs' must not be empty"))
+	}
+	for _, metric := range t.Metrics {
+		err = multierr.Append(err, metric.Validate())
+	}
+	for _, logs := range t.Logs {
+		err = multierr.Append(err, logs.Validate())
+	}
+
+	return err
+}
+
+// MetricCfg maps the items selected in a document to the data points of a metric.
+type MetricCfg struct {
+	MetricName string `mapstructure:"metric_name"`
+	// Path selects the items of the document, each item being a data point.
+	// Defaults to the whole document.
+	Path string `mapstructure:"path"`
+	// Value is the path of the value of a data point, relative to its item.
+	Value string `mapstructure:"value"`
+	// Attributes maps the attribute names of a data point to their path,
+	// relative to its item.
+	Attributes       map[string]string `mapstructure:"attributes"`
+	StaticAttributes map[string]string `mapstructure:"static_attributes"`
+	Monotonic        bool              `mapstructure:"monotonic"`
+	ValueType        MetricValueType   `mapstructure:"value_type"`
+	DataType         MetricType        `mapstructure:"data_type"`
+	Aggregation      MetricAggregation `mapstructure:"aggregation"`
+	Unit             string            `mapstructure:"unit"`
+	Description      string            `mapstructure:"description"`
+}
+
+func (c MetricCfg) Validate() error {
+	var errs error
+	if c.MetricName == "" {
+		errs = multierr.Append(errs, errors.New("'metric_name' cannot be empty"))
+	}
+	if c.Value == "" {
+		errs = multierr.Append(errs, errors.New("'value' cannot be empty"))
+	}
+	errs = multierr.Append(errs, validatePaths(c.Path, c.Value, c.Attributes))
+	if err := c.ValueType.Validate(); err != nil {
+		errs = multierr.Append(errs, err)
+	}
+	if err := c.DataType.Validate(); err != nil {
+		errs = multierr.Append(errs, err)
+	}
+	if err := c.Aggregation.Validate(); err != nil {
+		errs = multierr.Append(errs, err)
+	}
+	if c.DataType != MetricTypeSum && c.Aggregation != "" {
+		errs = multierr.Append(errs, fmt.Errorf("aggregation=%s but data_type=%s does not support aggregation", c.Aggregation, c.DataType))
+	}
+	if errs != nil && c.MetricName != "" {
+		errs = multierr.Append(fmt.Errorf("invalid metric config with metric_name '%s'", c.MetricName), errs)
+	}
+	return errs
+}
+
+// LogsCfg maps the items selected in a document to log records.
+type LogsCfg struct {
+	// Path selects the items of the document, each item being the body of a
+	// log record. Defaults to the whole document.
+	Path string `mapstructure:"path"`
+	// Attributes maps the attribute names of a log record to their path,
+	// relative to its item.
+	Attributes map[string]string `mapstructure:"attributes"`
+}
+
+func (c LogsCfg) Validate() error {
+	return validatePaths(c.Path, "", c.Attributes)
+}
+
+func validatePaths(path string, value string, attributes map[string]string) error {
+	var errs error
+	if path != "" {
+		if _, err := jp.ParseString(path); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid 'path' %q: %w", path, err))
+		}
+	}
+	if value != "" {
+		if _, err := jp.ParseString(value); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid 'value' %q: %w", value, err))
+		}
+	}
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := jp.ParseString(attributes[name]); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid path %q of attribute '%s': %w", attributes[name], name, err))
+		}
+	}
+	return errs
+}
+
+type MetricType string
+
+const (
+	MetricTypeUnspecified MetricType = ""
+	MetricTypeGauge       MetricType = "gauge"
+	MetricTypeSum         MetricType = "sum"
+)
+
+func (t MetricType) Validate() error {
+	switch t {
+	case MetricTypeUnspecified, MetricTypeGauge, MetricTypeSum:
+		return nil
+	}
+	return fmt.Errorf("metric config has unsupported data_type: '%s'", t)
+}
+
+type MetricValueType string
+
+const (
+	MetricValueTypeUnspecified MetricValueType = ""
+	MetricValueTypeInt         MetricValueType = "int"
+	MetricValueTypeDouble      MetricValueType = "double"
+)
+
+func (t MetricValueType) Validate() error {
+	switch t {
+	case MetricValueTypeUnspecified, MetricValueTypeInt, MetricValueTypeDouble:
+		return nil
+	}
+	return fmt.Errorf("metric config has unsupported value_type: '%s'", t)
+}
+
+type MetricAggregation string
+
+const (
+	MetricAggregationUnspecified MetricAggregation = ""
+	MetricAggregationCumulative  MetricAggregation = "cumulative"
+	MetricAggregationDelta       MetricAggregation = "delta"
+)
+
+func (a MetricAggregation) Validate() error {
+	switch a {
+	case MetricAggregationUnspecified, MetricAggregationCumulative, MetricAggregationDelta:
+		return nil
+	}
+	return fmt.Errorf("metric config has unsupported aggregation: '%s'", a)
+}
@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpjsonreceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpjsonreceiver/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, ""),
+			expected: &Config{
+				ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+					CollectionInterval: 30 * time.Second,
+					InitialDelay:       time.Second,
+				},
+				Targets: []*Target{
+					{
+						HTTPClientSettings: newClientSettings("http://localhost:8080/status", map[string]configopaque.String{
+							"X-Api-Key": "secret",
+						}),
+						Metrics: []MetricCfg{
+							{
+								MetricName:       "queue.depth",
+								Path:             "$.queues[*]",
+								Value:            "$.depth",
+								Attributes:       map[string]string{"queue": "$.name"},
+								StaticAttributes: map[string]string{"service": "orders"},
+								ValueType:        MetricValueTypeInt,
+							},
+							{
+								MetricName:  "requests.count",
+								Value:       "$.stats.requests",
+								DataType:    MetricTypeSum,
+								Monotonic:   true,
+								Aggregation: MetricAggregationCumulative,
+								Unit:        "{requests}",
+								Description: "Requests served since the start of the service.",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "logs"),
+			expected: &Config{
+				ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+					CollectionInterval: 60 * time.Second,
+					InitialDelay:       time.Second,
+				},
+				Targets: []*Target{
+					{
+						HTTPClientSettings: newClientSettings("http://localhost:8080/jobs", nil),
+						Logs: []LogsCfg{
+							{
+								Path:       "$.jobs[*]",
+								Attributes: map[string]string{"job.id": "$.id"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_endpoint"),
+			errorMessage: `"endpoint" must be specified`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_metrics_logs"),
+			errorMessage: "at least one of 'metrics' and 'logs' must not be empty",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "missing_value"),
+			errorMessage: "'value' cannot be empty",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_path"),
+			errorMessage: `invalid 'path' "$.queues["`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_attribute"),
+			errorMessage: `invalid path "$[" of attribute 'job.id'`,
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "unnecessary_aggregation"),
+			errorMessage: "aggregation=delta but data_type= does not support aggregation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expected == nil {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestConfig_Validate_Multierr(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "multierr").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+	err = component.ValidateConfig(cfg)
+
+	assert.ErrorContains(t, err, "invalid metric config with metric_name 'my.metric'")
+	assert.ErrorContains(t, err, "metric config has unsupported value_type: 'xint'")
+	assert.ErrorContains(t, err, "metric config has unsupported data_type: 'xgauge'")
+	assert.ErrorContains(t, err, "metric config has unsupported aggregation: 'xcumulative'")
+}
+
+func TestConfig_Validate_NoTargets(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.ErrorContains(t, component.ValidateConfig(cfg), "no targets configured")
+}
+
+// newClientSettings returns the default client settings of a target.
+func newClientSettings(endpoint string, headers map[string]configopaque.String) confighttp.HTTPClientSettings {
+	return confighttp.HTTPClientSettings{
+		Endpoint: endpoint,
+		Headers:  headers,
+	}
+}
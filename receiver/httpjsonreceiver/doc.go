@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package httpjsonreceiver polls HTTP endpoints returning JSON documents and
+// maps them to metrics and logs.
+package httpjsonreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpjsonreceiver"
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpjsonreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpjsonreceiver"
+
+import (
+	"fmt"
+
+	"github.com/ohler55/ojg/jp"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/multierr"
+)
+
+// logsMapper maps a document to log records, with the paths of its configuration parsed.
+type logsMapper struct {
+	cfg        LogsCfg
+	path       jp.Expr
+	attributes map[string]jp.Expr
+}
+
+func newLogsMapper(cfg LogsCfg) (*logsMapper, error) {
+	path, err := compilePath(cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'path' %q: %w", cfg.Path, err)
+	}
+	attributes, err := compileAttributes(cfg.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	return &logsMapper{cfg: cfg, path: path, attributes: attributes}, nil
+}
+
+// docToLogs appends a log record to the slice for each item selected in the
+// document, the item being its body. The attributes that can't be found are
+// omitted.
+func (m *logsMapper) docToLogs(doc any, dest plog.LogRecordSlice, ts pcommon.Timestamp) error {
+	var errs error
+	for _, item := range selectItems(m.path, doc) {
+		record := plog.NewLogRecord()
+		record.SetObservedTimestamp(ts)
+		if err := record.Body().FromRaw(item); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("log body: %w", err))
+			continue
+		}
+		attrs := record.Attributes()
+		for name, path := range m.attributes {
+			values := path.Get(item)
+			if len(values) == 0 {
+				continue
+			}
+			if err := attrs.PutEmpty(name).FromRaw(values[0]); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("attribute '%s': %w", name, err))
+			}
+		}
+		record.MoveTo(dest.AppendEmpty())
+	}
+	return errs
+}
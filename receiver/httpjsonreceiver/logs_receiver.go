@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpjsonreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpjsonreceiver"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	conventions "go.opentelemetry.io/collector/semconv/v1.18.0"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpjsonreceiver/internal/metadata"
+)
+
+// logsTarget is a target whose document is mapped to logs.
+type logsTarget struct {
+	client  *targetClient
+	mappers []*logsMapper
+}
+
+type logsReceiver struct {
+	config       *Config
+	settings     receiver.CreateSettings
+	targets      []*logsTarget
+	nextConsumer consumer.Logs
+
+	isStarted                bool
+	collectionIntervalTicker *time.Ticker
+	cancel                   context.CancelFunc
+	wg                       sync.WaitGroup
+
+	obsrecv *receiverhelper.ObsReport
+}
+
+func newLogsReceiver(config *Config, settings receiver.CreateSettings, nextConsumer consumer.Logs) (*logsReceiver, error) {
+	obsr, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             settings.ID,
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &logsReceiver{
+		config:       config,
+		settings:     settings,
+		nextConsumer: nextConsumer,
+		obsrecv:      obsr,
+	}, nil
+}
+
+func (receiver *logsReceiver) Start(_ context.Context, host component.Host) error {
+	if receiver.isStarted {
+		receiver.settings.Logger.Debug("requested start, but already started, ignoring.")
+		return nil
+	}
+	receiver.settings.Logger.Debug("starting...")
+
+	receiver.targets = nil
+	for _, target := range receiver.config.Targets {
+		if len(target.Logs) == 0 {
+			continue
+		}
+		client, err := newTargetClient(target, host, receiver.settings.TelemetrySettings)
+		if err != nil {
+			return err
+		}
+		lt := &logsTarget{client: client}
+		for _, logsCfg := range target.Logs {
+			mapper, err := newLogsMapper(logsCfg)
+			if err != nil {
+				return err
+			}
+			lt.mappers = append(lt.mappers, mapper)
+		}
+		receiver.targets = append(receiver.targets, lt)
+	}
+
+	receiver.isStarted = true
+	receiver.startCollecting()
+	receiver.settings.Logger.Debug("started.")
+	return nil
+}
+
+// startCollecting collects the logs every collection interval until Shutdown
+// cancels the context of the requests.
+func (receiver *logsReceiver) startCollecting() {
+	var ctx context.Context
+	ctx, receiver.cancel = context.WithCancel(context.Background())
+	receiver.collectionIntervalTicker = time.NewTicker(receiver.config.CollectionInterval)
+
+	receiver.wg.Add(1)
+	go func() {
+		defer receiver.wg.Done()
+		for {
+			select {
+			case <-receiver.collectionIntervalTicker.C:
+				receiver.collect(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (receiver *logsReceiver) collect(ctx context.Context) {
+	// The requests of a collection don't outlast the collection interval.
+	ctx, cancel := context.WithTimeout(ctx, receiver.config.CollectionInterval)
+	defer cancel()
+
+	logsChannel := make(chan plog.Logs)
+	for _, target := range receiver.targets {
+		go func(target *logsTarget) {
+			logsChannel <- receiver.collectTarget(ctx, target)
+		}(target)
+	}
+
+	allLogs := plog.NewLogs()
+	for range receiver.targets {
+		logs := <-logsChannel
+		logs.ResourceLogs().MoveAndAppendTo(allLogs.ResourceLogs())
+	}
+
+	logRecordCount := allLogs.LogRecordCount()
+	if logRecordCount > 0 {
+		ctx := receiver.obsrecv.StartLogsOp(context.Background())
+		err := receiver.nextConsumer.ConsumeLogs(context.Background(), allLogs)
+		receiver.obsrecv.EndLogsOp(ctx, metadata.Type, logRecordCount, err)
+		if err != nil {
+			receiver.settings.Logger.Error("failed to send logs", zap.Error(err))
+		}
+	}
+}
+
+func (receiver *logsReceiver) collectTarget(ctx context.Context, target *logsTarget) plog.Logs {
+	logs := plog.NewLogs()
+	doc, err := target.client.fetch(ctx)
+	if err != nil {
+		receiver.settings.Logger.Error("error collecting logs", zap.Error(err))
+		return logs
+	}
+
+	ts := pcommon.NewTimestampFromTime(time.Now())
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr(conventions.AttributeHTTPURL, target.client.target.Endpoint)
+	records := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for _, mapper := range target.mappers {
+		if err := mapper.docToLogs(doc, records, ts); err != nil {
+			receiver.settings.Logger.Error("error mapping logs", zap.Error(err), zap.String("endpoint", target.client.target.Endpoint))
+		}
+	}
+	return logs
+}
+
+func (receiver *logsReceiver) Shutdown(_ context.Context) error {
+	if !receiver.isStarted {
+		receiver.settings.Logger.Debug("Requested shutdown, but not started, ignoring.")
+		return nil
+	}
+
+	receiver.settings.Logger.Debug("stopping...")
+	receiver.stopCollecting()
+	receiver.isStarted = false
+	receiver.settings.Logger.Debug("stopped.")
+	return nil
+}
+
+func (receiver *logsReceiver) stopCollecting() {
+	if receiver.collectionIntervalTicker != nil {
+		receiver.collectionIntervalTicker.Stop()
+	}
+	receiver.cancel()
+	receiver.wg.Wait()
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpjsonreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestLogsReceiver(t *testing.T) {
+	server := newStatusServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.CollectionInterval = 10 * time.Millisecond
+	cfg.Targets = []*Target{
+		newTarget(server.URL+"/status", nil, []LogsCfg{
+			{},
+			{Path: "$.queues[*]", Attributes: map[string]string{"queue": "$.name", "consumers": "$.consumers"}},
+		}),
+		newTarget(server.URL+"/status", []MetricCfg{{MetricName: "up", Value: "$.up"}}, nil),
+	}
+
+	sink := new(consumertest.LogsSink)
+	receiver, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return len(sink.AllLogs()) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+
+	logs := sink.AllLogs()[0]
+	require.Equal(t, 1, logs.ResourceLogs().Len())
+	rl := logs.ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{"http.url": server.URL + "/status"}, rl.Resource().Attributes().AsRaw())
+
+	records := rl.ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 4, records.Len())
+
+	doc := records.At(0)
+	assert.NotZero(t, doc.ObservedTimestamp())
+	assert.Equal(t, "1.4.2", doc.Body().Map().AsRaw()["version"])
+	assert.Equal(t, 0, doc.Attributes().Len())
+
+	for i, expected := range []map[string]any{
+		{"queue": "orders", "consumers": int64(2)},
+		{"queue": "invoices"},
+		{"queue": "refunds"},
+	} {
+		record := records.At(i + 1)
+		assert.Equal(t, expected, record.Attributes().AsRaw())
+		assert.Equal(t, expected["queue"], record.Body().Map().AsRaw()["name"])
+	}
+}
+
+func TestLogsReceiverTargetError(t *testing.T) {
+	server := newStatusServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.CollectionInterval = 10 * time.Millisecond
+	cfg.Targets = []*Target{
+		newTarget(server.URL+"/missing", nil, []LogsCfg{{}}),
+		newTarget(server.URL+"/status", nil, []LogsCfg{{Path: "$.queues[*]"}}),
+	}
+
+	sink := new(consumertest.LogsSink)
+	receiver, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool {
+		return len(sink.AllLogs()) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+
+	assert.Equal(t, 3, sink.AllLogs()[0].LogRecordCount())
+}
+
+func TestLogsReceiverRestart(t *testing.T) {
+	server := newStatusServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.CollectionInterval = 10 * time.Millisecond
+	cfg.Targets = []*Target{newTarget(server.URL+"/status", nil, []LogsCfg{{}})}
+
+	sink := new(consumertest.LogsSink)
+	receiver, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+	for i := 1; i <= 2; i++ {
+		require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+		require.Eventually(t, func() bool {
+			return len(sink.AllLogs()) >= i
+		}, 5*time.Second, 10*time.Millisecond)
+		require.NoError(t, receiver.Shutdown(context.Background()))
+	}
+}
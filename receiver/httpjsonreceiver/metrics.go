@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpjsonreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpjsonreceiver"
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ohler55/ojg/jp"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+)
+
+// metricMapper maps a document to a metric, with the paths of its configuration parsed.
+type metricMapper struct {
+	cfg        MetricCfg
+	path       jp.Expr
+	value      jp.Expr
+	attributes map[string]jp.Expr
+}
+
+func newMetricMapper(cfg MetricCfg) (*metricMapper, error) {
+	path, err := compilePath(cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'path' %q: %w", cfg.Path, err)
+	}
+	value, err := jp.ParseString(cfg.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'value' %q: %w", cfg.Value, err)
+	}
+	attributes, err := compileAttributes(cfg.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	return &metricMapper{cfg: cfg, path: path, value: value, attributes: attributes}, nil
+}
+
+// docToMetric appends a data point to the metric for each item selected in the
+// document. The items whose value or attributes can't be found are skipped and
+// reported in the returned error.
+func (m *metricMapper) docToMetric(doc any, dest pmetric.Metric, startTime pcommon.Timestamp, ts pcommon.Timestamp, collectionInterval time.Duration) error {
+	dest.SetName(m.cfg.MetricName)
+	dest.SetDescription(m.cfg.Description)
+	dest.SetUnit(m.cfg.Unit)
+	dataPoints := setMetricFields(m.cfg, dest)
+
+	var errs error
+	for _, item := range selectItems(m.path, doc) {
+		dataPoint := pmetric.NewNumberDataPoint()
+		if err := m.itemToDataPoint(item, dataPoint); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("metric '%s': %w", m.cfg.MetricName, err))
+			continue
+		}
+		setTimestamp(m.cfg, dataPoint, startTime, ts, collectionInterval)
+		dataPoint.MoveTo(dataPoints.AppendEmpty())
+	}
+	return errs
+}
+
+// dataPointCount returns the number of data points of a metric mapped by a metricMapper.
+func dataPointCount(metric pmetric.Metric) int {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		return metric.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return metric.Sum().DataPoints().Len()
+	default:
+		return 0
+	}
+}
+
+func (m *metricMapper) itemToDataPoint(item any, dest pmetric.NumberDataPoint) error {
+	values := m.value.Get(item)
+	if len(values) != 1 {
+		return fmt.Errorf("'value' %q selected %d values, expected one", m.cfg.Value, len(values))
+	}
+	if err := setDataPointValue(m.cfg, values[0], dest); err != nil {
+		return err
+	}
+	attrs := dest.Attributes()
+	for k, v := range m.cfg.StaticAttributes {
+		attrs.PutStr(k, v)
+	}
+	for name, path := range m.attributes {
+		values := path.Get(item)
+		if len(values) == 0 {
+			return fmt.Errorf("attribute '%s' not found", name)
+		}
+		if err := attrs.PutEmpty(name).FromRaw(values[0]); err != nil {
+			return fmt.Errorf("attribute '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+func setTimestamp(cfg MetricCfg, dp pmetric.NumberDataPoint, startTime pcommon.Timestamp, ts pcommon.Timestamp, collectionInterval time.Duration) {
+	dp.SetTimestamp(ts)
+
+	// Cumulative sum should have a start time set to the beginning of the data points cumulation
+	if cfg.Aggregation != MetricAggregationDelta && cfg.DataType == MetricTypeSum {
+		dp.SetStartTimestamp(startTime)
+	}
+
+	// Non-cumulative sum should have a start time set to the previous endpoint
+	if cfg.Aggregation == MetricAggregationDelta && cfg.DataType == MetricTypeSum {
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(ts.AsTime().Add(-collectionInterval)))
+	}
+}
+
+func setMetricFields(cfg MetricCfg, dest pmetric.Metric) pmetric.NumberDataPointSlice {
+	var out pmetric.NumberDataPointSlice
+	switch cfg.DataType {
+	case MetricTypeUnspecified, MetricTypeGauge:
+		out = dest.SetEmptyGauge().DataPoints()
+	case MetricTypeSum:
+		sum := dest.SetEmptySum()
+		sum.SetIsMonotonic(cfg.Monotonic)
+		sum.SetAggregationTemporality(cfgToAggregationTemporality(cfg.Aggregation))
+		out = sum.DataPoints()
+	}
+	return out
+}
+
+func cfgToAggregationTemporality(agg MetricAggregation) pmetric.AggregationTemporality {
+	var out pmetric.AggregationTemporality
+	switch agg {
+	case MetricAggregationUnspecified, MetricAggregationCumulative:
+		out = pmetric.AggregationTemporalityCumulative
+	case MetricAggregationDelta:
+		out = pmetric.AggregationTemporalityDelta
+	}
+	return out
+}
+
+// setDataPointValue sets the value of the data point from a JSON number,
+// boolean or numeric string. If the value type is unspecified, integers are
+// recorded as int and other numbers as double.
+func setDataPointValue(cfg MetricCfg, value any, dest pmetric.NumberDataPoint) error {
+	var intVal int64
+	var doubleVal float64
+	isInt := false
+	switch v := value.(type) {
+	case int64:
+		intVal, doubleVal, isInt = v, float64(v), true
+	case float64:
+		intVal, doubleVal = int64(v), v
+	case bool:
+		if v {
+			intVal, doubleVal = 1, 1
+		}
+		isInt = true
+	case string:
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			intVal, doubleVal, isInt = i, float64(i), true
+		} else {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return fmt.Errorf("'value' %q: error converting %q to a number: %w", cfg.Value, v, err)
+			}
+			intVal, doubleVal = int64(f), f
+		}
+	default:
+		return fmt.Errorf("'value' %q: unsupported value %v of type %T", cfg.Value, value, value)
+	}
+
+	switch cfg.ValueType {
+	case MetricValueTypeInt:
+		dest.SetIntValue(intVal)
+	case MetricValueTypeDouble:
+		dest.SetDoubleValue(doubleVal)
+	case MetricValueTypeUnspecified:
+		if isInt {
+			dest.SetIntValue(intVal)
+		} else {
+			dest.SetDoubleValue(doubleVal)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpjsonreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ohler55/ojg/oj"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const statusDoc = `{
+	"up": true,
+	"version": "1.4.2",
+	"stats": {"requests": 1024, "load": 0.75, "uptime": "3600"},
+	"queues": [
+		{"name": "orders", "depth": 3, "consumers": 2},
+		{"name": "invoices", "depth": 12},
+		{"name": "refunds"}
+	]
+}`
+
+func TestDocToMetric(t *testing.T) {
+	doc, err := oj.ParseString(statusDoc)
+	require.NoError(t, err)
+
+	startTime := pcommon.NewTimestampFromTime(time.Unix(1000, 0))
+	ts := pcommon.NewTimestampFromTime(time.Unix(1060, 0))
+
+	tests := []struct {
+		name     string
+		cfg      MetricCfg
+		validate func(*testing.T, pmetric.Metric)
+		errMsg   string
+	}{
+		{
+			name: "gauge from the document",
+			cfg:  MetricCfg{MetricName: "up", Value: "$.up", Unit: "1", Description: "Whether the service is up."},
+			validate: func(t *testing.T, m pmetric.Metric) {
+				assert.Equal(t, "up", m.Name())
+				assert.Equal(t, "1", m.Unit())
+				assert.Equal(t, "Whether the service is up.", m.Description())
+				require.Equal(t, pmetric.MetricTypeGauge, m.Type())
+				require.Equal(t, 1, m.Gauge().DataPoints().Len())
+				dp := m.Gauge().DataPoints().At(0)
+				assert.Equal(t, int64(1), dp.IntValue())
+				assert.Equal(t, ts, dp.Timestamp())
+				assert.Equal(t, pcommon.Timestamp(0), dp.StartTimestamp())
+			},
+		},
+		{
+			name: "double value",
+			cfg:  MetricCfg{MetricName: "load", Value: "$.stats.load"},
+			validate: func(t *testing.T, m pmetric.Metric) {
+				dp := m.Gauge().DataPoints().At(0)
+				assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+				assert.Equal(t, 0.75, dp.DoubleValue())
+			},
+		},
+		{
+			name: "numeric string converted to double",
+			cfg:  MetricCfg{MetricName: "uptime", Value: "$.stats.uptime", ValueType: MetricValueTypeDouble},
+			validate: func(t *testing.T, m pmetric.Metric) {
+				dp := m.Gauge().DataPoints().At(0)
+				assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+				assert.Equal(t, 3600.0, dp.DoubleValue())
+			},
+		},
+		{
+			name: "cumulative sum",
+			cfg: MetricCfg{
+				MetricName:  "requests",
+				Value:       "$.stats.requests",
+				DataType:    MetricTypeSum,
+				Monotonic:   true,
+				Aggregation: MetricAggregationCumulative,
+			},
+			validate: func(t *testing.T, m pmetric.Metric) {
+				require.Equal(t, pmetric.MetricTypeSum, m.Type())
+				assert.True(t, m.Sum().IsMonotonic())
+				assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+				dp := m.Sum().DataPoints().At(0)
+				assert.Equal(t, int64(1024), dp.IntValue())
+				assert.Equal(t, startTime, dp.StartTimestamp())
+			},
+		},
+		{
+			name: "delta sum",
+			cfg: MetricCfg{
+				MetricName:  "requests",
+				Value:       "$.stats.requests",
+				DataType:    MetricTypeSum,
+				Aggregation: MetricAggregationDelta,
+			},
+			validate: func(t *testing.T, m pmetric.Metric) {
+				assert.Equal(t, pmetric.AggregationTemporalityDelta, m.Sum().AggregationTemporality())
+				dp := m.Sum().DataPoints().At(0)
+				assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(1030, 0)), dp.StartTimestamp())
+			},
+		},
+		{
+			name: "data point per item with attributes",
+			cfg: MetricCfg{
+				MetricName:       "queue.depth",
+				Path:             "$.queues[?(@.depth)]",
+				Value:            "$.depth",
+				Attributes:       map[string]string{"queue": "$.name"},
+				StaticAttributes: map[string]string{"service": "orders"},
+			},
+			validate: func(t *testing.T, m pmetric.Metric) {
+				dps := m.Gauge().DataPoints()
+				require.Equal(t, 2, dps.Len())
+				for i, expected := range []struct {
+					queue string
+					depth int64
+				}{{"orders", 3}, {"invoices", 12}} {
+					assert.Equal(t, expected.depth, dps.At(i).IntValue())
+					assert.Equal(t, map[string]any{"queue": expected.queue, "service": "orders"}, dps.At(i).Attributes().AsRaw())
+				}
+			},
+		},
+		{
+			name: "items missing the value are skipped",
+			cfg: MetricCfg{
+				MetricName: "queue.depth",
+				Path:       "$.queues[*]",
+				Value:      "$.depth",
+			},
+			validate: func(t *testing.T, m pmetric.Metric) {
+				assert.Equal(t, 2, m.Gauge().DataPoints().Len())
+			},
+			errMsg: `metric 'queue.depth': 'value' "$.depth" selected 0 values, expected one`,
+		},
+		{
+			name: "items missing an attribute are skipped",
+			cfg: MetricCfg{
+				MetricName: "queue.depth",
+				Path:       "$.queues[?(@.depth)]",
+				Value:      "$.depth",
+				Attributes: map[string]string{"consumers": "$.consumers"},
+			},
+			validate: func(t *testing.T, m pmetric.Metric) {
+				require.Equal(t, 1, m.Gauge().DataPoints().Len())
+				assert.Equal(t, int64(3), m.Gauge().DataPoints().At(0).IntValue())
+			},
+			errMsg: "attribute 'consumers' not found",
+		},
+		{
+			name: "value not a number",
+			cfg:  MetricCfg{MetricName: "version", Value: "$.version"},
+			validate: func(t *testing.T, m pmetric.Metric) {
+				assert.Equal(t, 0, m.Gauge().DataPoints().Len())
+			},
+			errMsg: `error converting "1.4.2" to a number`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapper, err := newMetricMapper(tt.cfg)
+			require.NoError(t, err)
+
+			metric := pmetric.NewMetric()
+			err = mapper.docToMetric(doc, metric, startTime, ts, 30*time.Second)
+			if tt.errMsg != "" {
+				assert.ErrorContains(t, err, tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+			tt.validate(t, metric)
+		})
+	}
+}
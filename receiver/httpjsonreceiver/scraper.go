@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpjsonreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpjsonreceiver"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	conventions "go.opentelemetry.io/collector/semconv/v1.18.0"
+	"go.uber.org/multierr"
+)
+
+var errClientNotInit = errors.New("client not initialized")
+
+// metricsTarget is a target whose document is mapped to metrics.
+type metricsTarget struct {
+	client  *targetClient
+	mappers []*metricMapper
+}
+
+type httpjsonScraper struct {
+	cfg       *Config
+	settings  component.TelemetrySettings
+	targets   []*metricsTarget
+	startTime pcommon.Timestamp
+}
+
+func newScraper(cfg *Config, settings receiver.CreateSettings) *httpjsonScraper {
+	return &httpjsonScraper{
+		cfg:      cfg,
+		settings: settings.TelemetrySettings,
+	}
+}
+
+// start creates the HTTP clients of the targets having metrics
+func (h *httpjsonScraper) start(_ context.Context, host component.Host) error {
+	h.startTime = pcommon.NewTimestampFromTime(time.Now())
+	// The targets are not nil once started, even if they only have logs.
+	h.targets = make([]*metricsTarget, 0, len(h.cfg.Targets))
+	for _, target := range h.cfg.Targets {
+		if len(target.Metrics) == 0 {
+			continue
+		}
+		client, err := newTargetClient(target, host, h.settings)
+		if err != nil {
+			return err
+		}
+		mt := &metricsTarget{client: client}
+		for _, metricCfg := range target.Metrics {
+			mapper, err := newMetricMapper(metricCfg)
+			if err != nil {
+				return err
+			}
+			mt.mappers = append(mt.mappers, mapper)
+		}
+		h.targets = append(h.targets, mt)
+	}
+	return nil
+}
+
+// scrape requests the documents of the targets and maps them to metrics
+func (h *httpjsonScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	metrics := pmetric.NewMetrics()
+	if h.targets == nil {
+		return metrics, errClientNotInit
+	}
+
+	var wg sync.WaitGroup
+	var mux sync.Mutex
+	var errs error
+	failedMetrics := 0
+
+	for _, target := range h.targets {
+		wg.Add(1)
+		go func(target *metricsTarget) {
+			defer wg.Done()
+
+			doc, err := target.client.fetch(ctx)
+			ts := pcommon.NewTimestampFromTime(time.Now())
+
+			mux.Lock()
+			defer mux.Unlock()
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				failedMetrics += len(target.mappers)
+				return
+			}
+
+			rm := pmetric.NewResourceMetrics()
+			rm.Resource().Attributes().PutStr(conventions.AttributeHTTPURL, target.client.target.Endpoint)
+			ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+			for _, mapper := range target.mappers {
+				metric := pmetric.NewMetric()
+				if err := mapper.docToMetric(doc, metric, h.startTime, ts, h.cfg.CollectionInterval); err != nil {
+					errs = multierr.Append(errs, err)
+					failedMetrics++
+				}
+				// The metric is only added if some of the selected items were mapped to data points.
+				if dataPointCount(metric) > 0 {
+					metric.MoveTo(ms.AppendEmpty())
+				}
+			}
+			if ms.Len() > 0 {
+				rm.MoveTo(metrics.ResourceMetrics().AppendEmpty())
+			}
+		}(target)
+	}
+	wg.Wait()
+
+	if errs != nil {
+		return metrics, scrapererror.NewPartialScrapeError(errs, failedMetrics)
+	}
+	return metrics, nil
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpjsonreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+func newStatusServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("X-Api-Key") != "secret":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/status":
+			assert.Equal(t, "application/json", r.Header.Get("Accept"))
+			_, _ = w.Write([]byte(statusDoc))
+		case r.URL.Path == "/invalid":
+			_, _ = w.Write([]byte(`{"up": `))
+		case r.URL.Path == "/large":
+			_, _ = w.Write([]byte(`["` + strings.Repeat("a", maxDocumentSize) + `"]`))
+		case r.URL.Path == "/slow":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTarget(endpoint string, metrics []MetricCfg, logs []LogsCfg) *Target {
+	return &Target{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: endpoint,
+			Headers:  map[string]configopaque.String{"X-Api-Key": "secret"},
+		},
+		Metrics: metrics,
+		Logs:    logs,
+	}
+}
+
+func TestScraper(t *testing.T) {
+	server := newStatusServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*Target{
+		newTarget(server.URL+"/status", []MetricCfg{
+			{MetricName: "up", Value: "$.up"},
+			{MetricName: "queue.depth", Path: "$.queues[*]", Value: "$.depth", Attributes: map[string]string{"queue": "$.name"}},
+			{MetricName: "missing", Path: "$.missing[*]", Value: "$.value"},
+		}, nil),
+		newTarget(server.URL+"/jobs", nil, []LogsCfg{{}}),
+		newTarget(server.URL+"/status", []MetricCfg{{MetricName: "missing", Value: "$.missing"}}, nil),
+	}
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	require.Len(t, scraper.targets, 2)
+
+	metrics, err := scraper.scrape(context.Background())
+	require.Error(t, err)
+	var partialErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, 2, partialErr.Failed)
+	assert.ErrorContains(t, err, `metric 'queue.depth': 'value' "$.depth" selected 0 values, expected one`)
+
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+	rm := metrics.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{"http.url": server.URL + "/status"}, rm.Resource().Attributes().AsRaw())
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.Len())
+	assert.Equal(t, "up", ms.At(0).Name())
+	assert.Equal(t, int64(1), ms.At(0).Gauge().DataPoints().At(0).IntValue())
+	assert.Equal(t, "queue.depth", ms.At(1).Name())
+	assert.Equal(t, 2, ms.At(1).Gauge().DataPoints().Len())
+}
+
+func TestScraperTargetErrors(t *testing.T) {
+	server := newStatusServer(t)
+
+	up := []MetricCfg{{MetricName: "up", Value: "$.up"}}
+	unauthorized := newTarget(server.URL+"/status", up, nil)
+	unauthorized.Headers = nil
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*Target{
+		newTarget(server.URL+"/status", up, nil),
+		newTarget(server.URL+"/missing", up, nil),
+		newTarget(server.URL+"/invalid", up, nil),
+		newTarget(server.URL+"/large", up, nil),
+		newTarget(server.URL+"/slow", up, nil),
+		unauthorized,
+	}
+	cfg.Targets[4].Timeout = 10 * time.Millisecond
+
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	metrics, err := scraper.scrape(context.Background())
+	var partialErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, 5, partialErr.Failed)
+	assert.ErrorContains(t, err, `unexpected status "404 Not Found"`)
+	assert.ErrorContains(t, err, `unexpected status "401 Unauthorized"`)
+	assert.ErrorContains(t, err, "failed to parse the JSON document")
+	assert.ErrorContains(t, err, "is larger than 10485760 bytes")
+	assert.ErrorContains(t, err, "Client.Timeout exceeded")
+	assert.Equal(t, 1, metrics.DataPointCount())
+}
+
+func TestTargetClientDefaultTimeout(t *testing.T) {
+	target := newTarget("http://localhost:8080/status", nil, nil)
+	client, err := newTargetClient(target, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	assert.Equal(t, defaultTimeout, client.client.Timeout)
+	assert.Zero(t, target.Timeout)
+
+	target.Timeout = time.Second
+	client, err = newTargetClient(target, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, client.client.Timeout)
+}
+
+func TestScraperNotStarted(t *testing.T) {
+	scraper := newScraper(createDefaultConfig().(*Config), receivertest.NewNopCreateSettings())
+	_, err := scraper.scrape(context.Background())
+	require.ErrorIs(t, err, errClientNotInit)
+}
+
+func TestScraperLogsOnly(t *testing.T) {
+	server := newStatusServer(t)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*Target{newTarget(server.URL+"/status", nil, []LogsCfg{{}})}
+
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	metrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, metrics.ResourceMetrics().Len())
+}
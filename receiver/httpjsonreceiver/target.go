@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpjsonreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpjsonreceiver"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// defaultTimeout is the timeout of the requests of the targets without timeout.
+	defaultTimeout = 10 * time.Second
+	// maxDocumentSize is the maximum size of the JSON document of a target.
+	maxDocumentSize = 10 * 1024 * 1024
+)
+
+// targetClient fetches the JSON document of a target.
+type targetClient struct {
+	target *Target
+	client *http.Client
+}
+
+func newTargetClient(target *Target, host component.Host, settings component.TelemetrySettings) (*targetClient, error) {
+	clientSettings := target.HTTPClientSettings
+	if clientSettings.Timeout == 0 {
+		clientSettings.Timeout = defaultTimeout
+	}
+	client, err := clientSettings.ToClient(host, settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client for %q: %w", target.Endpoint, err)
+	}
+	return &targetClient{target: target, client: client}, nil
+}
+
+// fetch requests the document of the target and parses it. The document is
+// made of map[string]any, []any, string, int64, float64, bool and nil values.
+func (c *targetClient) fetch(ctx context.Context) (any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.target.Endpoint, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %q: %w", c.target.Endpoint, err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request %q: %w", c.target.Endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDocumentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response of %q: %w", c.target.Endpoint, err)
+	}
+	if len(body) > maxDocumentSize {
+		return nil, fmt.Errorf("the response of %q is larger than %d bytes", c.target.Endpoint, maxDocumentSize)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status %q from %q", resp.Status, c.target.Endpoint)
+	}
+
+	doc, err := oj.Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the JSON document of %q: %w", c.target.Endpoint, err)
+	}
+	return doc, nil
+}
+
+// selectItems returns the items selected by the path in the document, or the
+// document itself if the path is nil.
+func selectItems(path jp.Expr, doc any) []any {
+	if path == nil {
+		return []any{doc}
+	}
+	return path.Get(doc)
+}
+
+// compilePath parses a path of the configuration, an empty path being nil.
+func compilePath(expr string) (jp.Expr, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	return jp.ParseString(expr)
+}
+
+// compileAttributes parses the paths of the attributes of the configuration.
+func compileAttributes(attributes map[string]string) (map[string]jp.Expr, error) {
+	out := make(map[string]jp.Expr, len(attributes))
+	for name, expr := range attributes {
+		path, err := jp.ParseString(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %q of attribute '%s': %w", expr, name, err)
+		}
+		out[name] = path
+	}
+	return out, nil
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdbreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/influxdbreceiver"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	attributeDatabase        = "influxdb.database"
+	attributeRetentionPolicy = "influxdb.retention_policy"
+	attributeOrg             = "influxdb.org"
+	attributeBucket          = "influxdb.bucket"
+)
+
+// writeTarget is where the line protocol is written: the database and retention policy
+// of InfluxDB 1.x, or the organization and bucket of InfluxDB 2.x.
+type writeTarget struct {
+	database        string
+	retentionPolicy string
+	org             string
+	bucket          string
+}
+
+// name returns the database, or the bucket, the line protocol is written to.
+func (t writeTarget) name() string {
+	if t.database != "" {
+		return t.database
+	}
+	return t.bucket
+}
+
+// addResourceAttributes adds the write target, and the resource attributes configured
+// for its database or bucket, to the resources of the metrics.
+func addResourceAttributes(metrics pmetric.Metrics, target writeTarget, databaseAttributes map[string]map[string]string) {
+	targetAttributes := map[string]string{
+		attributeDatabase:        target.database,
+		attributeRetentionPolicy: target.retentionPolicy,
+		attributeOrg:             target.org,
+		attributeBucket:          target.bucket,
+	}
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		attrs := metrics.ResourceMetrics().At(i).Resource().Attributes()
+		for k, v := range targetAttributes {
+			if v != "" {
+				attrs.PutStr(k, v)
+			}
+		}
+		for k, v := range databaseAttributes[target.name()] {
+			attrs.PutStr(k, v)
+		}
+	}
+}
@@ -4,10 +4,57 @@
 package influxdbreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/influxdbreceiver"
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config/confighttp"
 )
 
 // Config defines configuration for the InfluxDB receiver.
 type Config struct {
 	confighttp.HTTPServerSettings `mapstructure:",squash"`
+
+	// UDP receives line protocol in UDP packets. Disabled if not set.
+	UDP *SocketSettings `mapstructure:"udp"`
+	// TCP receives newline-delimited line protocol over TCP connections. Disabled if not set.
+	TCP *SocketSettings `mapstructure:"tcp"`
+	// DatabaseResourceAttributes are the resource attributes added to the metrics written to
+	// a database (InfluxDB 1.x) or bucket (InfluxDB 2.x), keyed by its name.
+	DatabaseResourceAttributes map[string]map[string]string `mapstructure:"database_resource_attributes"`
+}
+
+// SocketSettings defines a listener receiving line protocol over UDP or TCP.
+type SocketSettings struct {
+	// Endpoint is the address to listen on.
+	Endpoint string `mapstructure:"endpoint"`
+	// Precision is the precision of the timestamps: ns, us, ms or s. default is ns.
+	Precision string `mapstructure:"precision"`
+	// Database is the database the metrics received on the socket are written to,
+	// like the `db` parameter of the HTTP write endpoints.
+	Database string `mapstructure:"database"`
+}
+
+var errNoSocketEndpoint = errors.New("endpoint must be specified")
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	var err error
+	if cfg.UDP != nil {
+		err = errors.Join(err, cfg.UDP.validate("udp"))
+	}
+	if cfg.TCP != nil {
+		err = errors.Join(err, cfg.TCP.validate("tcp"))
+	}
+	return err
+}
+
+func (s *SocketSettings) validate(name string) error {
+	var err error
+	if s.Endpoint == "" {
+		err = errors.Join(err, fmt.Errorf("%s: %w", name, errNoSocketEndpoint))
+	}
+	if _, ok := precisions[s.Precision]; s.Precision != "" && !ok {
+		err = errors.Join(err, fmt.Errorf("%s: unrecognized precision %q", name, s.Precision))
+	}
+	return err
 }
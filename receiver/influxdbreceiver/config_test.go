@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdbreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.UDP = &SocketSettings{Endpoint: "0.0.0.0:8089", Precision: "ms"}
+	cfg.TCP = &SocketSettings{Endpoint: "0.0.0.0:8094"}
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.UDP.Precision = "h"
+	cfg.TCP.Endpoint = ""
+	err := component.ValidateConfig(cfg)
+	assert.ErrorContains(t, err, `udp: unrecognized precision "h"`)
+	assert.ErrorIs(t, err, errNoSocketEndpoint)
+}
@@ -27,9 +27,12 @@ import (
 type metricsReceiver struct {
 	nextConsumer       consumer.Metrics
 	httpServerSettings *confighttp.HTTPServerSettings
+	config             *Config
 	converter          *influx2otel.LineProtocolToOtelMetrics
 
 	server *http.Server
+	udp    *udpServer
+	tcp    *tcpServer
 	wg     sync.WaitGroup
 
 	logger common.Logger
@@ -54,17 +57,29 @@ func newMetricsReceiver(config *Config, settings receiver.CreateSettings, nextCo
 		return nil, err
 	}
 
-	return &metricsReceiver{
+	r := &metricsReceiver{
 		nextConsumer:       nextConsumer,
 		httpServerSettings: &config.HTTPServerSettings,
+		config:             config,
 		converter:          converter,
 		logger:             influxLogger,
 		obsrecv:            obsrecv,
 		settings:           settings.TelemetrySettings,
-	}, err
+	}
+	if config.UDP != nil {
+		if r.udp, err = newUDPServer(r, config.UDP, settings); err != nil {
+			return nil, err
+		}
+	}
+	if config.TCP != nil {
+		if r.tcp, err = newTCPServer(r, config.TCP, settings); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
 }
 
-func (r *metricsReceiver) Start(_ context.Context, host component.Host) error {
+func (r *metricsReceiver) Start(ctx context.Context, host component.Host) error {
 	ln, err := r.httpServerSettings.ToListener()
 	if err != nil {
 		return fmt.Errorf("failed to bind to address %s: %w", r.httpServerSettings.Endpoint, err)
@@ -74,11 +89,12 @@ func (r *metricsReceiver) Start(_ context.Context, host component.Host) error {
 	router.HandleFunc("/write", r.handleWrite)        // InfluxDB 1.x
 	router.HandleFunc("/api/v2/write", r.handleWrite) // InfluxDB 2.x
 
-	r.wg.Add(1)
 	r.server, err = r.httpServerSettings.ToServer(host, r.settings, router)
 	if err != nil {
+		_ = ln.Close()
 		return err
 	}
+	r.wg.Add(1)
 	go func() {
 		defer r.wg.Done()
 		if errHTTP := r.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
@@ -86,18 +102,34 @@ func (r *metricsReceiver) Start(_ context.Context, host component.Host) error {
 		}
 	}()
 
+	// The servers already started are shut down if the others fail to start.
+	if r.udp != nil {
+		if err = r.udp.start(&r.wg); err != nil {
+			return errors.Join(err, r.Shutdown(ctx))
+		}
+	}
+	if r.tcp != nil {
+		if err = r.tcp.start(&r.wg); err != nil {
+			return errors.Join(err, r.Shutdown(ctx))
+		}
+	}
+
 	return nil
 }
 
 func (r *metricsReceiver) Shutdown(_ context.Context) error {
-	if r.server == nil {
-		return nil
+	var errs error
+	if r.server != nil {
+		errs = errors.Join(errs, r.server.Close())
 	}
-	if err := r.server.Close(); err != nil {
-		return err
+	if r.udp != nil {
+		errs = errors.Join(errs, r.udp.shutdown())
+	}
+	if r.tcp != nil {
+		errs = errors.Join(errs, r.tcp.shutdown())
 	}
 	r.wg.Wait()
-	return nil
+	return errs
 }
 
 const (
@@ -132,72 +164,91 @@ func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request)
 	}
 
 	batch := r.converter.NewBatch()
-	lpDecoder := lineprotocol.NewDecoder(req.Body)
-
-	ctx := r.obsrecv.StartMetricsOp(req.Context())
+	if err := addLines(batch, lineprotocol.NewDecoder(req.Body), precision); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(w, err.Error())
+		return
+	}
 
-	var k, vTag []byte
-	var vField lineprotocol.Value
-	for line := 0; lpDecoder.Next(); line++ {
-		measurement, err := lpDecoder.Measurement()
-		if err != nil {
+	query := req.URL.Query()
+	target := writeTarget{
+		database:        query.Get("db"),
+		retentionPolicy: query.Get("rp"),
+		org:             query.Get("org"),
+		bucket:          query.Get("bucket"),
+	}
+	err := r.consumeBatch(req.Context(), r.obsrecv, batch, target)
+	if err != nil {
+		if consumererror.IsPermanent(err) {
 			w.WriteHeader(http.StatusBadRequest)
-			_, _ = fmt.Fprintf(w, "failed to parse measurement on line %d", line)
-			return
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
 		}
+		r.logger.Debug("failed to pass metrics to next consumer: %s", err)
+		return
+	}
 
-		tags := make(map[string]string)
-		for k, vTag, err = lpDecoder.NextTag(); k != nil && err == nil; k, vTag, err = lpDecoder.NextTag() {
-			tags[string(k)] = string(vTag)
-		}
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = fmt.Fprintf(w, "failed to parse tag on line %d", line)
-			return
-		}
+	w.WriteHeader(http.StatusNoContent)
+}
 
-		fields := make(map[string]any)
-		for k, vField, err = lpDecoder.NextField(); k != nil && err == nil; k, vField, err = lpDecoder.NextField() {
-			fields[string(k)] = vField.Interface()
-		}
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = fmt.Fprintf(w, "failed to parse field on line %d", line)
-			return
+// addLines adds the points of the line protocol read by the decoder to the batch.
+func addLines(batch *influx2otel.MetricsBatch, lpDecoder *lineprotocol.Decoder, precision lineprotocol.Precision) error {
+	for line := 0; lpDecoder.Next(); line++ {
+		if err := addLine(batch, lpDecoder, precision, line); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		ts, err := lpDecoder.Time(precision, time.Time{})
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = fmt.Fprintf(w, "failed to parse timestamp on line %d", line)
-			return
-		}
+// addLine adds the point of the current line of the decoder to the batch.
+func addLine(batch *influx2otel.MetricsBatch, lpDecoder *lineprotocol.Decoder, precision lineprotocol.Precision, line int) error {
+	measurement, err := lpDecoder.Measurement()
+	if err != nil {
+		return fmt.Errorf("failed to parse measurement on line %d", line)
+	}
 
-		if err = lpDecoder.Err(); err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = fmt.Fprintf(w, "failed to parse line: %s", err.Error())
-			return
-		}
+	var k, vTag []byte
+	tags := make(map[string]string)
+	for k, vTag, err = lpDecoder.NextTag(); k != nil && err == nil; k, vTag, err = lpDecoder.NextTag() {
+		tags[string(k)] = string(vTag)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse tag on line %d", line)
+	}
 
-		err = batch.AddPoint(string(measurement), tags, fields, ts, common.InfluxMetricValueTypeUntyped)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = fmt.Fprintf(w, "failed to append to the batch")
-			return
-		}
+	var vField lineprotocol.Value
+	fields := make(map[string]any)
+	for k, vField, err = lpDecoder.NextField(); k != nil && err == nil; k, vField, err = lpDecoder.NextField() {
+		fields[string(k)] = vField.Interface()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse field on line %d", line)
 	}
 
-	err := r.nextConsumer.ConsumeMetrics(req.Context(), batch.GetMetrics())
-	r.obsrecv.EndMetricsOp(ctx, dataFormat, batch.GetMetrics().DataPointCount(), err)
+	ts, err := lpDecoder.Time(precision, time.Time{})
 	if err != nil {
-		if consumererror.IsPermanent(err) {
-			w.WriteHeader(http.StatusBadRequest)
-		} else {
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-		r.logger.Debug("failed to pass metrics to next consumer: %s", err)
-		return
+		return fmt.Errorf("failed to parse timestamp on line %d", line)
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	if err = lpDecoder.Err(); err != nil {
+		return fmt.Errorf("failed to parse line: %s", err.Error())
+	}
+
+	if err = batch.AddPoint(string(measurement), tags, fields, ts, common.InfluxMetricValueTypeUntyped); err != nil {
+		return errors.New("failed to append to the batch")
+	}
+	return nil
+}
+
+// consumeBatch passes the metrics of the batch, with the resource attributes of the
+// write target, to the next consumer.
+func (r *metricsReceiver) consumeBatch(ctx context.Context, obsrecv *receiverhelper.ObsReport, batch *influx2otel.MetricsBatch, target writeTarget) error {
+	metrics := batch.GetMetrics()
+	addResourceAttributes(metrics, target, r.config.DatabaseResourceAttributes)
+
+	obsCtx := obsrecv.StartMetricsOp(ctx)
+	err := r.nextConsumer.ConsumeMetrics(ctx, metrics)
+	obsrecv.EndMetricsOp(obsCtx, dataFormat, metrics.DataPointCount(), err)
+	return err
 }
@@ -27,6 +27,9 @@ func TestWriteLineProtocol_v2API(t *testing.T) {
 		HTTPServerSettings: confighttp.HTTPServerSettings{
 			Endpoint: addr,
 		},
+		DatabaseResourceAttributes: map[string]map[string]string{
+			"my-bucket": {"region": "eu"},
+		},
 	}
 	nextConsumer := new(mockConsumer)
 
@@ -79,6 +82,11 @@ func TestWriteLineProtocol_v2API(t *testing.T) {
 		metrics := nextConsumer.lastMetricsConsumed
 		if assert.NotNil(t, metrics) && assert.Less(t, 0, metrics.DataPointCount()) {
 			assert.Equal(t, 1, metrics.MetricCount())
+			assert.Equal(t, map[string]any{
+				"influxdb.org":    "my-org",
+				"influxdb.bucket": "my-bucket",
+				"region":          "eu",
+			}, metrics.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
 			metric := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
 			assert.Equal(t, "cpu_temp", metric.Name())
 			if assert.Equal(t, pmetric.MetricTypeGauge, metric.Type()) && assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, metric.Gauge().DataPoints().At(0).ValueType()) {
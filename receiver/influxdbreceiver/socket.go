@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdbreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/influxdbreceiver"
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/influxdata/line-protocol/v2/lineprotocol"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxUDPPacketSize is the maximum size of a UDP datagram.
+	maxUDPPacketSize = 64 * 1024
+	// maxTCPLineSize is the maximum size of a line received over TCP.
+	maxTCPLineSize = 1024 * 1024
+	// maxTCPBatchSize is the size of the lines received over TCP after which they
+	// are passed to the next consumer, even if more lines are available.
+	maxTCPBatchSize = 1024 * 1024
+)
+
+// socketHandler converts the line protocol received on a socket.
+type socketHandler struct {
+	receiver  *metricsReceiver
+	settings  *SocketSettings
+	precision lineprotocol.Precision
+	obsrecv   *receiverhelper.ObsReport
+	logger    *zap.Logger
+}
+
+func newSocketHandler(r *metricsReceiver, settings *SocketSettings, transport string, set receiver.CreateSettings) (*socketHandler, error) {
+	precision := defaultPrecision
+	if settings.Precision != "" {
+		var ok bool
+		if precision, ok = precisions[settings.Precision]; !ok {
+			return nil, fmt.Errorf("unrecognized precision %q", settings.Precision)
+		}
+	}
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: set,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &socketHandler{
+		receiver:  r,
+		settings:  settings,
+		precision: precision,
+		obsrecv:   obsrecv,
+		logger:    set.Logger.With(zap.String("transport", transport)),
+	}, nil
+}
+
+// handleLines passes the metrics of the line protocol to the next consumer. As there
+// is no response to the client, the errors are logged, and the lines failing to be
+// parsed are skipped so that they don't drop the other lines of the packet or batch.
+func (h *socketHandler) handleLines(data []byte) {
+	batch := h.receiver.converter.NewBatch()
+	lpDecoder := lineprotocol.NewDecoderWithBytes(data)
+	added, skipped := 0, 0
+	var firstErr error
+	for line := 0; lpDecoder.Next(); line++ {
+		if err := addLine(batch, lpDecoder, h.precision, line); err != nil {
+			if skipped == 0 {
+				firstErr = err
+			}
+			skipped++
+			continue
+		}
+		added++
+	}
+	if skipped > 0 {
+		h.logger.Warn("Skipped lines failing to be parsed", zap.Int("skipped_lines", skipped), zap.Error(firstErr))
+	}
+	if added == 0 {
+		return
+	}
+	err := h.receiver.consumeBatch(context.Background(), h.obsrecv, batch, writeTarget{database: h.settings.Database})
+	if err != nil {
+		h.logger.Debug("Failed to pass metrics to next consumer", zap.Error(err))
+	}
+}
+
+// udpServer receives line protocol in UDP packets.
+type udpServer struct {
+	*socketHandler
+	conn net.PacketConn
+}
+
+func newUDPServer(r *metricsReceiver, settings *SocketSettings, set receiver.CreateSettings) (*udpServer, error) {
+	h, err := newSocketHandler(r, settings, "udp", set)
+	if err != nil {
+		return nil, err
+	}
+	return &udpServer{socketHandler: h}, nil
+}
+
+func (s *udpServer) start(wg *sync.WaitGroup) error {
+	conn, err := net.ListenPacket("udp", s.settings.Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", s.settings.Endpoint, err)
+	}
+	s.conn = conn
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		buf := make([]byte, maxUDPPacketSize)
+		for {
+			n, _, err := conn.ReadFrom(buf)
+			if n > 0 {
+				s.handleLines(buf[:n])
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			if err != nil {
+				s.logger.Debug("Failed to read UDP packet", zap.Error(err))
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *udpServer) shutdown() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// tcpServer receives newline-delimited line protocol over TCP connections.
+type tcpServer struct {
+	*socketHandler
+	listener net.Listener
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+}
+
+func newTCPServer(r *metricsReceiver, settings *SocketSettings, set receiver.CreateSettings) (*tcpServer, error) {
+	h, err := newSocketHandler(r, settings, "tcp", set)
+	if err != nil {
+		return nil, err
+	}
+	return &tcpServer{socketHandler: h, conns: make(map[net.Conn]struct{})}, nil
+}
+
+func (s *tcpServer) start(wg *sync.WaitGroup) error {
+	listener, err := net.Listen("tcp", s.settings.Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", s.settings.Endpoint, err)
+	}
+	s.listener = listener
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			if err != nil {
+				s.logger.Debug("Failed to accept TCP connection", zap.Error(err))
+				continue
+			}
+			if !s.track(conn) {
+				_ = conn.Close()
+				return
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer s.untrack(conn)
+				s.serveConn(conn)
+			}()
+		}
+	}()
+	return nil
+}
+
+// track records the connection to close it on shutdown. It returns false if the
+// server is shut down.
+func (s *tcpServer) track(conn net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conns == nil {
+		return false
+	}
+	s.conns[conn] = struct{}{}
+	return true
+}
+
+func (s *tcpServer) untrack(conn net.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.conns, conn)
+	_ = conn.Close()
+}
+
+// serveConn reads the lines of the connection, and passes them to the next consumer
+// once all the lines received so far are read.
+func (s *tcpServer) serveConn(conn net.Conn) {
+	reader := bufio.NewReaderSize(conn, maxTCPLineSize)
+	var lines []byte
+	for {
+		line, err := reader.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) {
+			s.logger.Warn("Line too long, closing the connection", zap.Int("max_line_size", maxTCPLineSize))
+			return
+		}
+		lines = append(lines, line...)
+		if len(lines) > 0 && (err != nil || reader.Buffered() == 0 || len(lines) >= maxTCPBatchSize) {
+			s.handleLines(lines)
+			lines = lines[:0]
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				s.logger.Debug("Failed to read TCP connection", zap.Error(err))
+			}
+			return
+		}
+	}
+}
+
+func (s *tcpServer) shutdown() error {
+	if s.listener == nil {
+		return nil
+	}
+	err := s.listener.Close()
+	s.listener = nil
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.conns = nil
+	return err
+}
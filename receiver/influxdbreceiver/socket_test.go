@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdbreceiver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func TestWriteLineProtocol_sockets(t *testing.T) {
+	udpAddr := testutil.GetAvailableLocalNetworkAddress(t, "udp")
+	tcpAddr := testutil.GetAvailableLocalAddress(t)
+	config := &Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		UDP: &SocketSettings{Endpoint: udpAddr, Precision: "s", Database: "edge"},
+		TCP: &SocketSettings{Endpoint: tcpAddr, Database: "telegraf"},
+		DatabaseResourceAttributes: map[string]map[string]string{
+			"edge": {"site": "edge-1"},
+		},
+	}
+	sink := new(consumertest.MetricsSink)
+
+	receiver, err := NewFactory().CreateMetricsReceiver(context.Background(), receivertest.NewNopCreateSettings(), config, sink)
+	require.NoError(t, err)
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, receiver.Shutdown(context.Background())) })
+
+	t.Run("udp", func(t *testing.T) {
+		sink.Reset()
+		conn, err := net.Dial("udp", udpAddr)
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = conn.Write([]byte("cpu_temp,foo=bar gauge=87.332 1700000000\n"))
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool { return sink.DataPointCount() == 1 }, 10*time.Second, 10*time.Millisecond)
+		rm := sink.AllMetrics()[0].ResourceMetrics().At(0)
+		assert.Equal(t, map[string]any{"influxdb.database": "edge", "site": "edge-1"}, rm.Resource().Attributes().AsRaw())
+		dp := rm.ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+		assert.Equal(t, time.Unix(1700000000, 0).UTC(), dp.Timestamp().AsTime())
+	})
+
+	t.Run("udp with invalid lines", func(t *testing.T) {
+		sink.Reset()
+		conn, err := net.Dial("udp", udpAddr)
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = conn.Write([]byte("cpu_temp,foo=bar gauge=1 1700000000\ncpu_temp gauge=\ncpu_temp,foo=baz gauge=2 1700000000\n"))
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool { return sink.DataPointCount() == 2 }, 10*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("tcp", func(t *testing.T) {
+		sink.Reset()
+		conn, err := net.Dial("tcp", tcpAddr)
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = conn.Write([]byte("cpu_temp,foo=bar gauge=87.332\ncpu_temp,foo=baz gauge=12.5\n"))
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool { return sink.DataPointCount() == 2 }, 10*time.Second, 10*time.Millisecond)
+		rm := sink.AllMetrics()[0].ResourceMetrics().At(0)
+		assert.Equal(t, map[string]any{"influxdb.database": "telegraf"}, rm.Resource().Attributes().AsRaw())
+	})
+}
+
+func TestStartSocketError(t *testing.T) {
+	tcpListener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer tcpListener.Close()
+
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	config := &Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: httpAddr,
+		},
+		UDP: &SocketSettings{Endpoint: testutil.GetAvailableLocalNetworkAddress(t, "udp")},
+		TCP: &SocketSettings{Endpoint: tcpListener.Addr().String()},
+	}
+	receiver, err := NewFactory().CreateMetricsReceiver(context.Background(), receivertest.NewNopCreateSettings(), config, consumertest.NewNop())
+	require.NoError(t, err)
+	require.Error(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The HTTP server started before the TCP listener failed is shut down.
+	ln, err := net.Listen("tcp", httpAddr)
+	require.NoError(t, err)
+	require.NoError(t, ln.Close())
+	require.NoError(t, receiver.Shutdown(context.Background()))
+}
@@ -4,6 +4,7 @@
 package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
 
 import (
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -48,10 +49,19 @@ type Config struct {
 	ResolveCanonicalBootstrapServersOnly bool `mapstructure:"resolve_canonical_bootstrap_servers_only"`
 	// Kafka protocol version
 	ProtocolVersion string `mapstructure:"protocol_version"`
-	// The name of the kafka topic to consume from (default "otlp_spans")
+	// The name of the kafka topic to consume from (default "otlp_spans"), ignored
+	// if Topics or TopicPatterns are set.
 	Topic string `mapstructure:"topic"`
+	// The names of the kafka topics to consume from
+	Topics []string `mapstructure:"topics"`
+	// Regular expressions matching the whole names of the kafka topics to consume from
+	TopicPatterns []string `mapstructure:"topic_patterns"`
+	// How often the topics matching TopicPatterns are refreshed (default 1m)
+	TopicRefreshInterval time.Duration `mapstructure:"topic_refresh_interval"`
 	// Encoding of the messages (default "otlp_proto")
 	Encoding string `mapstructure:"encoding"`
+	// Encoding of the messages of some topics, overriding Encoding
+	TopicEncodings map[string]string `mapstructure:"topic_encodings"`
 	// The consumer group that receiver will be consuming messages from (default "otel-collector")
 	GroupID string `mapstructure:"group_id"`
 	// The consumer client ID that receiver will use (default "otel-collector")
@@ -74,6 +84,9 @@ type Config struct {
 
 	// Extract headers from kafka records
 	HeaderExtraction HeaderExtraction `mapstructure:"header_extraction"`
+
+	// If true, the topic, partition and offset of the kafka records are added as resource attributes
+	MessageAttributes bool `mapstructure:"message_attributes"`
 }
 
 const (
@@ -85,5 +98,14 @@ var _ component.Config = (*Config)(nil)
 
 // Validate checks the receiver configuration is valid
 func (cfg *Config) Validate() error {
+	if len(cfg.TopicPatterns) == 0 {
+		return nil
+	}
+	if _, err := compileTopicPatterns(cfg.TopicPatterns); err != nil {
+		return err
+	}
+	if cfg.TopicRefreshInterval <= 0 {
+		return fmt.Errorf("topic_refresh_interval must be positive, got %v", cfg.TopicRefreshInterval)
+	}
 	return nil
 }
@@ -4,6 +4,7 @@
 package kafkareceiver
 
 import (
+	"errors"
 	"path/filepath"
 	"testing"
 	"time"
@@ -34,6 +35,7 @@ func TestLoadConfig(t *testing.T) {
 			id: component.NewIDWithName(metadata.Type, ""),
 			expected: &Config{
 				Topic:                                "spans",
+				TopicRefreshInterval:                 time.Minute,
 				Encoding:                             "otlp_proto",
 				Brokers:                              []string{"foo:123", "bar:456"},
 				ResolveCanonicalBootstrapServersOnly: true,
@@ -66,12 +68,13 @@ func TestLoadConfig(t *testing.T) {
 
 			id: component.NewIDWithName(metadata.Type, "logs"),
 			expected: &Config{
-				Topic:         "logs",
-				Encoding:      "direct",
-				Brokers:       []string{"coffee:123", "foobar:456"},
-				ClientID:      "otel-collector",
-				GroupID:       "otel-collector",
-				InitialOffset: "earliest",
+				Topic:                "logs",
+				TopicRefreshInterval: time.Minute,
+				Encoding:             "direct",
+				Brokers:              []string{"coffee:123", "foobar:456"},
+				ClientID:             "otel-collector",
+				GroupID:              "otel-collector",
+				InitialOffset:        "earliest",
 				Authentication: kafka.Authentication{
 					TLS: &configtls.TLSClientSetting{
 						TLSSetting: configtls.TLSSetting{
@@ -94,6 +97,41 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "topics"),
+			expected: &Config{
+				Topic:                "otlp_spans",
+				Topics:               []string{"app_logs", "audit_logs"},
+				TopicPatterns:        []string{"app-.*-logs"},
+				TopicRefreshInterval: 30 * time.Second,
+				Encoding:             "otlp_proto",
+				TopicEncodings:       map[string]string{"audit_logs": "raw"},
+				MessageAttributes:    true,
+				Brokers:              []string{"localhost:9092"},
+				ClientID:             "otel-collector",
+				GroupID:              "otel-collector",
+				InitialOffset:        "latest",
+				Metadata: kafkaexporter.Metadata{
+					Full: true,
+					Retry: kafkaexporter.MetadataRetry{
+						Max:     3,
+						Backoff: 250 * time.Millisecond,
+					},
+				},
+				AutoCommit: AutoCommit{
+					Enable:   true,
+					Interval: 1 * time.Second,
+				},
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_topic_pattern"),
+			expectedErr: errors.New("invalid topic pattern \"app-(.*-logs\": error parsing regexp: missing closing ): `^(?:app-(.*-logs)$`"),
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_topic_refresh_interval"),
+			expectedErr: errors.New("topic_refresh_interval must be positive, got 0s"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -105,6 +143,10 @@ func TestLoadConfig(t *testing.T) {
 			require.NoError(t, err)
 			require.NoError(t, component.UnmarshalConfig(sub, cfg))
 
+			if tt.expectedErr != nil {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.expectedErr.Error())
+				return
+			}
 			assert.NoError(t, component.ValidateConfig(cfg))
 			assert.Equal(t, tt.expected, cfg)
 		})
@@ -33,8 +33,10 @@ type kafkaTracesConsumer struct {
 	consumerGroup     sarama.ConsumerGroup
 	nextConsumer      consumer.Traces
 	topics            []string
+	topicWatcher      *topicWatcher
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       TracesUnmarshaler
+	topicUnmarshalers map[string]TracesUnmarshaler
 
 	settings receiver.CreateSettings
 
@@ -42,6 +44,7 @@ type kafkaTracesConsumer struct {
 	messageMarking    MessageMarking
 	headerExtraction  bool
 	headers           []string
+	messageAttributes bool
 }
 
 // kafkaMetricsConsumer uses sarama to consume and handle messages from kafka.
@@ -49,8 +52,10 @@ type kafkaMetricsConsumer struct {
 	consumerGroup     sarama.ConsumerGroup
 	nextConsumer      consumer.Metrics
 	topics            []string
+	topicWatcher      *topicWatcher
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       MetricsUnmarshaler
+	topicUnmarshalers map[string]MetricsUnmarshaler
 
 	settings receiver.CreateSettings
 
@@ -58,6 +63,7 @@ type kafkaMetricsConsumer struct {
 	messageMarking    MessageMarking
 	headerExtraction  bool
 	headers           []string
+	messageAttributes bool
 }
 
 // kafkaLogsConsumer uses sarama to consume and handle messages from kafka.
@@ -65,8 +71,10 @@ type kafkaLogsConsumer struct {
 	consumerGroup     sarama.ConsumerGroup
 	nextConsumer      consumer.Logs
 	topics            []string
+	topicWatcher      *topicWatcher
 	cancelConsumeLoop context.CancelFunc
 	unmarshaler       LogsUnmarshaler
+	topicUnmarshalers map[string]LogsUnmarshaler
 
 	settings receiver.CreateSettings
 
@@ -74,6 +82,7 @@ type kafkaLogsConsumer struct {
 	messageMarking    MessageMarking
 	headerExtraction  bool
 	headers           []string
+	messageAttributes bool
 }
 
 var _ receiver.Traces = (*kafkaTracesConsumer)(nil)
@@ -85,6 +94,12 @@ func newTracesReceiver(config Config, set receiver.CreateSettings, unmarshalers
 	if unmarshaler == nil {
 		return nil, errUnrecognizedEncoding
 	}
+	topicUnmarshalers := make(map[string]TracesUnmarshaler, len(config.TopicEncodings))
+	for topic, encoding := range config.TopicEncodings {
+		if topicUnmarshalers[topic] = unmarshalers[encoding]; topicUnmarshalers[topic] == nil {
+			return nil, fmt.Errorf("%w %q of topic %q", errUnrecognizedEncoding, encoding, topic)
+		}
+	}
 
 	c := sarama.NewConfig()
 	c.ClientID = config.ClientID
@@ -111,20 +126,23 @@ func newTracesReceiver(config Config, set receiver.CreateSettings, unmarshalers
 	if err := kafka.ConfigureAuthentication(config.Authentication, c); err != nil {
 		return nil, err
 	}
-	client, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
+	client, watcher, err := newConsumerGroup(config, c, set.Logger)
 	if err != nil {
 		return nil, err
 	}
 	return &kafkaTracesConsumer{
 		consumerGroup:     client,
-		topics:            []string{config.Topic},
+		topics:            consumedTopics(config),
+		topicWatcher:      watcher,
 		nextConsumer:      nextConsumer,
 		unmarshaler:       unmarshaler,
+		topicUnmarshalers: topicUnmarshalers,
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
 		headerExtraction:  config.HeaderExtraction.ExtractHeaders,
 		headers:           config.HeaderExtraction.Headers,
+		messageAttributes: config.MessageAttributes,
 	}, nil
 }
 
@@ -142,12 +160,14 @@ func (c *kafkaTracesConsumer) Start(_ context.Context, host component.Host) erro
 	consumerGroup := &tracesConsumerGroupHandler{
 		logger:            c.settings.Logger,
 		unmarshaler:       c.unmarshaler,
+		topicUnmarshalers: c.topicUnmarshalers,
 		nextConsumer:      c.nextConsumer,
 		ready:             make(chan bool),
 		obsrecv:           obsrecv,
 		autocommitEnabled: c.autocommitEnabled,
 		messageMarking:    c.messageMarking,
 		headerExtractor:   &nopHeaderExtractor{},
+		messageAttributes: c.messageAttributes,
 	}
 	if c.headerExtraction {
 		consumerGroup.headerExtractor = &headerExtractor{
@@ -160,7 +180,10 @@ func (c *kafkaTracesConsumer) Start(_ context.Context, host component.Host) erro
 			host.ReportFatalError(err)
 		}
 	}()
-	<-consumerGroup.ready
+	// With topic patterns, no topic may match yet so the first session isn't waited for.
+	if c.topicWatcher == nil {
+		<-consumerGroup.ready
+	}
 	return nil
 }
 
@@ -169,7 +192,7 @@ func (c *kafkaTracesConsumer) consumeLoop(ctx context.Context, handler sarama.Co
 		// `Consume` should be called inside an infinite loop, when a
 		// server-side rebalance happens, the consumer session will need to be
 		// recreated to get the new claims
-		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
+		if err := consumeTopics(ctx, c.consumerGroup, c.topics, c.topicWatcher, handler); err != nil {
 			c.settings.Logger.Error("Error from consumer", zap.Error(err))
 		}
 		// check if context was cancelled, signaling that the consumer should stop
@@ -190,6 +213,12 @@ func newMetricsReceiver(config Config, set receiver.CreateSettings, unmarshalers
 	if unmarshaler == nil {
 		return nil, errUnrecognizedEncoding
 	}
+	topicUnmarshalers := make(map[string]MetricsUnmarshaler, len(config.TopicEncodings))
+	for topic, encoding := range config.TopicEncodings {
+		if topicUnmarshalers[topic] = unmarshalers[encoding]; topicUnmarshalers[topic] == nil {
+			return nil, fmt.Errorf("%w %q of topic %q", errUnrecognizedEncoding, encoding, topic)
+		}
+	}
 
 	c := sarama.NewConfig()
 	c.ClientID = config.ClientID
@@ -213,20 +242,23 @@ func newMetricsReceiver(config Config, set receiver.CreateSettings, unmarshalers
 	if err := kafka.ConfigureAuthentication(config.Authentication, c); err != nil {
 		return nil, err
 	}
-	client, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
+	client, watcher, err := newConsumerGroup(config, c, set.Logger)
 	if err != nil {
 		return nil, err
 	}
 	return &kafkaMetricsConsumer{
 		consumerGroup:     client,
-		topics:            []string{config.Topic},
+		topics:            consumedTopics(config),
+		topicWatcher:      watcher,
 		nextConsumer:      nextConsumer,
 		unmarshaler:       unmarshaler,
+		topicUnmarshalers: topicUnmarshalers,
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
 		headerExtraction:  config.HeaderExtraction.ExtractHeaders,
 		headers:           config.HeaderExtraction.Headers,
+		messageAttributes: config.MessageAttributes,
 	}, nil
 }
 
@@ -244,12 +276,14 @@ func (c *kafkaMetricsConsumer) Start(_ context.Context, host component.Host) err
 	metricsConsumerGroup := &metricsConsumerGroupHandler{
 		logger:            c.settings.Logger,
 		unmarshaler:       c.unmarshaler,
+		topicUnmarshalers: c.topicUnmarshalers,
 		nextConsumer:      c.nextConsumer,
 		ready:             make(chan bool),
 		obsrecv:           obsrecv,
 		autocommitEnabled: c.autocommitEnabled,
 		messageMarking:    c.messageMarking,
 		headerExtractor:   &nopHeaderExtractor{},
+		messageAttributes: c.messageAttributes,
 	}
 	if c.headerExtraction {
 		metricsConsumerGroup.headerExtractor = &headerExtractor{
@@ -262,7 +296,10 @@ func (c *kafkaMetricsConsumer) Start(_ context.Context, host component.Host) err
 			host.ReportFatalError(err)
 		}
 	}()
-	<-metricsConsumerGroup.ready
+	// With topic patterns, no topic may match yet so the first session isn't waited for.
+	if c.topicWatcher == nil {
+		<-metricsConsumerGroup.ready
+	}
 	return nil
 }
 
@@ -271,7 +308,7 @@ func (c *kafkaMetricsConsumer) consumeLoop(ctx context.Context, handler sarama.C
 		// `Consume` should be called inside an infinite loop, when a
 		// server-side rebalance happens, the consumer session will need to be
 		// recreated to get the new claims
-		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
+		if err := consumeTopics(ctx, c.consumerGroup, c.topics, c.topicWatcher, handler); err != nil {
 			c.settings.Logger.Error("Error from consumer", zap.Error(err))
 		}
 		// check if context was cancelled, signaling that the consumer should stop
@@ -304,6 +341,12 @@ func newLogsReceiver(config Config, set receiver.CreateSettings, unmarshalers ma
 	if err != nil {
 		return nil, err
 	}
+	topicUnmarshalers := make(map[string]LogsUnmarshaler, len(config.TopicEncodings))
+	for topic, encoding := range config.TopicEncodings {
+		if topicUnmarshalers[topic], err = getLogsUnmarshaler(encoding, unmarshalers); err != nil {
+			return nil, fmt.Errorf("%w %q of topic %q", err, encoding, topic)
+		}
+	}
 	if config.ProtocolVersion != "" {
 		var version sarama.KafkaVersion
 		version, err = sarama.ParseKafkaVersion(config.ProtocolVersion)
@@ -315,20 +358,23 @@ func newLogsReceiver(config Config, set receiver.CreateSettings, unmarshalers ma
 	if err = kafka.ConfigureAuthentication(config.Authentication, c); err != nil {
 		return nil, err
 	}
-	client, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
+	client, watcher, err := newConsumerGroup(config, c, set.Logger)
 	if err != nil {
 		return nil, err
 	}
 	return &kafkaLogsConsumer{
 		consumerGroup:     client,
-		topics:            []string{config.Topic},
+		topics:            consumedTopics(config),
+		topicWatcher:      watcher,
 		nextConsumer:      nextConsumer,
 		unmarshaler:       unmarshaler,
+		topicUnmarshalers: topicUnmarshalers,
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
 		headerExtraction:  config.HeaderExtraction.ExtractHeaders,
 		headers:           config.HeaderExtraction.Headers,
+		messageAttributes: config.MessageAttributes,
 	}, nil
 }
 
@@ -374,12 +420,14 @@ func (c *kafkaLogsConsumer) Start(_ context.Context, host component.Host) error
 	logsConsumerGroup := &logsConsumerGroupHandler{
 		logger:            c.settings.Logger,
 		unmarshaler:       c.unmarshaler,
+		topicUnmarshalers: c.topicUnmarshalers,
 		nextConsumer:      c.nextConsumer,
 		ready:             make(chan bool),
 		obsrecv:           obsrecv,
 		autocommitEnabled: c.autocommitEnabled,
 		messageMarking:    c.messageMarking,
 		headerExtractor:   &nopHeaderExtractor{},
+		messageAttributes: c.messageAttributes,
 	}
 	if c.headerExtraction {
 		logsConsumerGroup.headerExtractor = &headerExtractor{
@@ -392,7 +440,10 @@ func (c *kafkaLogsConsumer) Start(_ context.Context, host component.Host) error
 			host.ReportFatalError(err)
 		}
 	}()
-	<-logsConsumerGroup.ready
+	// With topic patterns, no topic may match yet so the first session isn't waited for.
+	if c.topicWatcher == nil {
+		<-logsConsumerGroup.ready
+	}
 	return nil
 }
 
@@ -401,7 +452,7 @@ func (c *kafkaLogsConsumer) consumeLoop(ctx context.Context, handler sarama.Cons
 		// `Consume` should be called inside an infinite loop, when a
 		// server-side rebalance happens, the consumer session will need to be
 		// recreated to get the new claims
-		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
+		if err := consumeTopics(ctx, c.consumerGroup, c.topics, c.topicWatcher, handler); err != nil {
 			c.settings.Logger.Error("Error from consumer", zap.Error(err))
 		}
 		// check if context was cancelled, signaling that the consumer should stop
@@ -424,6 +475,9 @@ type tracesConsumerGroupHandler struct {
 	ready        chan bool
 	readyCloser  sync.Once
 
+	// topicUnmarshalers overrides unmarshaler for the messages of some topics.
+	topicUnmarshalers map[string]TracesUnmarshaler
+
 	logger *zap.Logger
 
 	obsrecv *receiverhelper.ObsReport
@@ -431,6 +485,7 @@ type tracesConsumerGroupHandler struct {
 	autocommitEnabled bool
 	messageMarking    MessageMarking
 	headerExtractor   HeaderExtractor
+	messageAttributes bool
 }
 
 type metricsConsumerGroupHandler struct {
@@ -440,6 +495,9 @@ type metricsConsumerGroupHandler struct {
 	ready        chan bool
 	readyCloser  sync.Once
 
+	// topicUnmarshalers overrides unmarshaler for the messages of some topics.
+	topicUnmarshalers map[string]MetricsUnmarshaler
+
 	logger *zap.Logger
 
 	obsrecv *receiverhelper.ObsReport
@@ -447,6 +505,7 @@ type metricsConsumerGroupHandler struct {
 	autocommitEnabled bool
 	messageMarking    MessageMarking
 	headerExtractor   HeaderExtractor
+	messageAttributes bool
 }
 
 type logsConsumerGroupHandler struct {
@@ -456,6 +515,9 @@ type logsConsumerGroupHandler struct {
 	ready        chan bool
 	readyCloser  sync.Once
 
+	// topicUnmarshalers overrides unmarshaler for the messages of some topics.
+	topicUnmarshalers map[string]LogsUnmarshaler
+
 	logger *zap.Logger
 
 	obsrecv *receiverhelper.ObsReport
@@ -463,6 +525,7 @@ type logsConsumerGroupHandler struct {
 	autocommitEnabled bool
 	messageMarking    MessageMarking
 	headerExtractor   HeaderExtractor
+	messageAttributes bool
 }
 
 var _ sarama.ConsumerGroupHandler = (*tracesConsumerGroupHandler)(nil)
@@ -510,7 +573,8 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 				statMessageOffset.M(message.Offset),
 				statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
 
-			traces, err := c.unmarshaler.Unmarshal(message.Value)
+			unmarshaler := unmarshalerForTopic(message.Topic, c.unmarshaler, c.topicUnmarshalers)
+			traces, err := unmarshaler.Unmarshal(message.Value)
 			if err != nil {
 				c.logger.Error("failed to unmarshal message", zap.Error(err))
 				if c.messageMarking.After && c.messageMarking.OnError {
@@ -520,9 +584,12 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 			}
 
 			c.headerExtractor.extractHeadersTraces(traces, message)
+			if c.messageAttributes {
+				addMessageAttributesTraces(traces, message)
+			}
 			spanCount := traces.SpanCount()
 			err = c.nextConsumer.ConsumeTraces(session.Context(), traces)
-			c.obsrecv.EndTracesOp(ctx, c.unmarshaler.Encoding(), spanCount, err)
+			c.obsrecv.EndTracesOp(ctx, unmarshaler.Encoding(), spanCount, err)
 			if err != nil {
 				if c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
@@ -586,7 +653,8 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 				statMessageOffset.M(message.Offset),
 				statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
 
-			metrics, err := c.unmarshaler.Unmarshal(message.Value)
+			unmarshaler := unmarshalerForTopic(message.Topic, c.unmarshaler, c.topicUnmarshalers)
+			metrics, err := unmarshaler.Unmarshal(message.Value)
 			if err != nil {
 				c.logger.Error("failed to unmarshal message", zap.Error(err))
 				if c.messageMarking.After && c.messageMarking.OnError {
@@ -595,10 +663,13 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 				return err
 			}
 			c.headerExtractor.extractHeadersMetrics(metrics, message)
+			if c.messageAttributes {
+				addMessageAttributesMetrics(metrics, message)
+			}
 
 			dataPointCount := metrics.DataPointCount()
 			err = c.nextConsumer.ConsumeMetrics(session.Context(), metrics)
-			c.obsrecv.EndMetricsOp(ctx, c.unmarshaler.Encoding(), dataPointCount, err)
+			c.obsrecv.EndMetricsOp(ctx, unmarshaler.Encoding(), dataPointCount, err)
 			if err != nil {
 				if c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
@@ -667,7 +738,8 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 				statMessageOffset.M(message.Offset),
 				statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
 
-			logs, err := c.unmarshaler.Unmarshal(message.Value)
+			unmarshaler := unmarshalerForTopic(message.Topic, c.unmarshaler, c.topicUnmarshalers)
+			logs, err := unmarshaler.Unmarshal(message.Value)
 			if err != nil {
 				c.logger.Error("failed to unmarshal message", zap.Error(err))
 				if c.messageMarking.After && c.messageMarking.OnError {
@@ -676,9 +748,12 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 				return err
 			}
 			c.headerExtractor.extractHeadersLogs(logs, message)
+			if c.messageAttributes {
+				addMessageAttributesLogs(logs, message)
+			}
 			logRecordCount := logs.LogRecordCount()
 			err = c.nextConsumer.ConsumeLogs(session.Context(), logs)
-			c.obsrecv.EndLogsOp(ctx, c.unmarshaler.Encoding(), logRecordCount, err)
+			c.obsrecv.EndLogsOp(ctx, unmarshaler.Encoding(), logRecordCount, err)
 			if err != nil {
 				if c.messageMarking.After && c.messageMarking.OnError {
 					session.MarkMessage(message, "")
@@ -1085,3 +1085,74 @@ func (t *testConsumerGroup) Resume(_ map[string][]int32) {
 func (t *testConsumerGroup) ResumeAll() {
 	panic("implement me")
 }
+
+func TestNewLogsReceiver_topic_encoding_err(t *testing.T) {
+	c := Config{
+		Encoding:       defaultEncoding,
+		TopicEncodings: map[string]string{"audit_logs": "foo"},
+	}
+	r, err := newLogsReceiver(c, receivertest.NewNopCreateSettings(), defaultLogsUnmarshalers(), consumertest.NewNop())
+	require.Error(t, err)
+	assert.Nil(t, r)
+	assert.ErrorIs(t, err, errUnrecognizedEncoding)
+}
+
+func TestLogsConsumerGroupHandler_topic_encodings(t *testing.T) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{ReceiverCreateSettings: receivertest.NewNopCreateSettings()})
+	require.NoError(t, err)
+	sink := &consumertest.LogsSink{}
+	c := logsConsumerGroupHandler{
+		unmarshaler:       newPdataLogsUnmarshaler(&plog.ProtoUnmarshaler{}, defaultEncoding),
+		topicUnmarshalers: map[string]LogsUnmarshaler{"audit_logs": newRawLogsUnmarshaler()},
+		logger:            zap.NewNop(),
+		ready:             make(chan bool),
+		nextConsumer:      sink,
+		obsrecv:           obsrecv,
+		headerExtractor:   &nopHeaderExtractor{},
+		messageAttributes: true,
+	}
+
+	groupClaim := &testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage),
+	}
+	testSession := testConsumerGroupSession{ctx: context.Background()}
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		assert.NoError(t, c.ConsumeClaim(testSession, groupClaim))
+		wg.Done()
+	}()
+
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("otlp")
+	bts, err := (&plog.ProtoMarshaler{}).MarshalLogs(logs)
+	require.NoError(t, err)
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Topic: "app_logs", Partition: 1, Offset: 10, Value: bts}
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Topic: "audit_logs", Partition: 2, Offset: 20, Value: []byte("raw")}
+	close(groupClaim.messageChan)
+	wg.Wait()
+
+	require.Len(t, sink.AllLogs(), 2)
+	for i, expected := range []struct {
+		topic     string
+		partition int64
+		offset    int64
+		body      string
+	}{
+		{topic: "app_logs", partition: 1, offset: 10, body: "otlp"},
+		{topic: "audit_logs", partition: 2, offset: 20, body: "raw"},
+	} {
+		rl := sink.AllLogs()[i].ResourceLogs().At(0)
+		assert.Equal(t, map[string]any{
+			"kafka.topic":     expected.topic,
+			"kafka.partition": expected.partition,
+			"kafka.offset":    expected.offset,
+		}, rl.Resource().Attributes().AsRaw())
+		lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+		if expected.body == "raw" {
+			assert.Equal(t, []byte("raw"), lr.Body().Bytes().AsRaw())
+		} else {
+			assert.Equal(t, expected.body, lr.Body().Str())
+		}
+	}
+}
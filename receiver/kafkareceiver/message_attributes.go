@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	attributeKafkaTopic     = "kafka.topic"
+	attributeKafkaPartition = "kafka.partition"
+	attributeKafkaOffset    = "kafka.offset"
+)
+
+func putMessageAttributes(attrs pcommon.Map, message *sarama.ConsumerMessage) {
+	attrs.PutStr(attributeKafkaTopic, message.Topic)
+	attrs.PutInt(attributeKafkaPartition, int64(message.Partition))
+	attrs.PutInt(attributeKafkaOffset, message.Offset)
+}
+
+// addMessageAttributesTraces adds the topic, partition and offset of the message
+// to the resources of the traces.
+func addMessageAttributesTraces(traces ptrace.Traces, message *sarama.ConsumerMessage) {
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		putMessageAttributes(traces.ResourceSpans().At(i).Resource().Attributes(), message)
+	}
+}
+
+// addMessageAttributesMetrics adds the topic, partition and offset of the message
+// to the resources of the metrics.
+func addMessageAttributesMetrics(metrics pmetric.Metrics, message *sarama.ConsumerMessage) {
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		putMessageAttributes(metrics.ResourceMetrics().At(i).Resource().Attributes(), message)
+	}
+}
+
+// addMessageAttributesLogs adds the topic, partition and offset of the message
+// to the resources of the logs.
+func addMessageAttributesLogs(logs plog.Logs, message *sarama.ConsumerMessage) {
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		putMessageAttributes(logs.ResourceLogs().At(i).Resource().Attributes(), message)
+	}
+}
+
+// unmarshalerForTopic returns the unmarshaler of the messages of the topic.
+func unmarshalerForTopic[T any](topic string, unmarshaler T, topicUnmarshalers map[string]T) T {
+	if u, ok := topicUnmarshalers[topic]; ok {
+		return u
+	}
+	return unmarshaler
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/IBM/sarama"
+	"go.uber.org/zap"
+)
+
+// consumedTopics returns the topics to consume from, without the ones matching
+// the topic patterns.
+func consumedTopics(config Config) []string {
+	if len(config.Topics) == 0 && len(config.TopicPatterns) == 0 {
+		return []string{config.Topic}
+	}
+	return config.Topics
+}
+
+// compileTopicPatterns compiles the topic patterns, which must match the whole
+// name of the topics.
+func compileTopicPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	regexps := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid topic pattern %q: %w", pattern, err)
+		}
+		regexps = append(regexps, re)
+	}
+	return regexps, nil
+}
+
+// metadataClient lists the topics of the cluster.
+type metadataClient interface {
+	RefreshMetadata(topics ...string) error
+	Topics() ([]string, error)
+}
+
+// topicWatcher periodically lists the topics matching the topic patterns.
+type topicWatcher struct {
+	client          metadataClient
+	patterns        []*regexp.Regexp
+	refreshInterval time.Duration
+	logger          *zap.Logger
+}
+
+// newConsumerGroup creates the consumer group of the receiver, and the topic watcher
+// if topic patterns are configured.
+func newConsumerGroup(config Config, c *sarama.Config, logger *zap.Logger) (sarama.ConsumerGroup, *topicWatcher, error) {
+	if len(config.TopicPatterns) == 0 {
+		group, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
+		return group, nil, err
+	}
+
+	patterns, err := compileTopicPatterns(config.TopicPatterns)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := sarama.NewClient(config.Brokers, c)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The client is closed with the consumer group.
+	group, err := sarama.NewConsumerGroupFromClient(config.GroupID, client)
+	if err != nil {
+		_ = client.Close()
+		return nil, nil, err
+	}
+	return group, &topicWatcher{
+		client:          client,
+		patterns:        patterns,
+		refreshInterval: config.TopicRefreshInterval,
+		logger:          logger,
+	}, nil
+}
+
+// matchingTopics returns the sorted topics of the cluster matching the patterns.
+func (w *topicWatcher) matchingTopics() ([]string, error) {
+	if err := w.client.RefreshMetadata(); err != nil {
+		return nil, err
+	}
+	topics, err := w.client.Topics()
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []string
+	for _, topic := range topics {
+		for _, pattern := range w.patterns {
+			if pattern.MatchString(topic) {
+				matching = append(matching, topic)
+				break
+			}
+		}
+	}
+	sort.Strings(matching)
+	return matching, nil
+}
+
+// watch calls onChange once the topics matching the patterns are different from
+// current, or returns when ctx is done.
+func (w *topicWatcher) watch(ctx context.Context, current []string, onChange func()) {
+	ticker := time.NewTicker(w.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			topics, err := w.matchingTopics()
+			if err != nil {
+				w.logger.Warn("Failed to refresh the topics matching the topic patterns", zap.Error(err))
+				continue
+			}
+			if !equalTopics(current, topics) {
+				w.logger.Info("The topics matching the topic patterns changed", zap.Strings("topics", topics))
+				onChange()
+				return
+			}
+		}
+	}
+}
+
+func containsTopic(topics []string, topic string) bool {
+	for _, t := range topics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
+func equalTopics(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// consumeTopics joins the consumer group for the topics and the ones matching the
+// patterns of the watcher. It returns when ctx is done, on a server-side rebalance
+// or once the topics matching the patterns change, so that it is called again with
+// the new topics.
+func consumeTopics(ctx context.Context, group sarama.ConsumerGroup, topics []string, watcher *topicWatcher, handler sarama.ConsumerGroupHandler) error {
+	if watcher == nil {
+		return group.Consume(ctx, topics, handler)
+	}
+
+	matching, err := watcher.matchingTopics()
+	if err != nil {
+		// Wait before retrying, as the consumer group would.
+		select {
+		case <-ctx.Done():
+		case <-time.After(watcher.refreshInterval):
+		}
+		return err
+	}
+	sessionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go watcher.watch(sessionCtx, matching, cancel)
+
+	allTopics := append([]string(nil), topics...)
+	for _, topic := range matching {
+		if !containsTopic(topics, topic) {
+			allTopics = append(allTopics, topic)
+		}
+	}
+	if len(allTopics) == 0 {
+		// Nothing to consume until a topic matching the patterns is created.
+		<-sessionCtx.Done()
+		return nil
+	}
+	return group.Consume(sessionCtx, allTopics, handler)
+}
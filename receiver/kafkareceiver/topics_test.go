@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkareceiver
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestConsumedTopics(t *testing.T) {
+	assert.Equal(t, []string{"otlp_spans"}, consumedTopics(Config{Topic: "otlp_spans"}))
+	assert.Equal(t, []string{"a", "b"}, consumedTopics(Config{Topic: "otlp_spans", Topics: []string{"a", "b"}}))
+	assert.Empty(t, consumedTopics(Config{Topic: "otlp_spans", TopicPatterns: []string{"a.*"}}))
+}
+
+type testMetadataClient struct {
+	mu        sync.Mutex
+	topics    []string
+	refreshes int
+}
+
+func (c *testMetadataClient) RefreshMetadata(...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refreshes++
+	return nil
+}
+
+func (c *testMetadataClient) refreshCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.refreshes
+}
+
+func (c *testMetadataClient) Topics() ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.topics...), nil
+}
+
+func (c *testMetadataClient) setTopics(topics ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.topics = topics
+}
+
+func newTestTopicWatcher(t *testing.T, client metadataClient, patterns ...string) *topicWatcher {
+	regexps, err := compileTopicPatterns(patterns)
+	require.NoError(t, err)
+	return &topicWatcher{
+		client:          client,
+		patterns:        regexps,
+		refreshInterval: 10 * time.Millisecond,
+		logger:          zap.NewNop(),
+	}
+}
+
+func TestTopicWatcherMatchingTopics(t *testing.T) {
+	client := &testMetadataClient{topics: []string{"app-b-logs", "app-a-logs", "app-a-logs-dlq", "audit", "metrics"}}
+	w := newTestTopicWatcher(t, client, "app-.*-logs", "audit")
+
+	topics, err := w.matchingTopics()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app-a-logs", "app-b-logs", "audit"}, topics)
+}
+
+// recordingConsumerGroup records the topics of the sessions, which last until
+// their context is done.
+type recordingConsumerGroup struct {
+	testConsumerGroup
+	sessions chan []string
+}
+
+func (g *recordingConsumerGroup) Consume(ctx context.Context, topics []string, _ sarama.ConsumerGroupHandler) error {
+	g.sessions <- topics
+	<-ctx.Done()
+	return nil
+}
+
+func TestConsumeTopicsRefresh(t *testing.T) {
+	client := &testMetadataClient{topics: []string{"app-a-logs"}}
+	w := newTestTopicWatcher(t, client, "app-.*-logs")
+	group := &recordingConsumerGroup{sessions: make(chan []string, 2)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() {
+		done <- consumeTopics(ctx, group, []string{"audit", "app-a-logs"}, w, nil)
+	}()
+	assert.Equal(t, []string{"audit", "app-a-logs"}, <-group.sessions)
+
+	// The session ends once a new topic matches the patterns.
+	client.setTopics("app-a-logs", "app-b-logs")
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the session didn't end after the topics changed")
+	}
+
+	go func() {
+		done <- consumeTopics(ctx, group, []string{"audit"}, w, nil)
+	}()
+	assert.Equal(t, []string{"audit", "app-a-logs", "app-b-logs"}, <-group.sessions)
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestConsumeTopicsNoMatchingTopics(t *testing.T) {
+	client := &testMetadataClient{}
+	w := newTestTopicWatcher(t, client, "app-.*-logs")
+	group := &recordingConsumerGroup{sessions: make(chan []string, 1)}
+
+	done := make(chan error)
+	go func() {
+		done <- consumeTopics(context.Background(), group, nil, w, nil)
+	}()
+	// The consumer group isn't joined until a topic matches.
+	require.Eventually(t, func() bool { return client.refreshCount() > 0 }, 10*time.Second, time.Millisecond)
+	client.setTopics("app-a-logs")
+	require.NoError(t, <-done)
+	assert.Empty(t, group.sessions)
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelarrowreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otelarrowreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	arrowpb "github.com/open-telemetry/otel-arrow/api/experimental/arrow/v1"
+	arrowRecord "github.com/open-telemetry/otel-arrow/pkg/otel/arrow_record"
+	arrowCommon "github.com/open-telemetry/otel-arrow/pkg/otel/common/arrow"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const dataFormatArrow = "arrow"
+
+// errDecode wraps the errors decoding the Arrow batches, which the exporter must not retry.
+var errDecode = errors.New("decode arrow batch")
+
+// errMemoryLimit is returned when decoding a batch exceeds the memory limit of the stream.
+var errMemoryLimit = errors.New("arrow memory limit exceeded")
+
+// arrowStream is the server side of an Arrow stream of any signal.
+type arrowStream interface {
+	Context() context.Context
+	Send(*arrowpb.BatchStatus) error
+	Recv() (*arrowpb.BatchArrowRecords, error)
+}
+
+// arrowReceiver serves the Arrow streams, each one decoded by its own consumer
+// keeping the schemas and the dictionaries of the batches already received.
+type arrowReceiver struct {
+	arrowpb.UnimplementedArrowTracesServiceServer
+	arrowpb.UnimplementedArrowMetricsServiceServer
+	arrowpb.UnimplementedArrowLogsServiceServer
+
+	nextTraces  consumer.Traces
+	nextMetrics consumer.Metrics
+	nextLogs    consumer.Logs
+
+	// memoryLimit is the memory limit of a stream, in bytes.
+	memoryLimit uint64
+	obsrep      *receiverhelper.ObsReport
+	settings    component.TelemetrySettings
+}
+
+func (r *arrowReceiver) ArrowTraces(stream arrowpb.ArrowTracesService_ArrowTracesServer) error {
+	return r.serve(stream, r.consumeTraces)
+}
+
+func (r *arrowReceiver) ArrowMetrics(stream arrowpb.ArrowMetricsService_ArrowMetricsServer) error {
+	return r.serve(stream, r.consumeMetrics)
+}
+
+func (r *arrowReceiver) ArrowLogs(stream arrowpb.ArrowLogsService_ArrowLogsServer) error {
+	return r.serve(stream, r.consumeLogs)
+}
+
+// serve consumes the batches of the stream in order, acknowledging each one
+// with its status, until the exporter closes the stream.
+func (r *arrowReceiver) serve(stream arrowStream, consume func(context.Context, *arrowRecord.Consumer, *arrowpb.BatchArrowRecords) error) error {
+	ac := arrowRecord.NewConsumer(
+		arrowRecord.WithMemoryLimit(r.memoryLimit),
+		arrowRecord.WithMeterProvider(r.settings.MeterProvider, r.settings.MetricsLevel),
+	)
+	defer func() {
+		_ = ac.Close()
+	}()
+
+	ctx := stream.Context()
+	for {
+		batch, err := stream.Recv()
+		if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = consume(ctx, ac, batch)
+		if err != nil {
+			r.settings.Logger.Debug("Failed to consume arrow batch", zap.Int64("batch_id", batch.BatchId), zap.Error(err))
+		}
+		if err = stream.Send(newBatchStatus(batch.BatchId, err)); err != nil {
+			return err
+		}
+	}
+}
+
+// consumeTraces consumes the payloads of the batch as a single payload, so that the status
+// of the batch applies to all of them and a retry of the batch doesn't duplicate the data of
+// the payloads already consumed. The same goes for the metrics and logs.
+func (r *arrowReceiver) consumeTraces(ctx context.Context, ac *arrowRecord.Consumer, batch *arrowpb.BatchArrowRecords) error {
+	traces, err := decode(ac.TracesFrom, batch)
+	if err != nil {
+		return err
+	}
+	td := ptrace.NewTraces()
+	for _, payload := range traces {
+		payload.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	}
+	ctx = r.obsrep.StartTracesOp(ctx)
+	err = r.nextTraces.ConsumeTraces(ctx, td)
+	r.obsrep.EndTracesOp(ctx, dataFormatArrow, td.SpanCount(), err)
+	return err
+}
+
+func (r *arrowReceiver) consumeMetrics(ctx context.Context, ac *arrowRecord.Consumer, batch *arrowpb.BatchArrowRecords) error {
+	metrics, err := decode(ac.MetricsFrom, batch)
+	if err != nil {
+		return err
+	}
+	md := pmetric.NewMetrics()
+	for _, payload := range metrics {
+		payload.ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+	}
+	ctx = r.obsrep.StartMetricsOp(ctx)
+	err = r.nextMetrics.ConsumeMetrics(ctx, md)
+	r.obsrep.EndMetricsOp(ctx, dataFormatArrow, md.DataPointCount(), err)
+	return err
+}
+
+func (r *arrowReceiver) consumeLogs(ctx context.Context, ac *arrowRecord.Consumer, batch *arrowpb.BatchArrowRecords) error {
+	logs, err := decode(ac.LogsFrom, batch)
+	if err != nil {
+		return err
+	}
+	ld := plog.NewLogs()
+	for _, payload := range logs {
+		payload.ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+	}
+	ctx = r.obsrep.StartLogsOp(ctx)
+	err = r.nextLogs.ConsumeLogs(ctx, ld)
+	r.obsrep.EndLogsOp(ctx, dataFormatArrow, ld.LogRecordCount(), err)
+	return err
+}
+
+// decode decodes a batch, the consumer panics when it exceeds its memory limit.
+func decode[T ptrace.Traces | pmetric.Metrics | plog.Logs](from func(*arrowpb.BatchArrowRecords) ([]T, error), batch *arrowpb.BatchArrowRecords) (data []T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			limitErr, ok := r.(arrowCommon.LimitError)
+			if !ok {
+				panic(r)
+			}
+			err = fmt.Errorf("%w: %w", errMemoryLimit, limitErr)
+		}
+	}()
+	if data, err = from(batch); err != nil {
+		return nil, fmt.Errorf("%w: %w", errDecode, err)
+	}
+	return data, nil
+}
+
+// newBatchStatus returns the status of a batch, whose code tells the exporter
+// whether to retry it.
+func newBatchStatus(batchID int64, err error) *arrowpb.BatchStatus {
+	if err == nil {
+		return &arrowpb.BatchStatus{BatchId: batchID, StatusCode: arrowpb.StatusCode_OK}
+	}
+
+	code := arrowpb.StatusCode_UNAVAILABLE
+	switch {
+	case errors.Is(err, errMemoryLimit):
+		code = arrowpb.StatusCode_RESOURCE_EXHAUSTED
+	case errors.Is(err, errDecode), consumererror.IsPermanent(err):
+		code = arrowpb.StatusCode_INVALID_ARGUMENT
+	}
+	return &arrowpb.BatchStatus{
+		BatchId:       batchID,
+		StatusCode:    code,
+		StatusMessage: err.Error(),
+	}
+}
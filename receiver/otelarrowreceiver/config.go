@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelarrowreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otelarrowreceiver"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+)
+
+// Config defines configuration for OTel-Arrow receiver.
+type Config struct {
+	// Configures the receiver server protocol.
+	configgrpc.GRPCServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Arrow configures the OTel-Arrow streams.
+	Arrow ArrowSettings `mapstructure:"arrow"`
+}
+
+// ArrowSettings configures the OTel-Arrow streams of the receiver.
+type ArrowSettings struct {
+	// MemoryLimitMiB is the memory available to decode the batches of a
+	// stream, including the dictionaries kept between the batches.
+	MemoryLimitMiB uint64 `mapstructure:"memory_limit_mib"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	return nil
+}
+
+// Validate checks if the Arrow settings are valid.
+func (cfg *ArrowSettings) Validate() error {
+	if cfg.MemoryLimitMiB == 0 {
+		return errors.New("memory_limit_mib must be greater than 0")
+	}
+	return nil
+}
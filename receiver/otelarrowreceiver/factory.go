@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelarrowreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otelarrowreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otelarrowreceiver/internal/metadata"
+)
+
+// NewFactory creates a new OTel-Arrow receiver factory.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTracesReceiver, metadata.TracesStability),
+		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability))
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		GRPCServerSettings: configgrpc.GRPCServerSettings{
+			NetAddr: confignet.NetAddr{
+				Endpoint:  "0.0.0.0:4317",
+				Transport: "tcp",
+			},
+			// We almost write 0 bytes, so no need to tune WriteBufferSize.
+			ReadBufferSize: 512 * 1024,
+		},
+		Arrow: ArrowSettings{
+			MemoryLimitMiB: 128,
+		},
+	}
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	set receiver.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (receiver.Traces, error) {
+	var err error
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var recv *otelArrowReceiver
+		recv, err = newOTelArrowReceiver(cfg.(*Config), set)
+		return recv
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*otelArrowReceiver).nextTraces = nextConsumer
+
+	return r, nil
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	set receiver.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (receiver.Metrics, error) {
+	var err error
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var recv *otelArrowReceiver
+		recv, err = newOTelArrowReceiver(cfg.(*Config), set)
+		return recv
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*otelArrowReceiver).nextMetrics = nextConsumer
+
+	return r, nil
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	set receiver.CreateSettings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (receiver.Logs, error) {
+	var err error
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var recv *otelArrowReceiver
+		recv, err = newOTelArrowReceiver(cfg.(*Config), set)
+		return recv
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*otelArrowReceiver).nextLogs = nextConsumer
+
+	return r, nil
+}
+
+// This is the map of already created OTel-Arrow receivers for particular configurations.
+// We maintain this map because the Factory is asked trace, metric and log receivers separately
+// when it gets CreateTracesReceiver(), CreateMetricsReceiver() and CreateLogsReceiver() but
+// they must not create separate objects, they must use one otelArrowReceiver object per configuration.
+var receivers = sharedcomponent.NewSharedComponents()
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelarrowreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otelarrowreceiver"
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	arrowpb "github.com/open-telemetry/otel-arrow/api/experimental/arrow/v1"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// otelArrowReceiver serves the OTel-Arrow streams and the OTLP services on
+// the same gRPC server.
+type otelArrowReceiver struct {
+	cfg        *Config
+	serverGRPC *grpc.Server
+	shutdownWG sync.WaitGroup
+
+	nextTraces  consumer.Traces
+	nextMetrics consumer.Metrics
+	nextLogs    consumer.Logs
+
+	obsrepGRPC *receiverhelper.ObsReport
+	settings   receiver.CreateSettings
+}
+
+func newOTelArrowReceiver(cfg *Config, set receiver.CreateSettings) (*otelArrowReceiver, error) {
+	obsrepGRPC, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		Transport:              "grpc",
+		ReceiverCreateSettings: set,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &otelArrowReceiver{
+		cfg:        cfg,
+		obsrepGRPC: obsrepGRPC,
+		settings:   set,
+	}, nil
+}
+
+// Start registers the services of the signals having a consumer and starts the gRPC server.
+func (r *otelArrowReceiver) Start(_ context.Context, host component.Host) error {
+	if r.nextTraces == nil && r.nextMetrics == nil && r.nextLogs == nil {
+		return errors.New("cannot start receiver: no consumers were specified")
+	}
+
+	var err error
+	if r.serverGRPC, err = r.cfg.GRPCServerSettings.ToServer(host, r.settings.TelemetrySettings); err != nil {
+		return err
+	}
+	arrow := &arrowReceiver{
+		nextTraces:  r.nextTraces,
+		nextMetrics: r.nextMetrics,
+		nextLogs:    r.nextLogs,
+		memoryLimit: r.cfg.Arrow.MemoryLimitMiB << 20,
+		obsrep:      r.obsrepGRPC,
+		settings:    r.settings.TelemetrySettings,
+	}
+	if r.nextTraces != nil {
+		ptraceotlp.RegisterGRPCServer(r.serverGRPC, &tracesReceiver{nextConsumer: r.nextTraces, obsrep: r.obsrepGRPC})
+		arrowpb.RegisterArrowTracesServiceServer(r.serverGRPC, arrow)
+	}
+	if r.nextMetrics != nil {
+		pmetricotlp.RegisterGRPCServer(r.serverGRPC, &metricsReceiver{nextConsumer: r.nextMetrics, obsrep: r.obsrepGRPC})
+		arrowpb.RegisterArrowMetricsServiceServer(r.serverGRPC, arrow)
+	}
+	if r.nextLogs != nil {
+		plogotlp.RegisterGRPCServer(r.serverGRPC, &logsReceiver{nextConsumer: r.nextLogs, obsrep: r.obsrepGRPC})
+		arrowpb.RegisterArrowLogsServiceServer(r.serverGRPC, arrow)
+	}
+
+	r.settings.Logger.Info("Starting GRPC server", zap.String("endpoint", r.cfg.NetAddr.Endpoint))
+	ln, err := r.cfg.GRPCServerSettings.ToListener()
+	if err != nil {
+		return err
+	}
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+
+		if errGrpc := r.serverGRPC.Serve(ln); errGrpc != nil && !errors.Is(errGrpc, grpc.ErrServerStopped) {
+			host.ReportFatalError(errGrpc)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the gRPC server. The Arrow streams are long-lived, so they are
+// canceled instead of waiting for the exporters to close them.
+func (r *otelArrowReceiver) Shutdown(context.Context) error {
+	if r.serverGRPC != nil {
+		r.serverGRPC.Stop()
+	}
+	r.shutdownWG.Wait()
+	return nil
+}
@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelarrowreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	arrowpb "github.com/open-telemetry/otel-arrow/api/experimental/arrow/v1"
+	arrowRecord "github.com/open-telemetry/otel-arrow/pkg/otel/arrow_record"
+	arrowCommon "github.com/open-telemetry/otel-arrow/pkg/otel/common/arrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+type testConsumers struct {
+	traces  consumer.Traces
+	metrics consumer.Metrics
+	logs    consumer.Logs
+}
+
+// startTestReceiver starts a receiver and returns a connection to it.
+func startTestReceiver(t *testing.T, consumers testConsumers) *grpc.ClientConn {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	set := receivertest.NewNopCreateSettings()
+
+	// The receivers of the signals share the same server.
+	var recv component.Component
+	var err error
+	if consumers.traces != nil {
+		recv, err = factory.CreateTracesReceiver(context.Background(), set, cfg, consumers.traces)
+		require.NoError(t, err)
+	}
+	if consumers.metrics != nil {
+		recv, err = factory.CreateMetricsReceiver(context.Background(), set, cfg, consumers.metrics)
+		require.NoError(t, err)
+	}
+	if consumers.logs != nil {
+		recv, err = factory.CreateLogsReceiver(context.Background(), set, cfg, consumers.logs)
+		require.NoError(t, err)
+	}
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, recv.Shutdown(context.Background()))
+	})
+
+	conn, err := grpc.Dial(cfg.NetAddr.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, conn.Close())
+	})
+	return conn
+}
+
+func TestArrowTraces(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	conn := startTestReceiver(t, testConsumers{traces: sink})
+
+	stream, err := arrowpb.NewArrowTracesServiceClient(conn).ArrowTraces(context.Background())
+	require.NoError(t, err)
+	producer := arrowRecord.NewProducer()
+	defer func() { assert.NoError(t, producer.Close()) }()
+
+	// The batches after the first one only carry the dictionary deltas.
+	for i := 0; i < 3; i++ {
+		batch, err := producer.BatchArrowRecordsFromTraces(testdata.GenerateTracesTwoSpansSameResource())
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(batch))
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, batch.BatchId, resp.BatchId)
+		assert.Equal(t, arrowpb.StatusCode_OK, resp.StatusCode)
+	}
+	require.NoError(t, stream.CloseSend())
+
+	assert.Equal(t, 6, sink.SpanCount())
+	// The encoding doesn't preserve the order of the span events.
+	spans := sink.AllTraces()[2].ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 2, spans.Len())
+	assert.Equal(t, "operationA", spans.At(0).Name())
+	assert.Equal(t, "operationB", spans.At(1).Name())
+}
+
+func TestArrowMetrics(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	conn := startTestReceiver(t, testConsumers{metrics: sink})
+
+	stream, err := arrowpb.NewArrowMetricsServiceClient(conn).ArrowMetrics(context.Background())
+	require.NoError(t, err)
+	producer := arrowRecord.NewProducer()
+	defer func() { assert.NoError(t, producer.Close()) }()
+
+	batch, err := producer.BatchArrowRecordsFromMetrics(testdata.GenerateMetricsOneMetric())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(batch))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, arrowpb.StatusCode_OK, resp.StatusCode)
+
+	assert.Equal(t, 2, sink.DataPointCount())
+}
+
+func TestArrowLogs(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	conn := startTestReceiver(t, testConsumers{logs: sink})
+
+	stream, err := arrowpb.NewArrowLogsServiceClient(conn).ArrowLogs(context.Background())
+	require.NoError(t, err)
+	producer := arrowRecord.NewProducer()
+	defer func() { assert.NoError(t, producer.Close()) }()
+
+	batch, err := producer.BatchArrowRecordsFromLogs(testdata.GenerateLogsOneLogRecord())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(batch))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, arrowpb.StatusCode_OK, resp.StatusCode)
+
+	require.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, testdata.GenerateLogsOneLogRecord(), sink.AllLogs()[0])
+}
+
+func TestArrowBatchStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		consumer   consumer.Traces
+		batch      func(*arrowRecord.Producer) (*arrowpb.BatchArrowRecords, error)
+		statusCode arrowpb.StatusCode
+	}{
+		{
+			name:     "permanent consumer error",
+			consumer: consumertest.NewErr(consumererror.NewPermanent(errors.New("invalid"))),
+			batch: func(producer *arrowRecord.Producer) (*arrowpb.BatchArrowRecords, error) {
+				return producer.BatchArrowRecordsFromTraces(testdata.GenerateTracesOneSpan())
+			},
+			statusCode: arrowpb.StatusCode_INVALID_ARGUMENT,
+		},
+		{
+			name:     "consumer error",
+			consumer: consumertest.NewErr(errors.New("unavailable")),
+			batch: func(producer *arrowRecord.Producer) (*arrowpb.BatchArrowRecords, error) {
+				return producer.BatchArrowRecordsFromTraces(testdata.GenerateTracesOneSpan())
+			},
+			statusCode: arrowpb.StatusCode_UNAVAILABLE,
+		},
+		{
+			name:     "invalid batch",
+			consumer: consumertest.NewNop(),
+			batch: func(*arrowRecord.Producer) (*arrowpb.BatchArrowRecords, error) {
+				return &arrowpb.BatchArrowRecords{
+					BatchId: 1,
+					ArrowPayloads: []*arrowpb.ArrowPayload{{
+						SchemaId: "0",
+						Type:     arrowpb.ArrowPayloadType_SPANS,
+						Record:   []byte("not arrow"),
+					}},
+				}, nil
+			},
+			statusCode: arrowpb.StatusCode_INVALID_ARGUMENT,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := startTestReceiver(t, testConsumers{traces: tt.consumer})
+			stream, err := arrowpb.NewArrowTracesServiceClient(conn).ArrowTraces(context.Background())
+			require.NoError(t, err)
+			producer := arrowRecord.NewProducer()
+			defer func() { assert.NoError(t, producer.Close()) }()
+
+			batch, err := tt.batch(producer)
+			require.NoError(t, err)
+			require.NoError(t, stream.Send(batch))
+			resp, err := stream.Recv()
+			require.NoError(t, err)
+			assert.Equal(t, batch.BatchId, resp.BatchId)
+			assert.Equal(t, tt.statusCode, resp.StatusCode)
+			assert.NotEmpty(t, resp.StatusMessage)
+		})
+	}
+}
+
+func TestArrowUnimplementedSignal(t *testing.T) {
+	// The exporters fall back to OTLP when the Arrow stream of their signal is not implemented.
+	conn := startTestReceiver(t, testConsumers{traces: consumertest.NewNop()})
+
+	stream, err := arrowpb.NewArrowLogsServiceClient(conn).ArrowLogs(context.Background())
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestOTLPTraces(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	conn := startTestReceiver(t, testConsumers{traces: sink})
+
+	td := testdata.GenerateTracesOneSpan()
+	_, err := ptraceotlp.NewGRPCClient(conn).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(td))
+	require.NoError(t, err)
+
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, td, sink.AllTraces()[0])
+}
+
+func TestDecodeMemoryLimit(t *testing.T) {
+	_, err := decode(func(*arrowpb.BatchArrowRecords) ([]plog.Logs, error) {
+		panic(arrowCommon.LimitError{Request: 1 << 20, Limit: 1 << 10})
+	}, &arrowpb.BatchArrowRecords{})
+	require.ErrorIs(t, err, errMemoryLimit)
+	assert.Equal(t, arrowpb.StatusCode_RESOURCE_EXHAUSTED, newBatchStatus(1, err).StatusCode)
+
+	assert.Panics(t, func() {
+		_, _ = decode(func(*arrowpb.BatchArrowRecords) ([]ptrace.Traces, error) {
+			panic("unexpected")
+		}, &arrowpb.BatchArrowRecords{})
+	})
+}
+
+func TestStartNoConsumers(t *testing.T) {
+	r, err := newOTelArrowReceiver(createDefaultConfig().(*Config), receivertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	assert.EqualError(t, r.Start(context.Background(), componenttest.NewNopHost()), "cannot start receiver: no consumers were specified")
+}
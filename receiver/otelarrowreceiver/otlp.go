@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelarrowreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otelarrowreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+const dataFormatProtobuf = "protobuf"
+
+// tracesReceiver receives the spans of the exporters which do not support OTel-Arrow.
+type tracesReceiver struct {
+	ptraceotlp.UnimplementedGRPCServer
+	nextConsumer consumer.Traces
+	obsrep       *receiverhelper.ObsReport
+}
+
+// Export implements the service Export traces func.
+func (r *tracesReceiver) Export(ctx context.Context, req ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	td := req.Traces()
+	numSpans := td.SpanCount()
+	if numSpans == 0 {
+		return ptraceotlp.NewExportResponse(), nil
+	}
+
+	ctx = r.obsrep.StartTracesOp(ctx)
+	err := r.nextConsumer.ConsumeTraces(ctx, td)
+	r.obsrep.EndTracesOp(ctx, dataFormatProtobuf, numSpans, err)
+
+	return ptraceotlp.NewExportResponse(), err
+}
+
+// metricsReceiver receives the metrics of the exporters which do not support OTel-Arrow.
+type metricsReceiver struct {
+	pmetricotlp.UnimplementedGRPCServer
+	nextConsumer consumer.Metrics
+	obsrep       *receiverhelper.ObsReport
+}
+
+// Export implements the service Export metrics func.
+func (r *metricsReceiver) Export(ctx context.Context, req pmetricotlp.ExportRequest) (pmetricotlp.ExportResponse, error) {
+	md := req.Metrics()
+	dataPointCount := md.DataPointCount()
+	if dataPointCount == 0 {
+		return pmetricotlp.NewExportResponse(), nil
+	}
+
+	ctx = r.obsrep.StartMetricsOp(ctx)
+	err := r.nextConsumer.ConsumeMetrics(ctx, md)
+	r.obsrep.EndMetricsOp(ctx, dataFormatProtobuf, dataPointCount, err)
+
+	return pmetricotlp.NewExportResponse(), err
+}
+
+// logsReceiver receives the logs of the exporters which do not support OTel-Arrow.
+type logsReceiver struct {
+	plogotlp.UnimplementedGRPCServer
+	nextConsumer consumer.Logs
+	obsrep       *receiverhelper.ObsReport
+}
+
+// Export implements the service Export logs func.
+func (r *logsReceiver) Export(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	ld := req.Logs()
+	numRecords := ld.LogRecordCount()
+	if numRecords == 0 {
+		return plogotlp.NewExportResponse(), nil
+	}
+
+	ctx = r.obsrep.StartLogsOp(ctx)
+	err := r.nextConsumer.ConsumeLogs(ctx, ld)
+	r.obsrep.EndLogsOp(ctx, dataFormatProtobuf, numRecords, err)
+
+	return plogotlp.NewExportResponse(), err
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/value"
 	"github.com/prometheus/prometheus/scrape"
@@ -50,6 +51,8 @@ type metricGroup struct {
 	created      float64
 	value        float64
 	complexValue []*dataPoint
+	hValue       *histogram.Histogram
+	fhValue      *histogram.FloatHistogram
 	exemplars    pmetric.ExemplarSlice
 }
 
@@ -156,6 +159,31 @@ func (mg *metricGroup) toDistributionPoint(dest pmetric.HistogramDataPointSlice)
 	mg.setExemplars(point.Exemplars())
 }
 
+func (mg *metricGroup) toExponentialHistogramDataPoint(dest pmetric.ExponentialHistogramDataPointSlice) {
+	if mg.hValue == nil && mg.fhValue == nil {
+		return
+	}
+
+	point := dest.AppendEmpty()
+	if mg.fhValue != nil {
+		convertFloatHistogram(mg.fhValue, point)
+	} else {
+		convertHistogram(mg.hValue, point)
+	}
+
+	// The timestamp MUST be in retrieved from milliseconds and converted to nanoseconds.
+	tsNanos := timestampFromMs(mg.ts)
+	if mg.created != 0 {
+		point.SetStartTimestamp(timestampFromFloat64(mg.created))
+	} else {
+		// metrics_adjuster adjusts the startTimestamp to the initial scrape timestamp
+		point.SetStartTimestamp(tsNanos)
+	}
+	point.SetTimestamp(tsNanos)
+	populateAttributes(pmetric.MetricTypeExponentialHistogram, mg.ls, point.Attributes())
+	mg.setExemplars(point.Exemplars())
+}
+
 func (mg *metricGroup) setExemplars(exemplars pmetric.ExemplarSlice) {
 	if mg == nil {
 		return
@@ -311,6 +339,19 @@ func (mf *metricFamily) addSeries(seriesRef uint64, metricName string, ls labels
 	return nil
 }
 
+func (mf *metricFamily) addExponentialHistogramSeries(seriesRef uint64, metricName string, ls labels.Labels, t int64, h *histogram.Histogram, fh *histogram.FloatHistogram) error {
+	mg := mf.loadMetricGroupOrCreate(seriesRef, ls, t)
+	if mg.ts != t {
+		return fmt.Errorf("inconsistent timestamps on metric points for metric %v", metricName)
+	}
+	if h == nil && fh == nil {
+		return fmt.Errorf("no histogram provided for metric %v", metricName)
+	}
+	mg.hValue = h
+	mg.fhValue = fh
+	return nil
+}
+
 func (mf *metricFamily) appendMetric(metrics pmetric.MetricSlice, trimSuffixes bool) {
 	metric := pmetric.NewMetric()
 	// Trims type and unit suffixes from metric name
@@ -352,7 +393,16 @@ func (mf *metricFamily) appendMetric(metrics pmetric.MetricSlice, trimSuffixes b
 		}
 		pointCount = sdpL.Len()
 
-	case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge, pmetric.MetricTypeExponentialHistogram:
+	case pmetric.MetricTypeExponentialHistogram:
+		histogram := metric.SetEmptyExponentialHistogram()
+		histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		hdpL := histogram.DataPoints()
+		for _, mg := range mf.groupOrders {
+			mg.toExponentialHistogramDataPoint(hdpL)
+		}
+		pointCount = hdpL.Len()
+
+	case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge:
 		fallthrough
 	default: // Everything else should be set to a Gauge.
 		gauge := metric.SetEmptyGauge()
@@ -407,6 +457,77 @@ func convertExemplar(pe exemplar.Exemplar, e pmetric.Exemplar) {
 	}
 }
 
+// convertHistogram converts an integer native histogram, whose buckets are delta encoded.
+func convertHistogram(h *histogram.Histogram, dp pmetric.ExponentialHistogramDataPoint) {
+	// Prometheus schemas are the same as OTLP scales, the bucket boundaries being base^index
+	// with base = 2^(2^-schema), so the scale can be used as is.
+	dp.SetScale(h.Schema)
+	if value.IsStaleNaN(h.Sum) {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+	dp.SetCount(h.Count)
+	dp.SetSum(h.Sum)
+	dp.SetZeroCount(h.ZeroCount)
+	convertBuckets(h.PositiveSpans, h.PositiveBuckets, true, dp.Positive())
+	convertBuckets(h.NegativeSpans, h.NegativeBuckets, true, dp.Negative())
+}
+
+// convertFloatHistogram converts a float native histogram, whose buckets hold absolute counts.
+func convertFloatHistogram(fh *histogram.FloatHistogram, dp pmetric.ExponentialHistogramDataPoint) {
+	dp.SetScale(fh.Schema)
+	if value.IsStaleNaN(fh.Sum) {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+	dp.SetCount(uint64(fh.Count))
+	dp.SetSum(fh.Sum)
+	dp.SetZeroCount(uint64(fh.ZeroCount))
+	convertBuckets(fh.PositiveSpans, fh.PositiveBuckets, false, dp.Positive())
+	convertBuckets(fh.NegativeSpans, fh.NegativeBuckets, false, dp.Negative())
+}
+
+// convertBuckets expands the sparse native histogram buckets into the dense OTLP buckets,
+// filling the gaps between the spans with empty buckets.
+func convertBuckets[IBC histogram.InternalBucketCount](spans []histogram.Span, buckets []IBC, deltas bool, dest pmetric.ExponentialHistogramDataPointBuckets) {
+	if len(spans) == 0 {
+		return
+	}
+
+	size := 0
+	for i, span := range spans {
+		if i > 0 {
+			size += int(span.Offset)
+		}
+		size += int(span.Length)
+	}
+
+	// A Prometheus bucket with index i covers (base^(i-1), base^i]
+	// while an OTLP bucket with index i covers (base^i, base^(i+1)].
+	dest.SetOffset(spans[0].Offset - 1)
+
+	counts := make([]uint64, 0, size)
+	var count IBC
+	bucketIdx := 0
+	for i, span := range spans {
+		if i > 0 {
+			for j := int32(0); j < span.Offset; j++ {
+				counts = append(counts, 0)
+			}
+		}
+		for j := uint32(0); j < span.Length && bucketIdx < len(buckets); j++ {
+			if deltas {
+				count += buckets[bucketIdx]
+			} else {
+				count = buckets[bucketIdx]
+			}
+			counts = append(counts, uint64(count))
+			bucketIdx++
+		}
+	}
+	dest.BucketCounts().FromRaw(counts)
+}
+
 /*
 	decodeAndCopyToLowerBytes copies src to dst on lower bytes instead of higher
 
@@ -30,6 +30,7 @@ func TestStartTimeMetricMatch(t *testing.T) {
 			inputs: metrics(
 				sumMetric("test_sum_metric", doublePoint(nil, startTime, currentTime, 16)),
 				histogramMetric("test_histogram_metric", histogramPoint(nil, startTime, currentTime, []float64{1, 2}, []uint64{2, 3, 4})),
+				exponentialHistogramMetric("test_exponential_histogram_metric", exponentialHistogramPoint(nil, startTime, currentTime, 3, 1, 0, []uint64{1, 2})),
 				summaryMetric("test_summary_metric", summaryPoint(nil, startTime, currentTime, 10, 100, []float64{10, 50, 90}, []float64{9, 15, 48})),
 				sumMetric("example_process_start_time_seconds", doublePoint(nil, startTime, currentTime, matchBuilderStartTime)),
 				sumMetric("process_start_time_seconds", doublePoint(nil, startTime, currentTime, matchBuilderStartTime+1)),
@@ -42,6 +43,7 @@ func TestStartTimeMetricMatch(t *testing.T) {
 			inputs: metrics(
 				sumMetric("test_sum_metric", doublePoint(nil, startTime, currentTime, 16)),
 				histogramMetric("test_histogram_metric", histogramPoint(nil, startTime, currentTime, []float64{1, 2}, []uint64{2, 3, 4})),
+				exponentialHistogramMetric("test_exponential_histogram_metric", exponentialHistogramPoint(nil, startTime, currentTime, 3, 1, 0, []uint64{1, 2})),
 				summaryMetric("test_summary_metric", summaryPoint(nil, startTime, currentTime, 10, 100, []float64{10, 50, 90}, []float64{9, 15, 48})),
 				sumMetric("example_process_start_time_seconds", doublePoint(nil, startTime, currentTime, matchBuilderStartTime)),
 				sumMetric("process_start_time_seconds", doublePoint(nil, startTime, currentTime, matchBuilderStartTime+1)),
@@ -53,6 +55,7 @@ func TestStartTimeMetricMatch(t *testing.T) {
 			inputs: metrics(
 				sumMetric("test_sum_metric", doublePoint(nil, startTime, currentTime, 16)),
 				histogramMetric("test_histogram_metric", histogramPoint(nil, startTime, currentTime, []float64{1, 2}, []uint64{2, 3, 4})),
+				exponentialHistogramMetric("test_exponential_histogram_metric", exponentialHistogramPoint(nil, startTime, currentTime, 3, 1, 0, []uint64{1, 2})),
 				summaryMetric("test_summary_metric", summaryPoint(nil, startTime, currentTime, 10, 100, []float64{10, 50, 90}, []float64{9, 15, 48})),
 				gaugeMetric("example_process_start_time_seconds", doublePoint(nil, startTime, currentTime, matchBuilderStartTime)),
 				gaugeMetric("process_start_time_seconds", doublePoint(nil, startTime, currentTime, matchBuilderStartTime+1)),
@@ -65,6 +68,7 @@ func TestStartTimeMetricMatch(t *testing.T) {
 			inputs: metrics(
 				sumMetric("test_sum_metric", doublePoint(nil, startTime, currentTime, 16)),
 				histogramMetric("test_histogram_metric", histogramPoint(nil, startTime, currentTime, []float64{1, 2}, []uint64{2, 3, 4})),
+				exponentialHistogramMetric("test_exponential_histogram_metric", exponentialHistogramPoint(nil, startTime, currentTime, 3, 1, 0, []uint64{1, 2})),
 				summaryMetric("test_summary_metric", summaryPoint(nil, startTime, currentTime, 10, 100, []float64{10, 50, 90}, []float64{9, 15, 48})),
 				gaugeMetric("example_process_start_time_seconds", doublePoint(nil, startTime, currentTime, matchBuilderStartTime)),
 				gaugeMetric("process_start_time_seconds", doublePoint(nil, startTime, currentTime, matchBuilderStartTime+1)),
@@ -139,7 +143,12 @@ func TestStartTimeMetricMatch(t *testing.T) {
 							for l := 0; l < dps.Len(); l++ {
 								assert.Equal(t, tt.expectedStartTime, dps.At(l).StartTimestamp())
 							}
-						case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge, pmetric.MetricTypeExponentialHistogram:
+						case pmetric.MetricTypeExponentialHistogram:
+							dps := metric.ExponentialHistogram().DataPoints()
+							for l := 0; l < dps.Len(); l++ {
+								assert.Equal(t, tt.expectedStartTime, dps.At(l).StartTimestamp())
+							}
+						case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge:
 						}
 					}
 				}
@@ -361,12 +361,14 @@ func isExtraScrapeMetrics(m pmetric.Metric) bool {
 type metricTypeComparator func(*testing.T, pmetric.Metric)
 type numberPointComparator func(*testing.T, pmetric.NumberDataPoint)
 type histogramPointComparator func(*testing.T, pmetric.HistogramDataPoint)
+type exponentialHistogramComparator func(*testing.T, pmetric.ExponentialHistogramDataPoint)
 type summaryPointComparator func(*testing.T, pmetric.SummaryDataPoint)
 
 type dataPointExpectation struct {
-	numberPointComparator    []numberPointComparator
-	histogramPointComparator []histogramPointComparator
-	summaryPointComparator   []summaryPointComparator
+	numberPointComparator               []numberPointComparator
+	histogramPointComparator            []histogramPointComparator
+	exponentialHistogramPointComparator []exponentialHistogramComparator
+	summaryPointComparator              []summaryPointComparator
 }
 
 type testExpectation func(*testing.T, pmetric.ResourceMetrics)
@@ -421,12 +423,17 @@ func assertMetricPresent(name string, metricTypeExpectations metricTypeComparato
 						require.Equal(t, m.Histogram().DataPoints().Len(), len(dataPointExpectations), "Expected number of data-points in Histogram metric '%s' does not match to testdata", name)
 						hpc(t, m.Histogram().DataPoints().At(i))
 					}
+				case pmetric.MetricTypeExponentialHistogram:
+					for _, ehpc := range de.exponentialHistogramPointComparator {
+						require.Equal(t, m.ExponentialHistogram().DataPoints().Len(), len(dataPointExpectations), "Expected number of data-points in Exponential Histogram metric '%s' does not match to testdata", name)
+						ehpc(t, m.ExponentialHistogram().DataPoints().At(i))
+					}
 				case pmetric.MetricTypeSummary:
 					for _, spc := range de.summaryPointComparator {
 						require.Equal(t, m.Summary().DataPoints().Len(), len(dataPointExpectations), "Expected number of data-points in Summary metric '%s' does not match to testdata", name)
 						spc(t, m.Summary().DataPoints().At(i))
 					}
-				case pmetric.MetricTypeEmpty, pmetric.MetricTypeExponentialHistogram:
+				case pmetric.MetricTypeEmpty:
 				}
 			}
 		}
@@ -586,6 +593,19 @@ func compareHistogram(count uint64, sum float64, upperBounds []float64, buckets
 	}
 }
 
+func compareExponentialHistogram(scale int32, count, zeroCount uint64, sum float64, negativeOffset int32, negativeBuckets []uint64, positiveOffset int32, positiveBuckets []uint64) exponentialHistogramComparator {
+	return func(t *testing.T, exponentialHistogramDataPoint pmetric.ExponentialHistogramDataPoint) {
+		assert.Equal(t, scale, exponentialHistogramDataPoint.Scale(), "Exponential Histogram scale value does not match")
+		assert.Equal(t, count, exponentialHistogramDataPoint.Count(), "Exponential Histogram count value does not match")
+		assert.Equal(t, zeroCount, exponentialHistogramDataPoint.ZeroCount(), "Exponential Histogram zero count value does not match")
+		assert.Equal(t, sum, exponentialHistogramDataPoint.Sum(), "Exponential Histogram sum value does not match")
+		assert.Equal(t, negativeOffset, exponentialHistogramDataPoint.Negative().Offset(), "Exponential Histogram negative offset value does not match")
+		assert.Equal(t, negativeBuckets, exponentialHistogramDataPoint.Negative().BucketCounts().AsRaw(), "Exponential Histogram negative bucket count values do not match")
+		assert.Equal(t, positiveOffset, exponentialHistogramDataPoint.Positive().Offset(), "Exponential Histogram positive offset value does not match")
+		assert.Equal(t, positiveBuckets, exponentialHistogramDataPoint.Positive().BucketCounts().AsRaw(), "Exponential Histogram positive bucket count values do not match")
+	}
+}
+
 func compareSummary(count uint64, sum float64, quantiles [][]float64) summaryPointComparator {
 	return func(t *testing.T, summaryDataPoint pmetric.SummaryDataPoint) {
 		assert.Equal(t, count, summaryDataPoint.Count(), "Summary count value does not match")
@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	dto "github.com/prometheus/prometheus/prompb/io/prometheus/client"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
@@ -156,3 +158,64 @@ func TestScrapeViaProtobuf(t *testing.T) {
 		c.EnableProtobufNegotiation = true
 	})
 }
+
+func TestNativeHistogram(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(enableNativeHistogramsGate.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(enableNativeHistogramsGate.ID(), false))
+	}()
+
+	mf := &dto.MetricFamily{
+		Name: "test_native_histogram",
+		Type: dto.MetricType_HISTOGRAM,
+		Metric: []dto.Metric{
+			{
+				Histogram: &dto.Histogram{
+					SampleCount:   1213,
+					SampleSum:     456,
+					Schema:        -1,
+					ZeroThreshold: 0.001,
+					ZeroCount:     2,
+					NegativeSpan: []dto.BucketSpan{
+						{Offset: 0, Length: 1},
+						{Offset: 1, Length: 1},
+					},
+					NegativeDelta: []int64{1, 1},
+					PositiveSpan: []dto.BucketSpan{
+						{Offset: -2, Length: 4},
+					},
+					PositiveDelta: []int64{1, 2, -1, 1200},
+				},
+			},
+		},
+	}
+	buffer := prometheusMetricFamilyToProtoBuf(t, nil, mf)
+
+	expectations := []testExpectation{
+		assertMetricPresent(
+			"test_native_histogram",
+			compareMetricType(pmetric.MetricTypeExponentialHistogram),
+			compareMetricUnit(""),
+			[]dataPointExpectation{{
+				exponentialHistogramPointComparator: []exponentialHistogramComparator{
+					compareExponentialHistogram(-1, 1213, 2, 456, -1, []uint64{1, 0, 2}, -3, []uint64{1, 3, 2, 1202}),
+				},
+			}},
+		),
+	}
+
+	targets := []*testData{
+		{
+			name: "target1",
+			pages: []mockPrometheusResponse{
+				{code: 200, useProtoBuf: true, buf: buffer.Bytes()},
+			},
+			validateFunc: func(t *testing.T, td *testData, result []pmetric.ResourceMetrics) {
+				verifyNumValidScrapeResults(t, td, result)
+				doCompare(t, "target1", td.attributes, result[0], expectations)
+			},
+		},
+	}
+
+	testComponent(t, targets, nil)
+}
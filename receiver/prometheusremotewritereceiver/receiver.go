@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+	"go.uber.org/zap"
+)
+
+const (
+	writePath  = "/api/v1/write"
+	dataFormat = "prometheus_remote_write"
+
+	// maxRequestSize is the maximum size of the compressed and of the decompressed body of a request,
+	// as the snappy block format allows tiny bodies to declare a huge decompressed size.
+	maxRequestSize = 32 << 20
+)
+
+type metricsReceiver struct {
+	nextConsumer       consumer.Metrics
+	httpServerSettings *confighttp.HTTPServerSettings
+	translator         *translator
+
+	server *http.Server
+	wg     sync.WaitGroup
+
+	obsrecv  *receiverhelper.ObsReport
+	settings component.TelemetrySettings
+}
+
+func newMetricsReceiver(config *Config, settings receiver.CreateSettings, nextConsumer consumer.Metrics) (*metricsReceiver, error) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             settings.ID,
+		Transport:              "http",
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &metricsReceiver{
+		nextConsumer:       nextConsumer,
+		httpServerSettings: &config.HTTPServerSettings,
+		translator:         newTranslator(settings.Logger, settings.BuildInfo),
+		obsrecv:            obsrecv,
+		settings:           settings.TelemetrySettings,
+	}, nil
+}
+
+func (r *metricsReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := r.httpServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.httpServerSettings.Endpoint, err)
+	}
+
+	router := http.NewServeMux()
+	router.HandleFunc(writePath, r.handleWrite)
+
+	// The remote write protocol uses the snappy block format, which is decoded by the handler,
+	// the snappy decoder only prevents the server from rejecting the encoding.
+	r.server, err = r.httpServerSettings.ToServer(host, r.settings, router, confighttp.WithDecoder("snappy", noopDecoder))
+	if err != nil {
+		return err
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if errHTTP := r.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+
+	return nil
+}
+
+func (r *metricsReceiver) Shutdown(_ context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	if err := r.server.Close(); err != nil {
+		return err
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request) {
+	defer func() {
+		_ = req.Body.Close()
+	}()
+
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := r.obsrecv.StartMetricsOp(req.Context())
+
+	writeReq, err := decodeWriteRequest(req.Body)
+	if err != nil {
+		r.obsrecv.EndMetricsOp(ctx, dataFormat, 0, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	md, err := r.translator.translate(writeReq)
+	if err != nil {
+		r.obsrecv.EndMetricsOp(ctx, dataFormat, 0, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	numPoints := md.DataPointCount()
+	if numPoints == 0 {
+		// Requests only carrying metadata have nothing to forward.
+		r.obsrecv.EndMetricsOp(ctx, dataFormat, 0, nil)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	err = r.nextConsumer.ConsumeMetrics(ctx, md)
+	r.obsrecv.EndMetricsOp(ctx, dataFormat, numPoints, err)
+	if err != nil {
+		r.settings.Logger.Debug("Failed to pass metrics to next consumer", zap.Error(err))
+		// Prometheus retries requests failing with a 5xx status code, but not the ones with a 4xx.
+		if consumererror.IsPermanent(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func noopDecoder(io.ReadCloser) (io.ReadCloser, error) {
+	return nil, nil
+}
+
+// decodeWriteRequest decompresses and unmarshals a snappy compressed remote write request.
+func decodeWriteRequest(body io.Reader) (*prompb.WriteRequest, error) {
+	compressed, err := io.ReadAll(io.LimitReader(body, maxRequestSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	if len(compressed) > maxRequestSize {
+		return nil, fmt.Errorf("request body is larger than %d bytes", maxRequestSize)
+	}
+	size, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress request body: %w", err)
+	}
+	if size > maxRequestSize {
+		return nil, fmt.Errorf("decompressed request body of %d bytes is larger than %d bytes", size, maxRequestSize)
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress request body: %w", err)
+	}
+	var writeReq prompb.WriteRequest
+	if err = writeReq.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal write request: %w", err)
+	}
+	return &writeReq, nil
+}
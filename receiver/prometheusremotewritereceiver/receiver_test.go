@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func startReceiver(t *testing.T, nextConsumer consumer.Metrics) string {
+	addr := testutil.GetAvailableLocalAddress(t)
+	config := &Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: addr,
+		},
+	}
+
+	receiver, err := NewFactory().CreateMetricsReceiver(context.Background(), receivertest.NewNopCreateSettings(), config, nextConsumer)
+	require.NoError(t, err)
+	require.NotNil(t, receiver)
+
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, receiver.Shutdown(context.Background())) })
+	return "http://" + addr + writePath
+}
+
+func encodeWriteRequest(t *testing.T, req *prompb.WriteRequest) []byte {
+	data, err := req.Marshal()
+	require.NoError(t, err)
+	return snappy.Encode(nil, data)
+}
+
+func postWriteRequest(t *testing.T, url string, body []byte, encoding string) int {
+	httpReq, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	httpReq.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if encoding != "" {
+		httpReq.Header.Set("Content-Encoding", encoding)
+	}
+	resp, err := http.DefaultClient.Do(httpReq)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp.StatusCode
+}
+
+func TestWrite(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	writeReq := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(1, "__name__", "up", "job", "node", "instance", "host1:9100"),
+			series(5, "__name__", "http_requests_total", "job", "node", "instance", "host1:9100", "code", "200"),
+		},
+	}
+
+	t.Run("snappy", func(t *testing.T) {
+		sink.Reset()
+		assert.Equal(t, http.StatusNoContent, postWriteRequest(t, url, encodeWriteRequest(t, writeReq), "snappy"))
+		require.Len(t, sink.AllMetrics(), 1)
+		assert.Equal(t, 2, sink.AllMetrics()[0].DataPointCount())
+	})
+
+	t.Run("no content encoding", func(t *testing.T) {
+		sink.Reset()
+		assert.Equal(t, http.StatusNoContent, postWriteRequest(t, url, encodeWriteRequest(t, writeReq), ""))
+		assert.Len(t, sink.AllMetrics(), 1)
+	})
+
+	t.Run("metadata only", func(t *testing.T) {
+		sink.Reset()
+		body := encodeWriteRequest(t, &prompb.WriteRequest{
+			Metadata: []prompb.MetricMetadata{{MetricFamilyName: "up", Type: prompb.MetricMetadata_GAUGE}},
+		})
+		assert.Equal(t, http.StatusNoContent, postWriteRequest(t, url, body, "snappy"))
+		assert.Empty(t, sink.AllMetrics())
+	})
+
+	t.Run("unsupported encoding", func(t *testing.T) {
+		sink.Reset()
+		assert.Equal(t, http.StatusBadRequest, postWriteRequest(t, url, encodeWriteRequest(t, writeReq), "br"))
+		assert.Empty(t, sink.AllMetrics())
+	})
+
+	t.Run("not compressed", func(t *testing.T) {
+		sink.Reset()
+		data, err := writeReq.Marshal()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, postWriteRequest(t, url, data, "snappy"))
+		assert.Empty(t, sink.AllMetrics())
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		sink.Reset()
+		// The snappy block starts with the size of the decompressed data.
+		body := binary.AppendUvarint(nil, maxRequestSize+1)
+		body = append(body, 0)
+		assert.Equal(t, http.StatusBadRequest, postWriteRequest(t, url, body, "snappy"))
+		assert.Equal(t, http.StatusBadRequest, postWriteRequest(t, url, make([]byte, maxRequestSize+1), "snappy"))
+		assert.Empty(t, sink.AllMetrics())
+	})
+
+	t.Run("invalid series", func(t *testing.T) {
+		sink.Reset()
+		body := encodeWriteRequest(t, &prompb.WriteRequest{
+			Timeseries: []prompb.TimeSeries{series(1, "job", "node")},
+		})
+		assert.Equal(t, http.StatusBadRequest, postWriteRequest(t, url, body, "snappy"))
+		assert.Empty(t, sink.AllMetrics())
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		resp, err := http.Get(url)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+}
+
+func TestWriteConsumerError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{
+			name:           "retryable error",
+			err:            errors.New("temporary failure"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "permanent error",
+			err:            consumererror.NewPermanent(errors.New("invalid data")),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := startReceiver(t, consumertest.NewErr(tt.err))
+			body := encodeWriteRequest(t, &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{series(1, "__name__", "up", "job", "node")},
+			})
+			assert.Equal(t, tt.expectedStatus, postWriteRequest(t, url, body, "snappy"))
+		})
+	}
+}
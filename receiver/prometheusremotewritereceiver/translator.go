@@ -0,0 +1,790 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver/internal/metadata"
+)
+
+const (
+	targetMetricName = "target_info"
+	scopeName        = "otelcol/" + metadata.Type + "receiver"
+
+	metricSuffixBucket = "_bucket"
+	metricSuffixSum    = "_sum"
+	metricSuffixCount  = "_count"
+	metricSuffixTotal  = "_total"
+
+	// labelSeparator separates the labels of a series signature, it is not a valid UTF-8 byte.
+	labelSeparator = "\xff"
+)
+
+var (
+	errMetricNameNotFound = errors.New("metric name not found from labels")
+	errEmptyLeLabel       = errors.New("'le' label on histogram metric is missing or empty")
+	errEmptyQuantileLabel = errors.New("'quantile' label on summary metric is missing or empty")
+)
+
+// translator converts remote write requests to OTLP metrics.
+// It keeps the metadata of the metric families and the target_info labels of the
+// resources across requests since Prometheus sends them separately from the samples.
+type translator struct {
+	logger    *zap.Logger
+	buildInfo component.BuildInfo
+
+	mu         sync.RWMutex
+	metadata   map[string]prompb.MetricMetadata
+	targetInfo map[resourceKey][]prompb.Label
+}
+
+func newTranslator(logger *zap.Logger, buildInfo component.BuildInfo) *translator {
+	return &translator{
+		logger:     logger,
+		buildInfo:  buildInfo,
+		metadata:   make(map[string]prompb.MetricMetadata),
+		targetInfo: make(map[resourceKey][]prompb.Label),
+	}
+}
+
+func (t *translator) updateMetadata(mds []prompb.MetricMetadata) {
+	if len(mds) == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, md := range mds {
+		t.metadata[md.MetricFamilyName] = md
+	}
+}
+
+func (t *translator) getMetadata(name string) (prompb.MetricMetadata, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	md, ok := t.metadata[name]
+	return md, ok
+}
+
+func (t *translator) updateTargetInfo(key resourceKey, ls []prompb.Label) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.targetInfo[key] = ls
+}
+
+func (t *translator) getTargetInfo(key resourceKey) ([]prompb.Label, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	ls, ok := t.targetInfo[key]
+	return ls, ok
+}
+
+// translate converts the series of the request to OTLP metrics, grouping them
+// in a resource per job and instance.
+func (t *translator) translate(req *prompb.WriteRequest) (pmetric.Metrics, error) {
+	t.updateMetadata(req.Metadata)
+
+	b := newMetricsBuilder()
+	// The _sum and _count series without metadata can only be attached to their family
+	// once it is known whether it is a histogram or a summary, hence they are added last.
+	var deferred []*prompb.TimeSeries
+	for i := range req.Timeseries {
+		ts := &req.Timeseries[i]
+		name, err := seriesName(ts.Labels)
+		if err != nil {
+			return pmetric.Metrics{}, err
+		}
+		rb := b.resource(ts.Labels)
+
+		if name == targetMetricName {
+			t.updateTargetInfo(rb.key, ts.Labels)
+			continue
+		}
+
+		if len(ts.Histograms) > 0 {
+			md, _ := t.getMetadata(name)
+			mf := rb.family(name, pmetric.MetricTypeExponentialHistogram, true, md)
+			if mf.mtype != pmetric.MetricTypeExponentialHistogram {
+				t.logger.Debug("Dropping native histogram of a family with a different type", zap.String("metric_name", name))
+				continue
+			}
+			t.addNativeHistograms(mf, name, ts)
+			continue
+		}
+
+		if len(ts.Samples) == 0 {
+			continue
+		}
+
+		familyName, mtype, isMonotonic, md, ok := t.familyOf(name, ts.Labels)
+		if !ok {
+			deferred = append(deferred, ts)
+			continue
+		}
+		if err = rb.family(familyName, mtype, isMonotonic, md).addSeries(name, ts); err != nil {
+			return pmetric.Metrics{}, fmt.Errorf("invalid series %s: %w", name, err)
+		}
+	}
+
+	for _, ts := range deferred {
+		name, _ := seriesName(ts.Labels)
+		rb := b.resource(ts.Labels)
+		base := trimSuffix(name)
+		if mf, ok := rb.families[base]; ok && (mf.mtype == pmetric.MetricTypeHistogram || mf.mtype == pmetric.MetricTypeSummary) {
+			if err := mf.addSeries(name, ts); err != nil {
+				return pmetric.Metrics{}, fmt.Errorf("invalid series %s: %w", name, err)
+			}
+			continue
+		}
+		if err := rb.family(name, pmetric.MetricTypeGauge, false, prompb.MetricMetadata{}).addSeries(name, ts); err != nil {
+			return pmetric.Metrics{}, fmt.Errorf("invalid series %s: %w", name, err)
+		}
+	}
+
+	// The series of a resource may be sent in other requests than its target_info series.
+	for _, rb := range b.resourceOrders {
+		if ls, ok := t.getTargetInfo(rb.key); ok {
+			rb.addTargetInfo(ls)
+		}
+	}
+	return b.build(t.buildInfo), nil
+}
+
+// familyOf returns the name, type and metadata of the family the series belongs to.
+// It returns false if the series is a _sum or _count series whose family is unknown.
+func (t *translator) familyOf(name string, ls []prompb.Label) (string, pmetric.MetricType, bool, prompb.MetricMetadata, bool) {
+	if md, ok := t.getMetadata(name); ok {
+		mtype, isMonotonic := convToMetricType(md.Type)
+		return name, mtype, isMonotonic, md, true
+	}
+
+	base := trimSuffix(name)
+	if base != name {
+		if md, ok := t.getMetadata(base); ok {
+			mtype, isMonotonic := convToMetricType(md.Type)
+			switch {
+			case mtype == pmetric.MetricTypeSum && strings.HasSuffix(name, metricSuffixTotal):
+				// Counters keep the name of the series, so that it doesn't depend on the metadata being available.
+				return name, mtype, isMonotonic, md, true
+			case (mtype == pmetric.MetricTypeHistogram || mtype == pmetric.MetricTypeSummary) && !strings.HasSuffix(name, metricSuffixTotal):
+				return base, mtype, isMonotonic, md, true
+			}
+		}
+	}
+
+	// The metadata is not known yet, the type is inferred from the naming conventions.
+	switch {
+	case strings.HasSuffix(name, metricSuffixBucket) && hasLabel(ls, model.BucketLabel):
+		return base, pmetric.MetricTypeHistogram, true, prompb.MetricMetadata{}, true
+	case hasLabel(ls, model.QuantileLabel):
+		return name, pmetric.MetricTypeSummary, true, prompb.MetricMetadata{}, true
+	case strings.HasSuffix(name, metricSuffixSum) || strings.HasSuffix(name, metricSuffixCount):
+		return "", pmetric.MetricTypeEmpty, false, prompb.MetricMetadata{}, false
+	case strings.HasSuffix(name, metricSuffixTotal):
+		return name, pmetric.MetricTypeSum, true, prompb.MetricMetadata{}, true
+	default:
+		return name, pmetric.MetricTypeGauge, false, prompb.MetricMetadata{}, true
+	}
+}
+
+func (t *translator) addNativeHistograms(mf *metricFamily, name string, ts *prompb.TimeSeries) {
+	dps := mf.metric.ExponentialHistogram().DataPoints()
+	for _, h := range ts.Histograms {
+		if h.ResetHint == prompb.Histogram_GAUGE {
+			t.logger.Debug("Dropping unsupported gauge histogram datapoint", zap.String("metric_name", name))
+			continue
+		}
+		dp := dps.AppendEmpty()
+		convertHistogram(h, dp)
+		dp.SetTimestamp(timestampFromMs(h.Timestamp))
+		populateAttributes(ts.Labels, dp.Attributes())
+	}
+}
+
+// convToMetricType returns the data type and if it is monotonic.
+func convToMetricType(metricType prompb.MetricMetadata_MetricType) (pmetric.MetricType, bool) {
+	switch metricType {
+	case prompb.MetricMetadata_COUNTER:
+		return pmetric.MetricTypeSum, true
+	case prompb.MetricMetadata_HISTOGRAM:
+		return pmetric.MetricTypeHistogram, true
+	case prompb.MetricMetadata_SUMMARY:
+		return pmetric.MetricTypeSummary, true
+	case prompb.MetricMetadata_INFO, prompb.MetricMetadata_STATESET:
+		return pmetric.MetricTypeSum, false
+	case prompb.MetricMetadata_GAUGEHISTOGRAM:
+		// dropping support for gaugehistogram for now, as done by the prometheus receiver,
+		// their series are converted to gauges.
+		fallthrough
+	case prompb.MetricMetadata_GAUGE, prompb.MetricMetadata_UNKNOWN:
+		fallthrough
+	default:
+		return pmetric.MetricTypeGauge, false
+	}
+}
+
+// metricsBuilder groups the metric families per resource.
+type metricsBuilder struct {
+	resources      map[resourceKey]*resourceBuilder
+	resourceOrders []*resourceBuilder
+}
+
+type resourceKey struct {
+	job      string
+	instance string
+}
+
+type resourceBuilder struct {
+	key          resourceKey
+	resource     pcommon.Resource
+	families     map[string]*metricFamily
+	familyOrders []*metricFamily
+}
+
+func newMetricsBuilder() *metricsBuilder {
+	return &metricsBuilder{resources: make(map[resourceKey]*resourceBuilder)}
+}
+
+// resource returns the builder of the resource identified by the job and instance labels.
+func (b *metricsBuilder) resource(ls []prompb.Label) *resourceBuilder {
+	key := resourceKey{job: getLabel(ls, model.JobLabel), instance: getLabel(ls, model.InstanceLabel)}
+	rb, ok := b.resources[key]
+	if !ok {
+		rb = &resourceBuilder{
+			key:      key,
+			resource: createResource(key.job, key.instance),
+			families: make(map[string]*metricFamily),
+		}
+		b.resources[key] = rb
+		// maintaining data insertion order is helpful to generate stable/reproducible metric output
+		b.resourceOrders = append(b.resourceOrders, rb)
+	}
+	return rb
+}
+
+func (b *metricsBuilder) build(buildInfo component.BuildInfo) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, rb := range b.resourceOrders {
+		if len(rb.familyOrders) == 0 {
+			continue
+		}
+		rm := md.ResourceMetrics().AppendEmpty()
+		rb.resource.MoveTo(rm.Resource())
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName(scopeName)
+		sm.Scope().SetVersion(buildInfo.Version)
+		for _, mf := range rb.familyOrders {
+			mf.appendMetric(sm.Metrics())
+		}
+	}
+	return md
+}
+
+// createResource creates the resource from the job and instance labels,
+// following the same conventions as the prometheus receiver.
+func createResource(job, instance string) pcommon.Resource {
+	resource := pcommon.NewResource()
+	attrs := resource.Attributes()
+	if job != "" {
+		attrs.PutStr(conventions.AttributeServiceName, job)
+	}
+	if instance == "" {
+		return resource
+	}
+	host, port, err := net.SplitHostPort(instance)
+	if err != nil {
+		host = instance
+	}
+	if isDiscernibleHost(host) {
+		attrs.PutStr(conventions.AttributeNetHostName, host)
+	}
+	attrs.PutStr(conventions.AttributeServiceInstanceID, instance)
+	if port != "" {
+		attrs.PutStr(conventions.AttributeNetHostPort, port)
+	}
+	return resource
+}
+
+// isDiscernibleHost checks if a host can be used as a value for the 'host.name' key.
+// localhost-like hosts and unspecified (0.0.0.0) hosts are not discernible.
+func isDiscernibleHost(host string) bool {
+	ip := net.ParseIP(host)
+	if ip != nil {
+		return !ip.IsLoopback() && !ip.IsUnspecified()
+	}
+	return host != "localhost"
+}
+
+// addTargetInfo converts the labels of the target_info metric to resource attributes.
+func (rb *resourceBuilder) addTargetInfo(ls []prompb.Label) {
+	attrs := rb.resource.Attributes()
+	for _, l := range ls {
+		if l.Name == model.JobLabel || l.Name == model.InstanceLabel || l.Name == model.MetricNameLabel {
+			continue
+		}
+		attrs.PutStr(l.Name, l.Value)
+	}
+}
+
+// family returns the metric family with the given name, creating it if it doesn't exist yet.
+func (rb *resourceBuilder) family(name string, mtype pmetric.MetricType, isMonotonic bool, md prompb.MetricMetadata) *metricFamily {
+	mf, ok := rb.families[name]
+	if !ok {
+		mf = newMetricFamily(name, mtype, isMonotonic, md)
+		rb.families[name] = mf
+		rb.familyOrders = append(rb.familyOrders, mf)
+	}
+	return mf
+}
+
+// metricFamily accumulates the series of a metric family. Gauges, sums and native
+// histograms are converted as soon as they are added, while the series of classic
+// histograms and summaries are grouped before being converted.
+type metricFamily struct {
+	mtype       pmetric.MetricType
+	metric      pmetric.Metric
+	groups      map[groupKey]*metricGroup
+	groupOrders []*metricGroup
+}
+
+// groupKey identifies the data point of a classic histogram or summary.
+type groupKey struct {
+	signature string
+	ts        int64
+}
+
+type metricGroup struct {
+	ts           int64
+	ls           []prompb.Label
+	count        float64
+	hasCount     bool
+	sum          float64
+	hasSum       bool
+	complexValue []*dataPoint
+}
+
+type dataPoint struct {
+	value    float64
+	boundary float64
+}
+
+func newMetricFamily(name string, mtype pmetric.MetricType, isMonotonic bool, md prompb.MetricMetadata) *metricFamily {
+	metric := pmetric.NewMetric()
+	metric.SetName(name)
+	metric.SetDescription(md.Help)
+	metric.SetUnit(prometheus.UnitWordToUCUM(md.Unit))
+	switch mtype {
+	case pmetric.MetricTypeSum:
+		sum := metric.SetEmptySum()
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		sum.SetIsMonotonic(isMonotonic)
+	case pmetric.MetricTypeHistogram:
+		metric.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case pmetric.MetricTypeExponentialHistogram:
+		metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case pmetric.MetricTypeSummary:
+		metric.SetEmptySummary()
+	case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge:
+		fallthrough
+	default:
+		mtype = pmetric.MetricTypeGauge
+		metric.SetEmptyGauge()
+	}
+	return &metricFamily{
+		mtype:  mtype,
+		metric: metric,
+		groups: make(map[groupKey]*metricGroup),
+	}
+}
+
+func (mf *metricFamily) addSeries(name string, ts *prompb.TimeSeries) error {
+	switch mf.mtype {
+	case pmetric.MetricTypeHistogram, pmetric.MetricTypeSummary:
+		return mf.addComplexSeries(name, ts)
+	case pmetric.MetricTypeSum:
+		addNumberDataPoints(mf.metric.Sum().DataPoints(), ts)
+	case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge, pmetric.MetricTypeExponentialHistogram:
+		fallthrough
+	default:
+		addNumberDataPoints(mf.metric.Gauge().DataPoints(), ts)
+	}
+	return nil
+}
+
+func addNumberDataPoints(dest pmetric.NumberDataPointSlice, ts *prompb.TimeSeries) {
+	for _, s := range ts.Samples {
+		dp := dest.AppendEmpty()
+		dp.SetTimestamp(timestampFromMs(s.Timestamp))
+		if value.IsStaleNaN(s.Value) {
+			dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		} else {
+			dp.SetDoubleValue(s.Value)
+		}
+		populateAttributes(ts.Labels, dp.Attributes())
+	}
+}
+
+// addComplexSeries adds the samples of a bucket, quantile, _sum or _count series
+// to the data points of the histogram or summary they belong to.
+func (mf *metricFamily) addComplexSeries(name string, ts *prompb.TimeSeries) error {
+	var boundary float64
+	isBoundary := false
+	switch {
+	case strings.HasSuffix(name, metricSuffixSum), strings.HasSuffix(name, metricSuffixCount):
+	default:
+		var err error
+		if boundary, err = getBoundary(mf.mtype, ts.Labels); err != nil {
+			return err
+		}
+		isBoundary = true
+	}
+
+	signature := seriesSignature(ts.Labels)
+	for _, s := range ts.Samples {
+		key := groupKey{signature: signature, ts: s.Timestamp}
+		mg, ok := mf.groups[key]
+		if !ok {
+			mg = &metricGroup{ts: s.Timestamp, ls: ts.Labels}
+			mf.groups[key] = mg
+			mf.groupOrders = append(mf.groupOrders, mg)
+		}
+		switch {
+		case isBoundary:
+			mg.complexValue = append(mg.complexValue, &dataPoint{value: s.Value, boundary: boundary})
+		case strings.HasSuffix(name, metricSuffixSum):
+			mg.sum = s.Value
+			mg.hasSum = true
+		default:
+			mg.count = s.Value
+			mg.hasCount = true
+		}
+	}
+	return nil
+}
+
+func (mf *metricFamily) appendMetric(metrics pmetric.MetricSlice) {
+	switch mf.mtype {
+	case pmetric.MetricTypeHistogram:
+		dps := mf.metric.Histogram().DataPoints()
+		for _, mg := range mf.groupOrders {
+			mg.toDistributionPoint(dps)
+		}
+	case pmetric.MetricTypeSummary:
+		dps := mf.metric.Summary().DataPoints()
+		for _, mg := range mf.groupOrders {
+			mg.toSummaryPoint(dps)
+		}
+	case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge, pmetric.MetricTypeSum, pmetric.MetricTypeExponentialHistogram:
+	}
+
+	if dataPointCount(mf.metric) == 0 {
+		return
+	}
+	mf.metric.MoveTo(metrics.AppendEmpty())
+}
+
+func dataPointCount(metric pmetric.Metric) int {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		return metric.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return metric.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return metric.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return metric.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return metric.Summary().DataPoints().Len()
+	case pmetric.MetricTypeEmpty:
+	}
+	return 0
+}
+
+func (mg *metricGroup) sortPoints() {
+	sort.Slice(mg.complexValue, func(i, j int) bool {
+		return mg.complexValue[i].boundary < mg.complexValue[j].boundary
+	})
+}
+
+// toDistributionPoint converts the group to a histogram data point. The series of a histogram may be
+// sent in different requests, so the point is only created from its buckets, and its count is the
+// one of the +Inf bucket. The sum is only set if the _sum series is in the same request.
+func (mg *metricGroup) toDistributionPoint(dest pmetric.HistogramDataPointSlice) {
+	if len(mg.complexValue) == 0 {
+		return
+	}
+
+	mg.sortPoints()
+
+	bucketCount := len(mg.complexValue) + 1
+	count, hasCount := mg.count, mg.hasCount
+	// if the final bucket is +Inf, we ignore it, its value being the total count
+	if last := mg.complexValue[bucketCount-2]; last.boundary == math.Inf(1) {
+		bucketCount--
+		count, hasCount = last.value, true
+	}
+	if !hasCount {
+		return
+	}
+
+	// for OTLP the bounds won't include +inf
+	bounds := make([]float64, bucketCount-1)
+	bucketCounts := make([]uint64, bucketCount)
+	var adjustedCount float64
+
+	pointIsStale := value.IsStaleNaN(mg.sum) || value.IsStaleNaN(count)
+	for i := 0; i < bucketCount-1; i++ {
+		bounds[i] = mg.complexValue[i].boundary
+		adjustedCount = mg.complexValue[i].value
+
+		// Buckets still need to be sent to know to set them as stale,
+		// but a staleness NaN converted to uint64 would be an extremely large number.
+		// Setting to 0 instead.
+		if pointIsStale {
+			adjustedCount = 0
+		} else if i != 0 {
+			adjustedCount -= mg.complexValue[i-1].value
+		}
+		bucketCounts[i] = uint64(adjustedCount)
+	}
+
+	// Add the final bucket based on the total count
+	adjustedCount = count
+	if pointIsStale {
+		adjustedCount = 0
+	} else if bucketCount > 1 {
+		adjustedCount -= mg.complexValue[bucketCount-2].value
+	}
+	bucketCounts[bucketCount-1] = uint64(adjustedCount)
+
+	point := dest.AppendEmpty()
+	if pointIsStale {
+		point.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	} else {
+		point.SetCount(uint64(count))
+		if mg.hasSum {
+			point.SetSum(mg.sum)
+		}
+	}
+	point.ExplicitBounds().FromRaw(bounds)
+	point.BucketCounts().FromRaw(bucketCounts)
+	point.SetTimestamp(timestampFromMs(mg.ts))
+	populateAttributes(mg.ls, point.Attributes())
+}
+
+// toSummaryPoint converts the group to a summary data point. The series of a summary may be
+// sent in different requests, so the point is only created from its quantiles, and its sum
+// and count are only set if the _sum and _count series are in the same request.
+func (mg *metricGroup) toSummaryPoint(dest pmetric.SummaryDataPointSlice) {
+	if len(mg.complexValue) == 0 {
+		return
+	}
+
+	mg.sortPoints()
+
+	point := dest.AppendEmpty()
+	pointIsStale := value.IsStaleNaN(mg.sum) || value.IsStaleNaN(mg.count)
+	if pointIsStale {
+		point.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	} else {
+		if mg.hasSum {
+			point.SetSum(mg.sum)
+		}
+		if mg.hasCount {
+			point.SetCount(uint64(mg.count))
+		}
+	}
+
+	quantileValues := point.QuantileValues()
+	for _, p := range mg.complexValue {
+		quantile := quantileValues.AppendEmpty()
+		// Quantiles still need to be sent to know to set them as stale,
+		// but a staleness NaN converted to uint64 would be an extremely large number.
+		// By not setting the quantile value, it will default to 0.
+		if !pointIsStale {
+			quantile.SetValue(p.value)
+		}
+		quantile.SetQuantile(p.boundary)
+	}
+	point.SetTimestamp(timestampFromMs(mg.ts))
+	populateAttributes(mg.ls, point.Attributes())
+}
+
+// convertHistogram converts a native histogram to an exponential histogram data point.
+func convertHistogram(h prompb.Histogram, dp pmetric.ExponentialHistogramDataPoint) {
+	// Prometheus schemas are the same as OTLP scales, the bucket boundaries being base^index
+	// with base = 2^(2^-schema), so the scale can be used as is.
+	dp.SetScale(h.Schema)
+	if value.IsStaleNaN(h.Sum) {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+	dp.SetSum(h.Sum)
+	if h.IsFloatHistogram() {
+		dp.SetCount(uint64(h.GetCountFloat()))
+		dp.SetZeroCount(uint64(h.GetZeroCountFloat()))
+		convertBuckets(h.PositiveSpans, h.PositiveCounts, false, dp.Positive())
+		convertBuckets(h.NegativeSpans, h.NegativeCounts, false, dp.Negative())
+		return
+	}
+	dp.SetCount(h.GetCountInt())
+	dp.SetZeroCount(h.GetZeroCountInt())
+	convertBuckets(h.PositiveSpans, h.PositiveDeltas, true, dp.Positive())
+	convertBuckets(h.NegativeSpans, h.NegativeDeltas, true, dp.Negative())
+}
+
+// convertBuckets expands the sparse native histogram buckets into the dense OTLP buckets,
+// filling the gaps between the spans with empty buckets. Integer histograms have delta
+// encoded buckets while float histograms hold absolute counts.
+func convertBuckets[BC int64 | float64](spans []prompb.BucketSpan, buckets []BC, deltas bool, dest pmetric.ExponentialHistogramDataPointBuckets) {
+	if len(spans) == 0 {
+		return
+	}
+
+	size := 0
+	for i, span := range spans {
+		if i > 0 {
+			size += int(span.Offset)
+		}
+		size += int(span.Length)
+	}
+
+	// A Prometheus bucket with index i covers (base^(i-1), base^i]
+	// while an OTLP bucket with index i covers (base^i, base^(i+1)].
+	dest.SetOffset(spans[0].Offset - 1)
+
+	counts := make([]uint64, 0, size)
+	var count BC
+	bucketIdx := 0
+	for i, span := range spans {
+		if i > 0 {
+			for j := int32(0); j < span.Offset; j++ {
+				counts = append(counts, 0)
+			}
+		}
+		for j := uint32(0); j < span.Length && bucketIdx < len(buckets); j++ {
+			if deltas {
+				count += buckets[bucketIdx]
+			} else {
+				count = buckets[bucketIdx]
+			}
+			counts = append(counts, uint64(count))
+			bucketIdx++
+		}
+	}
+	dest.BucketCounts().FromRaw(counts)
+}
+
+// populateAttributes converts the labels of a series to data point attributes,
+// the labels identifying the metric, the resource or the bucket are omitted.
+func populateAttributes(ls []prompb.Label, dest pcommon.Map) {
+	dest.EnsureCapacity(len(ls))
+	for _, l := range ls {
+		if isNotUsefulLabel(l.Name) || l.Value == "" {
+			// empty label values should be omitted
+			continue
+		}
+		dest.PutStr(l.Name, l.Value)
+	}
+}
+
+func isNotUsefulLabel(name string) bool {
+	switch name {
+	case model.MetricNameLabel, model.JobLabel, model.InstanceLabel, model.BucketLabel, model.QuantileLabel:
+		return true
+	}
+	return false
+}
+
+// seriesSignature returns a signature of the labels of the series identifying its data point.
+func seriesSignature(ls []prompb.Label) string {
+	sorted := ls
+	if !sort.SliceIsSorted(ls, func(i, j int) bool { return ls[i].Name < ls[j].Name }) {
+		sorted = make([]prompb.Label, len(ls))
+		copy(sorted, ls)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	}
+	var sb strings.Builder
+	for _, l := range sorted {
+		if isNotUsefulLabel(l.Name) {
+			continue
+		}
+		sb.WriteString(l.Name)
+		sb.WriteString(labelSeparator)
+		sb.WriteString(l.Value)
+		sb.WriteString(labelSeparator)
+	}
+	return sb.String()
+}
+
+func seriesName(ls []prompb.Label) (string, error) {
+	seen := make(map[string]struct{}, len(ls))
+	for _, l := range ls {
+		if _, ok := seen[l.Name]; ok {
+			return "", fmt.Errorf("invalid sample: non-unique label names: %q", l.Name)
+		}
+		seen[l.Name] = struct{}{}
+	}
+	name := getLabel(ls, model.MetricNameLabel)
+	if name == "" {
+		return "", errMetricNameNotFound
+	}
+	return name, nil
+}
+
+func getBoundary(mtype pmetric.MetricType, ls []prompb.Label) (float64, error) {
+	var val string
+	if mtype == pmetric.MetricTypeHistogram {
+		if val = getLabel(ls, model.BucketLabel); val == "" {
+			return 0, errEmptyLeLabel
+		}
+	} else if val = getLabel(ls, model.QuantileLabel); val == "" {
+		return 0, errEmptyQuantileLabel
+	}
+	return strconv.ParseFloat(val, 64)
+}
+
+func getLabel(ls []prompb.Label, name string) string {
+	for _, l := range ls {
+		if l.Name == name {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+func hasLabel(ls []prompb.Label, name string) bool {
+	return getLabel(ls, name) != ""
+}
+
+// trimSuffix trims the suffix of the series of a histogram, summary or counter.
+func trimSuffix(name string) string {
+	for _, s := range []string{metricSuffixBucket, metricSuffixSum, metricSuffixCount, metricSuffixTotal} {
+		if strings.HasSuffix(name, s) && name != s {
+			return strings.TrimSuffix(name, s)
+		}
+	}
+	return name
+}
+
+func timestampFromMs(timeAtMs int64) pcommon.Timestamp {
+	return pcommon.Timestamp(timeAtMs * 1e6)
+}
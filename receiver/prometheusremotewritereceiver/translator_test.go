@@ -0,0 +1,405 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+const ts = int64(1700000000000)
+
+func labels(kvs ...string) []prompb.Label {
+	ls := make([]prompb.Label, 0, len(kvs)/2)
+	for i := 0; i < len(kvs); i += 2 {
+		ls = append(ls, prompb.Label{Name: kvs[i], Value: kvs[i+1]})
+	}
+	return ls
+}
+
+func series(v float64, kvs ...string) prompb.TimeSeries {
+	return prompb.TimeSeries{
+		Labels:  labels(kvs...),
+		Samples: []prompb.Sample{{Value: v, Timestamp: ts}},
+	}
+}
+
+func newTestTranslator() *translator {
+	return newTranslator(zap.NewNop(), component.BuildInfo{Version: "latest"})
+}
+
+// metricsByName returns the metrics of the first resource indexed by name.
+func metricsByName(t *testing.T, md pmetric.Metrics) map[string]pmetric.Metric {
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	sms := md.ResourceMetrics().At(0).ScopeMetrics()
+	require.Equal(t, 1, sms.Len())
+	metrics := make(map[string]pmetric.Metric)
+	for i := 0; i < sms.At(0).Metrics().Len(); i++ {
+		m := sms.At(0).Metrics().At(i)
+		metrics[m.Name()] = m
+	}
+	return metrics
+}
+
+func TestTranslateResources(t *testing.T) {
+	tr := newTestTranslator()
+	md, err := tr.translate(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(1, "__name__", "up", "job", "node", "instance", "host1:9100"),
+			series(1, "__name__", "up", "job", "node", "instance", "localhost:9100"),
+			series(1, "__name__", "target_info", "job", "node", "instance", "host1:9100", "cloud_region", "eu-west-1"),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{
+		"service.name":        "node",
+		"service.instance.id": "host1:9100",
+		"net.host.name":       "host1",
+		"net.host.port":       "9100",
+		"cloud_region":        "eu-west-1",
+	}, rm.Resource().Attributes().AsRaw())
+	require.Equal(t, 1, rm.ScopeMetrics().Len())
+	assert.Equal(t, scopeName, rm.ScopeMetrics().At(0).Scope().Name())
+	assert.Equal(t, "latest", rm.ScopeMetrics().At(0).Scope().Version())
+	require.Equal(t, 1, rm.ScopeMetrics().At(0).Metrics().Len())
+	assert.Equal(t, "up", rm.ScopeMetrics().At(0).Metrics().At(0).Name())
+
+	assert.Equal(t, map[string]any{
+		"service.name":        "node",
+		"service.instance.id": "localhost:9100",
+		"net.host.port":       "9100",
+	}, md.ResourceMetrics().At(1).Resource().Attributes().AsRaw())
+}
+
+func TestTranslateGaugesAndCounters(t *testing.T) {
+	tr := newTestTranslator()
+	md, err := tr.translate(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(10, "__name__", "http_requests_total", "job", "app", "method", "GET", "empty", ""),
+			series(0.5, "__name__", "cpu_usage", "job", "app"),
+			series(3, "__name__", "queue_length", "job", "app"),
+			series(math.Float64frombits(value.StaleNaN), "__name__", "memory_bytes", "job", "app"),
+		},
+		Metadata: []prompb.MetricMetadata{
+			{MetricFamilyName: "queue_length", Type: prompb.MetricMetadata_COUNTER, Help: "Queue length", Unit: "seconds"},
+		},
+	})
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 4)
+
+	m := metrics["http_requests_total"]
+	require.Equal(t, pmetric.MetricTypeSum, m.Type())
+	assert.True(t, m.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+	require.Equal(t, 1, m.Sum().DataPoints().Len())
+	dp := m.Sum().DataPoints().At(0)
+	assert.Equal(t, 10.0, dp.DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(ts*1e6), dp.Timestamp())
+	assert.Equal(t, map[string]any{"method": "GET"}, dp.Attributes().AsRaw())
+
+	m = metrics["cpu_usage"]
+	require.Equal(t, pmetric.MetricTypeGauge, m.Type())
+	assert.Equal(t, 0.5, m.Gauge().DataPoints().At(0).DoubleValue())
+	assert.Equal(t, 0, m.Gauge().DataPoints().At(0).Attributes().Len())
+
+	m = metrics["queue_length"]
+	require.Equal(t, pmetric.MetricTypeSum, m.Type())
+	assert.Equal(t, "Queue length", m.Description())
+	assert.Equal(t, "s", m.Unit())
+
+	m = metrics["memory_bytes"]
+	require.Equal(t, pmetric.MetricTypeGauge, m.Type())
+	assert.True(t, m.Gauge().DataPoints().At(0).Flags().NoRecordedValue())
+}
+
+func TestTranslateHistogram(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata []prompb.MetricMetadata
+	}{
+		{
+			name: "without metadata",
+		},
+		{
+			name:     "with metadata",
+			metadata: []prompb.MetricMetadata{{MetricFamilyName: "latency", Type: prompb.MetricMetadata_HISTOGRAM}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := newTestTranslator()
+			md, err := tr.translate(&prompb.WriteRequest{
+				// The _sum and _count series come before the buckets to check they are
+				// attached to the histogram when its type is unknown.
+				Timeseries: []prompb.TimeSeries{
+					series(100, "__name__", "latency_sum", "job", "app", "path", "/"),
+					series(10, "__name__", "latency_count", "job", "app", "path", "/"),
+					series(10, "__name__", "latency_bucket", "job", "app", "path", "/", "le", "+Inf"),
+					series(2, "__name__", "latency_bucket", "job", "app", "path", "/", "le", "1"),
+					series(7, "__name__", "latency_bucket", "job", "app", "path", "/", "le", "5"),
+				},
+				Metadata: tt.metadata,
+			})
+			require.NoError(t, err)
+			metrics := metricsByName(t, md)
+			require.Len(t, metrics, 1)
+
+			m := metrics["latency"]
+			require.Equal(t, pmetric.MetricTypeHistogram, m.Type())
+			require.Equal(t, 1, m.Histogram().DataPoints().Len())
+			dp := m.Histogram().DataPoints().At(0)
+			assert.Equal(t, uint64(10), dp.Count())
+			assert.Equal(t, 100.0, dp.Sum())
+			assert.Equal(t, []float64{1, 5}, dp.ExplicitBounds().AsRaw())
+			assert.Equal(t, []uint64{2, 5, 3}, dp.BucketCounts().AsRaw())
+			assert.Equal(t, map[string]any{"path": "/"}, dp.Attributes().AsRaw())
+		})
+	}
+}
+
+func TestTranslateSplitFamily(t *testing.T) {
+	tr := newTestTranslator()
+	md, err := tr.translate(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(2, "__name__", "latency_bucket", "job", "app", "le", "1"),
+			series(10, "__name__", "latency_bucket", "job", "app", "le", "+Inf"),
+			series(0.1, "__name__", "rpc_duration", "job", "app", "quantile", "0.5"),
+		},
+		Metadata: []prompb.MetricMetadata{
+			{MetricFamilyName: "latency", Type: prompb.MetricMetadata_HISTOGRAM},
+			{MetricFamilyName: "rpc_duration", Type: prompb.MetricMetadata_SUMMARY},
+		},
+	})
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 2)
+
+	// The count of the histogram is the one of the +Inf bucket.
+	dp := metrics["latency"].Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(10), dp.Count())
+	assert.False(t, dp.HasSum())
+	assert.Equal(t, []float64{1}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{2, 8}, dp.BucketCounts().AsRaw())
+
+	summaryDp := metrics["rpc_duration"].Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(0), summaryDp.Count())
+	assert.Equal(t, 1, summaryDp.QuantileValues().Len())
+
+	// The _sum and _count series sent alone don't create points, nor gauges since the families are known.
+	md, err = tr.translate(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(100, "__name__", "latency_sum", "job", "app"),
+			series(10, "__name__", "latency_count", "job", "app"),
+			series(12, "__name__", "rpc_duration_sum", "job", "app"),
+			series(40, "__name__", "rpc_duration_count", "job", "app"),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.DataPointCount())
+}
+
+func TestTranslateTargetInfoInOtherRequest(t *testing.T) {
+	tr := newTestTranslator()
+	md, err := tr.translate(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(1, "__name__", "target_info", "job", "node", "instance", "host1:9100", "cloud_region", "eu-west-1"),
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	md, err = tr.translate(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(1, "__name__", "up", "job", "node", "instance", "host1:9100"),
+			series(1, "__name__", "up", "job", "node", "instance", "host2:9100"),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+	region, ok := md.ResourceMetrics().At(0).Resource().Attributes().Get("cloud_region")
+	require.True(t, ok)
+	assert.Equal(t, "eu-west-1", region.Str())
+	_, ok = md.ResourceMetrics().At(1).Resource().Attributes().Get("cloud_region")
+	assert.False(t, ok)
+}
+
+func TestTranslateSummary(t *testing.T) {
+	tr := newTestTranslator()
+	// The metadata is sent in a separate request.
+	md, err := tr.translate(&prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{{MetricFamilyName: "rpc_duration", Type: prompb.MetricMetadata_SUMMARY}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.DataPointCount())
+
+	md, err = tr.translate(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(0.9, "__name__", "rpc_duration", "job", "app", "quantile", "0.99"),
+			series(0.1, "__name__", "rpc_duration", "job", "app", "quantile", "0.5"),
+			series(12, "__name__", "rpc_duration_sum", "job", "app"),
+			series(40, "__name__", "rpc_duration_count", "job", "app"),
+		},
+	})
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 1)
+
+	m := metrics["rpc_duration"]
+	require.Equal(t, pmetric.MetricTypeSummary, m.Type())
+	require.Equal(t, 1, m.Summary().DataPoints().Len())
+	dp := m.Summary().DataPoints().At(0)
+	assert.Equal(t, uint64(40), dp.Count())
+	assert.Equal(t, 12.0, dp.Sum())
+	require.Equal(t, 2, dp.QuantileValues().Len())
+	assert.Equal(t, 0.5, dp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 0.1, dp.QuantileValues().At(0).Value())
+	assert.Equal(t, 0.99, dp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 0.9, dp.QuantileValues().At(1).Value())
+}
+
+func TestTranslateSumAndCountWithoutFamily(t *testing.T) {
+	tr := newTestTranslator()
+	md, err := tr.translate(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series(4, "__name__", "bytes_sum", "job", "app"),
+			series(2, "__name__", "items_count", "job", "app"),
+		},
+	})
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 2)
+	assert.Equal(t, pmetric.MetricTypeGauge, metrics["bytes_sum"].Type())
+	assert.Equal(t, pmetric.MetricTypeGauge, metrics["items_count"].Type())
+}
+
+func TestTranslateNativeHistogram(t *testing.T) {
+	tests := []struct {
+		name      string
+		histogram prompb.Histogram
+	}{
+		{
+			name: "integer histogram",
+			histogram: prompb.Histogram{
+				Count:          &prompb.Histogram_CountInt{CountInt: 12},
+				Sum:            18.4,
+				Schema:         1,
+				ZeroThreshold:  0.001,
+				ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 2},
+				PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 2}, {Offset: 1, Length: 2}},
+				PositiveDeltas: []int64{1, 1, -1, 0},
+				NegativeSpans:  []prompb.BucketSpan{{Offset: 0, Length: 2}},
+				NegativeDeltas: []int64{3, -1},
+				Timestamp:      ts,
+			},
+		},
+		{
+			name: "float histogram",
+			histogram: prompb.Histogram{
+				Count:          &prompb.Histogram_CountFloat{CountFloat: 12},
+				Sum:            18.4,
+				Schema:         1,
+				ZeroThreshold:  0.001,
+				ZeroCount:      &prompb.Histogram_ZeroCountFloat{ZeroCountFloat: 2},
+				PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 2}, {Offset: 1, Length: 2}},
+				PositiveCounts: []float64{1, 2, 1, 1},
+				NegativeSpans:  []prompb.BucketSpan{{Offset: 0, Length: 2}},
+				NegativeCounts: []float64{3, 2},
+				Timestamp:      ts,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := newTestTranslator()
+			md, err := tr.translate(&prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels:     labels("__name__", "request_size", "job", "app", "code", "200"),
+						Histograms: []prompb.Histogram{tt.histogram},
+					},
+				},
+			})
+			require.NoError(t, err)
+			metrics := metricsByName(t, md)
+			require.Len(t, metrics, 1)
+
+			m := metrics["request_size"]
+			require.Equal(t, pmetric.MetricTypeExponentialHistogram, m.Type())
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.ExponentialHistogram().AggregationTemporality())
+			require.Equal(t, 1, m.ExponentialHistogram().DataPoints().Len())
+			dp := m.ExponentialHistogram().DataPoints().At(0)
+			assert.Equal(t, int32(1), dp.Scale())
+			assert.Equal(t, uint64(12), dp.Count())
+			assert.Equal(t, 18.4, dp.Sum())
+			assert.Equal(t, uint64(2), dp.ZeroCount())
+			assert.Equal(t, int32(-1), dp.Positive().Offset())
+			assert.Equal(t, []uint64{1, 2, 0, 1, 1}, dp.Positive().BucketCounts().AsRaw())
+			assert.Equal(t, int32(-1), dp.Negative().Offset())
+			assert.Equal(t, []uint64{3, 2}, dp.Negative().BucketCounts().AsRaw())
+			assert.Equal(t, pcommon.Timestamp(ts*1e6), dp.Timestamp())
+			assert.Equal(t, map[string]any{"code": "200"}, dp.Attributes().AsRaw())
+		})
+	}
+}
+
+func TestTranslateNativeHistogramStaleAndGauge(t *testing.T) {
+	tr := newTestTranslator()
+	md, err := tr.translate(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels: labels("__name__", "request_size", "job", "app"),
+				Histograms: []prompb.Histogram{
+					{Sum: math.Float64frombits(value.StaleNaN), Timestamp: ts},
+					{ResetHint: prompb.Histogram_GAUGE, Timestamp: ts},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 1)
+	dps := metrics["request_size"].ExponentialHistogram().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.True(t, dps.At(0).Flags().NoRecordedValue())
+}
+
+func TestTranslateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		series prompb.TimeSeries
+	}{
+		{
+			name:   "missing metric name",
+			series: series(1, "job", "app"),
+		},
+		{
+			name:   "duplicate labels",
+			series: series(1, "__name__", "up", "job", "app", "job", "other"),
+		},
+		{
+			name:   "invalid bucket boundary",
+			series: series(1, "__name__", "latency_bucket", "job", "app", "le", "one"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := newTestTranslator()
+			_, err := tr.translate(&prompb.WriteRequest{Timeseries: []prompb.TimeSeries{tt.series}})
+			assert.Error(t, err)
+		})
+	}
+}
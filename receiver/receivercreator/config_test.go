@@ -114,6 +114,7 @@ func TestLoadConfig(t *testing.T) {
 					observer.PortType:       {"port.key": "port.value"},
 					observer.HostPortType:   {"hostport.key": "hostport.value"},
 					observer.K8sServiceType: {"k8s.service.key": "k8s.service.value"},
+					observer.K8sIngressType: {"k8s.ingress.key": "k8s.ingress.value"},
 					observer.K8sNodeType:    {"k8s.node.key": "k8s.node.value"},
 				},
 			},
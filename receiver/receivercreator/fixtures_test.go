@@ -45,6 +45,31 @@ var serviceEndpoint = observer.Endpoint{
 	Details: &service,
 }
 
+var ingress = observer.K8sIngress{
+	UID:       "uid-1",
+	Namespace: "default",
+	Name:      "ingress-1",
+	Labels: map[string]string{
+		"app":    "redis2",
+		"region": "west-1",
+	},
+	Annotations: map[string]string{
+		"scrape": "true",
+	},
+	Scheme:      "https",
+	Host:        "host-1",
+	Path:        "/",
+	TLS:         true,
+	ServiceName: "service-1",
+	ServicePort: 8080,
+}
+
+var ingressEndpoint = observer.Endpoint{
+	ID:      "ingress-1",
+	Target:  "https://host-1/",
+	Details: &ingress,
+}
+
 var portEndpoint = observer.Endpoint{
 	ID:     "port-1",
 	Target: "localhost:1234",
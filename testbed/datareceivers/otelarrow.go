@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package datareceivers // import "github.com/open-telemetry/opentelemetry-collector-contrib/testbed/datareceivers"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otelarrowreceiver"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/testbed/testbed"
+)
+
+// otelArrowDataReceiver implements OTel-Arrow format receiver.
+type otelArrowDataReceiver struct {
+	testbed.DataReceiverBase
+	traceReceiver   receiver.Traces
+	metricsReceiver receiver.Metrics
+	logReceiver     receiver.Logs
+}
+
+// NewOTelArrowDataReceiver creates a new otelArrowDataReceiver that will listen on the specified port after Start
+// is called.
+func NewOTelArrowDataReceiver(port int) testbed.DataReceiver {
+	return &otelArrowDataReceiver{DataReceiverBase: testbed.DataReceiverBase{Port: port}}
+}
+
+func (oar *otelArrowDataReceiver) Start(tc consumer.Traces, mc consumer.Metrics, lc consumer.Logs) error {
+	factory := otelarrowreceiver.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*otelarrowreceiver.Config)
+	cfg.NetAddr = confignet.NetAddr{Endpoint: fmt.Sprintf("127.0.0.1:%d", oar.Port), Transport: "tcp"}
+	var err error
+	set := receivertest.NewNopCreateSettings()
+	if oar.traceReceiver, err = factory.CreateTracesReceiver(context.Background(), set, cfg, tc); err != nil {
+		return err
+	}
+	if oar.metricsReceiver, err = factory.CreateMetricsReceiver(context.Background(), set, cfg, mc); err != nil {
+		return err
+	}
+	if oar.logReceiver, err = factory.CreateLogsReceiver(context.Background(), set, cfg, lc); err != nil {
+		return err
+	}
+	// The receivers of the signals share the same server, which is started once.
+	return oar.traceReceiver.Start(context.Background(), componenttest.NewNopHost())
+}
+
+func (oar *otelArrowDataReceiver) Stop() error {
+	return oar.traceReceiver.Shutdown(context.Background())
+}
+
+func (oar *otelArrowDataReceiver) GenConfigYAMLStr() string {
+	// Note that this generates an exporter config for agent.
+	return fmt.Sprintf(`
+  otelarrow:
+    endpoint: "127.0.0.1:%d"
+    tls:
+      insecure: true`, oar.Port)
+}
+
+func (oar *otelArrowDataReceiver) ProtocolName() string {
+	return "otelarrow"
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package datasenders // import "github.com/open-telemetry/opentelemetry-collector-contrib/testbed/datasenders"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otelarrowexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/testbed/testbed"
+)
+
+type otelArrowDataSender struct {
+	testbed.DataSenderBase
+}
+
+func (oads *otelArrowDataSender) fillConfig(cfg *otelarrowexporter.Config) *otelarrowexporter.Config {
+	cfg.Endpoint = oads.GetEndpoint().String()
+	// Disable retries, we should push data and if error just log it.
+	cfg.RetrySettings.Enabled = false
+	// Disable sending queue, we should push data from the caller goroutine.
+	cfg.QueueSettings.Enabled = false
+	// The data is pushed from a single goroutine, so that one stream carries all the batches.
+	cfg.Arrow.NumStreams = 1
+	cfg.TLSSetting = configtls.TLSClientSetting{
+		Insecure: true,
+	}
+	return cfg
+}
+
+func (oads *otelArrowDataSender) GenConfigYAMLStr() string {
+	// Note that this generates a receiver config for agent.
+	return fmt.Sprintf(`
+  otelarrow:
+    endpoint: "%s"`, oads.GetEndpoint())
+}
+
+func (oads *otelArrowDataSender) ProtocolName() string {
+	return "otelarrow"
+}
+
+// otelArrowTracesDataSender implements TraceDataSender for the OTel-Arrow traces exporter.
+type otelArrowTracesDataSender struct {
+	otelArrowDataSender
+	consumer.Traces
+}
+
+// NewOTelArrowTraceDataSender creates a new TraceDataSender for the OTel-Arrow traces exporter.
+func NewOTelArrowTraceDataSender(host string, port int) testbed.TraceDataSender {
+	return &otelArrowTracesDataSender{
+		otelArrowDataSender: otelArrowDataSender{
+			DataSenderBase: testbed.DataSenderBase{
+				Port: port,
+				Host: host,
+			},
+		},
+	}
+}
+
+func (oats *otelArrowTracesDataSender) Start() error {
+	factory := otelarrowexporter.NewFactory()
+	cfg := oats.fillConfig(factory.CreateDefaultConfig().(*otelarrowexporter.Config))
+	params := exportertest.NewNopCreateSettings()
+	params.Logger = zap.L()
+
+	exp, err := factory.CreateTracesExporter(context.Background(), params, cfg)
+	if err != nil {
+		return err
+	}
+
+	oats.Traces = exp
+	return exp.Start(context.Background(), componenttest.NewNopHost())
+}
+
+// otelArrowMetricsDataSender implements MetricDataSender for the OTel-Arrow metrics exporter.
+type otelArrowMetricsDataSender struct {
+	otelArrowDataSender
+	consumer.Metrics
+}
+
+// NewOTelArrowMetricDataSender creates a new OTel-Arrow metric exporter sender that will send
+// to the specified port after Start is called.
+func NewOTelArrowMetricDataSender(host string, port int) testbed.MetricDataSender {
+	return &otelArrowMetricsDataSender{
+		otelArrowDataSender: otelArrowDataSender{
+			DataSenderBase: testbed.DataSenderBase{
+				Port: port,
+				Host: host,
+			},
+		},
+	}
+}
+
+func (oams *otelArrowMetricsDataSender) Start() error {
+	factory := otelarrowexporter.NewFactory()
+	cfg := oams.fillConfig(factory.CreateDefaultConfig().(*otelarrowexporter.Config))
+	params := exportertest.NewNopCreateSettings()
+	params.Logger = zap.L()
+
+	exp, err := factory.CreateMetricsExporter(context.Background(), params, cfg)
+	if err != nil {
+		return err
+	}
+
+	oams.Metrics = exp
+	return exp.Start(context.Background(), componenttest.NewNopHost())
+}
+
+// otelArrowLogsDataSender implements LogDataSender for the OTel-Arrow logs exporter.
+type otelArrowLogsDataSender struct {
+	otelArrowDataSender
+	consumer.Logs
+}
+
+// NewOTelArrowLogsDataSender creates a new OTel-Arrow logs exporter sender that will send
+// to the specified port after Start is called.
+func NewOTelArrowLogsDataSender(host string, port int) testbed.LogDataSender {
+	return &otelArrowLogsDataSender{
+		otelArrowDataSender: otelArrowDataSender{
+			DataSenderBase: testbed.DataSenderBase{
+				Port: port,
+				Host: host,
+			},
+		},
+	}
+}
+
+func (oals *otelArrowLogsDataSender) Start() error {
+	factory := otelarrowexporter.NewFactory()
+	cfg := oals.fillConfig(factory.CreateDefaultConfig().(*otelarrowexporter.Config))
+	params := exportertest.NewNopCreateSettings()
+	params.Logger = zap.L()
+
+	exp, err := factory.CreateLogsExporter(context.Background(), params, cfg)
+	if err != nil {
+		return err
+	}
+
+	oals.Logs = exp
+	return exp.Start(context.Background(), componenttest.NewNopHost())
+}
@@ -20,10 +20,12 @@ import (
 	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/opencensusexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otelarrowexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/zipkinexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/jaegerreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/opencensusreceiver"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otelarrowreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/syslogreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/zipkinreceiver"
 )
@@ -44,6 +46,7 @@ func Components() (
 	receivers, err := receiver.MakeFactoryMap(
 		jaegerreceiver.NewFactory(),
 		opencensusreceiver.NewFactory(),
+		otelarrowreceiver.NewFactory(),
 		otlpreceiver.NewFactory(),
 		syslogreceiver.NewFactory(),
 		zipkinreceiver.NewFactory(),
@@ -53,6 +56,7 @@ func Components() (
 	exporters, err := exporter.MakeFactoryMap(
 		loggingexporter.NewFactory(),
 		opencensusexporter.NewFactory(),
+		otelarrowexporter.NewFactory(),
 		otlpexporter.NewFactory(),
 		otlphttpexporter.NewFactory(),
 		syslogexporter.NewFactory(),
@@ -40,6 +40,15 @@ func TestLog10kDPS(t *testing.T) {
 				ExpectedMaxRAM: 120,
 			},
 		},
+		{
+			name:     "OTel-Arrow",
+			sender:   datasenders.NewOTelArrowLogsDataSender(testbed.DefaultHost, testbed.GetAvailablePort(t)),
+			receiver: datareceivers.NewOTelArrowDataReceiver(testbed.GetAvailablePort(t)),
+			resourceSpec: testbed.ResourceSpec{
+				ExpectedMaxCPU: 30,
+				ExpectedMaxRAM: 200,
+			},
+		},
 		{
 			name:     "filelog",
 			sender:   datasenders.NewFileLogWriter(),
@@ -64,6 +64,15 @@ func TestMetric10kDPS(t *testing.T) {
 				ExpectedMaxRAM: 100,
 			},
 		},
+		{
+			name:     "OTel-Arrow",
+			sender:   datasenders.NewOTelArrowMetricDataSender(testbed.DefaultHost, testbed.GetAvailablePort(t)),
+			receiver: datareceivers.NewOTelArrowDataReceiver(testbed.GetAvailablePort(t)),
+			resourceSpec: testbed.ResourceSpec{
+				ExpectedMaxCPU: 40,
+				ExpectedMaxRAM: 200,
+			},
+		},
 		{
 			name:     "SignalFx",
 			sender:   datasenders.NewSFxMetricDataSender(testbed.GetAvailablePort(t)),
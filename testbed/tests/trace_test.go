@@ -91,6 +91,15 @@ func TestTrace10kSPS(t *testing.T) {
 				ExpectedMaxRAM: 220,
 			},
 		},
+		{
+			"OTel-Arrow",
+			datasenders.NewOTelArrowTraceDataSender(testbed.DefaultHost, testbed.GetAvailablePort(t)),
+			datareceivers.NewOTelArrowDataReceiver(testbed.GetAvailablePort(t)),
+			testbed.ResourceSpec{
+				ExpectedMaxCPU: 40,
+				ExpectedMaxRAM: 250,
+			},
+		},
 		{
 			"SAPM",
 			datasenders.NewSapmDataSender(testbed.GetAvailablePort(t), ""),
@@ -0,0 +1,250 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tests
+
+// This file contains Test functions comparing the bytes on the wire of the protocols
+// sending the same data between two collectors. Run them with:
+// RUN_TESTBED=1 go test -v -run TestWireBytes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/testbed/datareceivers"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/testbed/datasenders"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/testbed/testbed"
+)
+
+// countingProxy forwards the TCP connections it accepts to the target address
+// and counts the bytes sent by the clients.
+type countingProxy struct {
+	listener net.Listener
+	target   string
+	sent     atomic.Int64
+	wg       sync.WaitGroup
+}
+
+func newCountingProxy(t *testing.T, port int, target string) *countingProxy {
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", testbed.DefaultHost, port))
+	require.NoError(t, err)
+	p := &countingProxy{listener: listener, target: target}
+	p.wg.Add(1)
+	go p.serve()
+	t.Cleanup(func() {
+		assert.NoError(t, p.listener.Close())
+		p.wg.Wait()
+	})
+	return p
+}
+
+func (p *countingProxy) serve() {
+	defer p.wg.Done()
+	var conns []net.Conn
+	defer func() {
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	}()
+	for {
+		client, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		server, err := net.Dial("tcp", p.target)
+		if err != nil {
+			_ = client.Close()
+			continue
+		}
+		conns = append(conns, client, server)
+		p.wg.Add(2)
+		go p.forward(&countingWriter{Writer: server, counter: &p.sent}, client, server)
+		go p.forward(client, server, client)
+	}
+}
+
+func (p *countingProxy) forward(dst io.Writer, src net.Conn, dstConn net.Conn) {
+	defer p.wg.Done()
+	_, _ = io.Copy(dst, src)
+	_ = dstConn.Close()
+}
+
+// countingWriter counts the bytes as they are written, so that the bytes of a
+// request are counted once its response is received.
+type countingWriter struct {
+	io.Writer
+	counter *atomic.Int64
+}
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	n, err := w.Writer.Write(b)
+	w.counter.Add(int64(n))
+	return n, err
+}
+
+// wireProtocol is a protocol whose bytes on the wire are measured.
+type wireProtocol struct {
+	name     string
+	sender   func(port int) testbed.DataSender
+	receiver func(port int) testbed.DataReceiver
+}
+
+// measureWireBytes sends the same batches with the protocol through a counting
+// proxy and returns the bytes sent by the exporter.
+func measureWireBytes(t *testing.T, protocol wireProtocol, send func(*testing.T, testbed.DataSender, testbed.DataProvider) int) int64 {
+	receiverPort := testbed.GetAvailablePort(t)
+	receiver := protocol.receiver(receiverPort)
+	tracesSink := new(consumertest.TracesSink)
+	metricsSink := new(consumertest.MetricsSink)
+	logsSink := new(consumertest.LogsSink)
+	require.NoError(t, receiver.Start(tracesSink, metricsSink, logsSink))
+	t.Cleanup(func() {
+		assert.NoError(t, receiver.Stop())
+	})
+
+	proxyPort := testbed.GetAvailablePort(t)
+	proxy := newCountingProxy(t, proxyPort, fmt.Sprintf("%s:%d", testbed.DefaultHost, receiverPort))
+
+	sender := protocol.sender(proxyPort)
+	require.NoError(t, sender.Start())
+
+	dataProvider := testbed.NewPerfTestDataProvider(testbed.LoadOptions{
+		ItemsPerBatch: 1000,
+		Attributes: map[string]string{
+			"service.name":   "wire-test",
+			"host.name":      "edge-collector-1",
+			"cloud.region":   "us-east-1",
+			"deployment.env": "production",
+		},
+	})
+	dataItemsGenerated := &atomic.Uint64{}
+	dataProvider.SetLoadGeneratorCounters(dataItemsGenerated)
+
+	items := send(t, sender, dataProvider)
+	require.Eventually(t, func() bool {
+		return tracesSink.SpanCount()+metricsSink.DataPointCount()+logsSink.LogRecordCount() == items
+	}, 10*time.Second, 10*time.Millisecond)
+	return proxy.sent.Load()
+}
+
+func TestWireBytes(t *testing.T) {
+	const batches = 100
+
+	tests := []struct {
+		name      string
+		protocols []wireProtocol
+		send      func(*testing.T, testbed.DataSender, testbed.DataProvider) int
+	}{
+		{
+			name: "Traces",
+			protocols: []wireProtocol{
+				{
+					name: "OTLP-gRPC-gzip",
+					sender: func(port int) testbed.DataSender {
+						return testbed.NewOTLPTraceDataSender(testbed.DefaultHost, port)
+					},
+					receiver: func(port int) testbed.DataReceiver { return testbed.NewOTLPDataReceiver(port) },
+				},
+				{
+					name: "OTel-Arrow",
+					sender: func(port int) testbed.DataSender {
+						return datasenders.NewOTelArrowTraceDataSender(testbed.DefaultHost, port)
+					},
+					receiver: datareceivers.NewOTelArrowDataReceiver,
+				},
+			},
+			send: func(t *testing.T, sender testbed.DataSender, dataProvider testbed.DataProvider) int {
+				items := 0
+				for i := 0; i < batches; i++ {
+					td, _ := dataProvider.GenerateTraces()
+					items += td.SpanCount()
+					require.NoError(t, sender.(testbed.TraceDataSender).ConsumeTraces(context.Background(), td))
+				}
+				return items
+			},
+		},
+		{
+			name: "Metrics",
+			protocols: []wireProtocol{
+				{
+					name: "OTLP-gRPC-gzip",
+					sender: func(port int) testbed.DataSender {
+						return testbed.NewOTLPMetricDataSender(testbed.DefaultHost, port)
+					},
+					receiver: func(port int) testbed.DataReceiver { return testbed.NewOTLPDataReceiver(port) },
+				},
+				{
+					name: "OTel-Arrow",
+					sender: func(port int) testbed.DataSender {
+						return datasenders.NewOTelArrowMetricDataSender(testbed.DefaultHost, port)
+					},
+					receiver: datareceivers.NewOTelArrowDataReceiver,
+				},
+			},
+			send: func(t *testing.T, sender testbed.DataSender, dataProvider testbed.DataProvider) int {
+				items := 0
+				for i := 0; i < batches; i++ {
+					md, _ := dataProvider.GenerateMetrics()
+					items += md.DataPointCount()
+					require.NoError(t, sender.(testbed.MetricDataSender).ConsumeMetrics(context.Background(), md))
+				}
+				return items
+			},
+		},
+		{
+			name: "Logs",
+			protocols: []wireProtocol{
+				{
+					name: "OTLP-gRPC-gzip",
+					sender: func(port int) testbed.DataSender {
+						return testbed.NewOTLPLogsDataSender(testbed.DefaultHost, port)
+					},
+					receiver: func(port int) testbed.DataReceiver { return testbed.NewOTLPDataReceiver(port) },
+				},
+				{
+					name: "OTel-Arrow",
+					sender: func(port int) testbed.DataSender {
+						return datasenders.NewOTelArrowLogsDataSender(testbed.DefaultHost, port)
+					},
+					receiver: datareceivers.NewOTelArrowDataReceiver,
+				},
+			},
+			send: func(t *testing.T, sender testbed.DataSender, dataProvider testbed.DataProvider) int {
+				items := 0
+				for i := 0; i < batches; i++ {
+					ld, _ := dataProvider.GenerateLogs()
+					items += ld.LogRecordCount()
+					require.NoError(t, sender.(testbed.LogDataSender).ConsumeLogs(context.Background(), ld))
+				}
+				return items
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The bytes are reported relative to the first protocol, the ratio
+			// depends on how well the data compresses.
+			var baseline int64
+			for _, protocol := range test.protocols {
+				wireBytes := measureWireBytes(t, protocol, test.send)
+				require.Positive(t, wireBytes)
+				if baseline == 0 {
+					baseline = wireBytes
+				}
+				t.Logf("%s %s: %d bytes on the wire (%.2fx %s)", test.name, protocol.name, wireBytes,
+					float64(wireBytes)/float64(baseline), test.protocols[0].name)
+			}
+		})
+	}
+}